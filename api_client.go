@@ -32,6 +32,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -42,6 +43,19 @@ const vertexPrefix = "vertex-genai-modules/"
 
 type apiClient struct {
 	clientConfig *ClientConfig
+	// auth resolves the credentials of the client on first use. It's nil if
+	// the client doesn't use credentials or the user provided the HTTP client.
+	auth *lazyAuth
+	// previewFeatures are the preview features whose calls use the preview
+	// API version while the others use the stable one. It's nil unless
+	// ClientConfig.PreviewFeatures is set for Vertex AI.
+	previewFeatures map[PreviewFeature]bool
+	// defaultBaseURL is whether the Vertex AI base URL was derived from the
+	// location of the client rather than set by the user.
+	defaultBaseURL bool
+	// cachedContents memoizes the lookups of cached contents made to annotate
+	// responses with their provenance, as cachedContentLookup values by name.
+	cachedContents sync.Map
 }
 
 // InternalAPIClient is an internal type that exposes the apiClient struct.
@@ -71,6 +85,7 @@ func sendStreamRequest[T responseStream[R], R any](ctx context.Context, ac *apiC
 
 	resp, err := doRequest(ac, req)
 	if err != nil {
+		ac.clientConfig.UsageReport.record(path, nil, err)
 		if cancel != nil {
 			cancel()
 		}
@@ -78,11 +93,20 @@ func sendStreamRequest[T responseStream[R], R any](ctx context.Context, ac *apiC
 	}
 
 	output.cancel = cancel
+	output.includeBody = ac.includeResponseBody(ctx)
+	if report := ac.clientConfig.UsageReport; report != nil {
+		output.report = func(usage map[string]any, err error) {
+			report.record(path, usage, err)
+		}
+	}
 
 	// resp.Body will be closed by the iterator
 	err = deserializeStreamResponse(resp, output)
-	if err != nil && cancel != nil {
-		cancel()
+	if err != nil {
+		ac.clientConfig.UsageReport.record(path, nil, err)
+		if cancel != nil {
+			cancel()
+		}
 	}
 	return err
 }
@@ -111,28 +135,47 @@ func sendRequest(ctx context.Context, ac *apiClient, path string, method string,
 
 	resp, err := doRequest(ac, req)
 	if err != nil {
+		ac.clientConfig.UsageReport.record(path, nil, err)
 		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	return deserializeUnaryResponse(resp)
+	output, err := deserializeUnaryResponse(resp, ac.includeResponseBody(ctx))
+	usage, _ := output["usageMetadata"].(map[string]any)
+	ac.clientConfig.UsageReport.record(path, usage, err)
+	return output, err
 }
 
 func downloadFile(ctx context.Context, ac *apiClient, path string, httpOptions *HTTPOptions) ([]byte, error) {
-	// The client and request timeout are not used for downloadFile.
-	// TODO(b/427540996): implement timeout.
-	req, _, err := buildRequest(ctx, ac, path, nil, http.MethodGet, httpOptions)
+	req, httpOptions, err := buildRequest(ctx, ac, path, nil, http.MethodGet, httpOptions)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(ctx)
 
-	resp, err := doRequest(ac, req)
+	requestContext := ctx
+	timeout := httpOptions.Timeout
+	var cancel context.CancelFunc
+	if timeout != nil && *timeout > 0*time.Second && isTimeoutBeforeDeadline(ctx, *timeout) {
+		requestContext, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	req = req.WithContext(requestContext)
+
+	// Downloads are redirected to the storage serving the file, so they use a
+	// copy of the client with their own redirect policy.
+	client := *ac.clientConfig.HTTPClient
+	client.CheckRedirect = downloadRedirectPolicy(client.CheckRedirect, requestOptionsFromContext(ctx).CheckRedirect)
+	resp, err := client.Do(req)
+	ac.clientConfig.UsageReport.recordResponse(path, resp, err)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("downloadFile: error sending request: %w", err)
 	}
+	defer resp.Body.Close()
 
+	if !httpStatusOk(resp) {
+		return nil, newAPIError(resp)
+	}
 	return io.ReadAll(resp.Body)
 }
 
@@ -157,9 +200,32 @@ func mapToStruct[R any](input map[string]any, output *R) error {
 }
 
 func (ac *apiClient) createAPIURL(suffix, method string, httpOptions *HTTPOptions) (*url.URL, error) {
+	return ac.createRequestURL(suffix, method, httpOptions, &RequestOptions{})
+}
+
+// createRequestURL is like createAPIURL, with the project and the location of
+// the client overridden by those of options.
+func (ac *apiClient) createRequestURL(suffix, method string, httpOptions *HTTPOptions, options *RequestOptions) (*url.URL, error) {
 	path, query, _ := strings.Cut(suffix, "?")
 
-	u, err := url.Parse(httpOptions.BaseURL)
+	project, location := ac.clientConfig.Project, ac.clientConfig.Location
+	baseURL := httpOptions.BaseURL
+	if options.Project != "" || options.Location != "" {
+		if ac.clientConfig.Backend != BackendVertexAI || ac.clientConfig.APIKey != "" {
+			return nil, fmt.Errorf("createAPIURL: project and location can only be overridden on Vertex AI without an API key")
+		}
+		if options.Project != "" {
+			project = options.Project
+		}
+		if options.Location != "" && options.Location != location {
+			location = options.Location
+			if ac.defaultBaseURL && baseURL == ac.clientConfig.HTTPOptions.BaseURL {
+				baseURL = vertexBaseURL(location, false)
+			}
+		}
+	}
+
+	u, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("createAPIURL: error parsing base URL: %w", err)
 	}
@@ -168,13 +234,13 @@ func (ac *apiClient) createAPIURL(suffix, method string, httpOptions *HTTPOption
 	if ac.clientConfig.Backend == BackendVertexAI {
 		queryVertexBaseModel := method == http.MethodGet && strings.HasPrefix(path, "publishers/google/models")
 		shouldPrepend := ac.clientConfig.APIKey == "" &&
-			ac.clientConfig.Project != "" &&
-			ac.clientConfig.Location != "" &&
+			project != "" &&
+			location != "" &&
 			httpOptions.BaseURLResourceScope != ResourceScopeCollection &&
 			(!strings.HasPrefix(path, "projects/") && !queryVertexBaseModel)
 
 		if shouldPrepend {
-			path = fmt.Sprintf("projects/%s/locations/%s/%s", ac.clientConfig.Project, ac.clientConfig.Location, path)
+			path = fmt.Sprintf("projects/%s/locations/%s/%s", project, location, path)
 		}
 		finalURL = u.JoinPath(httpOptions.APIVersion, path)
 	} else {
@@ -294,14 +360,24 @@ func appendSDKHeaders(headers http.Header) {
 }
 
 func buildRequest(ctx context.Context, ac *apiClient, path string, body map[string]any, method string, httpOptions *HTTPOptions) (*http.Request, *HTTPOptions, error) {
+	if httpOptions.APIVersion == "" && ac.previewFeatures[previewFeatureOf(path, body)] {
+		options := *httpOptions
+		options.APIVersion = previewVertexAPIVersion
+		httpOptions = &options
+	}
 	patchedHTTPOptions, err := patchHTTPOptions(ac.clientConfig.HTTPOptions, *httpOptions)
 	if err != nil {
 		return nil, nil, err
 	}
-	url, err := ac.createAPIURL(path, method, patchedHTTPOptions)
+	options := requestOptionsFromContext(ctx)
+	url, err := ac.createRequestURL(path, method, patchedHTTPOptions, options)
 	if err != nil {
 		return nil, nil, err
 	}
+	setReferenceTypes(path, body)
+	if err := checkDataResidency(ac.clientConfig.AllowedLocations, url, body); err != nil {
+		return nil, nil, err
+	}
 
 	if patchedHTTPOptions.ExtraBody != nil {
 		recursiveMapMerge(body, patchedHTTPOptions.ExtraBody)
@@ -325,6 +401,15 @@ func buildRequest(ctx context.Context, ac *apiClient, path string, body map[stri
 	}
 	// Set headers
 	req.Header = patchedHTTPOptions.Headers
+	if provider := chainHeadersProviders(ac.clientConfig.HeadersProvider, options.HeadersProvider); provider != nil {
+		headers, err := provider(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("buildRequest: providing headers: %w", err)
+		}
+		for k, v := range headers {
+			req.Header[textproto.CanonicalMIMEHeaderKey(k)] = v
+		}
+	}
 	timeoutSeconds := inferTimeout(ctx, ac, patchedHTTPOptions.Timeout).Seconds()
 	if timeoutSeconds > 0 {
 		req.Header.Set("x-server-timeout", strconv.FormatInt(int64(math.Ceil(timeoutSeconds)), 10))
@@ -334,10 +419,53 @@ func buildRequest(ctx context.Context, ac *apiClient, path string, body map[stri
 	if ac.clientConfig.APIKey != "" {
 		req.Header.Set("x-goog-api-key", ac.clientConfig.APIKey)
 	}
+	signer := ac.clientConfig.RequestSigner
+	if options.RequestSigner != nil {
+		signer = options.RequestSigner
+	}
+	if signer != nil {
+		if err := signer(req, b.Bytes()); err != nil {
+			return nil, nil, fmt.Errorf("buildRequest: signing request: %w", err)
+		}
+	}
 
 	return req, patchedHTTPOptions, nil
 }
 
+// includeResponseBody reports whether the payload of the responses to the
+// requests made with ctx is kept, see [ClientConfig.IncludeResponseBody].
+func (ac *apiClient) includeResponseBody(ctx context.Context) bool {
+	return ac.clientConfig.IncludeResponseBody || requestOptionsFromContext(ctx).IncludeResponseBody
+}
+
+// chainHeadersProviders returns a [HeadersProvider] that calls first and then
+// second, the headers of second replacing those of first. Either may be nil.
+func chainHeadersProviders(first, second HeadersProvider) HeadersProvider {
+	if first == nil {
+		return second
+	}
+	if second == nil {
+		return first
+	}
+	return func(ctx context.Context) (http.Header, error) {
+		headers, err := first(ctx)
+		if err != nil {
+			return nil, err
+		}
+		more, err := second(ctx)
+		if err != nil {
+			return nil, err
+		}
+		merged := http.Header{}
+		for _, h := range []http.Header{headers, more} {
+			for k, v := range h {
+				merged[textproto.CanonicalMIMEHeaderKey(k)] = v
+			}
+		}
+		return merged, nil
+	}
+}
+
 // recursiveMapMerge recursively merges key-value pairs from a source map (`src`)
 // into a destination map (`dest`), modifying `dest` in-place.
 //
@@ -368,6 +496,38 @@ func recursiveMapMerge(dest, src map[string]any) {
 	}
 }
 
+// withExtraBody returns a copy of options whose ExtraBody also contains the
+// fields of extra, which win over those already there. It's used to send
+// fields that the generated request converters don't know about. Neither
+// options nor its ExtraBody is modified.
+func withExtraBody(options *HTTPOptions, extra map[string]any) *HTTPOptions {
+	merged := HTTPOptions{}
+	if options != nil {
+		merged = *options
+	}
+	merged.ExtraBody = copyBodyMap(merged.ExtraBody)
+	if merged.ExtraBody == nil {
+		merged.ExtraBody = map[string]any{}
+	}
+	recursiveMapMerge(merged.ExtraBody, extra)
+	return &merged
+}
+
+// copyBodyMap returns a copy of m in which nested maps are copied too.
+func copyBodyMap(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]any, len(m))
+	for k, v := range m {
+		if nested, ok := v.(map[string]any); ok {
+			v = copyBodyMap(nested)
+		}
+		c[k] = v
+	}
+	return c
+}
+
 // TODO(b/428730853): HTTP Client timeout should be considered.
 func isTimeoutBeforeDeadline(ctx context.Context, timeout time.Duration) bool {
 	deadline, ok := ctx.Deadline()
@@ -419,7 +579,9 @@ func doRequest(ac *apiClient, req *http.Request) (*http.Response, error) {
 	return resp, nil
 }
 
-func deserializeUnaryResponse(resp *http.Response) (map[string]any, error) {
+// deserializeUnaryResponse returns the contents of resp. If includeBody is
+// true, the raw body is kept in the sdkHttpResponse.
+func deserializeUnaryResponse(resp *http.Response, includeBody bool) (map[string]any, error) {
 	if !httpStatusOk(resp) {
 		return nil, newAPIError(resp)
 	}
@@ -439,6 +601,9 @@ func deserializeUnaryResponse(resp *http.Response) (map[string]any, error) {
 	httpResponse := map[string]any{
 		"headers": resp.Header,
 	}
+	if includeBody {
+		httpResponse["body"] = string(respBody)
+	}
 	output["sdkHttpResponse"] = httpResponse
 	return output, nil
 }
@@ -448,6 +613,11 @@ type responseStream[R any] struct {
 	rc     io.ReadCloser
 	h      http.Header
 	cancel context.CancelFunc
+	// includeBody keeps the payload of each chunk in its sdkHttpResponse.
+	includeBody bool
+	// report, if set, is called once the stream ends with the last usage
+	// metadata received and the first error.
+	report func(usage map[string]any, err error)
 }
 
 func iterateResponseStream[R any](rs *responseStream[R], responseConverter func(responseMap map[string]any) (*R, error)) iter.Seq2[*R, error] {
@@ -461,6 +631,18 @@ func iterateResponseStream[R any](rs *responseStream[R], responseConverter func(
 				rs.cancel()
 			}
 		}()
+		var usage map[string]any
+		if rs.report != nil {
+			var streamErr error
+			defer func() { rs.report(usage, streamErr) }()
+			yieldChunk := yield
+			yield = func(resp *R, err error) bool {
+				if streamErr == nil {
+					streamErr = err
+				}
+				return yieldChunk(resp, err)
+			}
+		}
 		for rs.r.Scan() {
 			line := rs.r.Bytes()
 			if len(line) == 0 {
@@ -478,6 +660,9 @@ func iterateResponseStream[R any](rs *responseStream[R], responseConverter func(
 						return
 					}
 				}
+				if u, ok := respRaw["usageMetadata"].(map[string]any); ok {
+					usage = u
+				}
 				// Step 2: The toStruct function calls fromConverter(handle Vertex and MLDev schema
 				// difference and get a unified response). Then toStruct function converts the unified
 				// response from map[string]any to struct type.
@@ -498,6 +683,9 @@ func iterateResponseStream[R any](rs *responseStream[R], responseConverter func(
 							field.Set(reflect.ValueOf(&HTTPResponse{}))
 						}
 						field.Interface().(*HTTPResponse).Headers = rs.h
+						if rs.includeBody {
+							field.Interface().(*HTTPResponse).Body = string(bytes.TrimPrefix(data, []byte(" ")))
+						}
 					}
 				}
 
@@ -638,14 +826,15 @@ func scan(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	return 0, nil, nil
 }
 
-func (ac *apiClient) upload(ctx context.Context, r io.Reader, uploadURL string, httpOptions *HTTPOptions) (map[string]any, error) {
+func (ac *apiClient) upload(ctx context.Context, r io.Reader, uploadURL string, httpOptions *HTTPOptions) (map[string]any, *UploadStats, error) {
 	var offset int64 = 0
 	var resp *http.Response
 	var respBody map[string]any
-	var uploadCommand = "upload"
+	stats := &UploadStats{}
 
 	buffer := make([]byte, maxChunkSize)
 	for {
+		uploadCommand := "upload"
 		bytesRead, err := io.ReadFull(r, buffer)
 		// Check both EOF and UnexpectedEOF errors.
 		// ErrUnexpectedEOF: Reading a file file_size%maxChunkSize<len(buffer).
@@ -653,62 +842,17 @@ func (ac *apiClient) upload(ctx context.Context, r io.Reader, uploadURL string,
 		if err == io.EOF || err == io.ErrUnexpectedEOF {
 			uploadCommand += ", finalize"
 		} else if err != nil {
-			return nil, fmt.Errorf("Failed to read bytes from file at offset %d: %w. Bytes actually read: %d", offset, err, bytesRead)
+			return nil, stats, fmt.Errorf("Failed to read bytes from file at offset %d: %w. Bytes actually read: %d", offset, err, bytesRead)
 		}
-		for attempt := 0; attempt < maxRetryCount; attempt++ {
-			patchedHTTPOptions, err := patchHTTPOptions(ac.clientConfig.HTTPOptions, *httpOptions)
-			if err != nil {
-				return nil, err
-			}
-
-			finalUploadURL := uploadURL
-			if patchedHTTPOptions.BaseURL != "" {
-				parsedBase, errBase := url.Parse(patchedHTTPOptions.BaseURL)
-				parsedUpload, errUpload := url.Parse(uploadURL)
-				if errBase == nil && errUpload == nil {
-					parsedUpload.Scheme = parsedBase.Scheme
-					parsedUpload.Host = parsedBase.Host
-					finalUploadURL = parsedUpload.String()
-				}
-			}
-
-			// TODO(b/427540996): Support timeout.
-			req, err := http.NewRequestWithContext(ctx, http.MethodPost, finalUploadURL, bytes.NewReader(buffer[:bytesRead]))
-			if err != nil {
-				return nil, fmt.Errorf("Failed to create upload request for chunk at offset %d: %w", offset, err)
-			}
-
-			req.Header = patchedHTTPOptions.Headers
-			req.Header.Set("Content-Type", "application/json")
-			if ac.clientConfig.APIKey != "" {
-				req.Header.Set("x-goog-api-key", ac.clientConfig.APIKey)
-			}
-			// TODO(b/427540996): Add timeout logging.
-
-			req.Header.Set("X-Goog-Upload-Command", uploadCommand)
-			req.Header.Set("X-Goog-Upload-Offset", strconv.FormatInt(offset, 10))
-			req.Header.Set("Content-Length", strconv.FormatInt(int64(bytesRead), 10))
-			resp, err = doRequest(ac, req)
-			if err != nil {
-				return nil, fmt.Errorf("upload request failed for chunk at offset %d: %w", offset, err)
-			}
-			if resp.Header.Get("X-Goog-Upload-Status") != "" {
-				break
-			}
-			resp.Body.Close()
-
-			select {
-			case <-ctx.Done():
-				return nil, fmt.Errorf("upload aborted while waiting to retry (attempt %d, offset %d): %w", attempt+1, offset, ctx.Err())
-			case <-time.After(initialRetryDelay * time.Duration(1<<attempt)):
-				// Sleep completed, continue to the next attempt.
-			}
+		resp, err = ac.uploadChunk(ctx, uploadURL, httpOptions, uploadCommand, offset, buffer[:bytesRead], stats)
+		if err != nil {
+			return nil, stats, err
 		}
-		defer resp.Body.Close()
 
-		respBody, err = deserializeUnaryResponse(resp)
+		respBody, err = deserializeUnaryResponse(resp, false)
+		resp.Body.Close()
 		if err != nil {
-			return nil, fmt.Errorf("response body is invalid for chunk at offset %d: %w", offset, err)
+			return nil, stats, fmt.Errorf("response body is invalid for chunk at offset %d: %w", offset, err)
 		}
 
 		offset += int64(bytesRead)
@@ -716,7 +860,7 @@ func (ac *apiClient) upload(ctx context.Context, r io.Reader, uploadURL string,
 		uploadStatus := resp.Header.Get("X-Goog-Upload-Status")
 
 		if uploadStatus != "final" && strings.Contains(uploadCommand, "finalize") {
-			return nil, fmt.Errorf("send finalize command but doesn't receive final status. Offset %d, Bytes read: %d, Upload status: %s", offset, bytesRead, uploadStatus)
+			return nil, stats, fmt.Errorf("send finalize command but doesn't receive final status. Offset %d, Bytes read: %d, Upload status: %s", offset, bytesRead, uploadStatus)
 		}
 		if uploadStatus != "active" {
 			// Upload is complete ('final') or interrupted ('cancelled', etc.)
@@ -725,19 +869,19 @@ func (ac *apiClient) upload(ctx context.Context, r io.Reader, uploadURL string,
 	}
 
 	if resp == nil {
-		return nil, fmt.Errorf("Upload request failed. No response received")
+		return nil, stats, fmt.Errorf("Upload request failed. No response received")
 	}
 
 	finalUploadStatus := resp.Header.Get("X-Goog-Upload-Status")
 	if finalUploadStatus != "final" {
-		return nil, fmt.Errorf("Failed to upload file: Upload status is not finalized")
+		return nil, stats, fmt.Errorf("Failed to upload file: Upload status is not finalized")
 	}
 
-	return respBody, nil
+	return respBody, stats, nil
 }
 
 func (ac *apiClient) uploadFile(ctx context.Context, r io.Reader, uploadURL string, httpOptions *HTTPOptions) (*File, error) {
-	respBody, err := ac.upload(ctx, r, uploadURL, httpOptions)
+	respBody, stats, err := ac.upload(ctx, r, uploadURL, httpOptions)
 	if err != nil {
 		return nil, err // Propagate any errors from the upload process
 	}
@@ -750,11 +894,12 @@ func (ac *apiClient) uploadFile(ctx context.Context, r io.Reader, uploadURL stri
 	if err != nil {
 		return nil, err
 	}
+	uploadStats.set(response, stats)
 	return response, nil
 }
 
 func (ac *apiClient) uploadToFileSearchStore(ctx context.Context, r io.Reader, uploadURL string, httpOptions *HTTPOptions) (*UploadToFileSearchStoreOperation, error) {
-	respBody, err := ac.upload(ctx, r, uploadURL, httpOptions)
+	respBody, _, err := ac.upload(ctx, r, uploadURL, httpOptions)
 	if err != nil {
 		return nil, err // Propagate any errors from the upload process
 	}
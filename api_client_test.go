@@ -3,7 +3,11 @@ package genai
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -1238,6 +1242,99 @@ func TestBuildRequest(t *testing.T) {
 	}
 }
 
+func TestHeadersProvider(t *testing.T) {
+	type userKey struct{}
+	var got http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+		fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+
+	ac := &apiClient{clientConfig: &ClientConfig{
+		HTTPOptions: HTTPOptions{
+			BaseURL: ts.URL,
+			Headers: http.Header{"X-Static": []string{"static"}},
+		},
+		HeadersProvider: func(ctx context.Context) (http.Header, error) {
+			user, _ := ctx.Value(userKey{}).(string)
+			return http.Header{"x-end-user": []string{user}, "X-Route": []string{"client"}}, nil
+		},
+		HTTPClient: ts.Client(),
+	}}
+	ctx := context.WithValue(context.Background(), userKey{}, "user-1")
+	perCall := WithRequestOptions(ctx, &RequestOptions{HeadersProvider: func(ctx context.Context) (http.Header, error) {
+		return http.Header{"X-Route": []string{"call"}, "Content-Type": []string{"text/plain"}}, nil
+	}})
+	if _, err := sendRequest(perCall, ac, "models/m:generateContent", http.MethodPost, map[string]any{}, &HTTPOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	for k, want := range map[string]string{
+		"X-Static":     "static",
+		"X-End-User":   "user-1",
+		"X-Route":      "call",
+		"Content-Type": "application/json",
+	} {
+		if got := got.Get(k); got != want {
+			t.Errorf("header %s = %q, want %q", k, got, want)
+		}
+	}
+	if !strings.Contains(got.Get("X-Goog-Api-Client"), "google-genai-sdk/") {
+		t.Errorf("SDK headers missing: %v", got)
+	}
+
+	errProvide := fmt.Errorf("no user")
+	failing := WithRequestOptions(ctx, &RequestOptions{HeadersProvider: func(ctx context.Context) (http.Header, error) { return nil, errProvide }})
+	if _, err := sendRequest(failing, ac, "models/m", http.MethodGet, nil, &HTTPOptions{}); !errors.Is(err, errProvide) {
+		t.Errorf("sendRequest() error = %v, want %v", err, errProvide)
+	}
+}
+
+func TestRequestSigner(t *testing.T) {
+	ctx := context.Background()
+	key := []byte("secret")
+	sign := func(body []byte) string {
+		mac := hmac.New(sha256.New, key)
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if got, want := r.Header.Get("X-Signature"), sign(body); got != want {
+			t.Errorf("X-Signature = %q, want %q", got, want)
+		}
+		if got := r.Header.Get("X-Signed-Path"); got != r.URL.Path {
+			t.Errorf("X-Signed-Path = %q, want %q", got, r.URL.Path)
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+
+	signer := func(req *http.Request, body []byte) error {
+		req.Header.Set("X-Signature", sign(body))
+		req.Header.Set("X-Signed-Path", req.URL.Path)
+		return nil
+	}
+	ac := &apiClient{clientConfig: &ClientConfig{
+		HTTPOptions:   HTTPOptions{BaseURL: ts.URL},
+		RequestSigner: signer,
+		HTTPClient:    ts.Client(),
+	}}
+	if _, err := sendRequest(ctx, ac, "models/m:generateContent", http.MethodPost, map[string]any{"contents": "hi"}, &HTTPOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sendRequest(ctx, ac, "models/m", http.MethodGet, nil, &HTTPOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	errSign := fmt.Errorf("no key")
+	failing := func(req *http.Request, body []byte) error { return errSign }
+	_, err := sendRequest(WithRequestOptions(ctx, &RequestOptions{RequestSigner: failing}), ac, "models/m", http.MethodGet, nil, &HTTPOptions{})
+	if !errors.Is(err, errSign) {
+		t.Errorf("sendRequest() error = %v, want %v", err, errSign)
+	}
+}
+
 func TestPatchHTTPOptions(t *testing.T) {
 	timeout1 := 10 * time.Second
 	timeout2 := 20 * time.Second
@@ -1906,3 +2003,137 @@ func TestUploadToFileSearchStore_RewritesAbsoluteURL(t *testing.T) {
 		t.Fatalf("uploadToFileSearchStore failed: %v", err)
 	}
 }
+
+func TestCreateAPIURLProjectLocationOverride(t *testing.T) {
+	vertex := &apiClient{
+		clientConfig: &ClientConfig{
+			Backend:     BackendVertexAI,
+			Project:     "p",
+			Location:    "us-central1",
+			HTTPOptions: HTTPOptions{BaseURL: "https://us-central1-aiplatform.googleapis.com/", APIVersion: "v1beta1"},
+		},
+		defaultBaseURL: true,
+	}
+	customBaseURL := &apiClient{
+		clientConfig: &ClientConfig{
+			Backend:     BackendVertexAI,
+			Project:     "p",
+			Location:    "us-central1",
+			HTTPOptions: HTTPOptions{BaseURL: "https://proxy.example.com/", APIVersion: "v1beta1"},
+		},
+	}
+	express := &apiClient{
+		clientConfig: &ClientConfig{
+			Backend:     BackendVertexAI,
+			APIKey:      "key",
+			HTTPOptions: HTTPOptions{BaseURL: "https://aiplatform.googleapis.com/", APIVersion: "v1beta1"},
+		},
+		defaultBaseURL: true,
+	}
+	path := "publishers/google/models/gemini-2.5-flash:generateContent"
+	tests := []struct {
+		name    string
+		ac      *apiClient
+		options RequestOptions
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "NoOverride",
+			ac:   vertex,
+			want: "https://us-central1-aiplatform.googleapis.com/v1beta1/projects/p/locations/us-central1/" + path,
+		},
+		{
+			name:    "Project",
+			ac:      vertex,
+			options: RequestOptions{Project: "tenant"},
+			want:    "https://us-central1-aiplatform.googleapis.com/v1beta1/projects/tenant/locations/us-central1/" + path,
+		},
+		{
+			name:    "ProjectAndLocation",
+			ac:      vertex,
+			options: RequestOptions{Project: "tenant", Location: "europe-west4"},
+			want:    "https://europe-west4-aiplatform.googleapis.com/v1beta1/projects/tenant/locations/europe-west4/" + path,
+		},
+		{
+			name:    "GlobalLocation",
+			ac:      vertex,
+			options: RequestOptions{Location: "global"},
+			want:    "https://aiplatform.googleapis.com/v1beta1/projects/p/locations/global/" + path,
+		},
+		{
+			name:    "CustomBaseURL",
+			ac:      customBaseURL,
+			options: RequestOptions{Location: "europe-west4"},
+			want:    "https://proxy.example.com/v1beta1/projects/p/locations/europe-west4/" + path,
+		},
+		{
+			name:    "APIKey",
+			ac:      express,
+			options: RequestOptions{Project: "tenant"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ac.createRequestURL(path, http.MethodPost, &tt.ac.clientConfig.HTTPOptions, &tt.options)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("createAPIURL() = %s, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("createAPIURL() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncludeResponseBody(t *testing.T) {
+	ctx := context.Background()
+	const payload = `{"candidates": [{"content": {"role": "model", "parts": [{"text": "hi"}]}, "finishReason": "STOP"}], "modelVersion": "gemini-2.5-flash"}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("alt") == "sse" {
+			fmt.Fprintf(w, "data: %s\n\ndata: %s\n\n", payload, payload)
+			return
+		}
+		fmt.Fprint(w, payload)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+
+	resp, err := m.GenerateContent(ctx, "gemini-2.5-flash", Text("hello"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.SDKHTTPResponse.Body != "" {
+		t.Errorf("Body = %q without IncludeResponseBody, want empty", resp.SDKHTTPResponse.Body)
+	}
+
+	ctx = WithRequestOptions(ctx, &RequestOptions{IncludeResponseBody: true})
+	resp, err = m.GenerateContent(ctx, "gemini-2.5-flash", Text("hello"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.SDKHTTPResponse.Body != payload {
+		t.Errorf("Body = %q, want %q", resp.SDKHTTPResponse.Body, payload)
+	}
+
+	chunks := 0
+	for chunk, err := range m.GenerateContentStream(ctx, "gemini-2.5-flash", Text("hello"), nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunks++
+		if chunk.SDKHTTPResponse.Body != payload {
+			t.Errorf("chunk %d Body = %q, want %q", chunks, chunk.SDKHTTPResponse.Body, payload)
+		}
+	}
+	if chunks != 2 {
+		t.Errorf("got %d chunks, want 2", chunks)
+	}
+}
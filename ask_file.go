@@ -0,0 +1,219 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	defaultInlineFileMaxBytes = 10 * 1024 * 1024 // 10 MB
+	defaultFilePollInterval   = 2 * time.Second
+)
+
+// AskAboutFileConfig configures [Models.AskAboutFile].
+type AskAboutFileConfig struct {
+	// Optional. Configuration used for the GenerateContent call.
+	GenerateContentConfig *GenerateContentConfig
+	// Optional. MIME type of the file. If empty, it is derived from the file
+	// extension.
+	MIMEType string
+	// Optional. Files up to this size are sent inline with the request. Larger
+	// files are uploaded with the Files API, which is only available in the
+	// Gemini API. Defaults to 10 MB.
+	InlineMaxBytes int64
+	// Optional. If non-zero, uploaded files of at least this size are placed in
+	// a context cache so follow-up questions can reuse it through
+	// [AskAboutFileResponse.CachedContent].
+	CacheMinBytes int64
+	// Optional. TTL of the context cache. Only used when the file is cached.
+	CacheTTL time.Duration
+	// Optional. Interval between checks while waiting for an uploaded file to
+	// become active. Defaults to 2 seconds.
+	PollInterval time.Duration
+}
+
+// AskAboutFileResponse is the answer returned by [Models.AskAboutFile].
+type AskAboutFileResponse struct {
+	// Answer is the text of the model's answer.
+	Answer string
+	// Citations are the sources the answer quotes from, if any.
+	Citations []*Citation
+	// GroundingMetadata is set when the answer was grounded, for example with
+	// the Google Search tool.
+	GroundingMetadata *GroundingMetadata
+	// File is the uploaded file. It's nil if the file was sent inline. The
+	// caller owns the file and may delete it with [Files.Delete].
+	File *File
+	// CachedContent is the context cache holding the file. It's nil unless the
+	// file was cached. The caller owns the cache and may delete it with
+	// [Caches.Delete].
+	CachedContent *CachedContent
+	// Response is the full model response.
+	Response *GenerateContentResponse
+}
+
+// AskAboutFile answers a question about a local file.
+//
+// Small files are sent inline with the request. Larger files are uploaded with
+// the Files API, and AskAboutFile waits until the file is active before asking
+// the model. If [AskAboutFileConfig.CacheMinBytes] is set and the file is at
+// least that large, the file is placed in a context cache first, together with
+// the system instruction, tools and tool config of
+// [AskAboutFileConfig.GenerateContentConfig], which are then left out of the
+// GenerateContent request.
+//
+// If AskAboutFile fails after it uploaded the file or created the cache, it
+// deletes them before returning the error.
+func (m Models) AskAboutFile(ctx context.Context, model, path, question string, config *AskAboutFileConfig) (_ *AskAboutFileResponse, err error) {
+	cfg := AskAboutFileConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.InlineMaxBytes == 0 {
+		cfg.InlineMaxBytes = defaultInlineFileMaxBytes
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultFilePollInterval
+	}
+
+	fileInfo, err := os.Stat(path)
+	if err != nil || fileInfo.IsDir() {
+		return nil, fmt.Errorf("AskAboutFile: %s is not a valid file path", path)
+	}
+	mimeType := cfg.MIMEType
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(path))
+		if mimeType == "" {
+			return nil, fmt.Errorf("AskAboutFile: could not determine the MIME type of %s, please set AskAboutFileConfig.MIMEType", path)
+		}
+	}
+
+	result := &AskAboutFileResponse{}
+	defer func() {
+		if err != nil {
+			m.deleteAskAboutFileResources(context.WithoutCancel(ctx), result)
+		}
+	}()
+	var genConfig GenerateContentConfig
+	if cfg.GenerateContentConfig != nil {
+		genConfig = *cfg.GenerateContentConfig
+	}
+	var filePart *Part
+	if fileInfo.Size() <= cfg.InlineMaxBytes {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		filePart = NewPartFromBytes(data, mimeType)
+	} else {
+		if m.apiClient.clientConfig.Backend == BackendVertexAI {
+			return nil, fmt.Errorf("AskAboutFile: %s is %d bytes, larger than the inline limit of %d bytes. Upload it to Cloud Storage and use NewPartFromURI with the Vertex AI backend", path, fileInfo.Size(), cfg.InlineMaxBytes)
+		}
+		files := Files{apiClient: m.apiClient}
+		file, err := files.UploadFromPath(ctx, path, &UploadFileConfig{MIMEType: mimeType})
+		if err != nil {
+			return nil, err
+		}
+		result.File = file
+		file, err = files.waitForActive(ctx, file, cfg.PollInterval)
+		if err != nil {
+			return nil, err
+		}
+		result.File = file
+		filePart = NewPartFromURI(file.URI, file.MIMEType)
+
+		if cfg.CacheMinBytes > 0 && fileInfo.Size() >= cfg.CacheMinBytes {
+			caches := Caches{apiClient: m.apiClient}
+			cached, err := caches.Create(ctx, model, &CreateCachedContentConfig{
+				TTL:               cfg.CacheTTL,
+				DisplayName:       filepath.Base(path),
+				Contents:          []*Content{NewContentFromParts([]*Part{filePart}, RoleUser)},
+				SystemInstruction: genConfig.SystemInstruction,
+				Tools:             genConfig.Tools,
+				ToolConfig:        genConfig.ToolConfig,
+			})
+			if err != nil {
+				return nil, err
+			}
+			result.CachedContent = cached
+			genConfig.CachedContent = cached.Name
+			genConfig.SystemInstruction = nil
+			genConfig.Tools = nil
+			genConfig.ToolConfig = nil
+			filePart = nil
+		}
+	}
+
+	parts := []*Part{}
+	if filePart != nil {
+		parts = append(parts, filePart)
+	}
+	parts = append(parts, NewPartFromText(question))
+	resp, err := m.GenerateContentWithOptions(ctx, model, []*Content{NewContentFromParts(parts, RoleUser)}, &genConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.Response = resp
+	if c := firstCandidate(resp); c != nil {
+		result.Answer = candidateText(c)
+		if c.CitationMetadata != nil {
+			result.Citations = c.CitationMetadata.Citations
+		}
+		result.GroundingMetadata = c.GroundingMetadata
+	}
+	return result, nil
+}
+
+// deleteAskAboutFileResources deletes the cache and the file that a failed
+// AskAboutFile call created. Errors are ignored, as the call already failed.
+func (m Models) deleteAskAboutFileResources(ctx context.Context, result *AskAboutFileResponse) {
+	if result.CachedContent != nil {
+		_, _ = Caches{apiClient: m.apiClient}.Delete(ctx, result.CachedContent.Name, nil)
+	}
+	if result.File != nil {
+		_, _ = Files{apiClient: m.apiClient}.Delete(ctx, result.File.Name, nil)
+	}
+}
+
+// waitForActive polls the file until it leaves the PROCESSING state.
+func (m Files) waitForActive(ctx context.Context, file *File, pollInterval time.Duration) (*File, error) {
+	for {
+		switch file.State {
+		case FileStateActive, FileStateUnspecified, "":
+			return file, nil
+		case FileStateFailed:
+			if file.Error != nil {
+				return nil, fmt.Errorf("file %s failed to process: %s", file.Name, file.Error.Message)
+			}
+			return nil, fmt.Errorf("file %s failed to process", file.Name)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for file %s to become active: %w", file.Name, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+		var err error
+		file, err = m.Get(ctx, file.Name, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAskAboutFile(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("The launch code is 1234."), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Inline", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Contents []*Content `json:"contents"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			parts := body.Contents[0].Parts
+			if len(parts) != 2 || parts[0].InlineData == nil || parts[0].InlineData.MIMEType != "text/plain; charset=utf-8" {
+				t.Errorf("unexpected request parts: %+v", parts)
+			}
+			if parts[1].Text != "What is the launch code?" {
+				t.Errorf("question = %q, want %q", parts[1].Text, "What is the launch code?")
+			}
+			fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "1234"}]},
+				"citationMetadata": {"citationSources": [{"uri": "https://example.com"}]}}]}`)
+		}))
+		defer ts.Close()
+
+		m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+			Backend:     BackendGeminiAPI,
+			HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+			HTTPClient:  ts.Client(),
+		}}}
+		got, err := m.AskAboutFile(ctx, "gemini-2.5-flash", path, "What is the launch code?", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Answer != "1234" {
+			t.Errorf("Answer = %q, want %q", got.Answer, "1234")
+		}
+		if len(got.Citations) != 1 || got.Citations[0].URI != "https://example.com" {
+			t.Errorf("Citations = %+v, want one citation", got.Citations)
+		}
+		if got.File != nil || got.CachedContent != nil {
+			t.Errorf("inline file shouldn't be uploaded or cached")
+		}
+	})
+
+	t.Run("CacheAndCleanup", func(t *testing.T) {
+		var cacheReq map[string]any
+		var genReq map[string]any
+		var deleted []string
+		var ts *httptest.Server
+		ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.URL.Path == "/upload/v1beta/files":
+				w.Header().Set("X-Goog-Upload-URL", ts.URL+"/upload-session")
+			case r.URL.Path == "/upload-session":
+				w.Header().Set("X-Goog-Upload-Status", "final")
+				fmt.Fprint(w, `{"file": {"name": "files/notes", "uri": "https://example.com/files/notes", "mimeType": "text/plain", "state": "ACTIVE"}}`)
+			case r.URL.Path == "/v1beta/cachedContents":
+				if err := json.NewDecoder(r.Body).Decode(&cacheReq); err != nil {
+					t.Fatal(err)
+				}
+				fmt.Fprint(w, `{"name": "cachedContents/notes"}`)
+			case strings.HasSuffix(r.URL.Path, ":generateContent"):
+				if err := json.NewDecoder(r.Body).Decode(&genReq); err != nil {
+					t.Fatal(err)
+				}
+				http.Error(w, `{"error": {"code": 500, "message": "internal", "status": "INTERNAL"}}`, http.StatusInternalServerError)
+			case r.Method == http.MethodDelete:
+				deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/v1beta/"))
+				fmt.Fprint(w, `{}`)
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		defer ts.Close()
+
+		m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+			Backend:     BackendGeminiAPI,
+			HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta"},
+			HTTPClient:  ts.Client(),
+		}}}
+		_, err := m.AskAboutFile(ctx, "gemini-2.5-flash", path, "What is the launch code?", &AskAboutFileConfig{
+			InlineMaxBytes: 1,
+			CacheMinBytes:  1,
+			GenerateContentConfig: &GenerateContentConfig{
+				SystemInstruction: NewContentFromText("Be brief.", RoleUser),
+				Tools:             []*Tool{{GoogleSearch: &GoogleSearch{}}},
+				ToolConfig:        &ToolConfig{FunctionCallingConfig: &FunctionCallingConfig{Mode: FunctionCallingConfigModeAuto}},
+			},
+		})
+		if err == nil {
+			t.Fatal("AskAboutFile() got no error, want error")
+		}
+		for _, field := range []string{"systemInstruction", "tools", "toolConfig"} {
+			if cacheReq[field] == nil {
+				t.Errorf("cache request has no %s", field)
+			}
+			if genReq[field] != nil {
+				t.Errorf("generateContent request has %s, want it only in the cache", field)
+			}
+		}
+		if want := []string{"cachedContents/notes", "files/notes"}; strings.Join(deleted, ",") != strings.Join(want, ",") {
+			t.Errorf("deleted %v, want %v", deleted, want)
+		}
+	})
+
+	t.Run("VertexTooLarge", func(t *testing.T) {
+		m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI}}}
+		_, err := m.AskAboutFile(ctx, "gemini-2.5-flash", path, "q", &AskAboutFileConfig{InlineMaxBytes: 1})
+		if err == nil || !strings.Contains(err.Error(), "Cloud Storage") {
+			t.Errorf("AskAboutFile() error = %v, want Cloud Storage hint", err)
+		}
+	})
+
+	t.Run("UnknownMIMEType", func(t *testing.T) {
+		p := filepath.Join(t.TempDir(), "data.unknownext")
+		if err := os.WriteFile(p, []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI}}}
+		if _, err := m.AskAboutFile(ctx, "gemini-2.5-flash", p, "q", nil); err == nil {
+			t.Errorf("AskAboutFile() got no error, want error")
+		}
+	})
+}
+
+func TestFilesWaitForActive(t *testing.T) {
+	ctx := context.Background()
+	states := []FileState{FileStateProcessing, FileStateActive}
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name": "files/abc", "state": %q}`, states[calls])
+		calls++
+	}))
+	defer ts.Close()
+
+	files := Files{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+	got, err := files.waitForActive(ctx, &File{Name: "files/abc", State: FileStateProcessing}, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.State != FileStateActive || calls != 2 {
+		t.Errorf("got state %s after %d calls, want %s after 2", got.State, calls, FileStateActive)
+	}
+
+	if _, err := files.waitForActive(ctx, &File{Name: "files/abc", State: FileStateFailed}, time.Millisecond); err == nil {
+		t.Errorf("waitForActive() on failed file: got no error, want error")
+	}
+}
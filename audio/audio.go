@@ -0,0 +1,202 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audio saves the audio returned by speech generation models, which
+// comes back as raw PCM in the inline data of the response, as WAV files.
+//
+//	resp, err := client.Models.GenerateContent(ctx, "gemini-2.5-flash-preview-tts", genai.Text("Say hello."), &genai.GenerateContentConfig{
+//		ResponseModalities: []string{"AUDIO"},
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if err := audio.SaveWAV("hello.wav", resp); err != nil {
+//		log.Fatal(err)
+//	}
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// Defaults of the audio returned by the models, used for the parameters that
+// are missing from its MIME type.
+const (
+	DefaultSampleRate    = 24000
+	DefaultChannels      = 1
+	DefaultBitsPerSample = 16
+)
+
+// ErrNoAudio is returned when a response doesn't contain audio.
+var ErrNoAudio = errors.New("audio: the response doesn't contain audio")
+
+// Format describes raw PCM audio.
+type Format struct {
+	// SampleRate is the number of samples per second.
+	SampleRate int
+	// Channels is the number of interleaved channels.
+	Channels int
+	// BitsPerSample is the size of a sample of a channel. Samples are signed
+	// little-endian integers, unless BitsPerSample is 8, in which case they're
+	// unsigned.
+	BitsPerSample int
+}
+
+// ParseMIMEType returns the format of the PCM audio of the given MIME type,
+// such as "audio/L16;codec=pcm;rate=24000". Parameters that are missing take
+// their default value. It returns an error for MIME types of other audio
+// encodings, such as "audio/mp3".
+func ParseMIMEType(mimeType string) (Format, error) {
+	mediaType, params, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return Format{}, fmt.Errorf("audio: invalid MIME type %q: %w", mimeType, err)
+	}
+	f := Format{SampleRate: DefaultSampleRate, Channels: DefaultChannels, BitsPerSample: DefaultBitsPerSample}
+	switch mediaType {
+	case "audio/pcm", "audio/l16":
+	case "audio/l8":
+		f.BitsPerSample = 8
+	case "audio/l24":
+		f.BitsPerSample = 24
+	default:
+		return Format{}, fmt.Errorf("audio: MIME type %q isn't PCM audio", mimeType)
+	}
+	for name, p := range map[string]*int{"rate": &f.SampleRate, "channels": &f.Channels} {
+		v, ok := params[name]
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return Format{}, fmt.Errorf("audio: invalid %s in MIME type %q", name, mimeType)
+		}
+		*p = n
+	}
+	return f, nil
+}
+
+// isPCM reports whether mimeType is the MIME type of PCM audio.
+func isPCM(mimeType string) bool {
+	mediaType, _, _ := strings.Cut(strings.ToLower(mimeType), ";")
+	switch strings.TrimSpace(mediaType) {
+	case "audio/pcm", "audio/l8", "audio/l16", "audio/l24":
+		return true
+	}
+	return false
+}
+
+// EncodeWAV writes pcm to w as a WAV file of the given format.
+func EncodeWAV(w io.Writer, pcm []byte, format Format) error {
+	if format.SampleRate <= 0 || format.Channels <= 0 || format.BitsPerSample <= 0 || format.BitsPerSample%8 != 0 {
+		return fmt.Errorf("audio: invalid format %+v", format)
+	}
+	if uint64(len(pcm)) > 1<<32-1-36 {
+		return fmt.Errorf("audio: %d bytes of audio don't fit in a WAV file", len(pcm))
+	}
+	blockAlign := format.Channels * format.BitsPerSample / 8
+	header := struct {
+		RIFF          [4]byte
+		Size          uint32
+		WAVE          [4]byte
+		Fmt           [4]byte
+		FmtSize       uint32
+		AudioFormat   uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+		Data          [4]byte
+		DataSize      uint32
+	}{
+		RIFF:          [4]byte{'R', 'I', 'F', 'F'},
+		Size:          uint32(36 + len(pcm)),
+		WAVE:          [4]byte{'W', 'A', 'V', 'E'},
+		Fmt:           [4]byte{'f', 'm', 't', ' '},
+		FmtSize:       16,
+		AudioFormat:   1, // PCM
+		Channels:      uint16(format.Channels),
+		SampleRate:    uint32(format.SampleRate),
+		ByteRate:      uint32(format.SampleRate * blockAlign),
+		BlockAlign:    uint16(blockAlign),
+		BitsPerSample: uint16(format.BitsPerSample),
+		Data:          [4]byte{'d', 'a', 't', 'a'},
+		DataSize:      uint32(len(pcm)),
+	}
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	_, err := w.Write(pcm)
+	return err
+}
+
+// ExtractPCM returns the PCM audio of the first candidate of resp, with all
+// its audio parts concatenated, and its format. It returns [ErrNoAudio] if the
+// response doesn't contain audio, and an error if the parts have different
+// formats.
+func ExtractPCM(resp *genai.GenerateContentResponse) ([]byte, Format, error) {
+	if resp == nil || len(resp.Candidates) == 0 || resp.Candidates[0] == nil || resp.Candidates[0].Content == nil {
+		return nil, Format{}, ErrNoAudio
+	}
+	var pcm []byte
+	var format Format
+	found := false
+	for _, p := range resp.Candidates[0].Content.Parts {
+		if p == nil || p.InlineData == nil || !isPCM(p.InlineData.MIMEType) {
+			continue
+		}
+		f, err := ParseMIMEType(p.InlineData.MIMEType)
+		if err != nil {
+			return nil, Format{}, err
+		}
+		if found && f != format {
+			return nil, Format{}, fmt.Errorf("audio: the response contains audio of different formats, %+v and %+v", format, f)
+		}
+		format, found = f, true
+		pcm = append(pcm, p.InlineData.Data...)
+	}
+	if !found {
+		return nil, Format{}, ErrNoAudio
+	}
+	return pcm, format, nil
+}
+
+// WriteWAV writes the audio of resp to w as a WAV file. See [ExtractPCM].
+func WriteWAV(w io.Writer, resp *genai.GenerateContentResponse) error {
+	pcm, format, err := ExtractPCM(resp)
+	if err != nil {
+		return err
+	}
+	return EncodeWAV(w, pcm, format)
+}
+
+// SaveWAV saves the audio of resp to the named file as a WAV file. See
+// [ExtractPCM].
+func SaveWAV(name string, resp *genai.GenerateContentResponse) error {
+	var b bytes.Buffer
+	if err := WriteWAV(&b, resp); err != nil {
+		return err
+	}
+	return os.WriteFile(name, b.Bytes(), 0o644)
+}
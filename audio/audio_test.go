@@ -0,0 +1,175 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+func TestParseMIMEType(t *testing.T) {
+	tests := []struct {
+		mimeType string
+		want     Format
+		wantErr  bool
+	}{
+		{"audio/pcm", Format{24000, 1, 16}, false},
+		{"audio/pcm;rate=16000", Format{16000, 1, 16}, false},
+		{"audio/L16;codec=pcm;rate=24000", Format{24000, 1, 16}, false},
+		{"audio/L16; rate=44100; channels=2", Format{44100, 2, 16}, false},
+		{"audio/L8;rate=8000", Format{8000, 1, 8}, false},
+		{"audio/L24", Format{24000, 1, 24}, false},
+		{"audio/mp3", Format{}, true},
+		{"audio/pcm;rate=fast", Format{}, true},
+		{"audio/pcm;channels=0", Format{}, true},
+		{"", Format{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mimeType, func(t *testing.T) {
+			got, err := ParseMIMEType(tt.mimeType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMIMEType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMIMEType() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodeWAV(t *testing.T) {
+	pcm := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	var b bytes.Buffer
+	if err := EncodeWAV(&b, pcm, Format{SampleRate: 16000, Channels: 2, BitsPerSample: 16}); err != nil {
+		t.Fatal(err)
+	}
+	data := b.Bytes()
+	if len(data) != 44+len(pcm) {
+		t.Fatalf("len(WAV) = %d, want %d", len(data), 44+len(pcm))
+	}
+	le := binary.LittleEndian
+	checks := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"RIFF", string(data[0:4]), "RIFF"},
+		{"size", le.Uint32(data[4:8]), uint32(36 + len(pcm))},
+		{"WAVE", string(data[8:12]), "WAVE"},
+		{"fmt", string(data[12:16]), "fmt "},
+		{"audio format", le.Uint16(data[20:22]), uint16(1)},
+		{"channels", le.Uint16(data[22:24]), uint16(2)},
+		{"sample rate", le.Uint32(data[24:28]), uint32(16000)},
+		{"byte rate", le.Uint32(data[28:32]), uint32(64000)},
+		{"block align", le.Uint16(data[32:34]), uint16(4)},
+		{"bits per sample", le.Uint16(data[34:36]), uint16(16)},
+		{"data", string(data[36:40]), "data"},
+		{"data size", le.Uint32(data[40:44]), uint32(len(pcm))},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if !bytes.Equal(data[44:], pcm) {
+		t.Errorf("samples = %v, want %v", data[44:], pcm)
+	}
+	if err := EncodeWAV(&b, pcm, Format{SampleRate: 16000, Channels: 1, BitsPerSample: 12}); err == nil {
+		t.Error("EncodeWAV() with 12 bits per sample succeeded, want error")
+	}
+}
+
+func audioResponse(parts ...*genai.Part) *genai.GenerateContentResponse {
+	return &genai.GenerateContentResponse{Candidates: []*genai.Candidate{{Content: &genai.Content{Role: genai.RoleModel, Parts: parts}}}}
+}
+
+func TestExtractPCM(t *testing.T) {
+	tests := []struct {
+		name       string
+		resp       *genai.GenerateContentResponse
+		wantPCM    []byte
+		wantFormat Format
+		wantErr    error
+	}{
+		{
+			name: "ConcatenatesParts",
+			resp: audioResponse(
+				genai.NewPartFromBytes([]byte{1, 2}, "audio/L16;codec=pcm;rate=24000"),
+				genai.NewPartFromText("Ignored."),
+				genai.NewPartFromBytes([]byte{3, 4}, "audio/L16;codec=pcm;rate=24000"),
+			),
+			wantPCM:    []byte{1, 2, 3, 4},
+			wantFormat: Format{24000, 1, 16},
+		},
+		{
+			name:    "NoAudio",
+			resp:    audioResponse(genai.NewPartFromText("No audio."), genai.NewPartFromBytes([]byte{1}, "audio/mp3")),
+			wantErr: ErrNoAudio,
+		},
+		{
+			name:    "NoCandidates",
+			resp:    &genai.GenerateContentResponse{},
+			wantErr: ErrNoAudio,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pcm, format, err := ExtractPCM(tt.resp)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ExtractPCM() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantPCM, pcm); diff != "" {
+				t.Errorf("ExtractPCM() PCM mismatch (-want +got):\n%s", diff)
+			}
+			if format != tt.wantFormat {
+				t.Errorf("ExtractPCM() format = %+v, want %+v", format, tt.wantFormat)
+			}
+		})
+	}
+
+	mixed := audioResponse(genai.NewPartFromBytes([]byte{1, 2}, "audio/pcm;rate=24000"), genai.NewPartFromBytes([]byte{3, 4}, "audio/pcm;rate=16000"))
+	if _, _, err := ExtractPCM(mixed); err == nil {
+		t.Error("ExtractPCM() with mixed formats succeeded, want error")
+	}
+}
+
+func TestSaveWAV(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "speech.wav")
+	resp := audioResponse(genai.NewPartFromBytes([]byte{1, 2, 3, 4}, "audio/pcm;rate=24000"))
+	if err := SaveWAV(name, resp); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want bytes.Buffer
+	if err := EncodeWAV(&want, []byte{1, 2, 3, 4}, Format{24000, 1, 16}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("SaveWAV() wrote %v, want %v", got, want.Bytes())
+	}
+	if err := SaveWAV(name, audioResponse()); !errors.Is(err, ErrNoAudio) {
+		t.Errorf("SaveWAV() without audio = %v, want %v", err, ErrNoAudio)
+	}
+}
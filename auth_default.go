@@ -0,0 +1,42 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !genai_noadc
+
+package genai
+
+import (
+	"net/http"
+
+	"cloud.google.com/go/auth"
+	"cloud.google.com/go/auth/credentials"
+	"cloud.google.com/go/auth/httptransport"
+)
+
+func detectDefaultCredentials() (*auth.Credentials, error) {
+	return credentials.DetectDefault(&credentials.DetectOptions{
+		Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+	})
+}
+
+func newAuthenticatedHTTPClient(cred *auth.Credentials, header http.Header) (*http.Client, error) {
+	return httptransport.NewClient(&httptransport.Options{
+		Credentials: cred,
+		Headers:     header,
+	})
+}
+
+func addAuthorizationMiddleware(client *http.Client, cred *auth.Credentials) error {
+	return httptransport.AddAuthorizationMiddleware(client, cred)
+}
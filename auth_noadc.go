@@ -0,0 +1,70 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build genai_noadc
+
+// With the genai_noadc build tag, Application Default Credentials aren't
+// supported, which removes the credential detection and OAuth transport
+// packages from the binary. Explicit ClientConfig.Credentials still work.
+
+package genai
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"cloud.google.com/go/auth"
+)
+
+func detectDefaultCredentials() (*auth.Credentials, error) {
+	return nil, errors.New("application default credentials are not supported in builds with the genai_noadc tag, set ClientConfig.Credentials, ClientConfig.APIKey or ClientConfig.HTTPClient")
+}
+
+func newAuthenticatedHTTPClient(cred *auth.Credentials, header http.Header) (*http.Client, error) {
+	return &http.Client{Transport: &authTransport{cred: cred, header: header, base: http.DefaultTransport}}, nil
+}
+
+func addAuthorizationMiddleware(client *http.Client, cred *auth.Credentials) error {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.Transport = &authTransport{cred: cred, base: base}
+	return nil
+}
+
+// authTransport sets the access token of cred and header on requests.
+type authTransport struct {
+	cred   *auth.Credentials
+	header http.Header
+	base   http.RoundTripper
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.cred.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+	req = req.Clone(req.Context())
+	for k, v := range t.header {
+		req.Header[k] = v
+	}
+	typ := token.Type
+	if typ == "" {
+		typ = "Bearer"
+	}
+	req.Header.Set("Authorization", typ+" "+token.Value)
+	return t.base.RoundTrip(req)
+}
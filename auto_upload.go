@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// defaultAutoUploadMaxInlineBytes keeps base64-encoded requests under the
+// 20 MB request size limit.
+const defaultAutoUploadMaxInlineBytes = 14 * 1024 * 1024
+
+// GCSUploader uploads data to Cloud Storage and returns the gs:// URI of the
+// object holding it.
+type GCSUploader func(ctx context.Context, data []byte, mimeType string) (string, error)
+
+// AutoUploadConfig configures the upload of oversized inline data by
+// [Models.GenerateContentWithOptions] and
+// [Models.GenerateContentStreamWithOptions].
+//
+// When the inline data of the contents adds up to more than MaxInlineBytes,
+// the largest inline data parts are uploaded until the rest fits, and are
+// replaced by file data parts referring to the uploaded files. With the Gemini
+// API, they're uploaded with the Files API, which deletes them after 48 hours.
+// With Vertex AI, they're uploaded to Cloud Storage by GCSUploader. The
+// contents passed by the caller aren't modified.
+type AutoUploadConfig struct {
+	// Optional. Maximum size, in bytes, of the inline data of a request.
+	// Defaults to 14 MB, which keeps base64-encoded requests under the 20 MB
+	// limit.
+	MaxInlineBytes int64
+	// Optional. Uploads the data with Vertex AI. Required to upload data with
+	// Vertex AI, which has no Files API.
+	GCSUploader GCSUploader
+	// Optional. Interval between checks while waiting for a file uploaded with
+	// the Files API to become active. Defaults to 2 seconds.
+	PollInterval time.Duration
+}
+
+// uploadOversizedInlineData returns contents with the inline data uploaded as
+// configured by upload, which may be nil.
+func (m Models) uploadOversizedInlineData(ctx context.Context, contents []*Content, upload *AutoUploadConfig) ([]*Content, error) {
+	if upload == nil {
+		return contents, nil
+	}
+	cfg := *upload
+	if cfg.MaxInlineBytes == 0 {
+		cfg.MaxInlineBytes = defaultAutoUploadMaxInlineBytes
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultFilePollInterval
+	}
+
+	type inlinePart struct {
+		content, part int
+		size          int64
+	}
+	var inline []inlinePart
+	var total int64
+	for i, c := range contents {
+		if c == nil {
+			continue
+		}
+		for j, p := range c.Parts {
+			if p != nil && p.InlineData != nil {
+				size := int64(len(p.InlineData.Data))
+				inline = append(inline, inlinePart{i, j, size})
+				total += size
+			}
+		}
+	}
+	if total <= cfg.MaxInlineBytes {
+		return contents, nil
+	}
+	sort.SliceStable(inline, func(i, j int) bool { return inline[i].size > inline[j].size })
+
+	uploaded := slices.Clone(contents)
+	copied := map[int]bool{}
+	for _, ip := range inline {
+		if total <= cfg.MaxInlineBytes {
+			break
+		}
+		if !copied[ip.content] {
+			c := *uploaded[ip.content]
+			c.Parts = slices.Clone(c.Parts)
+			uploaded[ip.content] = &c
+			copied[ip.content] = true
+		}
+		p := *uploaded[ip.content].Parts[ip.part]
+		uri, err := m.uploadInlineData(ctx, p.InlineData, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("uploading inline data of %d bytes: %w", ip.size, err)
+		}
+		p.FileData = &FileData{FileURI: uri, MIMEType: p.InlineData.MIMEType, DisplayName: p.InlineData.DisplayName}
+		p.InlineData = nil
+		uploaded[ip.content].Parts[ip.part] = &p
+		total -= ip.size
+	}
+	return uploaded, nil
+}
+
+// uploadInlineData uploads blob and returns the URI of the uploaded file.
+func (m Models) uploadInlineData(ctx context.Context, blob *Blob, cfg AutoUploadConfig) (string, error) {
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		if cfg.GCSUploader == nil {
+			return "", fmt.Errorf("AutoUploadConfig.GCSUploader is required to upload data with Vertex AI")
+		}
+		return cfg.GCSUploader(ctx, blob.Data, blob.MIMEType)
+	}
+	files := Files{apiClient: m.apiClient}
+	file, err := files.Upload(ctx, bytes.NewReader(blob.Data), &UploadFileConfig{MIMEType: blob.MIMEType, DisplayName: blob.DisplayName})
+	if err != nil {
+		return "", err
+	}
+	file, err = files.waitForActive(ctx, file, cfg.PollInterval)
+	if err != nil {
+		return "", err
+	}
+	return file.URI, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAutoUpload(t *testing.T) {
+	ctx := context.Background()
+	var uploads []string
+	var gotParts []*Part
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("X-Goog-Upload-Command") == "start":
+			w.Header().Set("X-Goog-Upload-Url", ts.URL+"/resumable")
+			fmt.Fprint(w, `{}`)
+		case r.URL.Path == "/resumable":
+			data, _ := io.ReadAll(r.Body)
+			uploads = append(uploads, string(data))
+			w.Header().Set("X-Goog-Upload-Status", "final")
+			fmt.Fprintf(w, `{"file": {"name": "files/f%d", "uri": "https://files.example.com/f%d", "state": "ACTIVE"}}`, len(uploads), len(uploads))
+		case strings.HasSuffix(r.URL.Path, "enerateContent"):
+			var body struct {
+				Contents []*Content `json:"contents"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			gotParts = body.Contents[0].Parts
+			if strings.HasSuffix(r.URL.Path, ":streamGenerateContent") {
+				fmt.Fprint(w, "data: ")
+			}
+			fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "ok"}]}}]}`+"\n\n")
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+	contents := []*Content{NewContentFromParts([]*Part{
+		NewPartFromBytes([]byte("small"), "text/plain"),
+		NewPartFromBytes([]byte("a large video"), "video/mp4"),
+		NewPartFromText("Describe these."),
+	}, RoleUser)}
+
+	t.Run("UnderLimit", func(t *testing.T) {
+		uploads = nil
+		options := &GenerateContentOptions{AutoUpload: &AutoUploadConfig{MaxInlineBytes: 100}}
+		if _, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", contents, nil, options); err != nil {
+			t.Fatal(err)
+		}
+		if len(uploads) != 0 || gotParts[1].InlineData == nil {
+			t.Errorf("data was uploaded under the limit: %q", uploads)
+		}
+	})
+
+	t.Run("OverLimit", func(t *testing.T) {
+		uploads = nil
+		options := &GenerateContentOptions{AutoUpload: &AutoUploadConfig{MaxInlineBytes: 10}}
+		if _, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", contents, nil, options); err != nil {
+			t.Fatal(err)
+		}
+		if len(uploads) != 1 || uploads[0] != "a large video" {
+			t.Fatalf("uploads = %q, want only the largest data", uploads)
+		}
+		if gotParts[0].InlineData == nil || string(gotParts[0].InlineData.Data) != "small" {
+			t.Errorf("part 0 = %+v, want the small inline data", gotParts[0])
+		}
+		want := &FileData{FileURI: "https://files.example.com/f1", MIMEType: "video/mp4"}
+		if gotParts[1].InlineData != nil || gotParts[1].FileData == nil || *gotParts[1].FileData != *want {
+			t.Errorf("part 1 = %+v, want file data %+v", gotParts[1], want)
+		}
+		if contents[0].Parts[1].InlineData == nil || contents[0].Parts[1].FileData != nil {
+			t.Errorf("the caller's contents were modified")
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		uploads = nil
+		options := &GenerateContentOptions{AutoUpload: &AutoUploadConfig{MaxInlineBytes: 1}}
+		for _, err := range m.GenerateContentStreamWithOptions(ctx, "gemini-2.5-flash", contents, nil, options) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(uploads) != 2 {
+			t.Errorf("uploads = %q, want all inline data", uploads)
+		}
+	})
+
+	t.Run("VertexGCSUploader", func(t *testing.T) {
+		var gotMIMEType string
+		uploader := func(ctx context.Context, data []byte, mimeType string) (string, error) {
+			gotMIMEType = mimeType
+			return "gs://bucket/upload-1", nil
+		}
+		vertex := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI}}}
+		got, err := vertex.uploadOversizedInlineData(ctx, contents, &AutoUploadConfig{MaxInlineBytes: 10, GCSUploader: uploader})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fd := got[0].Parts[1].FileData; fd == nil || fd.FileURI != "gs://bucket/upload-1" || gotMIMEType != "video/mp4" {
+			t.Errorf("part 1 = %+v, want the uploaded Cloud Storage object", got[0].Parts[1])
+		}
+
+		_, err = vertex.uploadOversizedInlineData(ctx, contents, &AutoUploadConfig{MaxInlineBytes: 10})
+		if err == nil || !strings.Contains(err.Error(), "GCSUploader") {
+			t.Errorf("uploadOversizedInlineData() error = %v, want a GCSUploader error", err)
+		}
+	})
+}
@@ -0,0 +1,182 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// BatchTemplateKeyMetadata is the metadata key under which the key of an item
+// of a [BatchTemplate] is set in its inlined request, to match the responses
+// with the items.
+const BatchTemplateKeyMetadata = "key"
+
+// BatchTemplate describes the requests of a batch job as a config shared by
+// all of them and a list of items, each with its own contents and config
+// overrides, for example a different system instruction or response schema.
+// [BatchTemplate.InlinedRequests] and [Batches.WriteJSONL] expand it into the
+// requests of a batch job.
+type BatchTemplate struct {
+	// Optional. Config shared by all the requests.
+	Config *GenerateContentConfig
+	// Required. Items of the batch, one per request.
+	Items []*BatchItem
+}
+
+// BatchItem is an item of a [BatchTemplate].
+type BatchItem struct {
+	// Optional. Key identifying the item. It's set in the metadata of inlined
+	// requests and as the key of the requests of JSONL files of the Gemini
+	// API, which is reported along with the response. Keys must be unique.
+	Key string
+	// Required. Contents of the request.
+	Contents []*Content
+	// Optional. Metadata of the inlined request.
+	Metadata map[string]string
+	// Optional. Overrides of the config of the template. The fields that are
+	// set, that is not zero, replace those of the config of the template.
+	Config *GenerateContentConfig
+}
+
+// InlinedRequests returns the inlined requests of the items of the template,
+// whose configs are the config of the template with the overrides of each
+// item.
+//
+//	requests, err := template.InlinedRequests()
+//	if err != nil {
+//		return err
+//	}
+//	job, err := client.Batches.Create(ctx, model, &genai.BatchJobSource{InlinedRequests: requests}, nil)
+func (t *BatchTemplate) InlinedRequests() ([]*InlinedRequest, error) {
+	if err := t.validate(); err != nil {
+		return nil, fmt.Errorf("InlinedRequests: %w", err)
+	}
+	requests := make([]*InlinedRequest, len(t.Items))
+	for i, item := range t.Items {
+		r := &InlinedRequest{Contents: item.Contents, Config: overrideConfig(t.Config, item.Config)}
+		if item.Key != "" || len(item.Metadata) > 0 {
+			r.Metadata = map[string]string{}
+			for k, v := range item.Metadata {
+				r.Metadata[k] = v
+			}
+			if item.Key != "" {
+				r.Metadata[BatchTemplateKeyMetadata] = item.Key
+			}
+		}
+		requests[i] = r
+	}
+	return requests, nil
+}
+
+// WriteJSONL writes the requests of the items of template to w in the JSONL
+// format of batch job input files of the backend of the client, one request
+// per line. Upload the file, with [Files.Upload] for the Gemini API or to
+// Cloud Storage for Vertex AI, and create the batch job with it as the source.
+//
+// The lines of the Gemini API hold the key of the item, if any. Vertex AI
+// doesn't support keys, and its responses are matched with the requests by
+// the requests themselves, so keys are left out.
+func (b Batches) WriteJSONL(w io.Writer, template *BatchTemplate) error {
+	if err := template.validate(); err != nil {
+		return fmt.Errorf("WriteJSONL: %w", err)
+	}
+	isVertex := b.apiClient.clientConfig.Backend == BackendVertexAI
+	toConverter := generateContentParametersToMldev
+	if isVertex {
+		toConverter = generateContentParametersToVertex
+	}
+	for i, item := range template.Items {
+		parameterMap := make(map[string]any)
+		kwargs := map[string]any{"contents": item.Contents, "config": overrideConfig(template.Config, item.Config)}
+		if err := InternalDeepMarshal(kwargs, &parameterMap); err != nil {
+			return fmt.Errorf("WriteJSONL: item %d: %w", i, err)
+		}
+		request, err := toConverter(b.apiClient, parameterMap, nil, parameterMap)
+		if err != nil {
+			return fmt.Errorf("WriteJSONL: item %d: %w", i, err)
+		}
+		delete(request, "_url")
+		line := map[string]any{"request": request}
+		if item.Key != "" && !isVertex {
+			line["key"] = item.Key
+		}
+		data, err := json.Marshal(line)
+		if err != nil {
+			return fmt.Errorf("WriteJSONL: item %d: %w", i, err)
+		}
+		if _, err := w.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("WriteJSONL: %w", err)
+		}
+	}
+	return nil
+}
+
+// CreateFromTemplate creates a batch job of model with the inlined requests
+// of template, see [BatchTemplate.InlinedRequests]. Inlined requests are only
+// supported by the Gemini API; use [Batches.WriteJSONL] for Vertex AI.
+func (b Batches) CreateFromTemplate(ctx context.Context, model string, template *BatchTemplate, config *CreateBatchJobConfig) (*BatchJob, error) {
+	requests, err := template.InlinedRequests()
+	if err != nil {
+		return nil, err
+	}
+	return b.Create(ctx, model, &BatchJobSource{InlinedRequests: requests}, config)
+}
+
+func (t *BatchTemplate) validate() error {
+	if t == nil || len(t.Items) == 0 {
+		return fmt.Errorf("the template has no items")
+	}
+	keys := map[string]bool{}
+	for i, item := range t.Items {
+		if item == nil || len(item.Contents) == 0 {
+			return fmt.Errorf("item %d has no contents", i)
+		}
+		if item.Key == "" {
+			continue
+		}
+		if keys[item.Key] {
+			return fmt.Errorf("item %d has duplicate key %q", i, item.Key)
+		}
+		keys[item.Key] = true
+	}
+	return nil
+}
+
+// overrideConfig returns a copy of base in which the fields set in override
+// replace those of base. Neither base nor override is modified.
+func overrideConfig(base, override *GenerateContentConfig) *GenerateContentConfig {
+	if base == nil && override == nil {
+		return nil
+	}
+	config := &GenerateContentConfig{}
+	if base != nil {
+		*config = *base
+	}
+	if override == nil {
+		return config
+	}
+	dst := reflect.ValueOf(config).Elem()
+	src := reflect.ValueOf(override).Elem()
+	for i := range src.NumField() {
+		if f := src.Field(i); src.Type().Field(i).IsExported() && !f.IsZero() {
+			dst.Field(i).Set(f)
+		}
+	}
+	return config
+}
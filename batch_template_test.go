@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testBatchTemplate() *BatchTemplate {
+	return &BatchTemplate{
+		Config: &GenerateContentConfig{
+			Temperature:       Ptr[float32](0.2),
+			SystemInstruction: NewContentFromText("Answer briefly.", RoleUser),
+		},
+		Items: []*BatchItem{
+			{Key: "a", Contents: Text("What is Go?")},
+			{
+				Key:      "b",
+				Contents: Text("List three colors."),
+				Metadata: map[string]string{"user": "42"},
+				Config: &GenerateContentConfig{
+					SystemInstruction: NewContentFromText("Answer in JSON.", RoleUser),
+					ResponseMIMEType:  "application/json",
+				},
+			},
+		},
+	}
+}
+
+func TestBatchTemplateInlinedRequests(t *testing.T) {
+	template := testBatchTemplate()
+	got, err := template.InlinedRequests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*InlinedRequest{
+		{
+			Contents: Text("What is Go?"),
+			Metadata: map[string]string{"key": "a"},
+			Config:   template.Config,
+		},
+		{
+			Contents: Text("List three colors."),
+			Metadata: map[string]string{"key": "b", "user": "42"},
+			Config: &GenerateContentConfig{
+				Temperature:       Ptr[float32](0.2),
+				SystemInstruction: NewContentFromText("Answer in JSON.", RoleUser),
+				ResponseMIMEType:  "application/json",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("InlinedRequests() mismatch (-want +got):\n%s", diff)
+	}
+	if got[0].Config == template.Config {
+		t.Error("InlinedRequests() shares the config of the template")
+	}
+	if template.Items[1].Metadata["key"] != "" {
+		t.Error("InlinedRequests() modified the metadata of an item")
+	}
+
+	for name, template := range map[string]*BatchTemplate{
+		"NoItems":       {},
+		"NoContents":    {Items: []*BatchItem{{Key: "a"}}},
+		"DuplicateKeys": {Items: []*BatchItem{{Key: "a", Contents: Text("1")}, {Key: "a", Contents: Text("2")}}},
+	} {
+		if _, err := template.InlinedRequests(); err == nil {
+			t.Errorf("%s: InlinedRequests() succeeded, want error", name)
+		}
+	}
+}
+
+func TestBatchesWriteJSONL(t *testing.T) {
+	tests := []struct {
+		backend Backend
+		want    []string
+	}{
+		{
+			backend: BackendGeminiAPI,
+			want: []string{
+				`{"key":"a","request":{"contents":[{"parts":[{"text":"What is Go?"}],"role":"user"}],"generationConfig":{"temperature":0.2},"systemInstruction":{"parts":[{"text":"Answer briefly."}],"role":"user"}}}`,
+				`{"key":"b","request":{"contents":[{"parts":[{"text":"List three colors."}],"role":"user"}],"generationConfig":{"responseMimeType":"application/json","temperature":0.2},"systemInstruction":{"parts":[{"text":"Answer in JSON."}],"role":"user"}}}`,
+			},
+		},
+		{
+			backend: BackendVertexAI,
+			want: []string{
+				`{"request":{"contents":[{"parts":[{"text":"What is Go?"}],"role":"user"}],"generationConfig":{"temperature":0.2},"systemInstruction":{"parts":[{"text":"Answer briefly."}],"role":"user"}}}`,
+				`{"request":{"contents":[{"parts":[{"text":"List three colors."}],"role":"user"}],"generationConfig":{"responseMimeType":"application/json","temperature":0.2},"systemInstruction":{"parts":[{"text":"Answer in JSON."}],"role":"user"}}}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.backend.String(), func(t *testing.T) {
+			batches := Batches{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: tt.backend}}}
+			var buf bytes.Buffer
+			if err := batches.WriteJSONL(&buf, testBatchTemplate()); err != nil {
+				t.Fatal(err)
+			}
+			got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("WriteJSONL() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBatchesCreateFromTemplate(t *testing.T) {
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(`{"name": "batches/123"}`))
+	}))
+	defer ts.Close()
+	batches := Batches{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta"}, HTTPClient: ts.Client()}}}
+
+	job, err := batches.CreateFromTemplate(context.Background(), "gemini-2.5-flash", testBatchTemplate(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Name != "batches/123" {
+		t.Errorf("CreateFromTemplate() name = %q, want batches/123", job.Name)
+	}
+	requests, _ := InternalGetValueByPath(body, []string{"batch", "inputConfig", "requests", "requests"}).([]any)
+	if len(requests) != 2 {
+		t.Fatalf("request body = %v, want 2 inlined requests", body)
+	}
+	if got := InternalGetValueByPath(requests[1].(map[string]any), []string{"metadata", "key"}); got != "b" {
+		t.Errorf("key of the second request = %v, want b", got)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestBatchesGetInlinedEmbeddings(t *testing.T) {
@@ -169,3 +171,45 @@ func TestBatchesGetInlinedText(t *testing.T) {
 		}
 	}
 }
+
+func TestBatchesCreateWithEncryption(t *testing.T) {
+	ctx := context.Background()
+	const kmsKeyName = "projects/my-project/locations/us-central1/keyRings/ring/cryptoKeys/key"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if diff := cmp.Diff(map[string]any{"kmsKeyName": kmsKeyName}, body["encryptionSpec"]); diff != "" {
+			t.Errorf("encryptionSpec mismatch (-want +got):\n%s", diff)
+		}
+		if got := body["labels"]; got == nil {
+			t.Errorf("labels = nil, want the labels of HTTPOptions.ExtraBody")
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"name":  "projects/my-project/locations/us-central1/batchPredictionJobs/123",
+			"state": "JOB_STATE_PENDING",
+		})
+	}))
+	defer ts.Close()
+	src := &BatchJobSource{GCSURI: []string{"gs://bucket/input.jsonl"}}
+	extraBody := map[string]any{"labels": map[string]any{"team": "ml"}}
+	config := &CreateBatchJobConfig{
+		Dest:        &BatchJobDestination{GCSURI: "gs://bucket/output"},
+		HTTPOptions: &HTTPOptions{ExtraBody: extraBody},
+	}
+	encryptionSpec := &EncryptionSpec{KmsKeyName: kmsKeyName}
+
+	b := Batches{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+	if _, err := b.CreateWithEncryption(ctx, "gemini-2.5-flash", src, encryptionSpec, config); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]any{"labels": map[string]any{"team": "ml"}}, config.HTTPOptions.ExtraBody); diff != "" {
+		t.Errorf("CreateWithEncryption() modified the ExtraBody of config (-want +got):\n%s", diff)
+	}
+
+	b = Batches{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta"}, HTTPClient: ts.Client()}}}
+	if _, err := b.CreateWithEncryption(ctx, "gemini-2.5-flash", &BatchJobSource{FileName: "files/input"}, encryptionSpec, nil); err == nil {
+		t.Error("CreateWithEncryption() on the Gemini API succeeded, want error")
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const defaultBestOfNSamples = 4
+
+// CandidateScorer scores a candidate. Higher scores are better.
+type CandidateScorer func(ctx context.Context, candidate *Candidate) (float64, error)
+
+// ScoreAvgLogprobs is a [CandidateScorer] that scores a candidate by the
+// average log probability of its tokens, as reported by the model in
+// [Candidate.AvgLogprobs].
+func ScoreAvgLogprobs(ctx context.Context, candidate *Candidate) (float64, error) {
+	return candidate.AvgLogprobs, nil
+}
+
+// NewJudgeScorer returns a [CandidateScorer] that asks model to grade the text
+// of each candidate from 0 to 10 following instructions, which should state
+// the task the candidates answer and the grading criteria.
+func NewJudgeScorer(m Models, model, instructions string) CandidateScorer {
+	type judgement struct {
+		Score float64 `json:"score" description:"Grade of the answer, from 0 (worst) to 10 (best)."`
+	}
+	return func(ctx context.Context, candidate *Candidate) (float64, error) {
+		prompt := fmt.Sprintf("%s\n\nGrade the following answer from 0 to 10.\n\n<answer>\n%s\n</answer>", instructions, candidateText(candidate))
+		j, _, err := GenerateContentAs[judgement](ctx, &m, model, Text(prompt), &GenerateContentConfig{Temperature: Ptr[float32](0)})
+		if err != nil {
+			return 0, fmt.Errorf("judge: %w", err)
+		}
+		return j.Score, nil
+	}
+}
+
+// BestOfNConfig configures [Models.GenerateBestOfN].
+type BestOfNConfig struct {
+	// Optional. Number of candidates to sample. Defaults to 4.
+	Samples int32
+	// Optional. Issues Samples separate GenerateContent calls, as
+	// [ConsensusConfig.SeparateCalls] does.
+	SeparateCalls bool
+	// Optional. Scores the candidates. Defaults to [ScoreAvgLogprobs].
+	Scorer CandidateScorer
+}
+
+// RankedCandidate is a candidate with its score.
+type RankedCandidate struct {
+	// Candidate is the scored candidate.
+	Candidate *Candidate
+	// Score is the score of the candidate.
+	Score float64
+	// Index is the position of the candidate among the sampled candidates.
+	// For [Models.GenerateBestOfN], it's the index of the candidate in the
+	// Candidates of the response, or of its response in Responses with
+	// SeparateCalls.
+	Index int
+}
+
+// BestOfNResult is the outcome of [Models.GenerateBestOfN].
+type BestOfNResult struct {
+	// Best is the candidate with the highest score. It's Rankings[0].
+	Best *RankedCandidate
+	// Rankings are all candidates, ordered by decreasing score and then by
+	// sampling order.
+	Rankings []*RankedCandidate
+	// Responses are the raw responses the candidates were taken from.
+	Responses []*GenerateContentResponse
+}
+
+// RankCandidates scores the candidates concurrently with scorer and returns
+// them ordered by decreasing score. Ties are broken in favor of the candidate
+// that comes first.
+func RankCandidates(ctx context.Context, candidates []*Candidate, scorer CandidateScorer) ([]*RankedCandidate, error) {
+	ranked := make([]*RankedCandidate, len(candidates))
+	errs := make([]error, len(candidates))
+	var wg sync.WaitGroup
+	for i, c := range candidates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			score, err := scorer(ctx, c)
+			ranked[i] = &RankedCandidate{Candidate: c, Score: score, Index: i}
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("scoring candidate %d: %w", i, err)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked, nil
+}
+
+// GenerateBestOfN samples several candidates for the same request, scores
+// them and returns the best one along with the rankings of all of them.
+//
+// By default a single request is made with CandidateCount set to the number of
+// samples, as by [Models.GenerateConsensus], and the candidates are scored by
+// their average log probability.
+// Set [BestOfNConfig.Scorer] to score them with custom code or with a judge
+// model, see [NewJudgeScorer].
+func (m Models) GenerateBestOfN(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, bestOfNConfig *BestOfNConfig) (*BestOfNResult, error) {
+	bc := BestOfNConfig{}
+	if bestOfNConfig != nil {
+		bc = *bestOfNConfig
+	}
+	if bc.Samples < 0 {
+		return nil, fmt.Errorf("GenerateBestOfN: samples must be positive, got %d", bc.Samples)
+	}
+	if bc.Samples == 0 {
+		bc.Samples = defaultBestOfNSamples
+	}
+	if bc.Scorer == nil {
+		bc.Scorer = ScoreAvgLogprobs
+	}
+
+	sampled, responses, err := m.sampleCandidates(ctx, model, contents, config, bc.Samples, bc.SeparateCalls)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*Candidate
+	// indexes are the positions of the candidates in sampled.
+	var indexes []int
+	for i, c := range sampled {
+		if c != nil {
+			candidates = append(candidates, c)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return &BestOfNResult{Responses: responses}, fmt.Errorf("GenerateBestOfN: the model returned no candidates")
+	}
+
+	ranked, err := RankCandidates(ctx, candidates, bc.Scorer)
+	if err != nil {
+		return nil, fmt.Errorf("GenerateBestOfN: %w", err)
+	}
+	for _, r := range ranked {
+		r.Index = indexes[r.Index]
+	}
+	return &BestOfNResult{Best: ranked[0], Rankings: ranked, Responses: responses}, nil
+}
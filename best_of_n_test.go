@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGenerateBestOfN(t *testing.T) {
+	ctx := context.Background()
+	var gotCandidateCount float64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		data, _ := json.Marshal(body["contents"])
+		if prompt := string(data); strings.Contains(prompt, "Grade the following answer") {
+			// Judge request: the longest answer gets the best grade.
+			score := 1
+			if strings.Contains(prompt, "a detailed answer") {
+				score = 9
+			}
+			fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "{\"score\": %d}"}]}}]}`, score)
+			return
+		}
+		gotCandidateCount, _ = body["generationConfig"].(map[string]any)["candidateCount"].(float64)
+		fmt.Fprint(w, `{"candidates": [
+			{"index": 0, "avgLogprobs": -0.5, "content": {"role": "model", "parts": [{"text": "short"}]}},
+			{"index": 1, "avgLogprobs": -0.1, "content": {"role": "model", "parts": [{"text": "a detailed answer"}]}},
+			{"index": 2, "avgLogprobs": -0.1, "content": {"role": "model", "parts": [{"text": "tie"}]}}
+		]}`)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+
+	t.Run("AvgLogprobs", func(t *testing.T) {
+		got, err := m.GenerateBestOfN(ctx, "gemini-2.5-flash", Text("Explain."), nil, &BestOfNConfig{Samples: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotCandidateCount != 3 {
+			t.Errorf("candidateCount = %v, want 3", gotCandidateCount)
+		}
+		var order []int
+		for _, r := range got.Rankings {
+			order = append(order, r.Index)
+		}
+		if fmt.Sprint(order) != "[1 2 0]" || got.Best != got.Rankings[0] {
+			t.Errorf("rankings = %v, want [1 2 0]", order)
+		}
+	})
+
+	t.Run("Judge", func(t *testing.T) {
+		got, err := m.GenerateBestOfN(ctx, "gemini-2.5-flash", Text("Explain."), nil, &BestOfNConfig{
+			Samples: 3,
+			Scorer:  NewJudgeScorer(m, "gemini-2.5-pro", "The task is to explain. Prefer detailed answers."),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Best.Index != 1 || got.Best.Score != 9 {
+			t.Errorf("best = candidate %d with score %v, want candidate 1 with score 9", got.Best.Index, got.Best.Score)
+		}
+	})
+
+	t.Run("MissingCandidates", func(t *testing.T) {
+		var calls atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch calls.Add(1) {
+			case 1:
+				fmt.Fprint(w, `{"candidates": []}`)
+			case 2:
+				fmt.Fprint(w, `{"candidates": [{"avgLogprobs": -0.5, "content": {"role": "model", "parts": [{"text": "short"}]}}]}`)
+			default:
+				fmt.Fprint(w, `{"candidates": [{"avgLogprobs": -0.1, "content": {"role": "model", "parts": [{"text": "a detailed answer"}]}}]}`)
+			}
+		}))
+		defer ts.Close()
+		m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+			Backend:     BackendGeminiAPI,
+			HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+			HTTPClient:  ts.Client(),
+		}}}
+		got, err := m.GenerateBestOfN(ctx, "gemini-2.5-flash", Text("Explain."), nil, &BestOfNConfig{Samples: 3, SeparateCalls: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range got.Rankings {
+			if c := firstCandidate(got.Responses[r.Index]); c != r.Candidate {
+				t.Errorf("candidate of response %d = %v, want %v", r.Index, c, r.Candidate)
+			}
+		}
+		if got.Best.Index != 2 {
+			t.Errorf("best = candidate %d, want candidate 2", got.Best.Index)
+		}
+	})
+
+	t.Run("ScorerError", func(t *testing.T) {
+		wantErr := errors.New("scorer failed")
+		_, err := m.GenerateBestOfN(ctx, "gemini-2.5-flash", Text("Explain."), nil, &BestOfNConfig{
+			Scorer: func(ctx context.Context, c *Candidate) (float64, error) { return 0, wantErr },
+		})
+		if !errors.Is(err, wantErr) {
+			t.Errorf("error = %v, want %v", err, wantErr)
+		}
+	})
+}
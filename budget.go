@@ -0,0 +1,221 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// ErrBudgetExceeded is matched by the [*BudgetExceededError] returned when a
+// [Budget] is used up. Use errors.Is(err, genai.ErrBudgetExceeded) to detect it.
+var ErrBudgetExceeded = errors.New("budget exceeded")
+
+// BudgetExceededError is returned by content generation calls once the
+// [Budget] they are charged to is used up.
+type BudgetExceededError struct {
+	// UsedTokens is the number of tokens used so far.
+	UsedTokens int64
+	// MaxTotalTokens is the token limit of the budget, or 0 if there is none.
+	MaxTotalTokens int64
+	// Spend is the estimated spend so far.
+	Spend float64
+	// MaxSpend is the spend limit of the budget, or 0 if there is none.
+	MaxSpend float64
+}
+
+// Error returns a string representation of the BudgetExceededError.
+func (e *BudgetExceededError) Error() string {
+	if e.MaxSpend > 0 && e.Spend >= e.MaxSpend {
+		return fmt.Sprintf("budget exceeded: estimated spend %g reached the limit of %g", e.Spend, e.MaxSpend)
+	}
+	return fmt.Sprintf("budget exceeded: %d tokens used, limit is %d", e.UsedTokens, e.MaxTotalTokens)
+}
+
+// Is reports whether target is [ErrBudgetExceeded].
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+// Budget limits the tokens or the estimated spend of content generation calls.
+// Attach it to a client with [ClientConfig.Budget] or to a chat with
+// [Chat.SetBudget]. The same Budget may be shared between several clients and
+// chats and is safe for concurrent use. It limits the calls of
+// [Models.GenerateContentWithOptions], [Models.GenerateContentStreamWithOptions]
+// and the helpers built on them, such as chats.
+//
+// Usage is recorded from the usage metadata of each response, including the
+// failed attempts of retried streams. A Budget attached to both a client and
+// one of its chats is charged once per call. Once the budget is used up,
+// further calls fail with a [*BudgetExceededError] before any request is sent.
+// A call that starts within budget may overshoot it, so set the limits with
+// some headroom.
+type Budget struct {
+	// Optional. Maximum total number of tokens, as reported in
+	// [GenerateContentResponseUsageMetadata.TotalTokenCount]. 0 means no limit.
+	MaxTotalTokens int64
+	// Optional. Maximum estimated spend, in the unit returned by EstimateSpend.
+	// 0 means no limit. Requires EstimateSpend.
+	MaxSpend float64
+	// Optional. EstimateSpend returns the cost of one response, for example from
+	// per-model token prices.
+	EstimateSpend func(model string, usage *GenerateContentResponseUsageMetadata) float64
+
+	mu         sync.Mutex
+	usedTokens int64
+	spend      float64
+}
+
+// Usage returns the tokens used and the estimated spend so far.
+func (b *Budget) Usage() (tokens int64, spend float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.usedTokens, b.spend
+}
+
+// Reset clears the recorded usage.
+func (b *Budget) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.usedTokens = 0
+	b.spend = 0
+}
+
+// check returns a *BudgetExceededError if the budget is used up. A nil budget
+// has no limits.
+func (b *Budget) check() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if (b.MaxTotalTokens > 0 && b.usedTokens >= b.MaxTotalTokens) || (b.MaxSpend > 0 && b.spend >= b.MaxSpend) {
+		return &BudgetExceededError{
+			UsedTokens:     b.usedTokens,
+			MaxTotalTokens: b.MaxTotalTokens,
+			Spend:          b.spend,
+			MaxSpend:       b.MaxSpend,
+		}
+	}
+	return nil
+}
+
+// record charges the usage of a response to the budget.
+func (b *Budget) record(model string, usage *GenerateContentResponseUsageMetadata) {
+	if b == nil || usage == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.usedTokens += int64(usage.TotalTokenCount)
+	if b.EstimateSpend != nil {
+		b.spend += b.EstimateSpend(model, usage)
+	}
+}
+
+type budgetContextKey struct{}
+
+// budgets are the budgets a call is charged to.
+type budgets []*Budget
+
+// contextWithBudget returns a context whose content generation calls are also
+// charged to budget. A budget already attached to ctx isn't attached again.
+func contextWithBudget(ctx context.Context, budget *Budget) context.Context {
+	if budget == nil {
+		return ctx
+	}
+	parent, _ := ctx.Value(budgetContextKey{}).(budgets)
+	if slices.Contains(parent, budget) {
+		return ctx
+	}
+	return context.WithValue(ctx, budgetContextKey{}, append(budgets{budget}, parent...))
+}
+
+// budgets returns the client budget and the budgets attached to ctx, each
+// once, so that a Budget shared by the client and a chat is charged once.
+func (m Models) budgets(ctx context.Context) budgets {
+	parent, _ := ctx.Value(budgetContextKey{}).(budgets)
+	b := m.apiClient.clientConfig.Budget
+	if b == nil || slices.Contains(parent, b) {
+		return parent
+	}
+	return append(budgets{b}, parent...)
+}
+
+func (bs budgets) check() error {
+	for _, b := range bs {
+		if err := b.check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bs budgets) record(model string, usage *GenerateContentResponseUsageMetadata) {
+	for _, b := range bs {
+		b.record(model, usage)
+	}
+}
+
+// recordStream returns an iterator that yields the chunks of stream and
+// charges the usage of the last chunk reporting usage metadata, which covers
+// the whole response, once the stream ends.
+func (bs budgets) recordStream(model string, stream iter.Seq2[*GenerateContentResponse, error]) iter.Seq2[*GenerateContentResponse, error] {
+	if len(bs) == 0 {
+		return stream
+	}
+	return func(yield func(*GenerateContentResponse, error) bool) {
+		var usage *GenerateContentResponseUsageMetadata
+		defer func() { bs.record(model, usage) }()
+		for chunk, err := range stream {
+			if chunk != nil && chunk.UsageMetadata != nil {
+				usage = chunk.UsageMetadata
+			}
+			if !yield(chunk, err) {
+				return
+			}
+		}
+	}
+}
+
+// generateContentWithBudget makes a single GenerateContent call charged to the
+// budgets of the client and ctx.
+func (m Models) generateContentWithBudget(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig) (*GenerateContentResponse, error) {
+	bs := m.budgets(ctx)
+	if err := bs.check(); err != nil {
+		return nil, err
+	}
+	resp, err := m.generateContent(ctx, model, contents, config)
+	if err != nil {
+		return nil, err
+	}
+	bs.record(model, resp.UsageMetadata)
+	return resp, nil
+}
+
+// generateContentStreamWithBudget makes a single GenerateContentStream call
+// charged to the budgets of the client and ctx. Retried streams make one call
+// per attempt, so that the usage of the failed attempts is charged too.
+func (m Models) generateContentStreamWithBudget(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig) iter.Seq2[*GenerateContentResponse, error] {
+	bs := m.budgets(ctx)
+	if err := bs.check(); err != nil {
+		return yieldErrorAndEndIterator[GenerateContentResponse](err)
+	}
+	return bs.recordStream(model, m.generateContentStream(ctx, model, contents, config))
+}
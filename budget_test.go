@@ -0,0 +1,166 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newBudgetTestConfig returns a ClientConfig for a test server that reports 10
+// tokens for every response, and a pointer to the number of requests served.
+func newBudgetTestConfig(t *testing.T, budget *Budget) (*ClientConfig, *int) {
+	t.Helper()
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		resp := `{"candidates": [{"content": {"role": "model", "parts": [{"text": "ok"}]}, "finishReason": "STOP"}], "usageMetadata": {"promptTokenCount": 4, "candidatesTokenCount": 6, "totalTokenCount": 10}}`
+		if strings.Contains(r.URL.Path, "streamGenerateContent") {
+			fmt.Fprintf(w, "data: %s\n\n", resp)
+			return
+		}
+		fmt.Fprint(w, resp)
+	}))
+	t.Cleanup(ts.Close)
+	return &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+		Budget:      budget,
+	}, &calls
+}
+
+func TestBudget(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Client", func(t *testing.T) {
+		budget := &Budget{MaxTotalTokens: 15}
+		cc, calls := newBudgetTestConfig(t, budget)
+		m := Models{apiClient: &apiClient{clientConfig: cc}}
+		for range 2 {
+			if _, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("hi"), nil, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+		_, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("hi"), nil, nil)
+		var budgetErr *BudgetExceededError
+		if !errors.Is(err, ErrBudgetExceeded) || !errors.As(err, &budgetErr) {
+			t.Fatalf("got error %v, want ErrBudgetExceeded", err)
+		}
+		if budgetErr.UsedTokens != 20 || *calls != 2 {
+			t.Errorf("got %d used tokens after %d calls, want 20 after 2", budgetErr.UsedTokens, *calls)
+		}
+		for _, err := range m.GenerateContentStreamWithOptions(ctx, "gemini-2.5-flash", Text("hi"), nil, nil) {
+			if !errors.Is(err, ErrBudgetExceeded) {
+				t.Errorf("stream got error %v, want ErrBudgetExceeded", err)
+			}
+		}
+	})
+
+	t.Run("ChatStreamSpend", func(t *testing.T) {
+		cc, _ := newBudgetTestConfig(t, nil)
+		chats := &Chats{apiClient: &apiClient{clientConfig: cc}}
+		chat, err := chats.Create(ctx, "gemini-2.5-flash", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		budget := &Budget{
+			MaxSpend: 0.01,
+			EstimateSpend: func(model string, usage *GenerateContentResponseUsageMetadata) float64 {
+				return float64(usage.TotalTokenCount) * 0.001
+			},
+		}
+		chat.SetBudget(budget)
+		for _, err := range chat.SendStream(ctx, NewPartFromText("hi")) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tokens, spend := budget.Usage(); tokens != 10 || spend < 0.01 {
+			t.Errorf("Usage() = %d, %g, want 10, 0.01", tokens, spend)
+		}
+		if _, err := chat.Send(ctx, NewPartFromText("hi")); !errors.Is(err, ErrBudgetExceeded) {
+			t.Errorf("got error %v, want ErrBudgetExceeded", err)
+		}
+
+		budget.Reset()
+		if _, err := chat.Send(ctx, NewPartFromText("hi")); err != nil {
+			t.Errorf("after Reset got error %v, want nil", err)
+		}
+	})
+	t.Run("SharedByClientAndChat", func(t *testing.T) {
+		budget := &Budget{}
+		cc, _ := newBudgetTestConfig(t, budget)
+		chats := &Chats{apiClient: &apiClient{clientConfig: cc}}
+		chat, err := chats.Create(ctx, "gemini-2.5-flash", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chat.SetBudget(budget)
+		if _, err := chat.Send(ctx, NewPartFromText("hi")); err != nil {
+			t.Fatal(err)
+		}
+		for _, err := range chat.SendStream(ctx, NewPartFromText("hi")) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tokens, _ := budget.Usage(); tokens != 20 {
+			t.Errorf("Usage() = %d tokens, want 20", tokens)
+		}
+	})
+
+	t.Run("StreamRetries", func(t *testing.T) {
+		calls := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			fmt.Fprint(w, `data: {"candidates": [{"content": {"role": "model", "parts": [{"text": "ok"}]}}], "usageMetadata": {"totalTokenCount": 10}}`+"\n\n")
+			if calls > 1 {
+				return
+			}
+			// Fail the first attempt after its first chunk.
+			w.(http.Flusher).Flush()
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("Hijack() failed: %v", err)
+				return
+			}
+			conn.Close()
+		}))
+		defer ts.Close()
+		budget := &Budget{}
+		m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+			Backend:     BackendGeminiAPI,
+			HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+			HTTPClient:  ts.Client(),
+			Budget:      budget,
+		}}}
+		options := &GenerateContentOptions{StreamRetry: &StreamRetryConfig{InitialDelay: time.Millisecond}}
+		for _, err := range m.GenerateContentStreamWithOptions(ctx, "gemini-2.5-flash", Text("hi"), nil, options) {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tokens, _ := budget.Usage(); tokens != 20 || calls != 2 {
+			t.Errorf("Usage() = %d tokens after %d requests, want 20 after 2", tokens, calls)
+		}
+	})
+}
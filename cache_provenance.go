@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// CacheProvenance describes the cached content that served the prefix of a
+// request, see [GenerateContentResponse.CacheProvenance].
+type CacheProvenance struct {
+	// Name is the name of the cached content, as set in
+	// [GenerateContentConfig.CachedContent].
+	Name string
+	// DisplayName is the display name of the cached content. It's empty unless
+	// [ClientConfig.LookupCacheProvenance] is set and the cached content could
+	// be looked up.
+	DisplayName string
+	// ExpireTime is when the cached content expires, as of its first lookup by
+	// the client. It's zero if the cached content wasn't looked up.
+	ExpireTime time.Time
+	// CachedTokens is the number of prompt tokens served by the cache.
+	CachedTokens int32
+	// PromptTokens is the number of prompt tokens, including the cached ones.
+	PromptTokens int32
+}
+
+// HitRatio returns the fraction of the prompt tokens that were served by the
+// cache.
+func (p *CacheProvenance) HitRatio() float64 {
+	if p.PromptTokens == 0 {
+		return 0
+	}
+	return float64(p.CachedTokens) / float64(p.PromptTokens)
+}
+
+// cacheProvenances are the cache provenances of the responses to requests with
+// cached content.
+var cacheProvenances sideTable[GenerateContentResponse, *CacheProvenance]
+
+// CacheProvenance returns the cached content that served the prefix of the
+// request, if GenerateContentConfig.CachedContent was set, or nil. It's set
+// by [Models.GenerateContentWithOptions] and
+// [Models.GenerateContentStreamWithOptions], on the chunks with usage
+// metadata. It's never returned by the API.
+func (r *GenerateContentResponse) CacheProvenance() *CacheProvenance {
+	return cacheProvenances.get(r)
+}
+
+// cachedContentLookupRetry is how long a failed lookup of cached content is
+// memoized before it's retried.
+const cachedContentLookupRetry = time.Minute
+
+// cachedContentLookup is the memoized result of a lookup of cached content.
+type cachedContentLookup struct {
+	cachedContent *CachedContent
+	// retryTime is when a failed lookup is retried. It's zero for successful
+	// lookups.
+	retryTime time.Time
+}
+
+// annotateCacheProvenance sets the cache provenance of resp if the request
+// used cached content.
+func (m Models) annotateCacheProvenance(ctx context.Context, resp *GenerateContentResponse, config *GenerateContentConfig) {
+	if resp == nil || config == nil || config.CachedContent == "" {
+		return
+	}
+	setCacheProvenance(resp, config.CachedContent, m.lookupCachedContent(ctx, config.CachedContent))
+}
+
+// setCacheProvenance sets the cache provenance of resp, with the details of
+// cc if it's not nil.
+func setCacheProvenance(resp *GenerateContentResponse, name string, cc *CachedContent) {
+	p := &CacheProvenance{Name: name}
+	if u := resp.UsageMetadata; u != nil {
+		p.CachedTokens = u.CachedContentTokenCount
+		p.PromptTokens = u.PromptTokenCount
+	}
+	if cc != nil {
+		p.DisplayName = cc.DisplayName
+		p.ExpireTime = cc.ExpireTime
+	}
+	cacheProvenances.set(resp, p)
+}
+
+// lookupCachedContent returns the cached content named name if
+// [ClientConfig.LookupCacheProvenance] is set. It's looked up once per client,
+// or again once it expired, and a failed lookup is only retried after
+// cachedContentLookupRetry. It returns nil if the lookup failed: provenance is
+// best effort and never fails a generation.
+func (m Models) lookupCachedContent(ctx context.Context, name string) *CachedContent {
+	if !m.apiClient.clientConfig.LookupCacheProvenance {
+		return nil
+	}
+	now := time.Now()
+	if v, ok := m.apiClient.cachedContents.Load(name); ok {
+		l := v.(cachedContentLookup)
+		if l.cachedContent == nil && now.Before(l.retryTime) {
+			return nil
+		}
+		if cc := l.cachedContent; cc != nil && (cc.ExpireTime.IsZero() || now.Before(cc.ExpireTime)) {
+			return cc
+		}
+	}
+	cc, err := Caches{apiClient: m.apiClient}.Get(ctx, name, nil)
+	if err != nil {
+		m.apiClient.cachedContents.Store(name, cachedContentLookup{retryTime: now.Add(cachedContentLookupRetry)})
+		return nil
+	}
+	m.apiClient.cachedContents.Store(name, cachedContentLookup{cachedContent: cc})
+	return cc
+}
+
+// annotateStreamCacheProvenance returns an iterator over the chunks of stream
+// in which the chunks with usage metadata are annotated with the cache
+// provenance. The cached content is looked up at most once per stream.
+func (m Models) annotateStreamCacheProvenance(ctx context.Context, stream iter.Seq2[*GenerateContentResponse, error], config *GenerateContentConfig) iter.Seq2[*GenerateContentResponse, error] {
+	return func(yield func(*GenerateContentResponse, error) bool) {
+		var cc *CachedContent
+		looked := false
+		for chunk, err := range stream {
+			if err == nil && chunk != nil && chunk.UsageMetadata != nil {
+				if !looked {
+					cc = m.lookupCachedContent(ctx, config.CachedContent)
+					looked = true
+				}
+				setCacheProvenance(chunk, config.CachedContent, cc)
+			}
+			if !yield(chunk, err) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCacheProvenance(t *testing.T) {
+	expire := time.Date(2100, 1, 2, 3, 4, 5, 0, time.UTC)
+	lookups := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const usage = `"usageMetadata": {"promptTokenCount": 1000, "cachedContentTokenCount": 800, "totalTokenCount": 1010}`
+		if r.Method == http.MethodGet {
+			lookups[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]]++
+		}
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "cachedContents/docs"):
+			fmt.Fprintf(w, `{"name": "cachedContents/docs", "displayName": "Product docs", "expireTime": %q}`, expire.Format(time.RFC3339))
+		case r.Method == http.MethodGet:
+			http.Error(w, `{"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}}`, http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "streamGenerateContent"):
+			fmt.Fprintf(w, "data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"o\"}]}}]}\n\ndata: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"k\"}]}}], %s}\n\ndata: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"!\"}]}}], %s}\n\n", usage, usage)
+		default:
+			fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "ok"}]}}], %s}`, usage)
+		}
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client(), LookupCacheProvenance: true}}}
+	ctx := context.Background()
+	config := &GenerateContentConfig{CachedContent: "cachedContents/docs"}
+	want := &CacheProvenance{Name: "cachedContents/docs", DisplayName: "Product docs", ExpireTime: expire, CachedTokens: 800, PromptTokens: 1000}
+
+	for range 2 {
+		resp, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("hi"), config, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, resp.CacheProvenance()); diff != "" {
+			t.Errorf("CacheProvenance mismatch (-want +got):\n%s", diff)
+		}
+	}
+	if lookups["docs"] != 1 {
+		t.Errorf("got %d lookups of the cached content, want 1", lookups["docs"])
+	}
+	if got := want.HitRatio(); got != 0.8 {
+		t.Errorf("HitRatio() = %v, want 0.8", got)
+	}
+
+	var last *GenerateContentResponse
+	for chunk, err := range m.GenerateContentStreamWithOptions(ctx, "gemini-2.5-flash", Text("hi"), config, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if last == nil && chunk.CacheProvenance() != nil {
+			t.Error("chunk without usage metadata was annotated")
+		}
+		last = chunk
+	}
+	if diff := cmp.Diff(want, last.CacheProvenance()); diff != "" {
+		t.Errorf("stream CacheProvenance mismatch (-want +got):\n%s", diff)
+	}
+
+	// A failed lookup doesn't fail the generation, and isn't retried right
+	// away, even by the chunks of a stream.
+	gone := &GenerateContentConfig{CachedContent: "cachedContents/gone"}
+	wantGone := &CacheProvenance{Name: "cachedContents/gone", CachedTokens: 800, PromptTokens: 1000}
+	for range 2 {
+		resp, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("hi"), gone, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(wantGone, resp.CacheProvenance()); diff != "" {
+			t.Errorf("CacheProvenance mismatch (-want +got):\n%s", diff)
+		}
+	}
+	if lookups["gone"] != 1 {
+		t.Errorf("got %d lookups of the missing cached content, want 1", lookups["gone"])
+	}
+
+	// A stream looks the cached content up once, however many chunks have
+	// usage metadata.
+	for _, err := range m.GenerateContentStreamWithOptions(ctx, "gemini-2.5-flash", Text("hi"), &GenerateContentConfig{CachedContent: "cachedContents/new"}, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if lookups["new"] != 1 {
+		t.Errorf("stream made %d lookups of the cached content, want 1", lookups["new"])
+	}
+
+	// Without LookupCacheProvenance, nothing is looked up.
+	plain := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+	resp, err := plain.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("hi"), &GenerateContentConfig{CachedContent: "cachedContents/other"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&CacheProvenance{Name: "cachedContents/other", CachedTokens: 800, PromptTokens: 1000}, resp.CacheProvenance()); diff != "" {
+		t.Errorf("CacheProvenance mismatch (-want +got):\n%s", diff)
+	}
+	if lookups["other"] != 0 {
+		t.Errorf("got %d lookups without LookupCacheProvenance, want 0", lookups["other"])
+	}
+
+	resp, err = m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("hi"), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.CacheProvenance() != nil {
+		t.Errorf("CacheProvenance = %+v, want nil without cached content", resp.CacheProvenance())
+	}
+}
@@ -119,3 +119,26 @@ func TestCachesAll(t *testing.T) {
 		})
 	}
 }
+
+func TestCachesCreateKmsKeyName(t *testing.T) {
+	ctx := context.Background()
+	const kmsKeyName = "projects/my-project/locations/us-central1/keyRings/ring/cryptoKeys/key"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if diff := cmp.Diff(map[string]any{"kmsKeyName": kmsKeyName}, body["encryption_spec"]); diff != "" {
+			t.Errorf("encryption_spec mismatch (-want +got):\n%s", diff)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"name": "projects/my-project/locations/us-central1/cachedContents/123",
+		})
+	}))
+	defer ts.Close()
+
+	c := Caches{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+	if _, err := c.Create(ctx, "gemini-2.5-flash", &CreateCachedContentConfig{Contents: Text("Cached."), KmsKeyName: kmsKeyName}); err != nil {
+		t.Fatal(err)
+	}
+}
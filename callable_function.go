@@ -0,0 +1,163 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+var (
+	contextType = reflect.TypeFor[context.Context]()
+	errorType   = reflect.TypeFor[error]()
+)
+
+// CallableFunction is a Go function together with the declaration the model
+// uses to call it.
+type CallableFunction struct {
+	// Declaration is the function declaration to send to the model.
+	Declaration *FunctionDeclaration
+	// Handler calls the Go function with the arguments of a function call.
+	Handler FunctionHandler
+}
+
+// NewCallableFunction wraps fn as a function the model can call.
+//
+// fn must have one of the following signatures, where Args is a struct type
+// (or pointer to one) and Result is any type encoding/json can marshal:
+//
+//	func(ctx context.Context, args Args) (Result, error)
+//	func(args Args) (Result, error)
+//	func(ctx context.Context) (Result, error)
+//	func() (Result, error)
+//
+// The parameter schema of the declaration is derived from Args with
+// [SchemaFor], so the json, description and enum struct tags apply. The
+// handler decodes the call arguments into Args and returns Result as the
+// function response. A Result that doesn't encode to a JSON object is returned
+// under the "result" key.
+//
+//	type WeatherArgs struct {
+//		City string `json:"city" description:"Name of the city."`
+//	}
+//	getWeather, err := genai.NewCallableFunction(func(ctx context.Context, args WeatherArgs) (string, error) {
+//		return "sunny", nil
+//	}, "get_weather", "Returns the weather in a city.")
+func NewCallableFunction(fn any, name, description string) (*CallableFunction, error) {
+	if name == "" {
+		return nil, fmt.Errorf("NewCallableFunction: name is required")
+	}
+	v := reflect.ValueOf(fn)
+	if !v.IsValid() {
+		return nil, fmt.Errorf("NewCallableFunction: %s: got nil, want a function", name)
+	}
+	t := v.Type()
+	if t.Kind() != reflect.Func {
+		return nil, fmt.Errorf("NewCallableFunction: %s: got %s, want a function", name, t)
+	}
+	if v.IsNil() {
+		return nil, fmt.Errorf("NewCallableFunction: %s: got a nil %s, want a function", name, t)
+	}
+	if t.NumOut() != 2 || t.Out(1) != errorType {
+		return nil, fmt.Errorf("NewCallableFunction: %s: function must return (Result, error)", name)
+	}
+	in := make([]reflect.Type, t.NumIn())
+	for i := range in {
+		in[i] = t.In(i)
+	}
+	withContext := len(in) > 0 && in[0] == contextType
+	if withContext {
+		in = in[1:]
+	}
+	if len(in) > 1 || t.IsVariadic() {
+		return nil, fmt.Errorf("NewCallableFunction: %s: function must take at most a context and one struct argument", name)
+	}
+
+	declaration := &FunctionDeclaration{Name: name, Description: description}
+	var argsType reflect.Type
+	if len(in) == 1 {
+		argsType = in[0]
+		structType := argsType
+		if structType.Kind() == reflect.Pointer {
+			structType = structType.Elem()
+		}
+		if structType.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("NewCallableFunction: %s: argument must be a struct, got %s", name, argsType)
+		}
+		schema, err := schemaForType(structType)
+		if err != nil {
+			return nil, fmt.Errorf("NewCallableFunction: %s: %w", name, err)
+		}
+		declaration.Parameters = schema
+	}
+
+	handler := func(ctx context.Context, args map[string]any) (map[string]any, error) {
+		var callArgs []reflect.Value
+		if withContext {
+			callArgs = append(callArgs, reflect.ValueOf(ctx))
+		}
+		if argsType != nil {
+			arg, err := decodeFunctionArgs(args, argsType)
+			if err != nil {
+				return nil, fmt.Errorf("invalid arguments for %s: %w", name, err)
+			}
+			callArgs = append(callArgs, arg)
+		}
+		out := v.Call(callArgs)
+		if err, _ := out[1].Interface().(error); err != nil {
+			return nil, err
+		}
+		return encodeFunctionResult(out[0].Interface())
+	}
+	return &CallableFunction{Declaration: declaration, Handler: handler}, nil
+}
+
+// decodeFunctionArgs decodes the arguments of a function call into a new value
+// of type t. If t is a pointer, the value is never nil, even if the call has
+// no arguments.
+func decodeFunctionArgs(args map[string]any, t reflect.Type) (reflect.Value, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	ptr := reflect.New(t)
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	if t.Kind() == reflect.Pointer && ptr.Elem().IsNil() {
+		ptr.Elem().Set(reflect.New(t.Elem()))
+	}
+	return ptr.Elem(), nil
+}
+
+// encodeFunctionResult converts the result of a function into the response
+// map of a FunctionResponse.
+func encodeFunctionResult(result any) (map[string]any, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err == nil && m != nil {
+		return m, nil
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return map[string]any{"result": value}, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testWeatherArgs struct {
+	City string `json:"city" description:"Name of the city."`
+	Days int    `json:"days,omitempty"`
+}
+
+type testForecast struct {
+	City     string `json:"city"`
+	Forecast string `json:"forecast"`
+}
+
+func TestNewCallableFunction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("StructResult", func(t *testing.T) {
+		f, err := NewCallableFunction(func(ctx context.Context, args testWeatherArgs) (testForecast, error) {
+			return testForecast{City: args.City, Forecast: "sunny"}, nil
+		}, "get_weather", "Returns the weather.")
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantDecl := &FunctionDeclaration{
+			Name:        "get_weather",
+			Description: "Returns the weather.",
+			Parameters: &Schema{
+				Type: TypeObject,
+				Properties: map[string]*Schema{
+					"city": {Type: TypeString, Description: "Name of the city."},
+					"days": {Type: TypeInteger, Format: "int32"},
+				},
+				PropertyOrdering: []string{"city", "days"},
+				Required:         []string{"city"},
+			},
+		}
+		if diff := cmp.Diff(wantDecl, f.Declaration); diff != "" {
+			t.Errorf("Declaration mismatch (-want +got):\n%s", diff)
+		}
+		got, err := f.Handler(ctx, map[string]any{"city": "Paris"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(map[string]any{"city": "Paris", "forecast": "sunny"}, got); diff != "" {
+			t.Errorf("Handler() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ScalarResult", func(t *testing.T) {
+		f, err := NewCallableFunction(func(args *testWeatherArgs) (int, error) {
+			return args.Days * 2, nil
+		}, "double_days", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := f.Handler(ctx, map[string]any{"city": "Oslo", "days": 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got["result"] != 6.0 {
+			t.Errorf("Handler() = %v, want result 6", got)
+		}
+	})
+
+	t.Run("PointerWithoutArgs", func(t *testing.T) {
+		f, err := NewCallableFunction(func(args *testWeatherArgs) (int, error) {
+			return args.Days, nil
+		}, "days", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, args := range []map[string]any{nil, {}} {
+			got, err := f.Handler(ctx, args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got["result"] != 0.0 {
+				t.Errorf("Handler(%v) = %v, want result 0", args, got)
+			}
+		}
+	})
+
+	t.Run("NoArgs", func(t *testing.T) {
+		f, err := NewCallableFunction(func() (string, error) { return "", errors.New("boom") }, "fail", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.Declaration.Parameters != nil {
+			t.Errorf("Parameters = %+v, want nil", f.Declaration.Parameters)
+		}
+		if _, err := f.Handler(ctx, nil); err == nil || err.Error() != "boom" {
+			t.Errorf("Handler() error = %v, want boom", err)
+		}
+	})
+
+	t.Run("InvalidArgs", func(t *testing.T) {
+		f, err := NewCallableFunction(func(args testWeatherArgs) (string, error) { return "", nil }, "get_weather", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Handler(ctx, map[string]any{"city": 42}); err == nil {
+			t.Errorf("Handler() with invalid arguments got no error, want error")
+		}
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		for _, fn := range []any{
+			nil,
+			(func(args testWeatherArgs) (string, error))(nil),
+			"not a function",
+			func(args testWeatherArgs) string { return "" },
+			func(a, b testWeatherArgs) (string, error) { return "", nil },
+			func(city string) (string, error) { return "", nil },
+		} {
+			if _, err := NewCallableFunction(fn, "f", ""); err == nil {
+				t.Errorf("NewCallableFunction(%T) got no error, want error", fn)
+			}
+		}
+		if _, err := NewCallableFunction(func() (string, error) { return "", nil }, "", ""); err == nil {
+			t.Errorf("NewCallableFunction() without name got no error, want error")
+		}
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// CallableTool is a set of functions that the model can call and that the SDK
+// can execute, for example with [AutomaticFunctionCallingConfig.Tools] or
+// [Session.ExecuteToolCall].
+type CallableTool interface {
+	// Declarations returns the declarations of the functions of the tool.
+	Declarations() []*FunctionDeclaration
+	// Call executes a function call for one of the declared functions.
+	Call(ctx context.Context, call *FunctionCall) (*FunctionResponse, error)
+}
+
+// Declarations returns the declaration of the function.
+func (f *CallableFunction) Declarations() []*FunctionDeclaration {
+	return []*FunctionDeclaration{f.Declaration}
+}
+
+// Call calls the function with the arguments of call.
+func (f *CallableFunction) Call(ctx context.Context, call *FunctionCall) (*FunctionResponse, error) {
+	output, err := f.Handler(ctx, call.Args)
+	if err != nil {
+		return nil, err
+	}
+	return &FunctionResponse{ID: call.ID, Name: call.Name, Response: output}, nil
+}
+
+// handlerTool adapts FunctionHandlers for functions declared elsewhere to a
+// CallableTool.
+type handlerTool map[string]FunctionHandler
+
+func (t handlerTool) Declarations() []*FunctionDeclaration {
+	return nil
+}
+
+func (t handlerTool) Call(ctx context.Context, call *FunctionCall) (*FunctionResponse, error) {
+	output, err := t[call.Name](ctx, call.Args)
+	if err != nil {
+		return nil, err
+	}
+	return &FunctionResponse{ID: call.ID, Name: call.Name, Response: output}, nil
+}
+
+// ToolExecutor dispatches function calls to the [CallableTool] that declares
+// them.
+type ToolExecutor struct {
+	tools        map[string]CallableTool
+	declarations []*FunctionDeclaration
+}
+
+// NewToolExecutor returns an executor for the given tools. It returns an error
+// if two tools declare the same function.
+func NewToolExecutor(tools ...CallableTool) (*ToolExecutor, error) {
+	e := &ToolExecutor{tools: map[string]CallableTool{}}
+	for _, tool := range tools {
+		if err := e.add(tool); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+func (e *ToolExecutor) add(tool CallableTool) error {
+	for _, decl := range tool.Declarations() {
+		if _, ok := e.tools[decl.Name]; ok {
+			return fmt.Errorf("function %q is declared by more than one tool", decl.Name)
+		}
+		e.tools[decl.Name] = tool
+		e.declarations = append(e.declarations, decl)
+	}
+	return nil
+}
+
+// addHandlers registers handlers for functions that are declared elsewhere.
+func (e *ToolExecutor) addHandlers(handlers map[string]FunctionHandler) error {
+	for name := range handlers {
+		if _, ok := e.tools[name]; ok {
+			return fmt.Errorf("function %q is declared by more than one tool", name)
+		}
+		e.tools[name] = handlerTool(handlers)
+	}
+	return nil
+}
+
+// Declarations returns the declarations of all functions of the executor.
+func (e *ToolExecutor) Declarations() []*FunctionDeclaration {
+	return append([]*FunctionDeclaration{}, e.declarations...)
+}
+
+// Tool returns a Tool declaring all functions of the executor, to be added to
+// [GenerateContentConfig.Tools] or [LiveConnectConfig.Tools].
+func (e *ToolExecutor) Tool() *Tool {
+	return &Tool{FunctionDeclarations: e.Declarations()}
+}
+
+// Handles reports whether the executor has a tool for the named function.
+func (e *ToolExecutor) Handles(name string) bool {
+	return e.tools[name] != nil
+}
+
+// Execute runs the calls concurrently and returns their responses in the
+// order of the calls. A call that fails, or that names an unknown function,
+// gets a response with the error message under the "error" key, so that the
+// model can react to it.
+func (e *ToolExecutor) Execute(ctx context.Context, calls []*FunctionCall) []*FunctionResponse {
+	responses := make([]*FunctionResponse, len(calls))
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i] = e.call(ctx, call)
+		}()
+	}
+	wg.Wait()
+	return responses
+}
+
+func (e *ToolExecutor) call(ctx context.Context, call *FunctionCall) *FunctionResponse {
+	tool := e.tools[call.Name]
+	if tool == nil {
+		return functionErrorResponse(call, fmt.Errorf("function %q not found", call.Name))
+	}
+	response, err := tool.Call(ctx, call)
+	if err != nil {
+		return functionErrorResponse(call, err)
+	}
+	if response == nil {
+		response = &FunctionResponse{}
+	}
+	if response.ID == "" {
+		response.ID = call.ID
+	}
+	if response.Name == "" {
+		response.Name = call.Name
+	}
+	return response
+}
+
+func functionErrorResponse(call *FunctionCall, err error) *FunctionResponse {
+	return &FunctionResponse{ID: call.ID, Name: call.Name, Response: map[string]any{"error": err.Error()}}
+}
+
+// Preview. ExecuteToolCall runs the function calls of toolCall with executor
+// and sends their responses to the session.
+//
+//	if msg.ToolCall != nil {
+//		if err := session.ExecuteToolCall(ctx, executor, msg.ToolCall); err != nil {
+//			return err
+//		}
+//	}
+func (s *Session) ExecuteToolCall(ctx context.Context, executor *ToolExecutor, toolCall *LiveServerToolCall) error {
+	if toolCall == nil || len(toolCall.FunctionCalls) == 0 {
+		return nil
+	}
+	responses := executor.Execute(ctx, toolCall.FunctionCalls)
+	return s.SendToolResponse(LiveToolResponseInput{FunctionResponses: responses})
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestToolExecutor(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	var finished []string
+	sleep, err := NewCallableFunction(func(args struct {
+		Ms int `json:"ms"`
+	}) (string, error) {
+		time.Sleep(time.Duration(args.Ms) * time.Millisecond)
+		mu.Lock()
+		defer mu.Unlock()
+		finished = append(finished, fmt.Sprint(args.Ms))
+		return fmt.Sprint(args.Ms), nil
+	}, "sleep", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor, err := NewToolExecutor(sleep)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := []*FunctionCall{
+		{ID: "1", Name: "sleep", Args: map[string]any{"ms": 30}},
+		{ID: "2", Name: "sleep", Args: map[string]any{"ms": 1}},
+		{ID: "3", Name: "missing"},
+	}
+	responses := executor.Execute(ctx, calls)
+	if len(responses) != 3 {
+		t.Fatalf("got %d responses, want 3", len(responses))
+	}
+	if responses[0].ID != "1" || responses[0].Response["result"] != "30" || responses[1].Response["result"] != "1" {
+		t.Errorf("responses out of order: %+v, %+v", responses[0], responses[1])
+	}
+	if finished[0] != "1" {
+		t.Errorf("calls didn't run concurrently, finish order %v", finished)
+	}
+	if responses[2].Name != "missing" || responses[2].Response["error"] == nil {
+		t.Errorf("unknown function response = %+v, want error", responses[2])
+	}
+
+	if _, err := NewToolExecutor(sleep, sleep); err == nil {
+		t.Errorf("NewToolExecutor() with duplicate functions got no error, want error")
+	}
+	if got := executor.Tool().FunctionDeclarations; len(got) != 1 || got[0].Name != "sleep" {
+		t.Errorf("Tool() declarations = %+v, want sleep", got)
+	}
+}
+
+func TestAutomaticFunctionCallingTools(t *testing.T) {
+	ctx := context.Background()
+	var requests []map[string]any
+	m := Models{apiClient: &apiClient{clientConfig: newFunctionCallingTestConfig(t, 1, &requests)}}
+	getWeather, err := NewCallableFunction(func(args struct {
+		City string `json:"city"`
+	}) (string, error) {
+		return "sunny in " + args.City, nil
+	}, "get_weather", "Returns the weather.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &GenerateContentConfig{}
+	options := &GenerateContentOptions{AutomaticFunctionCalling: &AutomaticFunctionCallingConfig{Tools: []CallableTool{getWeather}}}
+	resp, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("Weather in Paris?"), config, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Text() != "Sunny." {
+		t.Errorf("Text() = %q, want %q", resp.Text(), "Sunny.")
+	}
+	if got := fmt.Sprint(requests[0]["tools"]); got == "<nil>" {
+		t.Errorf("request has no tools, want get_weather declaration")
+	}
+	if config.Tools != nil {
+		t.Errorf("config.Tools = %+v, want caller's config unchanged", config.Tools)
+	}
+}
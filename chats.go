@@ -21,8 +21,14 @@ import (
 	"fmt"
 	"io"
 	"iter"
+	"time"
 )
 
+// ClientMetadataTurnTimedOut is the [Content.ClientMetadata] key set to true
+// on the model content of a chat turn that timed out, see
+// [Chat.SetTurnTimeout].
+const ClientMetadataTurnTimedOut = "turnTimedOut"
+
 // Chats provides util functions for creating a new chat session.
 // You don't need to initiate this struct. Create a client instance via NewClient, and
 // then access Chats through client.Models field.
@@ -44,6 +50,23 @@ type Chat struct {
 	comprehensiveHistory []*Content
 	// Curated history is the set of valid turns that will be used in the subsequent send requests.
 	curatedHistory []*Content
+	// Budget that the turns of this chat are charged to, in addition to the client's budget.
+	budget *Budget
+	// Deadline of each turn sent with SendContent.
+	turnTimeout *TurnTimeoutConfig
+	// Whether thought parts of the model are recorded in the history.
+	includeThoughts bool
+	// Features of the SDK applied to the turns.
+	options *GenerateContentOptions
+}
+
+// TurnTimeoutConfig configures [Chat.SetTurnTimeout].
+type TurnTimeoutConfig struct {
+	// Required. Maximum duration of a turn.
+	Timeout time.Duration
+	// Optional. Text answered in place of the model when a turn times out. If
+	// empty, the turn fails with [context.DeadlineExceeded].
+	FallbackText string
 }
 
 func validateContent(content *Content) bool {
@@ -144,6 +167,9 @@ func (c *Chats) Create(ctx context.Context, model string, config *GenerateConten
 }
 
 func (c *Chat) recordHistory(ctx context.Context, inputContent *Content, outputContents []*Content, isValid bool) {
+	if !c.includeThoughts {
+		outputContents = withoutThoughts(outputContents)
+	}
 	c.comprehensiveHistory = append(c.comprehensiveHistory, inputContent)
 	if len(outputContents) == 0 {
 		c.comprehensiveHistory = append(c.comprehensiveHistory, &Content{Role: RoleModel, Parts: []*Part{}})
@@ -161,6 +187,73 @@ func (c *Chat) recordHistory(ctx context.Context, inputContent *Content, outputC
 	}
 }
 
+// SetBudget sets a budget that the turns of this chat are charged to. Once it
+// is used up, Send and SendStream fail with a [*BudgetExceededError]. The
+// budget applies in addition to [ClientConfig.Budget]. Pass nil to remove it.
+func (c *Chat) SetBudget(budget *Budget) {
+	c.budget = budget
+}
+
+// SetOptions sets the features of the SDK applied to the turns of the chat,
+// such as automatic function calling, as by
+// [Models.GenerateContentWithOptions]. It applies to the messages sent
+// afterwards. Pass nil to remove them.
+func (c *Chat) SetOptions(options *GenerateContentOptions) {
+	c.options = options
+}
+
+// SetSystemInstruction replaces the system instruction of the chat. It applies
+// to the messages sent afterwards. The history, including the turns answered
+// with the previous instruction, is kept as it is. Pass nil to remove the
+// system instruction.
+func (c *Chat) SetSystemInstruction(instruction *Content) {
+	config := c.copyConfig()
+	config.SystemInstruction = instruction
+	c.config = config
+}
+
+// SetTools replaces the tools available to the model in the chat. It applies
+// to the messages sent afterwards. Function calls and responses of removed
+// tools are kept in the history. Pass nil to remove all tools.
+func (c *Chat) SetTools(tools []*Tool) {
+	config := c.copyConfig()
+	config.Tools = tools
+	c.config = config
+}
+
+// copyConfig returns a copy of the config of the chat, so that updating it
+// doesn't change the config passed to [Chats.Create].
+func (c *Chat) copyConfig() *GenerateContentConfig {
+	config := &GenerateContentConfig{}
+	if c.config != nil {
+		*config = *c.config
+	}
+	return config
+}
+
+// SetTurnTimeout bounds the duration of each turn sent with SendMessage, Send
+// and SendContent. When a turn takes longer than config.Timeout, its request is
+// canceled. If config.FallbackText is set, the turn then returns a response
+// whose first candidate answers the fallback text, otherwise it fails with
+// [context.DeadlineExceeded].
+//
+// A turn that timed out is kept in the comprehensive history, with
+// ClientMetadata()[ClientMetadataTurnTimedOut] set to true on its model content,
+// but not in the curated history, so it isn't sent along with the next
+// messages. Pass nil to remove the timeout. Streamed turns aren't bounded.
+func (c *Chat) SetTurnTimeout(config *TurnTimeoutConfig) {
+	c.turnTimeout = config
+}
+
+// SetIncludeThoughtsInHistory sets whether the thought parts returned by the
+// model, see [ThinkingConfig.IncludeThoughts], are recorded in the history. By
+// default they aren't, so they are neither sent back to the model nor shown
+// along with the history. Thought signatures are always kept. It applies to
+// the messages sent afterwards.
+func (c *Chat) SetIncludeThoughtsInHistory(include bool) {
+	c.includeThoughts = include
+}
+
 // History returns the chat history. Returns the curated history if
 // curated is true, otherwise returns the comprehensive history.
 func (c *Chat) History(curated bool) []*Content {
@@ -170,6 +263,19 @@ func (c *Chat) History(curated bool) []*Content {
 	return c.comprehensiveHistory
 }
 
+// SummarizeHistory replaces the curated history, which is sent along with each
+// message, by a summary of it in about budget tokens, see [SummarizeHistory].
+// Use it to keep long chats within the context window of the model. The
+// comprehensive history is kept.
+func (c *Chat) SummarizeHistory(ctx context.Context, budget int32) error {
+	summary, err := summarizeHistory(ctx, c.Models, c.curatedHistory, budget)
+	if err != nil {
+		return err
+	}
+	c.curatedHistory = []*Content{summary}
+	return nil
+}
+
 // SendMessage is a wrapper around Send.
 func (c *Chat) SendMessage(ctx context.Context, parts ...Part) (*GenerateContentResponse, error) {
 	// Transform Parts to single Content
@@ -182,19 +288,42 @@ func (c *Chat) SendMessage(ctx context.Context, parts ...Part) (*GenerateContent
 
 // Send function sends the conversation history with the additional user's message and returns the model's response.
 func (c *Chat) Send(ctx context.Context, parts ...*Part) (*GenerateContentResponse, error) {
-	inputContent := &Content{Parts: parts, Role: RoleUser}
+	return c.SendContent(ctx, &Content{Parts: parts, Role: RoleUser})
+}
+
+// SendContent is like Send, but takes the user's message as a Content, so that
+// the client metadata of the Content is kept in the history. If the Content
+// has no role, a copy with the user role is sent and recorded; inputContent
+// itself isn't modified.
+func (c *Chat) SendContent(ctx context.Context, inputContent *Content) (*GenerateContentResponse, error) {
+	inputContent, err := userTurnContent(inputContent)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = contextWithBudget(ctx, c.budget)
 
 	// Combine history with input content to send to model
 	contents := append(c.curatedHistory, inputContent)
 
 	// Generate Content
-	modelOutput, err := c.GenerateContent(ctx, c.model, contents, c.config)
+	turnCtx := ctx
+	if c.turnTimeout != nil && c.turnTimeout.Timeout > 0 {
+		var cancel context.CancelFunc
+		turnCtx, cancel = context.WithTimeout(ctx, c.turnTimeout.Timeout)
+		defer cancel()
+	}
+	modelOutput, err := c.GenerateContentWithOptions(turnCtx, c.model, contents, c.config, c.options)
+	if err != nil && ctx.Err() == nil && turnCtx.Err() == context.DeadlineExceeded {
+		return c.turnTimedOut(ctx, inputContent)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	// Record history. By default, use the first candidate for history.
-	var outputContents []*Content
+	// Record history. By default, use the first candidate for history. Turns
+	// exchanged by automatic function calling precede the final output.
+	outputContents := append([]*Content{}, modelOutput.AutomaticFunctionCallingHistory()...)
 	if len(modelOutput.Candidates) > 0 && modelOutput.Candidates[0].Content != nil {
 		outputContents = append(outputContents, modelOutput.Candidates[0].Content)
 	}
@@ -203,6 +332,21 @@ func (c *Chat) Send(ctx context.Context, parts ...*Part) (*GenerateContentRespon
 	return modelOutput, err
 }
 
+// turnTimedOut records a turn that timed out and returns the fallback
+// response, if any.
+func (c *Chat) turnTimedOut(ctx context.Context, inputContent *Content) (*GenerateContentResponse, error) {
+	output := &Content{Role: RoleModel, Parts: []*Part{}}
+	output.SetClientMetadata(map[string]any{ClientMetadataTurnTimedOut: true})
+	if c.turnTimeout.FallbackText != "" {
+		output.Parts = []*Part{{Text: c.turnTimeout.FallbackText}}
+	}
+	c.recordHistory(ctx, inputContent, []*Content{output}, false)
+	if c.turnTimeout.FallbackText == "" {
+		return nil, fmt.Errorf("chat turn timed out after %v: %w", c.turnTimeout.Timeout, context.DeadlineExceeded)
+	}
+	return &GenerateContentResponse{Candidates: []*Candidate{{Content: output, FinishReason: FinishReasonOther}}}, nil
+}
+
 // SendMessageStream is a wrapper around SendStream.
 func (c *Chat) SendMessageStream(ctx context.Context, parts ...Part) iter.Seq2[*GenerateContentResponse, error] {
 	// Transform Parts to single Content
@@ -215,13 +359,39 @@ func (c *Chat) SendMessageStream(ctx context.Context, parts ...Part) iter.Seq2[*
 
 // SendStream function sends the conversation history with the additional user's message and returns the model's response.
 func (c *Chat) SendStream(ctx context.Context, parts ...*Part) iter.Seq2[*GenerateContentResponse, error] {
-	inputContent := &Content{Parts: parts, Role: RoleUser}
+	return c.SendContentStream(ctx, &Content{Parts: parts, Role: RoleUser})
+}
+
+// userTurnContent returns the user's message of a turn, with the user role if
+// content has none. content is copied rather than modified.
+func userTurnContent(content *Content) (*Content, error) {
+	if content == nil {
+		return nil, fmt.Errorf("chat: the content of a message must not be nil")
+	}
+	if content.Role == "" {
+		c := *content
+		c.Role = RoleUser
+		c.SetClientMetadata(content.ClientMetadata())
+		content = &c
+	}
+	return content, nil
+}
+
+// SendContentStream is like SendStream, but takes the user's message as a
+// Content, so that the client metadata of the Content is kept in the history.
+func (c *Chat) SendContentStream(ctx context.Context, inputContent *Content) iter.Seq2[*GenerateContentResponse, error] {
+	inputContent, err := userTurnContent(inputContent)
+	if err != nil {
+		return yieldErrorAndEndIterator[GenerateContentResponse](err)
+	}
+
+	ctx = contextWithBudget(ctx, c.budget)
 
 	// Combine history with input content to send to model
 	contents := append(c.curatedHistory, inputContent)
 
 	// Generate Content
-	response := c.GenerateContentStream(ctx, c.model, contents, c.config)
+	response := c.GenerateContentStreamWithOptions(ctx, c.model, contents, c.config, c.options)
 
 	// Return a new iterator that will yield the responses and record history with merged response.
 	return func(yield func(*GenerateContentResponse, error) bool) {
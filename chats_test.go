@@ -16,11 +16,17 @@ package genai
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/auth"
 	"github.com/google/go-cmp/cmp"
@@ -179,6 +185,237 @@ func TestChatsUnitTest(t *testing.T) {
 
 }
 
+func TestChatsClientMetadata(t *testing.T) {
+	ctx := context.Background()
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "hi"}]}, "finishReason": "STOP"}]}`)
+	}))
+	defer ts.Close()
+
+	chats := &Chats{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+	chat, err := chats.Create(ctx, "gemini-2.5-flash", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part := &Part{Text: "hello"}
+	part.SetClientMetadata(map[string]any{"source": "doc-1"})
+	input := &Content{Parts: []*Part{part}}
+	input.SetClientMetadata(map[string]any{"messageID": "m1"})
+	if _, err := chat.SendContent(ctx, input); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(body), "clientMetadata") {
+		t.Errorf("request body contains client metadata: %s", body)
+	}
+	if input.Role != "" {
+		t.Errorf("SendContent() set the role of the input to %q, want it unmodified", input.Role)
+	}
+
+	history := chat.History(true)
+	if len(history) != 2 {
+		t.Fatalf("got %d history entries, want 2", len(history))
+	}
+	if history[0].Role != RoleUser || history[0].ClientMetadata()["messageID"] != "m1" || history[0].Parts[0].ClientMetadata()["source"] != "doc-1" {
+		t.Errorf("client metadata not kept in history: %+v", history[0])
+	}
+	exported, err := MarshalHistory(history)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := UnmarshalHistory(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(history, restored); diff != "" {
+		t.Errorf("history JSON round trip mismatch (-want +got):\n%s", diff)
+	}
+	if restored[0].ClientMetadata()["messageID"] != "m1" || restored[0].Parts[0].ClientMetadata()["source"] != "doc-1" || restored[1].ClientMetadata() != nil {
+		t.Errorf("client metadata not restored from %s", exported)
+	}
+}
+
+func TestChatsSendContentNil(t *testing.T) {
+	ctx := context.Background()
+	chats := &Chats{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI}}}
+	chat, err := chats.Create(ctx, "gemini-2.5-flash", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.SendContent(ctx, nil); err == nil {
+		t.Errorf("SendContent(nil) got no error, want error")
+	}
+	for _, err := range chat.SendContentStream(ctx, nil) {
+		if err == nil {
+			t.Errorf("SendContentStream(nil) got no error, want error")
+		}
+	}
+	if len(chat.History(false)) != 0 {
+		t.Errorf("History() = %v, want empty", chat.History(false))
+	}
+}
+
+func TestChatsThoughtsInHistory(t *testing.T) {
+	ctx := context.Background()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "hmm", "thought": true}, {"text": "hi"}]}, "finishReason": "STOP"}]}`)
+	}))
+	defer ts.Close()
+
+	chats := &Chats{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+	chat, err := chats.Create(ctx, "gemini-2.5-flash", &GenerateContentConfig{ThinkingConfig: ThinkingDynamic(true)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	chat.SetIncludeThoughtsInHistory(true)
+	if _, err := chat.SendMessage(ctx, Part{Text: "hello again"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, curated := range []bool{true, false} {
+		history := chat.History(curated)
+		if len(history) != 4 {
+			t.Fatalf("History(%v) has %d entries, want 4", curated, len(history))
+		}
+		if diff := cmp.Diff([]*Part{{Text: "hi"}}, history[1].Parts); diff != "" {
+			t.Errorf("History(%v) first answer mismatch (-want +got):\n%s", curated, diff)
+		}
+		if diff := cmp.Diff([]*Part{{Text: "hmm", Thought: true}, {Text: "hi"}}, history[3].Parts); diff != "" {
+			t.Errorf("History(%v) second answer mismatch (-want +got):\n%s", curated, diff)
+		}
+	}
+}
+
+func TestChatsSetSystemInstructionAndTools(t *testing.T) {
+	ctx := context.Background()
+	var bodies []map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		bodies = append(bodies, body)
+		fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "ok"}]}, "finishReason": "STOP"}]}`)
+	}))
+	defer ts.Close()
+
+	chats := &Chats{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+	config := &GenerateContentConfig{SystemInstruction: Text("Be brief.")[0]}
+	chat, err := chats.Create(ctx, "gemini-2.5-flash", config, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := chat.SendMessage(ctx, Part{Text: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	chat.SetSystemInstruction(Text("Answer in French.")[0])
+	chat.SetTools([]*Tool{{FunctionDeclarations: []*FunctionDeclaration{{Name: "lookup"}}}})
+	if _, err := chat.SendMessage(ctx, Part{Text: "hello again"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if config.SystemInstruction.Parts[0].Text != "Be brief." || config.Tools != nil {
+		t.Errorf("config passed to Create was modified: %+v", config)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("got %d requests, want 2", len(bodies))
+	}
+	instruction := func(body map[string]any) any {
+		return body["systemInstruction"].(map[string]any)["parts"].([]any)[0].(map[string]any)["text"]
+	}
+	if got := instruction(bodies[0]); got != "Be brief." {
+		t.Errorf("first system instruction = %v, want %q", got, "Be brief.")
+	}
+	if _, ok := bodies[0]["tools"]; ok {
+		t.Errorf("first request has tools: %v", bodies[0]["tools"])
+	}
+	if got := instruction(bodies[1]); got != "Answer in French." {
+		t.Errorf("second system instruction = %v, want %q", got, "Answer in French.")
+	}
+	if _, ok := bodies[1]["tools"]; !ok {
+		t.Error("second request has no tools")
+	}
+	if got := len(bodies[1]["contents"].([]any)); got != 3 {
+		t.Errorf("second request has %d contents, want 3", got)
+	}
+}
+
+func TestChatsSetTurnTimeout(t *testing.T) {
+	ctx := context.Background()
+	var slow atomic.Bool
+	slow.Store(true)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slow.Load() {
+			io.Copy(io.Discard, r.Body)
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "ok"}]}, "finishReason": "STOP"}]}`)
+	}))
+	defer ts.Close()
+
+	chats := &Chats{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+	chat, err := chats.Create(ctx, "gemini-2.5-flash", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chat.SetTurnTimeout(&TurnTimeoutConfig{Timeout: 50 * time.Millisecond, FallbackText: "Sorry, that took too long."})
+	resp, err := chat.SendMessage(ctx, Part{Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Text(); got != "Sorry, that took too long." {
+		t.Errorf("Text() = %q, want the fallback text", got)
+	}
+
+	chat.SetTurnTimeout(&TurnTimeoutConfig{Timeout: 50 * time.Millisecond})
+	if _, err := chat.SendMessage(ctx, Part{Text: "hello?"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendMessage() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	slow.Store(false)
+	if _, err := chat.SendMessage(ctx, Part{Text: "still there?"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := len(chat.History(true)); got != 2 {
+		t.Errorf("got %d curated history entries, want 2", got)
+	}
+	history := chat.History(false)
+	if len(history) != 6 {
+		t.Fatalf("got %d history entries, want 6", len(history))
+	}
+	for i, timedOut := range []bool{true, true, false} {
+		if got := history[2*i+1].ClientMetadata()[ClientMetadataTurnTimedOut] == true; got != timedOut {
+			t.Errorf("turn %d timed out = %v, want %v", i, got, timedOut)
+		}
+	}
+	if got := history[1].Parts[0].Text; got != "Sorry, that took too long." {
+		t.Errorf("history has %q for the first turn, want the fallback text", got)
+	}
+}
+
 func TestChatsText(t *testing.T) {
 	if *mode != apiMode {
 		t.Skip("Skip. This test is only in the API mode")
@@ -19,12 +19,11 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 
 	"cloud.google.com/go/auth"
-	"cloud.google.com/go/auth/credentials"
-	"cloud.google.com/go/auth/httptransport"
 )
 
 // Client is the GenAI client. It provides access to the various GenAI services.
@@ -50,6 +49,10 @@ type Client struct {
 	Tunings *Tunings
 	// Tokens provides access to the Tokens service.
 	AuthTokens *Tokens
+
+	// stop stops the background work of the client and waits for it to
+	// finish. It's nil if the client has none.
+	stop func()
 }
 
 // Backend is the GenAI backend to use for the client.
@@ -113,6 +116,15 @@ type ClientConfig struct {
 	// [Application Default Credentials]: https://developers.google.com/accounts/docs/application-default-credentials
 	Credentials *auth.Credentials
 
+	// Optional. If true, NewClient doesn't look up Application Default
+	// Credentials and the quota project, which can take hundreds of
+	// milliseconds, but leaves it to the first request or to [Client.Prime].
+	// Errors finding the credentials are then returned by that request, and
+	// the lookup is tried again by the next one. Credentials stays nil in the
+	// ClientConfig of the client. Only used with
+	// Vertex AI when HTTPClient is nil.
+	LazyCredentials bool
+
 	// Optional HTTP client to use. If nil, a default client will be created.
 	// For Vertex AI, this client must handle authentication appropriately.
 	// Otherwise, call [UseDefaultCredentials] convenience method to add default credentials to the
@@ -122,6 +134,72 @@ type ClientConfig struct {
 	// Optional HTTP options to override.
 	HTTPOptions HTTPOptions
 
+	// Optional. Dialer of the WebSocket connections of [Live.Connect]. If nil,
+	// a default dialer for the platform is used. Set it to use another
+	// WebSocket library or a proxy.
+	LiveDialer LiveDialer
+
+	// Optional. If true, the exact payload of each response is kept in the
+	// Body of the SDKHTTPResponse field of the returned response, for
+	// auditing. For streamed responses, each chunk keeps its own payload.
+	IncludeResponseBody bool
+
+	// Optional. A function called with each request once it's complete, right
+	// before it's sent, to sign it. See [RequestSigner].
+	RequestSigner RequestSigner
+
+	// Optional. A function called with the context of each request to compute
+	// headers to send with it, see [HeadersProvider] and
+	// [RequestOptions.HeadersProvider].
+	HeadersProvider HeadersProvider
+
+	// Optional. Budget that every GenerateContentWithOptions and
+	// GenerateContentStreamWithOptions call of the client, including calls made
+	// by chats, is charged to. Once it is used up, calls fail with a
+	// [*BudgetExceededError].
+	Budget *Budget
+
+	// Optional. Counts the requests, errors and tokens of the calls of the
+	// client by model and module. See [UsageReport].
+	UsageReport *UsageReport
+
+	// Optional. If true, the [CacheProvenance] of responses to requests with
+	// cached content includes its display name and expire time, which are
+	// looked up with [Caches.Get] the first time a cached content is used.
+	// Otherwise, only the name and the token counts are set, at no cost.
+	LookupCacheProvenance bool
+
+	// Optional. If set, NewClient opens the HTTP connection to the API host in
+	// the background and keeps it open, so that the first requests don't wait
+	// for the TLS and HTTP/2 handshakes. See [WarmConnectionConfig].
+	WarmConnection *WarmConnectionConfig
+
+	// Optional. Preview features to enable on Vertex AI. If set, and
+	// HTTPOptions.APIVersion isn't, calls use the stable v1 API version, except
+	// for the calls of the enabled features, which use v1beta1. If nil, all
+	// calls use v1beta1. It's ignored by the Gemini API.
+	PreviewFeatures []PreviewFeature
+
+	// Optional. Low-cost model used to summarize conversations, by
+	// [SummarizeHistory] and [Chat.SummarizeHistory], and tool results, unless
+	// [ToolResultLimitConfig.SummarizationModel] is set. Defaults to
+	// gemini-2.5-flash-lite.
+	SummarizationModel string
+
+	// Optional. Locations that requests are allowed to be sent to, such as
+	// "europe-west4". If set, requests sent to another location, including
+	// global endpoints, or that refer to resources in another location, fail
+	// with a [*DataResidencyError] before they are sent. Add "global" to allow
+	// global endpoints. NewClient fails if the location of the client isn't
+	// allowed, which is always the case for the Gemini API unless "global" is.
+	AllowedLocations []string
+
+	// Optional. Returns the local tokenizer of a model, which
+	// [Models.ComputeTokensWithLocalTokenizer] uses on the Gemini API, as it
+	// has no computeTokens method. Set it to tokenizer.NewTokenComputer of
+	// google.golang.org/genai/tokenizer. It's ignored by Vertex AI.
+	LocalTokenizer func(model string) (TokenComputer, error)
+
 	envVarProvider func() map[string]string
 }
 
@@ -272,6 +350,9 @@ func NewInternalAPIClient(ctx context.Context, cc *ClientConfig) (*InternalAPICl
 		cc.Location = envLocation
 	}
 
+	// defaultBaseURL is whether the Vertex AI base URL is derived from the
+	// location, in which case requests for other locations use their own.
+	defaultBaseURL := false
 	if cc.Backend == BackendVertexAI {
 		// Handle when to use Vertex AI in express mode (api key).
 		// Explicit initializer arguments are already validated above.
@@ -323,13 +404,8 @@ func NewInternalAPIClient(ctx context.Context, cc *ClientConfig) (*InternalAPICl
 
 		// Set default BaseURL if still empty.
 		if cc.HTTPOptions.BaseURL == "" {
-			if cc.Location == "global" || cc.APIKey != "" {
-				cc.HTTPOptions.BaseURL = "https://aiplatform.googleapis.com/"
-			} else if multiRegionalLocations[cc.Location] {
-				cc.HTTPOptions.BaseURL = fmt.Sprintf("https://aiplatform.%s.rep.googleapis.com/", cc.Location)
-			} else {
-				cc.HTTPOptions.BaseURL = fmt.Sprintf("https://%s-aiplatform.googleapis.com/", cc.Location)
-			}
+			cc.HTTPOptions.BaseURL = vertexBaseURL(cc.Location, cc.APIKey != "")
+			defaultBaseURL = true
 		}
 	} else {
 		// Mldev API
@@ -348,44 +424,66 @@ func NewInternalAPIClient(ctx context.Context, cc *ClientConfig) (*InternalAPICl
 	}
 
 	skipADC := cc.HTTPOptions.BaseURL != "" && cc.Project == "" && cc.Location == "" && cc.APIKey == ""
-	if cc.Backend == BackendVertexAI && cc.Credentials == nil && cc.APIKey == "" && cc.HTTPClient == nil && !skipADC {
-		cred, err := credentials.DetectDefault(&credentials.DetectOptions{
-			Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to find default credentials: %w", err)
+	useADC := cc.Backend == BackendVertexAI && cc.Credentials == nil && cc.APIKey == "" && cc.HTTPClient == nil && !skipADC
+
+	var previewFeatures map[PreviewFeature]bool
+	if cc.HTTPOptions.APIVersion == "" && cc.Backend == BackendVertexAI && cc.PreviewFeatures != nil {
+		cc.HTTPOptions.APIVersion = stableVertexAPIVersion
+		previewFeatures = make(map[PreviewFeature]bool)
+		for _, f := range cc.PreviewFeatures {
+			previewFeatures[f] = true
 		}
-		cc.Credentials = cred
-	}
-
-	if cc.HTTPOptions.APIVersion == "" && cc.Backend == BackendVertexAI {
-		cc.HTTPOptions.APIVersion = "v1beta1"
+	} else if cc.HTTPOptions.APIVersion == "" && cc.Backend == BackendVertexAI {
+		cc.HTTPOptions.APIVersion = previewVertexAPIVersion
 	} else if cc.HTTPOptions.APIVersion == "" {
 		cc.HTTPOptions.APIVersion = "v1beta"
 	}
 
+	if len(cc.AllowedLocations) > 0 {
+		u, err := url.Parse(cc.HTTPOptions.BaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing base URL: %w", err)
+		}
+		if err := checkDataResidency(cc.AllowedLocations, u, nil); err != nil {
+			return nil, err
+		}
+		if cc.Location != "" {
+			if err := checkLocation(cc.AllowedLocations, cc.Location, "ClientConfig.Location"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ac := &apiClient{clientConfig: cc, previewFeatures: previewFeatures, defaultBaseURL: defaultBaseURL}
 	if cc.HTTPClient == nil {
 		// x-goog-api-key header is set for Express mode in api_client.go
-		if cc.Backend == BackendVertexAI && cc.APIKey == "" && cc.Credentials != nil {
-			quotaProjectID, err := cc.Credentials.QuotaProjectID(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get quota project ID: %w", err)
+		if cc.Backend == BackendVertexAI && cc.APIKey == "" && (cc.Credentials != nil || useADC) {
+			ac.auth = &lazyAuth{cred: cc.Credentials}
+			cc.HTTPClient = &http.Client{Transport: ac.auth}
+			// With LazyCredentials, default credentials and the quota project
+			// are resolved on the first request, or by Client.Prime.
+			if !cc.LazyCredentials {
+				cred, _, err := ac.auth.resolve(ctx)
+				if err != nil {
+					return nil, err
+				}
+				cc.Credentials = cred
 			}
-			client, err := httptransport.NewClient(&httptransport.Options{
-				Credentials: cc.Credentials,
-				Headers: http.Header{
-					"X-Goog-User-Project": []string{quotaProjectID},
-				},
-			})
-			if err != nil {
-				return nil, fmt.Errorf("failed to create HTTP client: %w", err)
-			}
-			cc.HTTPClient = client
 		} else {
 			cc.HTTPClient = &http.Client{}
 		}
 	}
-	return &apiClient{clientConfig: cc}, nil
+	return ac, nil
+}
+
+// vertexBaseURL returns the default Vertex AI base URL of location.
+func vertexBaseURL(location string, expressMode bool) string {
+	if location == "global" || expressMode {
+		return "https://aiplatform.googleapis.com/"
+	} else if multiRegionalLocations[location] {
+		return fmt.Sprintf("https://aiplatform.%s.rep.googleapis.com/", location)
+	}
+	return fmt.Sprintf("https://%s-aiplatform.googleapis.com/", location)
 }
 
 // NewClient creates a new GenAI client.
@@ -414,6 +512,8 @@ func NewInternalAPIClient(ctx context.Context, cc *ClientConfig) (*InternalAPICl
 //
 // If using the Vertex AI backend and no credentials are provided in the
 // ClientConfig, the client will attempt to use application default credentials.
+// If [ClientConfig.LazyCredentials] is set, they are looked up on the first
+// request, or by [Client.Prime], instead of by NewClient.
 func NewClient(ctx context.Context, cc *ClientConfig) (*Client, error) {
 	if cc == nil {
 		cc = &ClientConfig{}
@@ -435,6 +535,18 @@ func NewClient(ctx context.Context, cc *ClientConfig) (*Client, error) {
 		Tunings:          &Tunings{apiClient: ac},
 		AuthTokens:       &Tokens{apiClient: ac},
 	}
+	if cc.WarmConnection != nil {
+		warmCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		c.stop = func() {
+			cancel()
+			<-done
+		}
+		go func() {
+			defer close(done)
+			warmConnection(warmCtx, cc.HTTPClient, cc.HTTPOptions.BaseURL, *cc.WarmConnection)
+		}()
+	}
 	return c, nil
 }
 
@@ -445,6 +557,34 @@ func (c Client) ClientConfig() ClientConfig {
 	return c.clientConfig
 }
 
+// Close stops the background work of the client, such as keeping the
+// connection warm for [ClientConfig.WarmConnection], and waits for it to
+// finish. The client can still send requests after Close. Calling Close more
+// than once has no effect.
+func (c *Client) Close() error {
+	if c.stop != nil {
+		c.stop()
+	}
+	return nil
+}
+
+// Prime resolves the credentials of the client and fetches an access token,
+// which would otherwise be done by the first request, and by NewClient for the
+// credentials unless [ClientConfig.LazyCredentials] is set. Call it where the
+// latency matters less than in the first request, for example during the
+// initialization of a server. It does nothing for clients that use an API key
+// or a user provided HTTP client.
+func (c *Client) Prime(ctx context.Context) error {
+	cred, err := c.Models.apiClient.credentials(ctx)
+	if err != nil || cred == nil {
+		return err
+	}
+	if _, err := cred.Token(ctx); err != nil {
+		return fmt.Errorf("failed to get token: %w", err)
+	}
+	return nil
+}
+
 // UseDefaultCredentials sets the credentials to use default credentials and
 // add authorization middleware to the HTTP client.
 //
@@ -456,16 +596,14 @@ func (cc *ClientConfig) UseDefaultCredentials() error {
 		return fmt.Errorf("Credentials are already set")
 	}
 	if cc.Credentials == nil {
-		cred, err := credentials.DetectDefault(&credentials.DetectOptions{
-			Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
-		})
+		cred, err := detectDefaultCredentials()
 		if err != nil {
 			return fmt.Errorf("failed to find default credentials: %w", err)
 		}
 		cc.Credentials = cred
 	}
 	if cc.HTTPClient != nil {
-		err := httptransport.AddAuthorizationMiddleware(cc.HTTPClient, cc.Credentials)
+		err := addAuthorizationMiddleware(cc.HTTPClient, cc.Credentials)
 		if err != nil {
 			return fmt.Errorf("failed to create HTTP client: %w", err)
 		}
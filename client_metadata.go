@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// clientMetadataKey is the JSON key of the client metadata in the histories
+// encoded by MarshalHistory.
+const clientMetadataKey = "clientMetadata"
+
+var (
+	partMetadata    sideTable[Part, map[string]any]
+	contentMetadata sideTable[Content, map[string]any]
+)
+
+// ClientMetadata returns the client-side metadata of the part, see
+// [Part.SetClientMetadata].
+func (p *Part) ClientMetadata() map[string]any {
+	return partMetadata.get(p)
+}
+
+// SetClientMetadata sets client-side metadata of the part, such as
+// application IDs or provenance. It's never sent to the API, but it's kept in
+// [Chat] history and encoded by [MarshalHistory]. It's attached to p, so
+// copies of the Part don't have it. Pass nil to remove it.
+func (p *Part) SetClientMetadata(metadata map[string]any) {
+	if metadata == nil {
+		partMetadata.delete(p)
+		return
+	}
+	partMetadata.set(p, metadata)
+}
+
+// ClientMetadata returns the client-side metadata of the content, see
+// [Content.SetClientMetadata].
+func (c *Content) ClientMetadata() map[string]any {
+	return contentMetadata.get(c)
+}
+
+// SetClientMetadata sets client-side metadata of the content, such as
+// application IDs or provenance. It's never sent to the API, but it's kept in
+// [Chat] history and encoded by [MarshalHistory]. It's attached to c, so
+// copies of the Content don't have it. Pass nil to remove it.
+func (c *Content) SetClientMetadata(metadata map[string]any) {
+	if metadata == nil {
+		contentMetadata.delete(c)
+		return
+	}
+	contentMetadata.set(c, metadata)
+}
+
+// MarshalHistory returns the JSON encoding of history, such as the history of a
+// [Chat], along with the client-side metadata of its contents and parts. Use
+// [UnmarshalHistory] to decode it.
+func MarshalHistory(history []*Content) ([]byte, error) {
+	contents := make([]map[string]json.RawMessage, len(history))
+	for i, c := range history {
+		content, err := withClientMetadata(c, c.ClientMetadata())
+		if err != nil {
+			return nil, fmt.Errorf("MarshalHistory: content %d: %w", i, err)
+		}
+		if c != nil && len(c.Parts) > 0 {
+			parts := make([]map[string]json.RawMessage, len(c.Parts))
+			for j, p := range c.Parts {
+				if parts[j], err = withClientMetadata(p, p.ClientMetadata()); err != nil {
+					return nil, fmt.Errorf("MarshalHistory: content %d, part %d: %w", i, j, err)
+				}
+			}
+			if content["parts"], err = json.Marshal(parts); err != nil {
+				return nil, fmt.Errorf("MarshalHistory: content %d: %w", i, err)
+			}
+		}
+		contents[i] = content
+	}
+	return json.Marshal(contents)
+}
+
+// withClientMetadata returns the fields of the JSON encoding of v, along with
+// metadata if it isn't empty.
+func withClientMetadata(v any, metadata map[string]any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if len(metadata) > 0 {
+		if fields == nil {
+			fields = map[string]json.RawMessage{}
+		}
+		if fields[clientMetadataKey], err = json.Marshal(metadata); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+// UnmarshalHistory decodes a history encoded by [MarshalHistory], with the
+// client-side metadata of its contents and parts.
+func UnmarshalHistory(data []byte) ([]*Content, error) {
+	var history []*Content
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("UnmarshalHistory: %w", err)
+	}
+	var metadata []*struct {
+		ClientMetadata map[string]any `json:"clientMetadata"`
+		Parts          []*struct {
+			ClientMetadata map[string]any `json:"clientMetadata"`
+		} `json:"parts"`
+	}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("UnmarshalHistory: %w", err)
+	}
+	for i, c := range history {
+		if c == nil || metadata[i] == nil {
+			continue
+		}
+		c.SetClientMetadata(metadata[i].ClientMetadata)
+		for j, p := range c.Parts {
+			if j < len(metadata[i].Parts) && metadata[i].Parts[j] != nil {
+				p.SetClientMetadata(metadata[i].Parts[j].ClientMetadata)
+			}
+		}
+	}
+	return history, nil
+}
@@ -16,8 +16,10 @@ package genai
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -1442,3 +1444,105 @@ func TestNewInternalAPIClient_BackendSelection(t *testing.T) {
 		})
 	}
 }
+
+type countingTokenProvider struct {
+	calls int
+}
+
+func (p *countingTokenProvider) Token(ctx context.Context) (*auth.Token, error) {
+	p.calls++
+	return &auth.Token{Value: "fake-token", Type: "Bearer"}, nil
+}
+
+func TestClientLazyCredentials(t *testing.T) {
+	ctx := context.Background()
+	var gotAuth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{"candidates": [{"content": {"role": "model", "parts": [{"text": "hi"}]}}]}`))
+	}))
+	defer ts.Close()
+
+	provider := &countingTokenProvider{}
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:     BackendVertexAI,
+		Project:     "test-project",
+		Location:    "test-location",
+		Credentials: auth.NewCredentials(&auth.CredentialsOptions{TokenProvider: provider}),
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if provider.calls != 0 {
+		t.Errorf("NewClient fetched %d tokens, want none", provider.calls)
+	}
+	if err := client.Prime(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if provider.calls != 1 {
+		t.Errorf("Prime fetched %d tokens, want 1", provider.calls)
+	}
+	if _, err := client.Models.GenerateContent(ctx, "gemini-2.0-flash", Text("hello"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Bearer fake-token"; gotAuth != want {
+		t.Errorf("Authorization header = %q, want %q", gotAuth, want)
+	}
+
+	if client.ClientConfig().Credentials == nil {
+		t.Errorf("ClientConfig().Credentials = nil, want the given credentials")
+	}
+
+	t.Run("Default credentials errors are returned by NewClient", func(t *testing.T) {
+		t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "testdata/does-not-exist.json")
+		_, err := NewClient(ctx, &ClientConfig{Backend: BackendVertexAI, Project: "test-project", Location: "test-location"})
+		if err == nil || !strings.Contains(err.Error(), "failed to find default credentials") {
+			t.Errorf("NewClient() error = %v, want a default credentials error", err)
+		}
+	})
+
+	t.Run("Failed lookups are retried", func(t *testing.T) {
+		quotaProjectCalls := 0
+		cred := auth.NewCredentials(&auth.CredentialsOptions{
+			TokenProvider: &countingTokenProvider{},
+			QuotaProjectIDProvider: auth.CredentialsPropertyFunc(func(ctx context.Context) (string, error) {
+				quotaProjectCalls++
+				if err := ctx.Err(); err != nil {
+					return "", err
+				}
+				if quotaProjectCalls == 1 {
+					return "", errors.New("metadata server unavailable")
+				}
+				return "quota-project", nil
+			}),
+		})
+		client, err := NewClient(ctx, &ClientConfig{Backend: BackendVertexAI, Project: "test-project", Location: "test-location", Credentials: cred, LazyCredentials: true, HTTPOptions: HTTPOptions{BaseURL: ts.URL}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.Prime(ctx); err == nil || !strings.Contains(err.Error(), "metadata server unavailable") {
+			t.Fatalf("Prime() error = %v, want the quota project error", err)
+		}
+		// The lookup isn't tied to the context of the request that triggers it.
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		if err := client.Prime(canceled); err != nil {
+			t.Fatalf("Prime() after a failed lookup failed: %v", err)
+		}
+		if _, err := client.Models.GenerateContent(ctx, "gemini-2.0-flash", Text("hello"), nil); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Default credentials errors are deferred with LazyCredentials", func(t *testing.T) {
+		t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "testdata/does-not-exist.json")
+		client, err := NewClient(ctx, &ClientConfig{Backend: BackendVertexAI, Project: "test-project", Location: "test-location", LazyCredentials: true})
+		if err != nil {
+			t.Fatalf("NewClient() failed: %v", err)
+		}
+		if err := client.Prime(ctx); err == nil || !strings.Contains(err.Error(), "failed to find default credentials") {
+			t.Errorf("Prime() error = %v, want a default credentials error", err)
+		}
+	})
+}
@@ -0,0 +1,314 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+// Task types of [EmbedContentConfig], which optimize embeddings for their
+// intended use.
+const (
+	// EmbeddingTaskRetrievalQuery embeds search queries.
+	EmbeddingTaskRetrievalQuery = "RETRIEVAL_QUERY"
+	// EmbeddingTaskRetrievalDocument embeds the documents being searched,
+	// including source code searched with [EmbeddingTaskCodeRetrievalQuery].
+	EmbeddingTaskRetrievalDocument = "RETRIEVAL_DOCUMENT"
+	// EmbeddingTaskSemanticSimilarity embeds texts to compare their similarity.
+	EmbeddingTaskSemanticSimilarity = "SEMANTIC_SIMILARITY"
+	// EmbeddingTaskClassification embeds texts to classify them.
+	EmbeddingTaskClassification = "CLASSIFICATION"
+	// EmbeddingTaskClustering embeds texts to cluster them.
+	EmbeddingTaskClustering = "CLUSTERING"
+	// EmbeddingTaskQuestionAnswering embeds questions answered by documents.
+	EmbeddingTaskQuestionAnswering = "QUESTION_ANSWERING"
+	// EmbeddingTaskFactVerification embeds statements to verify.
+	EmbeddingTaskFactVerification = "FACT_VERIFICATION"
+	// EmbeddingTaskCodeRetrievalQuery embeds natural language queries searching
+	// for code.
+	EmbeddingTaskCodeRetrievalQuery = "CODE_RETRIEVAL_QUERY"
+)
+
+const (
+	defaultCodeChunkLines       = 60
+	defaultCodeEmbedBatchSize   = 100
+	defaultCodeEmbedMaxFileSize = 1024 * 1024
+)
+
+// codeLanguage describes how the source files of a language are chunked.
+type codeLanguage struct {
+	name string
+	// declarations are the prefixes of top-level declarations. If nil, any
+	// unindented line after a blank line or a closing brace starts one.
+	declarations []string
+}
+
+var codeLanguages = func() map[string]codeLanguage {
+	goLang := codeLanguage{"Go", []string{"func ", "type ", "var ", "const "}}
+	python := codeLanguage{"Python", []string{"def ", "async def ", "class ", "@"}}
+	js := codeLanguage{"JavaScript", []string{"function ", "async function ", "class ", "export ", "const ", "let "}}
+	ts := codeLanguage{"TypeScript", []string{"function ", "async function ", "class ", "export ", "const ", "let ", "interface ", "type "}}
+	rust := codeLanguage{"Rust", []string{"fn ", "pub ", "impl ", "struct ", "enum ", "trait ", "mod ", "#["}}
+	ruby := codeLanguage{"Ruby", []string{"def ", "class ", "module "}}
+	languages := map[string]codeLanguage{
+		".go": goLang, ".py": python, ".js": js, ".jsx": js, ".mjs": js, ".ts": ts, ".tsx": ts,
+		".rs": rust, ".rb": ruby,
+	}
+	for ext, name := range map[string]string{
+		".c": "C", ".h": "C", ".cc": "C++", ".cpp": "C++", ".hpp": "C++", ".java": "Java",
+		".kt": "Kotlin", ".cs": "C#", ".swift": "Swift", ".php": "PHP", ".scala": "Scala",
+	} {
+		languages[ext] = codeLanguage{name: name}
+	}
+	return languages
+}()
+
+// CodeChunk is a chunk of a source file embedded by [Models.EmbedRepository].
+type CodeChunk struct {
+	// Path is the slash-separated path of the file, relative to the root.
+	Path string
+	// Language is the programming language of the file, such as "Go".
+	Language string
+	// StartLine is the first line of the chunk, starting from 1.
+	StartLine int
+	// EndLine is the last line of the chunk, included.
+	EndLine int
+	// Text is the source code of the chunk.
+	Text string
+}
+
+// EmbedRepositoryConfig configures [Models.EmbedRepository].
+type EmbedRepositoryConfig struct {
+	// Optional. Reports whether the file or directory at path, relative to the
+	// root, is embedded. By default, the source files of known languages are,
+	// except in hidden, vendor and node_modules directories.
+	Include func(path string, d fs.DirEntry) bool
+	// Optional. Maximum number of lines of a chunk. Defaults to 60.
+	MaxChunkLines int
+	// Optional. Maximum number of chunks embedded by a request. Defaults to 100,
+	// or to 1 for Vertex AI models that embed one content at a time.
+	BatchSize int
+	// Optional. Larger files are skipped. Defaults to 1 MB.
+	MaxFileBytes int64
+	// Optional. Configuration of the embedding requests. TaskType defaults to
+	// [EmbeddingTaskRetrievalDocument], so that the chunks can be searched
+	// with queries embedded with [EmbeddingTaskCodeRetrievalQuery].
+	EmbedConfig *EmbedContentConfig
+}
+
+// EmbedRepository walks the directory root, splits its source files into
+// chunks at the boundaries of top-level declarations, and embeds them with
+// model in batches. fn is called with each chunk and its embedding as
+// batches complete, and the walk stops if it returns an error. The path of
+// each file is embedded along with its chunks.
+//
+//	err := client.Models.EmbedRepository(ctx, "gemini-embedding-001", "./src", nil, func(chunk *genai.CodeChunk, embedding *genai.ContentEmbedding) error {
+//		return index.Add(chunk.Path, chunk.StartLine, embedding.Values)
+//	})
+func (m Models) EmbedRepository(ctx context.Context, model, root string, config *EmbedRepositoryConfig, fn func(chunk *CodeChunk, embedding *ContentEmbedding) error) error {
+	cfg := EmbedRepositoryConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.Include == nil {
+		cfg.Include = includeSourceFile
+	}
+	if cfg.MaxChunkLines <= 0 {
+		cfg.MaxChunkLines = defaultCodeChunkLines
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultCodeEmbedBatchSize
+		if m.apiClient.clientConfig.Backend == BackendVertexAI && tIsVertexEmbedContentModel(model) {
+			cfg.BatchSize = 1
+		}
+	}
+	if cfg.MaxFileBytes <= 0 {
+		cfg.MaxFileBytes = defaultCodeEmbedMaxFileSize
+	}
+	embedConfig := EmbedContentConfig{}
+	if cfg.EmbedConfig != nil {
+		embedConfig = *cfg.EmbedConfig
+	}
+	if embedConfig.TaskType == "" {
+		embedConfig.TaskType = EmbeddingTaskRetrievalDocument
+	}
+
+	var batch []*CodeChunk
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		contents := make([]*Content, len(batch))
+		for i, c := range batch {
+			contents[i] = NewContentFromText(c.Path+"\n\n"+c.Text, RoleUser)
+		}
+		resp, err := m.EmbedContent(ctx, model, contents, &embedConfig)
+		if err != nil {
+			return fmt.Errorf("EmbedRepository: embedding %s: %w", batch[0].Path, err)
+		}
+		if len(resp.Embeddings) != len(batch) {
+			return fmt.Errorf("EmbedRepository: got %d embeddings for %d chunks", len(resp.Embeddings), len(batch))
+		}
+		for i, c := range batch {
+			if err := fn(c, resp.Embeddings[i]); err != nil {
+				return err
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	fsys := os.DirFS(root)
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == "." {
+			return nil
+		}
+		if !cfg.Include(p, d) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err != nil || info.Size() > cfg.MaxFileBytes || !info.Mode().IsRegular() {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(data) {
+			return nil
+		}
+		for _, chunk := range chunkSourceFile(p, string(data), cfg.MaxChunkLines) {
+			batch = append(batch, chunk)
+			if len(batch) == cfg.BatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		return ctx.Err()
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+// includeSourceFile is the default filter of EmbedRepository.
+func includeSourceFile(p string, d fs.DirEntry) bool {
+	name := d.Name()
+	if d.IsDir() {
+		return !strings.HasPrefix(name, ".") && name != "vendor" && name != "node_modules"
+	}
+	_, ok := codeLanguages[path.Ext(name)]
+	return ok && !strings.HasPrefix(name, ".")
+}
+
+// chunkSourceFile splits the source file at p into chunks of at most
+// maxLines lines. Chunks start at top-level declarations, along with the
+// comments and annotations before them, and longer declarations are split at
+// blank lines if possible.
+func chunkSourceFile(p, text string, maxLines int) []*CodeChunk {
+	lang := codeLanguages[path.Ext(p)]
+	lines := strings.SplitAfter(text, "\n")
+	if n := len(lines); n > 0 && lines[n-1] == "" {
+		lines = lines[:n-1]
+	}
+	// Find the lines starting a declaration.
+	starts := []int{0}
+	for i := 1; i < len(lines); i++ {
+		if !isDeclarationStart(lang, lines[i], lines[i-1]) {
+			continue
+		}
+		start := i
+		for start > 0 && isCommentLine(lines[start-1]) {
+			start--
+		}
+		if start > starts[len(starts)-1] {
+			starts = append(starts, start)
+		}
+	}
+	starts = append(starts, len(lines))
+
+	var chunks []*CodeChunk
+	for i := 0; i+1 < len(starts); i++ {
+		for from, to := starts[i], starts[i+1]; from < to; {
+			end := to
+			if end-from > maxLines {
+				end = from + maxLines
+				// Split at the last blank line of the chunk, if any.
+				for j := end - 1; j > from+maxLines/2; j-- {
+					if strings.TrimSpace(lines[j]) == "" {
+						end = j + 1
+						break
+					}
+				}
+			}
+			text := strings.Join(lines[from:end], "")
+			if strings.TrimSpace(text) != "" {
+				chunks = append(chunks, &CodeChunk{Path: p, Language: lang.name, StartLine: from + 1, EndLine: end, Text: text})
+			}
+			from = end
+		}
+	}
+	return chunks
+}
+
+func isDeclarationStart(lang codeLanguage, line, previous string) bool {
+	if line == "" || line[0] == ' ' || line[0] == '\t' || strings.TrimSpace(line) == "" {
+		return false
+	}
+	if lang.declarations == nil {
+		prev := strings.TrimSpace(previous)
+		return !strings.HasPrefix(line, "}") && (prev == "" || strings.HasPrefix(prev, "}"))
+	}
+	for _, d := range lang.declarations {
+		if strings.HasPrefix(line, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCommentLine reports whether line is an unindented comment or annotation.
+func isCommentLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" {
+		return false
+	}
+	if line[0] == ' ' || line[0] == '\t' {
+		// The continuation of a block comment, such as " * text".
+		return strings.HasPrefix(trimmed, "*")
+	}
+	for _, prefix := range []string{"//", "#", "/*", "@"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
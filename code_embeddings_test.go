@@ -0,0 +1,144 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChunkSourceFile(t *testing.T) {
+	src := `package main
+
+import "fmt"
+
+// Hello greets.
+// It's exported.
+func Hello() {
+	fmt.Println("hello")
+}
+
+type T struct{}
+
+func (T) M() {}
+`
+	var got []string
+	for _, c := range chunkSourceFile("main.go", src, 60) {
+		got = append(got, fmt.Sprintf("%d-%d", c.StartLine, c.EndLine))
+		if c.Language != "Go" {
+			t.Errorf("Language = %q, want Go", c.Language)
+		}
+	}
+	if want := "[1-4 5-10 11-12 13-13]"; fmt.Sprint(got) != want {
+		t.Errorf("chunks = %v, want %s", got, want)
+	}
+
+	// Long declarations are split, at blank lines if possible.
+	long := "def f():\n" + strings.Repeat("    x = 1\n", 5) + "\n" + strings.Repeat("    y = 2\n", 5)
+	got = nil
+	for _, c := range chunkSourceFile("f.py", long, 8) {
+		got = append(got, fmt.Sprintf("%d-%d", c.StartLine, c.EndLine))
+	}
+	if want := "[1-7 8-12]"; fmt.Sprint(got) != want {
+		t.Errorf("chunks = %v, want %s", got, want)
+	}
+
+	// Languages without declaration prefixes split after closing braces.
+	c := "int a() {\n  return 1;\n}\n\n/* b */\nint b() {\n  return 2;\n}\n"
+	got = nil
+	for _, c := range chunkSourceFile("a.c", c, 60) {
+		got = append(got, fmt.Sprintf("%d-%d", c.StartLine, c.EndLine))
+	}
+	if want := "[1-4 5-8]"; fmt.Sprint(got) != want {
+		t.Errorf("chunks = %v, want %s", got, want)
+	}
+}
+
+func TestEmbedRepository(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"main.go":             "package main\n\nfunc main() {}\n",
+		"lib/util.py":         "def util():\n    pass\n",
+		"README.md":           "# Not code\n",
+		"vendor/dep/dep.go":   "package dep\n",
+		".git/hooks/pre.go":   "package hooks\n",
+		"node_modules/m/m.js": "function m() {}\n",
+		"lib/binary.go":       "\xff\xfe",
+	} {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var requests, gotTaskType string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Requests []struct {
+				Content  *Content `json:"content"`
+				TaskType string   `json:"taskType"`
+			} `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		requests += fmt.Sprint(len(body.Requests))
+		var embeddings []string
+		for _, req := range body.Requests {
+			gotTaskType = req.TaskType
+			embeddings = append(embeddings, fmt.Sprintf(`{"values": [%d]}`, len(req.Content.Parts[0].Text)))
+		}
+		fmt.Fprintf(w, `{"embeddings": [%s]}`, strings.Join(embeddings, ","))
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+
+	var got []string
+	err := m.EmbedRepository(ctx, "gemini-embedding-001", root, &EmbedRepositoryConfig{BatchSize: 2}, func(chunk *CodeChunk, embedding *ContentEmbedding) error {
+		got = append(got, fmt.Sprintf("%s:%d=%v", chunk.Path, chunk.StartLine, embedding.Values))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[lib/util.py:1=[34] main.go:1=[23] main.go:3=[24]]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("embedded chunks = %v, want %s", got, want)
+	}
+	if requests != "21" || gotTaskType != EmbeddingTaskRetrievalDocument {
+		t.Errorf("batches = %s with task type %q, want 2 then 1 with %q", requests, gotTaskType, EmbeddingTaskRetrievalDocument)
+	}
+
+	stop := fmt.Errorf("stop")
+	err = m.EmbedRepository(ctx, "gemini-embedding-001", root, nil, func(*CodeChunk, *ContentEmbedding) error { return stop })
+	if err != stop {
+		t.Errorf("EmbedRepository() error = %v, want the callback error", err)
+	}
+}
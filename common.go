@@ -434,6 +434,19 @@ func createURLQuery(query map[string]any) (string, error) {
 	return v.Encode(), nil
 }
 
+// updateMask returns the comma-separated field mask of a PATCH request body,
+// which lists the top-level fields set in body.
+func updateMask(body map[string]any) string {
+	fields := make([]string, 0, len(body))
+	for k := range body {
+		if !strings.HasPrefix(k, "_") {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ",")
+}
+
 func yieldErrorAndEndIterator[T any](err error) iter.Seq2[*T, error] {
 	return func(yield func(*T, error) bool) {
 		if !yield(nil, err) {
@@ -476,6 +489,9 @@ func mergeHTTPOptions(clientConfig *ClientConfig, configHTTPOptions *HTTPOptions
 		if configHTTPOptions.ExtrasRequestProvider != nil {
 			result.ExtrasRequestProvider = configHTTPOptions.ExtrasRequestProvider
 		}
+		if configHTTPOptions.Timeout != nil {
+			result.Timeout = configHTTPOptions.Timeout
+		}
 	}
 	result.Headers = mergeHeaders(clientHTTPOptions, configHTTPOptions)
 	return &result
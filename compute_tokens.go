@@ -0,0 +1,179 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// sentencePieceSpace is the meta symbol SentencePiece uses to encode a space.
+const sentencePieceSpace = "▁"
+
+// TokenComputer computes the tokens of contents without calling the API. A
+// *tokenizer.LocalTokenizer of google.golang.org/genai/tokenizer implements
+// it.
+type TokenComputer interface {
+	ComputeTokens(contents []*Content) (*ComputeTokensResult, error)
+}
+
+// ComputeTokensWithLocalTokenizer computes the tokens of the provided
+// contents on either backend.
+//
+// The Gemini API has no computeTokens method, so on the Gemini API the tokens
+// are computed locally by the tokenizer that [ClientConfig.LocalTokenizer]
+// returns for the model. It only supports text, and the config is ignored. On
+// Vertex AI, or if ClientConfig.LocalTokenizer is nil, it calls
+// [Models.ComputeTokens].
+func (m Models) ComputeTokensWithLocalTokenizer(ctx context.Context, model string, contents []*Content, config *ComputeTokensConfig) (*ComputeTokensResponse, error) {
+	cc := m.apiClient.ClientConfig()
+	if cc.Backend == BackendVertexAI || cc.LocalTokenizer == nil {
+		return m.ComputeTokens(ctx, model, contents, config)
+	}
+	tok, err := cc.LocalTokenizer(model)
+	if err != nil {
+		return nil, fmt.Errorf("ComputeTokensWithLocalTokenizer: creating the local tokenizer of %s: %w", model, err)
+	}
+	result, err := tok.ComputeTokens(contents)
+	if err != nil {
+		return nil, fmt.Errorf("ComputeTokensWithLocalTokenizer: %w", err)
+	}
+	return &ComputeTokensResponse{TokensInfo: result.TokensInfo}, nil
+}
+
+// TokenPiece is a single token of a tokenized input, as returned by
+// [Models.ComputeTokens] or the local tokenizer.
+type TokenPiece struct {
+	// ID is the token ID in the model vocabulary.
+	ID int64
+	// Piece is the string form of the token. SentencePiece space markers are
+	// kept as is, so pieces can be displayed unambiguously.
+	Piece string
+	// Role is the role of the Content the token belongs to.
+	Role string
+}
+
+// Pieces pairs every token ID in t with its string piece. If the server didn't
+// return the token bytes for an ID, its Piece is empty.
+func (t *TokensInfo) Pieces() []TokenPiece {
+	if t == nil {
+		return nil
+	}
+	pieces := make([]TokenPiece, len(t.TokenIDs))
+	for i, id := range t.TokenIDs {
+		pieces[i] = TokenPiece{ID: id, Role: t.Role}
+		if i < len(t.Tokens) {
+			pieces[i].Piece = string(t.Tokens[i])
+		}
+	}
+	return pieces
+}
+
+// Text reassembles the text that was tokenized into t, converting
+// SentencePiece space markers back to spaces.
+func (t *TokensInfo) Text() string {
+	if t == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, token := range t.Tokens {
+		sb.Write(token)
+	}
+	return strings.ReplaceAll(sb.String(), sentencePieceSpace, " ")
+}
+
+// Pieces returns the token pieces of all TokensInfo entries in order.
+func (r *ComputeTokensResponse) Pieces() []TokenPiece {
+	if r == nil {
+		return nil
+	}
+	return tokensInfoPieces(r.TokensInfo)
+}
+
+// Vocabulary returns the token ID to piece mapping observed in r. IDs whose
+// token bytes weren't returned are left out.
+func (r *ComputeTokensResponse) Vocabulary() map[int64]string {
+	if r == nil {
+		return nil
+	}
+	return tokensInfoVocabulary(r.TokensInfo)
+}
+
+// Decode maps token IDs back to text using the vocabulary observed in r. It
+// returns an error if an ID didn't occur in r, or occurred without its token
+// bytes.
+func (r *ComputeTokensResponse) Decode(ids []int64) (string, error) {
+	return decodeTokenIDs(r.Vocabulary(), ids)
+}
+
+// Pieces returns the token pieces of all TokensInfo entries in order.
+func (r *ComputeTokensResult) Pieces() []TokenPiece {
+	if r == nil {
+		return nil
+	}
+	return tokensInfoPieces(r.TokensInfo)
+}
+
+// Vocabulary returns the token ID to piece mapping observed in r. IDs whose
+// token bytes weren't returned are left out.
+func (r *ComputeTokensResult) Vocabulary() map[int64]string {
+	if r == nil {
+		return nil
+	}
+	return tokensInfoVocabulary(r.TokensInfo)
+}
+
+// Decode maps token IDs back to text using the vocabulary observed in r. It
+// returns an error if an ID didn't occur in r, or occurred without its token
+// bytes.
+func (r *ComputeTokensResult) Decode(ids []int64) (string, error) {
+	return decodeTokenIDs(r.Vocabulary(), ids)
+}
+
+func tokensInfoPieces(infos []*TokensInfo) []TokenPiece {
+	var pieces []TokenPiece
+	for _, info := range infos {
+		pieces = append(pieces, info.Pieces()...)
+	}
+	return pieces
+}
+
+func tokensInfoVocabulary(infos []*TokensInfo) map[int64]string {
+	vocabulary := make(map[int64]string)
+	for _, info := range infos {
+		if info == nil {
+			continue
+		}
+		for i, id := range info.TokenIDs {
+			if i < len(info.Tokens) {
+				vocabulary[id] = string(info.Tokens[i])
+			}
+		}
+	}
+	return vocabulary
+}
+
+func decodeTokenIDs(vocabulary map[int64]string, ids []int64) (string, error) {
+	var sb strings.Builder
+	for _, id := range ids {
+		piece, ok := vocabulary[id]
+		if !ok {
+			return "", fmt.Errorf("decodeTokenIDs: no piece is known for token ID %d", id)
+		}
+		sb.WriteString(piece)
+	}
+	return strings.ReplaceAll(sb.String(), sentencePieceSpace, " "), nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTokenPieces(t *testing.T) {
+	resp := &ComputeTokensResponse{
+		TokensInfo: []*TokensInfo{
+			{Role: "user", TokenIDs: []int64{17534, 2134}, Tokens: [][]byte{[]byte("hello"), []byte("▁world")}},
+			{Role: "model", TokenIDs: []int64{235341}, Tokens: [][]byte{[]byte("!")}},
+		},
+	}
+
+	t.Run("Pieces", func(t *testing.T) {
+		want := []TokenPiece{
+			{ID: 17534, Piece: "hello", Role: "user"},
+			{ID: 2134, Piece: "▁world", Role: "user"},
+			{ID: 235341, Piece: "!", Role: "model"},
+		}
+		if diff := cmp.Diff(want, resp.Pieces()); diff != "" {
+			t.Errorf("Pieces() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		if got := resp.TokensInfo[0].Text(); got != "hello world" {
+			t.Errorf("Text() = %q, want %q", got, "hello world")
+		}
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		got, err := resp.Decode([]int64{2134, 235341, 17534})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != " world!hello" {
+			t.Errorf("Decode() = %q, want %q", got, " world!hello")
+		}
+		if _, err := resp.Decode([]int64{1}); err == nil {
+			t.Errorf("Decode() with unknown ID: got no error, want error")
+		}
+	})
+
+	t.Run("MissingTokens", func(t *testing.T) {
+		info := &TokensInfo{TokenIDs: []int64{1, 2}, Tokens: [][]byte{[]byte("a")}}
+		want := []TokenPiece{{ID: 1, Piece: "a"}, {ID: 2}}
+		if diff := cmp.Diff(want, info.Pieces()); diff != "" {
+			t.Errorf("Pieces() mismatch (-want +got):\n%s", diff)
+		}
+		r := &ComputeTokensResult{TokensInfo: []*TokensInfo{info}}
+		if _, err := r.Decode([]int64{1, 2}); err == nil {
+			t.Errorf("Decode() with an ID without token bytes: got no error, want error")
+		}
+	})
+}
+
+type fakeTokenComputer struct{}
+
+func (fakeTokenComputer) ComputeTokens(contents []*Content) (*ComputeTokensResult, error) {
+	var infos []*TokensInfo
+	for _, c := range contents {
+		for _, p := range c.Parts {
+			infos = append(infos, &TokensInfo{Role: c.Role, TokenIDs: []int64{int64(len(p.Text))}, Tokens: [][]byte{[]byte(p.Text)}})
+		}
+	}
+	return &ComputeTokensResult{TokensInfo: infos}, nil
+}
+
+func TestComputeTokensGeminiAPI(t *testing.T) {
+	ctx := context.Background()
+	contents := Text("hello")
+
+	t.Run("LocalTokenizer", func(t *testing.T) {
+		var gotModel string
+		m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+			Backend: BackendGeminiAPI,
+			LocalTokenizer: func(model string) (TokenComputer, error) {
+				gotModel = model
+				return fakeTokenComputer{}, nil
+			},
+		}}}
+		resp, err := m.ComputeTokensWithLocalTokenizer(ctx, "gemini-2.5-flash", contents, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotModel != "gemini-2.5-flash" {
+			t.Errorf("LocalTokenizer called with %q, want %q", gotModel, "gemini-2.5-flash")
+		}
+		want := []TokenPiece{{ID: 5, Piece: "hello", Role: "user"}}
+		if diff := cmp.Diff(want, resp.Pieces()); diff != "" {
+			t.Errorf("Pieces() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("LocalTokenizerError", func(t *testing.T) {
+		m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+			Backend: BackendGeminiAPI,
+			LocalTokenizer: func(model string) (TokenComputer, error) {
+				return nil, errors.New("model not supported")
+			},
+		}}}
+		if _, err := m.ComputeTokensWithLocalTokenizer(ctx, "gemini-x", contents, nil); err == nil {
+			t.Errorf("ComputeTokensWithLocalTokenizer() got no error, want error")
+		}
+	})
+
+	t.Run("NoLocalTokenizer", func(t *testing.T) {
+		m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI}}}
+		if _, err := m.ComputeTokensWithLocalTokenizer(ctx, "gemini-2.5-flash", contents, nil); err == nil {
+			t.Errorf("ComputeTokensWithLocalTokenizer() got no error, want error")
+		}
+	})
+}
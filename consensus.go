@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const defaultConsensusSamples = 5
+
+// ConsensusConfig configures [Models.GenerateConsensus].
+type ConsensusConfig struct {
+	// Optional. Number of answers to sample. Defaults to 5.
+	Samples int32
+	// Optional. If true, Samples separate GenerateContent calls are issued
+	// instead of a single call with CandidateCount set to Samples. Use this for
+	// models that don't support multiple candidates.
+	SeparateCalls bool
+	// Optional. Normalize maps an answer to the key used for clustering. If nil,
+	// [NormalizeAnswer] is used.
+	Normalize func(string) string
+}
+
+// ConsensusCluster is a group of answers that normalize to the same key.
+type ConsensusCluster struct {
+	// Key is the normalized answer shared by every member of the cluster.
+	Key string
+	// Answers are the raw answers in the cluster, in sampling order.
+	Answers []string
+	// Indexes are the positions of the answers in the sampled answer list.
+	Indexes []int
+}
+
+// ConsensusResult is the outcome of a majority vote.
+type ConsensusResult struct {
+	// Answer is the first raw answer of the winning cluster.
+	Answer string
+	// Clusters are all clusters, ordered by size and then by first appearance.
+	// Clusters[0] is the winning cluster.
+	Clusters []*ConsensusCluster
+	// Total is the number of non-empty answers that took part in the vote.
+	Total int
+	// Agreement is the fraction of answers that belong to the winning cluster.
+	Agreement float64
+	// Responses are the raw responses the answers were extracted from. It's
+	// only populated by [Models.GenerateConsensus].
+	Responses []*GenerateContentResponse
+}
+
+// NormalizeAnswer is the default normalization used for majority voting.
+// JSON answers are re-encoded in canonical form (sorted object keys, no
+// insignificant whitespace), so structurally equal objects compare equal. Other
+// answers are lower-cased and have their whitespace collapsed.
+func NormalizeAnswer(answer string) string {
+	trimmed := strings.TrimSpace(answer)
+	var v any
+	if err := json.Unmarshal([]byte(trimmed), &v); err == nil {
+		if b, err := json.Marshal(v); err == nil {
+			return string(b)
+		}
+	}
+	return strings.Join(strings.Fields(strings.ToLower(trimmed)), " ")
+}
+
+// MajorityVote clusters answers by their normalized form and returns the
+// largest cluster as the consensus. Ties are broken in favor of the answer
+// that appeared first. Empty answers are ignored. If normalize is nil,
+// [NormalizeAnswer] is used.
+func MajorityVote(answers []string, normalize func(string) string) *ConsensusResult {
+	if normalize == nil {
+		normalize = NormalizeAnswer
+	}
+	result := &ConsensusResult{}
+	byKey := map[string]*ConsensusCluster{}
+	for i, answer := range answers {
+		if strings.TrimSpace(answer) == "" {
+			continue
+		}
+		key := normalize(answer)
+		cluster, ok := byKey[key]
+		if !ok {
+			cluster = &ConsensusCluster{Key: key}
+			byKey[key] = cluster
+			result.Clusters = append(result.Clusters, cluster)
+		}
+		cluster.Answers = append(cluster.Answers, answer)
+		cluster.Indexes = append(cluster.Indexes, i)
+		result.Total++
+	}
+	if result.Total == 0 {
+		return result
+	}
+	// Clusters are already in order of first appearance, so a stable sort keeps
+	// the earliest cluster first among equally sized ones.
+	sort.SliceStable(result.Clusters, func(i, j int) bool {
+		return len(result.Clusters[i].Answers) > len(result.Clusters[j].Answers)
+	})
+	winner := result.Clusters[0]
+	result.Answer = winner.Answers[0]
+	result.Agreement = float64(len(winner.Answers)) / float64(result.Total)
+	return result
+}
+
+// GenerateConsensus samples several answers for the same request and returns
+// the majority answer along with agreement statistics (self-consistency).
+//
+// By default a single request is made with CandidateCount set to the number of
+// samples. Set [ConsensusConfig.SeparateCalls] to issue one request per sample
+// instead. The text of each candidate is used as its answer, so the helper
+// works equally well for free-form text and structured (JSON) outputs.
+func (m Models) GenerateConsensus(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, consensusConfig *ConsensusConfig) (*ConsensusResult, error) {
+	cc := ConsensusConfig{}
+	if consensusConfig != nil {
+		cc = *consensusConfig
+	}
+	if cc.Samples < 0 {
+		return nil, fmt.Errorf("GenerateConsensus: samples must be positive, got %d", cc.Samples)
+	}
+	if cc.Samples == 0 {
+		cc.Samples = defaultConsensusSamples
+	}
+
+	candidates, responses, err := m.sampleCandidates(ctx, model, contents, config, cc.Samples, cc.SeparateCalls)
+	if err != nil {
+		return nil, err
+	}
+	answers := make([]string, len(candidates))
+	for i, c := range candidates {
+		answers[i] = candidateText(c)
+	}
+
+	result := MajorityVote(answers, cc.Normalize)
+	result.Responses = responses
+	if result.Total == 0 {
+		return result, fmt.Errorf("GenerateConsensus: the model returned no text answers")
+	}
+	return result, nil
+}
+
+// sampleCandidates samples n candidates for the same request, with a single
+// GenerateContent call with CandidateCount set to n or, if separateCalls is
+// set, with n calls. With separate calls, the first candidate of each response
+// is used, which is nil if the response has none.
+func (m Models) sampleCandidates(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, n int32, separateCalls bool) ([]*Candidate, []*GenerateContentResponse, error) {
+	var candidates []*Candidate
+	var responses []*GenerateContentResponse
+	if separateCalls {
+		for range n {
+			resp, err := m.GenerateContentWithOptions(ctx, model, contents, config, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			responses = append(responses, resp)
+			candidates = append(candidates, firstCandidate(resp))
+		}
+		return candidates, responses, nil
+	}
+	c := GenerateContentConfig{}
+	if config != nil {
+		c = *config
+	}
+	c.CandidateCount = n
+	resp, err := m.GenerateContentWithOptions(ctx, model, contents, &c, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Candidates, []*GenerateContentResponse{resp}, nil
+}
+
+func firstCandidate(resp *GenerateContentResponse) *Candidate {
+	if resp == nil || len(resp.Candidates) == 0 {
+		return nil
+	}
+	return resp.Candidates[0]
+}
+
+// candidateText concatenates the non-thought text parts of a candidate.
+func candidateText(c *Candidate) string {
+	if c == nil || c.Content == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, part := range c.Content.Parts {
+		if part == nil || part.Thought {
+			continue
+		}
+		sb.WriteString(part.Text)
+	}
+	return sb.String()
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormalizeAnswer(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"Text", "  Paris \n", "paris"},
+		{"TextWhitespace", "New   York\tCity", "new york city"},
+		{"JSONObjectKeyOrder", `{"b": 1, "a": "x"}`, `{"a":"x","b":1}`},
+		{"JSONNumber", " 42 ", "42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeAnswer(tt.input); got != tt.want {
+				t.Errorf("NormalizeAnswer(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMajorityVote(t *testing.T) {
+	t.Run("Majority", func(t *testing.T) {
+		got := MajorityVote([]string{"Paris", "paris ", "Lyon", "", "PARIS"}, nil)
+		if got.Answer != "Paris" {
+			t.Errorf("Answer = %q, want %q", got.Answer, "Paris")
+		}
+		if got.Total != 4 {
+			t.Errorf("Total = %d, want 4", got.Total)
+		}
+		if got.Agreement != 0.75 {
+			t.Errorf("Agreement = %v, want 0.75", got.Agreement)
+		}
+		if diff := cmp.Diff([]int{0, 1, 4}, got.Clusters[0].Indexes); diff != "" {
+			t.Errorf("winning cluster indexes mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("TieFavorsFirst", func(t *testing.T) {
+		got := MajorityVote([]string{"b", "a", "a", "b"}, nil)
+		if got.Answer != "b" {
+			t.Errorf("Answer = %q, want %q", got.Answer, "b")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		got := MajorityVote([]string{`{"x":1,"y":2}`, `{"y": 2, "x": 1}`, `{"x":3}`}, nil)
+		if len(got.Clusters) != 2 || len(got.Clusters[0].Answers) != 2 {
+			t.Errorf("unexpected clusters: %+v", got.Clusters)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		got := MajorityVote(nil, nil)
+		if got.Total != 0 || got.Answer != "" || len(got.Clusters) != 0 {
+			t.Errorf("MajorityVote(nil) = %+v, want empty result", got)
+		}
+	})
+}
+
+func TestGenerateConsensus(t *testing.T) {
+	ctx := context.Background()
+	answers := []string{"4", "4", "5"}
+
+	t.Run("CandidateCount", func(t *testing.T) {
+		var gotCandidateCount float64
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			gotCandidateCount, _ = body["generationConfig"].(map[string]any)["candidateCount"].(float64)
+			var candidates []map[string]any
+			for _, a := range answers {
+				candidates = append(candidates, map[string]any{
+					"content": map[string]any{"role": "model", "parts": []map[string]any{{"text": a}}},
+				})
+			}
+			json.NewEncoder(w).Encode(map[string]any{"candidates": candidates})
+		}))
+		defer ts.Close()
+
+		m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+			Backend:     BackendGeminiAPI,
+			HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+			HTTPClient:  ts.Client(),
+		}}}
+		got, err := m.GenerateConsensus(ctx, "gemini-2.5-flash", Text("2+2?"), nil, &ConsensusConfig{Samples: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotCandidateCount != 3 {
+			t.Errorf("candidateCount = %v, want 3", gotCandidateCount)
+		}
+		if got.Answer != "4" || got.Total != 3 {
+			t.Errorf("got answer %q from %d answers, want %q from 3", got.Answer, got.Total, "4")
+		}
+	})
+
+	t.Run("SeparateCalls", func(t *testing.T) {
+		calls := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": %q}]}}]}`, answers[calls%len(answers)])
+			calls++
+		}))
+		defer ts.Close()
+
+		m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+			Backend:     BackendGeminiAPI,
+			HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+			HTTPClient:  ts.Client(),
+		}}}
+		got, err := m.GenerateConsensus(ctx, "gemini-2.5-flash", Text("2+2?"), nil, &ConsensusConfig{Samples: 3, SeparateCalls: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if calls != 3 {
+			t.Errorf("calls = %d, want 3", calls)
+		}
+		if len(got.Responses) != 3 {
+			t.Errorf("len(Responses) = %d, want 3", len(got.Responses))
+		}
+		if got.Answer != "4" {
+			t.Errorf("Answer = %q, want %q", got.Answer, "4")
+		}
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const defaultCountTokensConcurrency = 4
+
+// CountTokensBatchConfig configures [Models.CountTokensBatch].
+type CountTokensBatchConfig struct {
+	// Optional. Configuration used for every CountTokens call.
+	CountTokensConfig *CountTokensConfig
+	// Optional. Maximum number of CountTokens calls in flight at the same time.
+	// Defaults to 4.
+	Concurrency int
+}
+
+// CountTokensBatchResponse is the result of [Models.CountTokensBatch].
+type CountTokensBatchResponse struct {
+	// TotalTokens is the sum of the token counts of all items.
+	TotalTokens int32
+	// CachedContentTokenCount is the sum of the cached token counts of all
+	// items. It's only available in the Gemini API.
+	CachedContentTokenCount int32
+	// Items holds the response for each content set, in input order.
+	Items []*CountTokensResponse
+}
+
+// CountTokensBatch counts the tokens of several independent content sets, for
+// example variants of the same prompt. The sets are counted concurrently, and
+// the per-item results are returned in input order along with their totals.
+// If any call fails, the remaining calls are canceled and the first error is
+// returned.
+func (m Models) CountTokensBatch(ctx context.Context, model string, contentSets [][]*Content, config *CountTokensBatchConfig) (*CountTokensBatchResponse, error) {
+	cfg := CountTokensBatchConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.Concurrency < 0 {
+		return nil, fmt.Errorf("CountTokensBatch: concurrency must be positive, got %d", cfg.Concurrency)
+	}
+	if cfg.Concurrency == 0 {
+		cfg.Concurrency = defaultCountTokensConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	items := make([]*CountTokensResponse, len(contentSets))
+	sem := make(chan struct{}, cfg.Concurrency)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for i, contents := range contentSets {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := m.CountTokens(ctx, model, contents, cfg.CountTokensConfig)
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("CountTokensBatch: item %d: %w", i, err)
+					cancel()
+				})
+				return
+			}
+			items[i] = resp
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &CountTokensBatchResponse{Items: items}
+	for _, item := range items {
+		result.TotalTokens += item.TotalTokens
+		result.CachedContentTokenCount += item.CachedContentTokenCount
+	}
+	return result, nil
+}
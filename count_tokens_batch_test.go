@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCountTokensBatch(t *testing.T) {
+	ctx := context.Background()
+	// The server counts one token per part.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Contents []*Content `json:"contents"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if body.Contents[0].Parts[0].Text == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"code": 400, "message": "bad request"}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"totalTokens": %d}`, len(body.Contents[0].Parts))
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+
+	sets := [][]*Content{
+		{NewContentFromParts([]*Part{{Text: "a"}}, RoleUser)},
+		{NewContentFromParts([]*Part{{Text: "a"}, {Text: "b"}, {Text: "c"}}, RoleUser)},
+		{NewContentFromParts([]*Part{{Text: "a"}, {Text: "b"}}, RoleUser)},
+	}
+	got, err := m.CountTokensBatch(ctx, "gemini-2.5-flash", sets, &CountTokensBatchConfig{Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TotalTokens != 6 {
+		t.Errorf("TotalTokens = %d, want 6", got.TotalTokens)
+	}
+	for i, want := range []int32{1, 3, 2} {
+		if got.Items[i].TotalTokens != want {
+			t.Errorf("Items[%d].TotalTokens = %d, want %d", i, got.Items[i].TotalTokens, want)
+		}
+	}
+
+	sets = append(sets, Text("fail"))
+	if _, err := m.CountTokensBatch(ctx, "gemini-2.5-flash", sets, nil); err == nil {
+		t.Errorf("CountTokensBatch() got no error, want error")
+	}
+}
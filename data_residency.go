@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// DataResidencyError is returned when a request would be sent to, or refer
+// to a resource in, a location that isn't in [ClientConfig.AllowedLocations].
+type DataResidencyError struct {
+	// Location is the location the request would be sent to or refers to. It's
+	// "global" for global endpoints, such as the Gemini API.
+	Location string
+	// Resource is the host or resource name that is in Location.
+	Resource string
+	// Allowed are the allowed locations.
+	Allowed []string
+}
+
+func (e *DataResidencyError) Error() string {
+	return fmt.Sprintf("data residency: %s is in location %q, allowed locations are %s", e.Resource, e.Location, strings.Join(e.Allowed, ", "))
+}
+
+// checkDataResidency returns a [*DataResidencyError] if u is the host of a
+// location that isn't allowed, or if u or body contain the name of a resource
+// in such a location. Hosts other than the Google API hosts, such as proxies,
+// aren't checked, but the resource names sent to them are.
+func checkDataResidency(allowed []string, u *url.URL, body map[string]any) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	if loc := hostLocation(u.Hostname()); loc != "" {
+		if err := checkLocation(allowed, loc, u.Hostname()); err != nil {
+			return err
+		}
+	}
+	if err := checkResourceLocations(allowed, u.Path); err != nil {
+		return err
+	}
+	return checkBodyLocations(allowed, body)
+}
+
+// hostLocation returns the location served by the Google API host, "global" for
+// global hosts, or "" for other hosts.
+func hostLocation(host string) string {
+	switch {
+	case host == "generativelanguage.googleapis.com", host == "aiplatform.googleapis.com":
+		return "global"
+	case strings.HasSuffix(host, "-aiplatform.googleapis.com"):
+		return strings.TrimSuffix(host, "-aiplatform.googleapis.com")
+	case strings.HasPrefix(host, "aiplatform.") && strings.HasSuffix(host, ".rep.googleapis.com"):
+		return strings.TrimSuffix(strings.TrimPrefix(host, "aiplatform."), ".rep.googleapis.com")
+	}
+	return ""
+}
+
+// checkResourceLocations checks the locations of the resource names in s, such
+// as projects/p/locations/l/cachedContents/c.
+func checkResourceLocations(allowed []string, s string) error {
+	segments := strings.Split(s, "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == "locations" && segments[i+1] != "" {
+			if err := checkLocation(allowed, segments[i+1], s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkBodyLocations checks the locations of the resource names in the string
+// values of v, such as a cached content or a tuned model endpoint.
+func checkBodyLocations(allowed []string, v any) error {
+	switch v := v.(type) {
+	case string:
+		if strings.HasPrefix(v, "projects/") {
+			return checkResourceLocations(allowed, v)
+		}
+	case map[string]any:
+		for _, e := range v {
+			if err := checkBodyLocations(allowed, e); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for _, e := range v {
+			if err := checkBodyLocations(allowed, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkLocation(allowed []string, location, resource string) error {
+	if slices.ContainsFunc(allowed, func(a string) bool { return strings.EqualFold(a, location) }) {
+		return nil
+	}
+	return &DataResidencyError{Location: location, Resource: resource, Allowed: allowed}
+}
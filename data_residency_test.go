@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckDataResidency(t *testing.T) {
+	allowed := []string{"europe-west4", "eu"}
+	tests := []struct {
+		name         string
+		url          string
+		body         map[string]any
+		wantLocation string
+	}{
+		{"RegionalHost", "https://europe-west4-aiplatform.googleapis.com/v1beta1/projects/p/locations/europe-west4/publishers/google/models/m:generateContent", nil, ""},
+		{"MultiRegionalHost", "https://aiplatform.eu.rep.googleapis.com/v1beta1/projects/p/locations/eu/publishers/google/models/m:generateContent", nil, ""},
+		{"UppercaseLocation", "https://europe-west4-aiplatform.googleapis.com/v1beta1/projects/p/locations/EUROPE-WEST4/models/m", nil, ""},
+		{"Proxy", "https://proxy.example.com/v1beta1/projects/p/locations/europe-west4/models/m", nil, ""},
+		{"OtherRegionHost", "https://us-central1-aiplatform.googleapis.com/v1beta1/projects/p/locations/europe-west4/models/m", nil, "us-central1"},
+		{"GlobalVertexHost", "https://aiplatform.googleapis.com/v1beta1/publishers/google/models/m:generateContent", nil, "global"},
+		{"GeminiAPIHost", "https://generativelanguage.googleapis.com/v1beta/models/m:generateContent", nil, "global"},
+		{"ResourceInPath", "https://proxy.example.com/v1beta1/projects/p/locations/us-east1/endpoints/1:generateContent", nil, "us-east1"},
+		{"ResourceInBody", "https://europe-west4-aiplatform.googleapis.com/v1beta1/projects/p/locations/europe-west4/models/m", map[string]any{"cachedContent": "projects/p/locations/us-east1/cachedContents/c"}, "us-east1"},
+		{"NestedResourceInBody", "https://proxy.example.com/v1beta1/models/m", map[string]any{"contents": []any{map[string]any{"uri": "projects/p/locations/asia-east1/x"}}}, "asia-east1"},
+		{"OtherStringsInBody", "https://proxy.example.com/v1beta1/models/m", map[string]any{"text": "Visit us at locations/us-east1."}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = checkDataResidency(allowed, u, tt.body)
+			var rerr *DataResidencyError
+			switch {
+			case tt.wantLocation == "" && err != nil:
+				t.Errorf("checkDataResidency() = %v, want nil", err)
+			case tt.wantLocation != "" && !errors.As(err, &rerr):
+				t.Errorf("checkDataResidency() = %v, want *DataResidencyError", err)
+			case tt.wantLocation != "" && rerr.Location != tt.wantLocation:
+				t.Errorf("DataResidencyError.Location = %q, want %q", rerr.Location, tt.wantLocation)
+			}
+		})
+	}
+	u, _ := url.Parse("https://aiplatform.googleapis.com/v1beta1/models/m")
+	if err := checkDataResidency(nil, u, nil); err != nil {
+		t.Errorf("checkDataResidency() without allowed locations = %v, want nil", err)
+	}
+}
+
+func TestNewClientAllowedLocations(t *testing.T) {
+	ctx := context.Background()
+	noEnv := func() map[string]string { return map[string]string{} }
+	tests := []struct {
+		name         string
+		config       *ClientConfig
+		wantLocation string
+	}{
+		{"VertexAllowed", &ClientConfig{Backend: BackendVertexAI, Project: "p", Location: "europe-west4", HTTPClient: &http.Client{}}, ""},
+		{"VertexOtherLocation", &ClientConfig{Backend: BackendVertexAI, Project: "p", Location: "us-central1", HTTPClient: &http.Client{}}, "us-central1"},
+		{"VertexGlobal", &ClientConfig{Backend: BackendVertexAI, Project: "p", Location: "global", HTTPClient: &http.Client{}}, "global"},
+		{"VertexExpressMode", &ClientConfig{Backend: BackendVertexAI, APIKey: "key"}, "global"},
+		{"GeminiAPI", &ClientConfig{Backend: BackendGeminiAPI, APIKey: "key"}, "global"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.AllowedLocations = []string{"europe-west4"}
+			tt.config.envVarProvider = noEnv
+			_, err := NewClient(ctx, tt.config)
+			var rerr *DataResidencyError
+			switch {
+			case tt.wantLocation == "" && err != nil:
+				t.Errorf("NewClient() = %v, want nil", err)
+			case tt.wantLocation != "" && !errors.As(err, &rerr):
+				t.Errorf("NewClient() = %v, want *DataResidencyError", err)
+			case tt.wantLocation != "" && rerr.Location != tt.wantLocation:
+				t.Errorf("DataResidencyError.Location = %q, want %q", rerr.Location, tt.wantLocation)
+			}
+		})
+	}
+}
+
+func TestModelsGenerateContentAllowedLocations(t *testing.T) {
+	ctx := context.Background()
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "Hi"}]}}]}`)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:          BackendVertexAI,
+		Project:          "my-project",
+		Location:         "europe-west4",
+		HTTPOptions:      HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"},
+		HTTPClient:       ts.Client(),
+		AllowedLocations: []string{"europe-west4"},
+	}}}
+
+	if _, err := m.GenerateContent(ctx, "gemini-2.5-flash", Text("Hi"), nil); err != nil {
+		t.Fatal(err)
+	}
+	var rerr *DataResidencyError
+	if _, err := m.GenerateContent(ctx, "projects/my-project/locations/us-central1/endpoints/123", Text("Hi"), nil); !errors.As(err, &rerr) {
+		t.Errorf("GenerateContent() with an endpoint in another location = %v, want *DataResidencyError", err)
+	}
+	if _, err := m.GenerateContent(ctx, "gemini-2.5-flash", Text("Hi"), &GenerateContentConfig{CachedContent: "projects/my-project/locations/us-central1/cachedContents/1"}); !errors.As(err, &rerr) {
+		t.Errorf("GenerateContent() with a cache in another location = %v, want *DataResidencyError", err)
+	}
+	if _, err := m.GenerateContent(WithRequestOptions(ctx, &RequestOptions{Location: "us-central1"}), "gemini-2.5-flash", Text("Hi"), nil); !errors.As(err, &rerr) {
+		t.Errorf("GenerateContent() overriding the location = %v, want *DataResidencyError", err)
+	}
+	want := []string{"/v1beta1/projects/my-project/locations/europe-west4/publishers/google/models/gemini-2.5-flash:generateContent"}
+	if diff := cmp.Diff(want, paths); diff != "" {
+		t.Errorf("requests sent mismatch (-want +got):\n%s", diff)
+	}
+}
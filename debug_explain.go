@@ -0,0 +1,315 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// FieldTransform is how the SDK transforms a field of a request for the API.
+type FieldTransform string
+
+const (
+	// FieldUnchanged means that the field is sent as it is, at the same path.
+	FieldUnchanged FieldTransform = "UNCHANGED"
+	// FieldRenamed means that the field is sent with the same value at another
+	// path, for example config.temperature as generationConfig.temperature.
+	FieldRenamed FieldTransform = "RENAMED"
+	// FieldTransformed means that the value of the field is changed, for
+	// example a model name expanded to a resource name.
+	FieldTransformed FieldTransform = "TRANSFORMED"
+	// FieldDropped means that the field isn't sent to the API.
+	FieldDropped FieldTransform = "DROPPED"
+	// FieldAdded means that the SDK adds the field to the request.
+	FieldAdded FieldTransform = "ADDED"
+)
+
+// FieldExplanation explains how a field of a request is sent to the API.
+type FieldExplanation struct {
+	// Path is the JSON path of the field in the request given to the SDK, such
+	// as config.temperature. It's empty for added fields.
+	Path string
+	// APIPath is the JSON path of the field in the request sent to the API,
+	// such as generationConfig.temperature. Fields of the URL are prefixed with
+	// _url and fields of the query with _query. It's empty for dropped fields.
+	APIPath string
+	// Transform is how the field is transformed.
+	Transform FieldTransform
+	// Value is the value given to the SDK.
+	Value any
+	// APIValue is the value sent to the API.
+	APIValue any
+}
+
+// RequestExplanation explains how the SDK sends a request to the active
+// backend, see [Models.DebugExplain].
+type RequestExplanation struct {
+	// Backend is the backend the request is sent to.
+	Backend Backend
+	// Method is the HTTP method of the request.
+	Method string
+	// URL is the URL of the request.
+	URL string
+	// Body is the body of the request.
+	Body map[string]any
+	// Fields explain the transformation of each leaf field of the request, in
+	// the order of their paths, followed by the added fields.
+	Fields []*FieldExplanation
+}
+
+// String formats the explanation as a table, one field per line.
+func (e *RequestExplanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s (%s)\n", e.Method, e.URL, e.Backend)
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "FIELD\tTRANSFORM\tAPI FIELD\tAPI VALUE")
+	for _, f := range e.Fields {
+		apiValue := ""
+		if f.Transform == FieldTransformed || f.Transform == FieldAdded {
+			apiValue = explainValue(f.APIValue)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.Path, f.Transform, f.APIPath, apiValue)
+	}
+	w.Flush()
+	return b.String()
+}
+
+func explainValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	const maxLen = 60
+	if len(b) > maxLen {
+		return string(b[:maxLen]) + "..."
+	}
+	return string(b)
+}
+
+// DebugExplain explains how a GenerateContent request is transformed for the
+// active backend: which fields are renamed, transformed, such as model names
+// expanded to resource names, dropped or added, along with the URL and the
+// body of the request. It doesn't send the request. Use it to diagnose
+// differences between the Gemini API and Vertex AI.
+//
+// HTTPOptions, which are applied to the URL and the headers, aren't listed.
+func (m Models) DebugExplain(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig) (*RequestExplanation, error) {
+	config.setDefaults()
+	config = config.withRawJSONSchema()
+	parameterMap := make(map[string]any)
+	kwargs := map[string]any{"model": model, "contents": contents, "config": config}
+	if err := deepMarshal(kwargs, &parameterMap); err != nil {
+		return nil, err
+	}
+	// The converters may modify the parameters.
+	var params map[string]any
+	if err := deepCopy(parameterMap, &params); err != nil {
+		return nil, err
+	}
+
+	toConverter := generateContentParametersToMldev
+	if m.apiClient.clientConfig.Backend == BackendVertexAI {
+		toConverter = generateContentParametersToVertex
+	}
+	body, err := toConverter(m.apiClient, parameterMap, nil, parameterMap)
+	if err != nil {
+		return nil, fmt.Errorf("DebugExplain: %w", err)
+	}
+	urlParams, _ := body["_url"].(map[string]any)
+	path, err := formatMap("{model}:generateContent", urlParams)
+	if err != nil {
+		return nil, fmt.Errorf("DebugExplain: invalid url params: %#v: %w", urlParams, err)
+	}
+	queryParams, _ := body["_query"].(map[string]any)
+	if queryParams != nil {
+		query, err := createURLQuery(queryParams)
+		if err != nil {
+			return nil, err
+		}
+		path += "?" + query
+	}
+	delete(body, "_url")
+	delete(body, "_query")
+
+	httpOptions := &HTTPOptions{}
+	if config != nil && config.HTTPOptions != nil {
+		httpOptions = config.HTTPOptions
+	}
+	req, _, err := buildRequest(ctx, m.apiClient, path, body, http.MethodPost, httpOptions)
+	if err != nil {
+		return nil, fmt.Errorf("DebugExplain: %w", err)
+	}
+	sent := map[string]any{}
+	if data, err := io.ReadAll(req.Body); err != nil {
+		return nil, err
+	} else if len(data) > 0 {
+		if err := json.Unmarshal(data, &sent); err != nil {
+			return nil, err
+		}
+	}
+
+	in := map[string]any{}
+	flattenJSON("", params, in)
+	delete(in, "config.httpOptions")
+	for p := range in {
+		if strings.HasPrefix(p, "config.httpOptions.") {
+			delete(in, p)
+		}
+	}
+	out := map[string]any{}
+	flattenJSON("", sent, out)
+	var extras map[string]any
+	if err := deepCopy(map[string]any{"_url": urlParams, "_query": queryParams}, &extras); err != nil {
+		return nil, err
+	}
+	flattenJSON("", extras, out)
+
+	return &RequestExplanation{
+		Backend: m.apiClient.clientConfig.Backend,
+		Method:  req.Method,
+		URL:     req.URL.String(),
+		Body:    sent,
+		Fields:  matchFields(in, out),
+	}, nil
+}
+
+// flattenJSON adds the leaves of v to leaves, by JSON path. Empty objects and
+// arrays are leaves.
+func flattenJSON(path string, v any, leaves map[string]any) {
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) == 0 && path != "" {
+			leaves[path] = v
+		}
+		for k, e := range v {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			flattenJSON(p, e, leaves)
+		}
+	case []any:
+		if len(v) == 0 {
+			leaves[path] = v
+		}
+		for i, e := range v {
+			flattenJSON(path+"["+strconv.Itoa(i)+"]", e, leaves)
+		}
+	case nil:
+	default:
+		leaves[path] = v
+	}
+}
+
+// lastSegment returns the last member name of a JSON path.
+func lastSegment(path string) string {
+	path = strings.TrimRight(path, "]0123456789[")
+	return path[strings.LastIndexByte(path, '.')+1:]
+}
+
+// commonSuffix returns the number of trailing path segments that a and b have
+// in common.
+func commonSuffix(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	n := 0
+	for n < len(as) && n < len(bs) && as[len(as)-1-n] == bs[len(bs)-1-n] {
+		n++
+	}
+	return n
+}
+
+// matchFields matches the leaves of the SDK request with the leaves of the
+// API request. A leaf is unchanged if it's at the same path with the same
+// value, renamed if the same value is elsewhere under the same member name,
+// and transformed if the member name is found with another value.
+func matchFields(in, out map[string]any) []*FieldExplanation {
+	var inPaths, outPaths []string
+	for p := range in {
+		inPaths = append(inPaths, p)
+	}
+	for p := range out {
+		outPaths = append(outPaths, p)
+	}
+	sort.Strings(inPaths)
+	sort.Strings(outPaths)
+	encoded := func(v any) string {
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+	used := map[string]bool{}
+	// best returns the unused output path with the longest common suffix with
+	// p among those accepted by ok.
+	best := func(p string, ok func(string) bool) string {
+		match, matchLen := "", -1
+		for _, o := range outPaths {
+			if used[o] || !ok(o) {
+				continue
+			}
+			if n := commonSuffix(p, o); n > matchLen {
+				match, matchLen = o, n
+			}
+		}
+		return match
+	}
+
+	// Exact matches are taken first so that they aren't taken as the renamed
+	// or transformed match of another field.
+	fields := make([]*FieldExplanation, len(inPaths))
+	for i, p := range inPaths {
+		fields[i] = &FieldExplanation{Path: p, Value: in[p], Transform: FieldDropped}
+		if o, ok := out[p]; ok && encoded(o) == encoded(in[p]) {
+			fields[i].APIPath, fields[i].Transform = p, FieldUnchanged
+			used[p] = true
+		}
+	}
+	for _, sameValue := range []bool{true, false} {
+		for _, f := range fields {
+			if f.APIPath != "" {
+				continue
+			}
+			name, v := lastSegment(f.Path), encoded(f.Value)
+			o := best(f.Path, func(o string) bool {
+				return lastSegment(o) == name && (!sameValue || encoded(out[o]) == v)
+			})
+			if o == "" {
+				continue
+			}
+			f.APIPath, f.Transform = o, FieldTransformed
+			if sameValue {
+				f.Transform = FieldRenamed
+			}
+			used[o] = true
+		}
+	}
+	for _, f := range fields {
+		if f.APIPath != "" {
+			f.APIValue = out[f.APIPath]
+		}
+	}
+	for _, o := range outPaths {
+		if !used[o] {
+			fields = append(fields, &FieldExplanation{APIPath: o, Transform: FieldAdded, APIValue: out[o]})
+		}
+	}
+	return fields
+}
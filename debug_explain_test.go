@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDebugExplain(t *testing.T) {
+	config := &GenerateContentConfig{
+		Temperature:       Ptr[float32](0.5),
+		SystemInstruction: NewContentFromText("be brief", RoleUser),
+		CachedContent:     "cachedContents/docs",
+		HTTPOptions:       &HTTPOptions{ExtraBody: map[string]any{"extra": "x"}},
+	}
+	tests := []struct {
+		backend Backend
+		url     string
+		want    map[string]*FieldExplanation
+	}{
+		{
+			backend: BackendGeminiAPI,
+			url:     "https://example.com/v1beta/models/gemini-2.5-flash:generateContent",
+			want: map[string]*FieldExplanation{
+				"model":                     {Path: "model", APIPath: "_url.model", Transform: FieldTransformed, Value: "gemini-2.5-flash", APIValue: "models/gemini-2.5-flash"},
+				"contents[0].parts[0].text": {Path: "contents[0].parts[0].text", APIPath: "contents[0].parts[0].text", Transform: FieldUnchanged, Value: "hi", APIValue: "hi"},
+				"config.temperature":        {Path: "config.temperature", APIPath: "generationConfig.temperature", Transform: FieldRenamed, Value: 0.5, APIValue: 0.5},
+				"config.cachedContent":      {Path: "config.cachedContent", APIPath: "cachedContent", Transform: FieldRenamed, Value: "cachedContents/docs", APIValue: "cachedContents/docs"},
+				"extra":                     {APIPath: "extra", Transform: FieldAdded, APIValue: "x"},
+			},
+		},
+		{
+			backend: BackendVertexAI,
+			url:     "https://example.com/v1beta1/projects/p/locations/us-central1/publishers/google/models/gemini-2.5-flash:generateContent",
+			want: map[string]*FieldExplanation{
+				"model":                {Path: "model", APIPath: "_url.model", Transform: FieldTransformed, Value: "gemini-2.5-flash", APIValue: "publishers/google/models/gemini-2.5-flash"},
+				"config.temperature":   {Path: "config.temperature", APIPath: "generationConfig.temperature", Transform: FieldRenamed, Value: 0.5, APIValue: 0.5},
+				"config.cachedContent": {Path: "config.cachedContent", APIPath: "cachedContent", Transform: FieldTransformed, Value: "cachedContents/docs", APIValue: "projects/p/locations/us-central1/cachedContents/docs"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.backend.String(), func(t *testing.T) {
+			m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+				Backend:     tt.backend,
+				Project:     "p",
+				Location:    "us-central1",
+				HTTPOptions: HTTPOptions{BaseURL: "https://example.com/", APIVersion: map[Backend]string{BackendGeminiAPI: "v1beta", BackendVertexAI: "v1beta1"}[tt.backend]},
+				HTTPClient:  &http.Client{},
+			}}}
+			e, err := m.DebugExplain(context.Background(), "gemini-2.5-flash", Text("hi"), config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.Method != http.MethodPost || e.URL != tt.url {
+				t.Errorf("got %s %s, want POST %s", e.Method, e.URL, tt.url)
+			}
+			fields := map[string]*FieldExplanation{}
+			for _, f := range e.Fields {
+				if strings.HasPrefix(f.Path, "config.httpOptions") {
+					t.Errorf("HTTP options field %s was explained", f.Path)
+				}
+				key := f.Path
+				if key == "" {
+					key = f.APIPath
+				}
+				fields[key] = f
+			}
+			for key, want := range tt.want {
+				if diff := cmp.Diff(want, fields[key]); diff != "" {
+					t.Errorf("field %s mismatch (-want +got):\n%s", key, diff)
+				}
+			}
+			if !strings.Contains(e.String(), "generationConfig.temperature") {
+				t.Errorf("String() = %q, want the fields", e.String())
+			}
+		})
+	}
+}
+
+func TestMatchFieldsDropped(t *testing.T) {
+	in := map[string]any{"config.a": "x", "config.b": true, "contents[0].role": "user"}
+	out := map[string]any{"contents[0].role": "user", "generationConfig.c": 1.0}
+	want := []*FieldExplanation{
+		{Path: "config.a", Transform: FieldDropped, Value: "x"},
+		{Path: "config.b", Transform: FieldDropped, Value: true},
+		{Path: "contents[0].role", APIPath: "contents[0].role", Transform: FieldUnchanged, Value: "user", APIValue: "user"},
+		{APIPath: "generationConfig.c", Transform: FieldAdded, APIValue: 1.0},
+	}
+	if diff := cmp.Diff(want, matchFields(in, out)); diff != "" {
+		t.Errorf("matchFields() mismatch (-want +got):\n%s", diff)
+	}
+}
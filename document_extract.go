@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ExtractDocumentConfig configures [Models.ExtractDocument].
+type ExtractDocumentConfig struct {
+	// Optional. Additional instructions, such as which parts of the document
+	// matter.
+	Instructions string
+	// Optional. Configuration used for the GenerateContent call. Its response
+	// schema is set by ExtractDocument.
+	GenerateContentConfig *GenerateContentConfig
+}
+
+// DocumentTable is a table of an [ExtractedDocument].
+type DocumentTable struct {
+	// Caption is the caption or title of the table, if any.
+	Caption string `json:"caption,omitempty" description:"Caption or title of the table, if any."`
+	// Headers are the column headers.
+	Headers []string `json:"headers" description:"Column headers."`
+	// Rows are the cells of the table, row by row.
+	Rows [][]string `json:"rows" description:"Cells of the table, row by row, in the order of the headers."`
+}
+
+// DocumentSection is a section of an [ExtractedDocument].
+type DocumentSection struct {
+	// Heading is the heading of the section.
+	Heading string `json:"heading" description:"Heading of the section, empty for text before the first heading."`
+	// Level is the nesting level of the heading, 1 for top-level sections.
+	Level int32 `json:"level" description:"Nesting level of the heading, 1 for top-level sections."`
+	// Page is the 1-based page the section starts on.
+	Page int32 `json:"page" description:"Page the section starts on, starting from 1."`
+	// Text is the text of the section, in reading order, without its tables.
+	Text string `json:"text" description:"Text of the section in reading order, excluding tables, headers and footers."`
+	// Tables are the tables of the section.
+	Tables []*DocumentTable `json:"tables,omitempty" description:"Tables of the section."`
+}
+
+// ExtractedDocument is the outcome of [Models.ExtractDocument].
+type ExtractedDocument struct {
+	// Title is the title of the document.
+	Title string `json:"title" description:"Title of the document."`
+	// Sections are the sections of the document, in order.
+	Sections []*DocumentSection `json:"sections" description:"Sections of the document, in order."`
+	// Response is the full model response.
+	Response *GenerateContentResponse `json:"-"`
+}
+
+// Tables returns the tables of all sections, in order.
+func (d *ExtractedDocument) Tables() []*DocumentTable {
+	var tables []*DocumentTable
+	for _, s := range d.Sections {
+		tables = append(tables, s.Tables...)
+	}
+	return tables
+}
+
+const extractDocumentPrompt = "Extract the content of this document. Recognize the text of scanned pages. " +
+	"Split it into sections following its headings, keep the text in reading order, " +
+	"and extract tables cell by cell instead of including them in the text."
+
+// ExtractDocument extracts the structure of a document, such as a PDF, into
+// sections and tables, recognizing the text of scanned pages. document is
+// typically built with [NewPartFromPath] or, for uploaded files,
+// [NewPartFromURI].
+//
+//	pdf, err := genai.NewPartFromPath("report.pdf", nil)
+//	if err != nil {
+//		return err
+//	}
+//	doc, err := client.Models.ExtractDocument(ctx, "gemini-2.5-flash", pdf, nil)
+func (m Models) ExtractDocument(ctx context.Context, model string, document *Part, config *ExtractDocumentConfig) (*ExtractedDocument, error) {
+	if document == nil {
+		return nil, fmt.Errorf("ExtractDocument: document is required")
+	}
+	cfg := ExtractDocumentConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	prompt := []string{extractDocumentPrompt}
+	if cfg.Instructions != "" {
+		prompt = append(prompt, cfg.Instructions)
+	}
+	contents := []*Content{NewContentFromParts([]*Part{document, NewPartFromText(strings.Join(prompt, " "))}, RoleUser)}
+
+	doc, resp, err := GenerateContentAs[ExtractedDocument](ctx, &m, model, contents, cfg.GenerateContentConfig)
+	if err != nil {
+		return nil, fmt.Errorf("ExtractDocument: %w", err)
+	}
+	doc.Response = resp
+	return &doc, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestExtractDocument(t *testing.T) {
+	ctx := context.Background()
+	var gotSchema map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		gotSchema, _ = body["generationConfig"].(map[string]any)["responseSchema"].(map[string]any)
+		doc := `{"title": "Q3 Report", "sections": [
+			{"heading": "Summary", "level": 1, "page": 1, "text": "Revenue grew."},
+			{"heading": "Revenue", "level": 2, "page": 2, "text": "By region:", "tables": [
+				{"caption": "Revenue by region", "headers": ["Region", "Revenue"], "rows": [["EMEA", "10"], ["APAC", "12"]]}
+			]}
+		]}`
+		answer, _ := json.Marshal(doc)
+		fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": %s}]}}]}`, answer)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+
+	got, err := m.ExtractDocument(ctx, "gemini-2.5-flash", NewPartFromBytes([]byte("%PDF-1.7"), "application/pdf"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := &DocumentTable{Caption: "Revenue by region", Headers: []string{"Region", "Revenue"}, Rows: [][]string{{"EMEA", "10"}, {"APAC", "12"}}}
+	want := &ExtractedDocument{Title: "Q3 Report", Sections: []*DocumentSection{
+		{Heading: "Summary", Level: 1, Page: 1, Text: "Revenue grew."},
+		{Heading: "Revenue", Level: 2, Page: 2, Text: "By region:", Tables: []*DocumentTable{table}},
+	}}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(ExtractedDocument{}, "Response")); diff != "" {
+		t.Errorf("ExtractDocument() mismatch (-want +got):\n%s", diff)
+	}
+	if got.Response == nil {
+		t.Errorf("Response is nil")
+	}
+	if diff := cmp.Diff([]*DocumentTable{table}, got.Tables()); diff != "" {
+		t.Errorf("Tables() mismatch (-want +got):\n%s", diff)
+	}
+	if _, ok := gotSchema["properties"].(map[string]any)["sections"]; !ok {
+		t.Errorf("response schema = %v, want the document schema", gotSchema)
+	}
+
+	if _, err := m.ExtractDocument(ctx, "gemini-2.5-flash", nil, nil); err == nil {
+		t.Errorf("ExtractDocument() without a document succeeded, want error")
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxDownloadRedirects is the number of redirects a download follows, as with
+// the default policy of [http.Client].
+const maxDownloadRedirects = 10
+
+// credentialHeaders are the headers that authenticate a request.
+var credentialHeaders = []string{"Authorization", "X-Goog-Api-Key"}
+
+// RejectCrossDomainRedirects can be set as [RequestOptions.CheckRedirect] to only
+// follow redirects to the host of the original request, for example in
+// environments where egress is restricted.
+func RejectCrossDomainRedirects(req *http.Request, via []*http.Request) error {
+	if len(via) > 0 && !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+		return fmt.Errorf("redirect from %s to %s rejected: cross-domain redirects aren't allowed", via[0].URL.Host, req.URL.Host)
+	}
+	return nil
+}
+
+// downloadRedirectPolicy returns the redirect policy of downloads. The
+// redirect is first checked by optionsCheck, or else by clientCheck, the
+// policy of the HTTP client, or else is followed up to maxDownloadRedirects
+// times.
+//
+// Credentials are kept when the redirect stays on the original host or on
+// another Google APIs host over HTTPS, which is where files are served from.
+// They are removed otherwise, including the API key that [http.Client] would
+// forward to any host.
+func downloadRedirectPolicy(clientCheck, optionsCheck func(*http.Request, []*http.Request) error) func(*http.Request, []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		switch {
+		case optionsCheck != nil:
+			if err := optionsCheck(req, via); err != nil {
+				return err
+			}
+		case clientCheck != nil:
+			if err := clientCheck(req, via); err != nil {
+				return err
+			}
+		case len(via) >= maxDownloadRedirects:
+			return errors.New("stopped after 10 redirects")
+		}
+		first := via[0]
+		for _, h := range credentialHeaders {
+			if keepCredentials(first, req) {
+				if v := first.Header.Values(h); len(v) > 0 {
+					req.Header[h] = v
+				}
+			} else {
+				req.Header.Del(h)
+			}
+		}
+		return nil
+	}
+}
+
+// keepCredentials reports whether the credentials of the original request can
+// be sent along with req.
+func keepCredentials(original, req *http.Request) bool {
+	if strings.EqualFold(req.URL.Host, original.URL.Host) {
+		return req.URL.Scheme == original.URL.Scheme || req.URL.Scheme == "https"
+	}
+	return req.URL.Scheme == "https" && isGoogleAPIsHost(req.URL.Hostname()) && isGoogleAPIsHost(original.URL.Hostname())
+}
+
+func isGoogleAPIsHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "googleapis.com" || strings.HasSuffix(host, ".googleapis.com")
+}
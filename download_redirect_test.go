@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDownloadRedirects(t *testing.T) {
+	var gotKey string
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("x-goog-api-key")
+		w.Write([]byte("file content"))
+	}))
+	defer storage.Close()
+	var api *httptest.Server
+	api = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1beta/files/local:download":
+			http.Redirect(w, r, api.URL+"/blob", http.StatusFound)
+		case "/v1beta/files/remote:download":
+			http.Redirect(w, r, storage.URL+"/blob", http.StatusFound)
+		case "/v1beta/files/missing:download":
+			http.Error(w, `{"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}}`, http.StatusNotFound)
+		case "/blob":
+			gotKey = r.Header.Get("x-goog-api-key")
+			w.Write([]byte("file content"))
+		}
+	}))
+	defer api.Close()
+	files := Files{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "test-api-key",
+		HTTPOptions: HTTPOptions{BaseURL: api.URL, APIVersion: "v1beta"},
+		HTTPClient:  api.Client(),
+	}}}
+
+	tests := []struct {
+		name    string
+		file    string
+		options *RequestOptions
+		wantKey string
+		wantErr bool
+	}{
+		{name: "SameHost", file: "files/local", wantKey: "test-api-key"},
+		{name: "CrossDomain", file: "files/remote", wantKey: ""},
+		{
+			name:    "CrossDomainRejected",
+			file:    "files/remote",
+			options: &RequestOptions{CheckRedirect: RejectCrossDomainRedirects},
+			wantErr: true,
+		},
+		{
+			name:    "SameHostAllowed",
+			file:    "files/local",
+			options: &RequestOptions{CheckRedirect: RejectCrossDomainRedirects},
+			wantKey: "test-api-key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotKey = "unset"
+			got, err := files.Download(WithRequestOptions(context.Background(), tt.options), &File{DownloadURI: tt.file}, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Download() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Download() failed: %v", err)
+			}
+			if string(got) != "file content" {
+				t.Errorf("Download() = %q, want %q", got, "file content")
+			}
+			if gotKey != tt.wantKey {
+				t.Errorf("redirected request has API key %q, want %q", gotKey, tt.wantKey)
+			}
+		})
+	}
+
+	t.Run("ClientCheckRedirect", func(t *testing.T) {
+		client := *api.Client()
+		client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+		files := Files{apiClient: &apiClient{clientConfig: &ClientConfig{
+			Backend:     BackendGeminiAPI,
+			HTTPOptions: HTTPOptions{BaseURL: api.URL, APIVersion: "v1beta"},
+			HTTPClient:  &client,
+		}}}
+		if _, err := files.Download(context.Background(), &File{DownloadURI: "files/local"}, nil); err == nil {
+			t.Errorf("Download() succeeded with the redirect response, want error")
+		}
+	})
+
+	t.Run("ErrorStatus", func(t *testing.T) {
+		_, err := files.Download(context.Background(), &File{DownloadURI: "files/missing"}, nil)
+		var apiErr APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+			t.Errorf("Download() error = %v, want a 404 APIError", err)
+		}
+	})
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+)
+
+const (
+	defaultEmbedAllMaxRetries   = 3
+	defaultEmbedAllInitialDelay = time.Second
+)
+
+// EmbedAllConfig configures [Models.EmbedAll].
+type EmbedAllConfig struct {
+	// Optional. Configuration of the embedding requests.
+	EmbedConfig *EmbedContentConfig
+	// Optional. Maximum number of contents embedded by a request. Defaults as
+	// [EmbedAutoBatchConfig.MaxContentsPerRequest].
+	BatchSize int
+	// Optional. Maximum number of tokens embedded by a request. Defaults as
+	// [EmbedAutoBatchConfig.MaxTokensPerRequest].
+	MaxTokensPerRequest int
+	// Optional. Number of requests in flight at the same time. Defaults to 4.
+	Workers int
+	// Optional. Maximum number of requests started per minute, across all
+	// workers. Defaults to no limit.
+	RequestsPerMinute int
+	// Optional. Maximum number of retries of a request that failed with a
+	// transient error, such as a 429 or 503 status. Defaults to 3. Set it to a
+	// negative value to disable retries.
+	MaxRetries int
+	// Optional. Delay before the first retry of a request, doubled for each
+	// following retry. Defaults to 1 second.
+	InitialRetryDelay time.Duration
+}
+
+// EmbedAllResult is the embedding of a content by [Models.EmbedAll].
+type EmbedAllResult struct {
+	// Index is the index of the content.
+	Index int
+	// Embedding is the embedding of the content, or nil on error.
+	Embedding *ContentEmbedding
+	// Err is the error of the request that embedded the content.
+	Err error
+}
+
+// EmbedAll embeds a large corpus of contents with model, split into batches
+// that are embedded by a pool of workers, with rate limiting and retries of
+// transient errors as configured by config. The results are yielded as the
+// batches complete, so not in input order, and each result holds the index of
+// its content. A batch that still fails after the retries yields a result with
+// the error for each of its contents, and the other batches go on. Stopping the
+// iteration cancels the requests in flight.
+//
+//	for r := range client.Models.EmbedAll(ctx, "gemini-embedding-001", docs, nil) {
+//		if r.Err != nil {
+//			log.Printf("doc %d: %v", r.Index, r.Err)
+//			continue
+//		}
+//		index.Add(ids[r.Index], r.Embedding.Values)
+//	}
+func (m Models) EmbedAll(ctx context.Context, model string, contents []*Content, config *EmbedAllConfig) iter.Seq[*EmbedAllResult] {
+	cfg := EmbedAllConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	return func(yield func(*EmbedAllResult) bool) {
+		limits, err := EmbedAutoBatchConfig{
+			MaxContentsPerRequest: cfg.BatchSize,
+			MaxTokensPerRequest:   cfg.MaxTokensPerRequest,
+			Concurrency:           cfg.Workers,
+		}.withDefaults(m.apiClient.clientConfig.Backend, model)
+		if err == nil && cfg.RequestsPerMinute < 0 {
+			err = fmt.Errorf("the requests per minute must be positive")
+		}
+		if err != nil {
+			for i := range contents {
+				if !yield(&EmbedAllResult{Index: i, Err: fmt.Errorf("EmbedAll: %w", err)}) {
+					return
+				}
+			}
+			return
+		}
+		if cfg.MaxRetries == 0 {
+			cfg.MaxRetries = defaultEmbedAllMaxRetries
+		}
+		if cfg.InitialRetryDelay == 0 {
+			cfg.InitialRetryDelay = defaultEmbedAllInitialDelay
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var pacer *requestPacer
+		if cfg.RequestsPerMinute > 0 {
+			pacer = &requestPacer{interval: time.Minute / time.Duration(cfg.RequestsPerMinute)}
+		}
+		batches := embedBatches(contents, limits.MaxContentsPerRequest, limits.MaxTokensPerRequest)
+		jobs := make(chan [2]int, len(batches))
+		for _, b := range batches {
+			jobs <- b
+		}
+		close(jobs)
+		type batchResult struct {
+			batch [2]int
+			resp  *EmbedContentResponse
+			err   error
+		}
+		results := make(chan batchResult, len(batches))
+		var wg sync.WaitGroup
+		for range min(limits.Concurrency, len(batches)) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for b := range jobs {
+					resp, err := m.embedBatchWithRetry(ctx, model, contents[b[0]:b[1]], cfg.EmbedConfig, pacer, cfg.MaxRetries, cfg.InitialRetryDelay)
+					results <- batchResult{b, resp, err}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for r := range results {
+			for i := r.batch[0]; i < r.batch[1]; i++ {
+				result := &EmbedAllResult{Index: i, Err: r.err}
+				if r.err == nil {
+					result.Embedding = r.resp.Embeddings[i-r.batch[0]]
+				}
+				if !yield(result) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// embedBatchWithRetry embeds contents in one request, retrying transient
+// errors up to maxRetries times.
+func (m Models) embedBatchWithRetry(ctx context.Context, model string, contents []*Content, config *EmbedContentConfig, pacer *requestPacer, maxRetries int, delay time.Duration) (*EmbedContentResponse, error) {
+	for attempt := 0; ; attempt++ {
+		if err := pacer.wait(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := m.EmbedContent(ctx, model, contents, config)
+		if err == nil && len(resp.Embeddings) != len(contents) {
+			return nil, fmt.Errorf("EmbedAll: got %d embeddings for %d contents", len(resp.Embeddings), len(contents))
+		}
+		if err == nil || !isTransientStreamError(err) || attempt >= maxRetries {
+			return resp, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// requestPacer spaces out requests by at least interval. A nil requestPacer
+// doesn't wait.
+type requestPacer struct {
+	interval time.Duration
+	mu       sync.Mutex
+	next     time.Time
+}
+
+// wait waits for the next slot to send a request.
+func (p *requestPacer) wait(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	now := time.Now()
+	slot := now
+	if p.next.After(now) {
+		slot = p.next
+	}
+	p.next = slot.Add(p.interval)
+	p.mu.Unlock()
+	if slot.Equal(now) {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(slot.Sub(now)):
+		return nil
+	}
+}
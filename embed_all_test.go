@@ -0,0 +1,138 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEmbedAll(t *testing.T) {
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Requests []struct {
+				Content *Content `json:"content"`
+			} `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		var texts, embeddings []string
+		for _, item := range req.Requests {
+			text := item.Content.Parts[0].Text
+			texts = append(texts, text)
+			embeddings = append(embeddings, fmt.Sprintf(`{"values": [%d]}`, len(text)))
+		}
+		key := strings.Join(texts, ",")
+		mu.Lock()
+		attempts[key]++
+		attempt := attempts[key]
+		mu.Unlock()
+		switch {
+		case strings.Contains(key, "flaky") && attempt == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error": {"code": 503, "message": "overloaded", "status": "UNAVAILABLE"}}`)
+		case strings.Contains(key, "bad"):
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"code": 400, "message": "bad content", "status": "INVALID_ARGUMENT"}}`)
+		default:
+			fmt.Fprintf(w, `{"embeddings": [%s]}`, strings.Join(embeddings, ","))
+		}
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+	ctx := context.Background()
+
+	var contents []*Content
+	for _, s := range []string{"a", "bb", "flaky", "cccc", "bad", "dddddd"} {
+		contents = append(contents, NewContentFromText(s, RoleUser))
+	}
+
+	t.Run("RetriesAndFailures", func(t *testing.T) {
+		config := &EmbedAllConfig{BatchSize: 2, Workers: 2, InitialRetryDelay: time.Millisecond}
+		var results []*EmbedAllResult
+		for r := range m.EmbedAll(ctx, "gemini-embedding-001", contents, config) {
+			results = append(results, r)
+		}
+		sort.Slice(results, func(i, j int) bool { return results[i].Index < results[j].Index })
+		var got []string
+		for _, r := range results {
+			switch {
+			case r.Err != nil:
+				got = append(got, fmt.Sprintf("%d: error", r.Index))
+			default:
+				got = append(got, fmt.Sprintf("%d: %v", r.Index, r.Embedding.Values[0]))
+			}
+		}
+		want := []string{"0: 1", "1: 2", "2: 5", "3: 4", "4: error", "5: error"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("results mismatch (-want +got):\n%s", diff)
+		}
+		if got := attempts["flaky,cccc"]; got != 2 {
+			t.Errorf("the flaky batch was sent %d times, want 2", got)
+		}
+		if got := attempts["bad,dddddd"]; got != 1 {
+			t.Errorf("the bad batch was sent %d times, want 1", got)
+		}
+	})
+
+	t.Run("RateLimit", func(t *testing.T) {
+		config := &EmbedAllConfig{BatchSize: 1, Workers: 4, RequestsPerMinute: 3000}
+		start := time.Now()
+		n := 0
+		for r := range m.EmbedAll(ctx, "gemini-embedding-001", contents[:4], config) {
+			if r.Err != nil {
+				t.Fatal(r.Err)
+			}
+			n++
+		}
+		if n != 4 {
+			t.Errorf("got %d results, want 4", n)
+		}
+		// 3000 requests per minute are one request every 20ms.
+		if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+			t.Errorf("4 requests took %v, want at least 60ms", elapsed)
+		}
+	})
+
+	t.Run("Break", func(t *testing.T) {
+		for r := range m.EmbedAll(ctx, "gemini-embedding-001", contents[:2], &EmbedAllConfig{BatchSize: 1}) {
+			if r.Err != nil {
+				t.Fatal(r.Err)
+			}
+			break
+		}
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		for r := range m.EmbedAll(ctx, "gemini-embedding-001", contents[:2], &EmbedAllConfig{Workers: -1}) {
+			if r.Err == nil {
+				t.Errorf("result %d has no error, want an invalid config error", r.Index)
+			}
+		}
+	})
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultEmbedMaxContentsGeminiAPI = 100
+	defaultEmbedMaxContentsVertexAI  = 250
+	defaultEmbedMaxTokensVertexAI    = 20000
+	defaultEmbedConcurrency          = 4
+	// embedBytesPerToken is the number of bytes of text per token used to
+	// estimate the token count of contents.
+	embedBytesPerToken = 4
+)
+
+// EmbedAutoBatchConfig configures how [Models.EmbedContentAutoBatch] splits
+// contents into several requests.
+type EmbedAutoBatchConfig struct {
+	// Optional. Maximum number of contents embedded by a request. Defaults to
+	// 100 in the Gemini API, and in Vertex AI to 250, or 1 for the models that
+	// embed one content at a time.
+	MaxContentsPerRequest int
+	// Optional. Maximum number of tokens embedded by a request, estimated at
+	// four bytes of text per token. A content that exceeds it on its own is
+	// embedded in a request of its own. Defaults to no limit in the Gemini API
+	// and to 20000 in Vertex AI.
+	MaxTokensPerRequest int
+	// Optional. Maximum number of requests in flight at the same time. Defaults
+	// to 4.
+	Concurrency int
+}
+
+// EmbedContentBatchFailure is a failed request of a
+// [Models.EmbedContentAutoBatch] call.
+type EmbedContentBatchFailure struct {
+	// Start is the index of the first content of the request.
+	Start int
+	// End is the index after the last content of the request.
+	End int
+	// Err is the error of the request.
+	Err error
+}
+
+// EmbedContentBatchError is returned, along with the embeddings of the
+// requests that succeeded, by a [Models.EmbedContentAutoBatch] call whose
+// requests failed in part.
+type EmbedContentBatchError struct {
+	// Failures are the failed requests, in input order. The embeddings of
+	// their contents are nil in the response.
+	Failures []*EmbedContentBatchFailure
+	// Requests is the number of requests made.
+	Requests int
+}
+
+func (e *EmbedContentBatchError) Error() string {
+	f := e.Failures[0]
+	return fmt.Sprintf("EmbedContentAutoBatch: %d of %d requests failed, the first one, for contents %d to %d: %v", len(e.Failures), e.Requests, f.Start, f.End-1, f.Err)
+}
+
+// Unwrap returns the errors of the failed requests.
+func (e *EmbedContentBatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, f := range e.Failures {
+		errs[i] = f.Err
+	}
+	return errs
+}
+
+// EmbedContentAutoBatch embeds contents like [Models.EmbedContent], in as many
+// requests as needed to stay within the limits of autoBatch, concurrently,
+// and stitches the embeddings back together in input order. If autoBatch is
+// nil, the default limits of the model are used. If some of the requests
+// fail, the embeddings of the others are returned along with an
+// [*EmbedContentBatchError].
+func (m Models) EmbedContentAutoBatch(ctx context.Context, model string, contents []*Content, autoBatch *EmbedAutoBatchConfig, config *EmbedContentConfig) (*EmbedContentResponse, error) {
+	cfg := EmbedAutoBatchConfig{}
+	if autoBatch != nil {
+		cfg = *autoBatch
+	}
+	cfg, err := cfg.withDefaults(m.apiClient.clientConfig.Backend, model)
+	if err != nil {
+		return nil, fmt.Errorf("EmbedContentAutoBatch: %w", err)
+	}
+
+	batches := embedBatches(contents, cfg.MaxContentsPerRequest, cfg.MaxTokensPerRequest)
+	responses := make([]*EmbedContentResponse, len(batches))
+	errs := make([]error, len(batches))
+	sem := make(chan struct{}, cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i, b := range batches {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			resp, err := m.EmbedContent(ctx, model, contents[b[0]:b[1]], config)
+			if err == nil && len(resp.Embeddings) != b[1]-b[0] {
+				err = fmt.Errorf("got %d embeddings for %d contents", len(resp.Embeddings), b[1]-b[0])
+			}
+			responses[i], errs[i] = resp, err
+		}()
+	}
+	wg.Wait()
+
+	result := &EmbedContentResponse{Embeddings: make([]*ContentEmbedding, len(contents))}
+	var batchErr *EmbedContentBatchError
+	for i, b := range batches {
+		if errs[i] != nil {
+			if batchErr == nil {
+				batchErr = &EmbedContentBatchError{Requests: len(batches)}
+			}
+			batchErr.Failures = append(batchErr.Failures, &EmbedContentBatchFailure{Start: b[0], End: b[1], Err: errs[i]})
+			continue
+		}
+		copy(result.Embeddings[b[0]:b[1]], responses[i].Embeddings)
+		if md := responses[i].Metadata; md != nil {
+			if result.Metadata == nil {
+				result.Metadata = &EmbedContentMetadata{}
+			}
+			result.Metadata.BillableCharacterCount += md.BillableCharacterCount
+		}
+	}
+	if batchErr != nil {
+		return result, batchErr
+	}
+	return result, nil
+}
+
+// withDefaults returns c with the defaults of model on backend.
+func (c EmbedAutoBatchConfig) withDefaults(backend Backend, model string) (EmbedAutoBatchConfig, error) {
+	if c.MaxContentsPerRequest < 0 || c.MaxTokensPerRequest < 0 || c.Concurrency < 0 {
+		return c, fmt.Errorf("the limits must be positive")
+	}
+	vertex := backend == BackendVertexAI
+	if c.MaxContentsPerRequest == 0 {
+		switch {
+		case vertex && tIsVertexEmbedContentModel(model):
+			c.MaxContentsPerRequest = 1
+		case vertex:
+			c.MaxContentsPerRequest = defaultEmbedMaxContentsVertexAI
+		default:
+			c.MaxContentsPerRequest = defaultEmbedMaxContentsGeminiAPI
+		}
+	}
+	if c.MaxTokensPerRequest == 0 && vertex {
+		c.MaxTokensPerRequest = defaultEmbedMaxTokensVertexAI
+	}
+	if c.Concurrency == 0 {
+		c.Concurrency = defaultEmbedConcurrency
+	}
+	return c, nil
+}
+
+// embedBatches splits contents into batches of at most maxContents contents
+// and, if maxTokens isn't 0, of at most maxTokens estimated tokens. Each batch
+// is the start and end indexes of its contents.
+func embedBatches(contents []*Content, maxContents, maxTokens int) [][2]int {
+	var batches [][2]int
+	start, tokens := 0, 0
+	for i, c := range contents {
+		n := estimateTextTokens(c)
+		if i > start && (i-start == maxContents || (maxTokens > 0 && tokens+n > maxTokens)) {
+			batches = append(batches, [2]int{start, i})
+			start, tokens = i, 0
+		}
+		tokens += n
+	}
+	if start < len(contents) {
+		batches = append(batches, [2]int{start, len(contents)})
+	}
+	return batches
+}
+
+// estimateTextTokens estimates the number of tokens of the text of c.
+func estimateTextTokens(c *Content) int {
+	if c == nil {
+		return 0
+	}
+	n := 0
+	for _, p := range c.Parts {
+		if p != nil {
+			n += len(p.Text)
+		}
+	}
+	return (n + embedBytesPerToken - 1) / embedBytesPerToken
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEmbedBatches(t *testing.T) {
+	contents := []*Content{
+		NewContentFromText(strings.Repeat("a", 40), RoleUser), // 10 tokens
+		NewContentFromText(strings.Repeat("a", 40), RoleUser),
+		NewContentFromText(strings.Repeat("a", 100), RoleUser), // 25 tokens
+		NewContentFromText("a", RoleUser),                      // 1 token
+		NewContentFromText("a", RoleUser),
+	}
+	tests := []struct {
+		name        string
+		maxContents int
+		maxTokens   int
+		want        [][2]int
+	}{
+		{name: "ContentLimit", maxContents: 2, want: [][2]int{{0, 2}, {2, 4}, {4, 5}}},
+		{name: "TokenLimit", maxContents: 100, maxTokens: 20, want: [][2]int{{0, 2}, {2, 3}, {3, 5}}},
+		{name: "BothLimits", maxContents: 1, maxTokens: 1000, want: [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}}},
+		{name: "NoSplit", maxContents: 5, maxTokens: 100, want: [][2]int{{0, 5}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := embedBatches(contents, tt.maxContents, tt.maxTokens)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("embedBatches() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEmbedContentAutoBatch(t *testing.T) {
+	var mu sync.Mutex
+	var requestSizes []int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, ":batchEmbedContents") {
+			t.Errorf("path = %s, want batchEmbedContents", r.URL.Path)
+		}
+		var req struct {
+			Requests []struct {
+				Content *Content `json:"content"`
+			} `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		requestSizes = append(requestSizes, len(req.Requests))
+		mu.Unlock()
+		var embeddings []string
+		for _, item := range req.Requests {
+			text := item.Content.Parts[0].Text
+			if text == "fail" {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error": {"code": 400, "message": "bad content", "status": "INVALID_ARGUMENT"}}`)
+				return
+			}
+			embeddings = append(embeddings, fmt.Sprintf(`{"values": [%s]}`, text))
+		}
+		fmt.Fprintf(w, `{"embeddings": [%s]}`, strings.Join(embeddings, ","))
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+	ctx := context.Background()
+
+	texts := func(texts ...string) []*Content {
+		var contents []*Content
+		for _, s := range texts {
+			contents = append(contents, NewContentFromText(s, RoleUser))
+		}
+		return contents
+	}
+	values := func(resp *EmbedContentResponse) []string {
+		var got []string
+		for _, e := range resp.Embeddings {
+			if e == nil {
+				got = append(got, "nil")
+				continue
+			}
+			got = append(got, strconv.Itoa(int(e.Values[0])))
+		}
+		return got
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		requestSizes = nil
+		contents := texts("0", "1", "2", "3", "4", "5", "6")
+		autoBatch := &EmbedAutoBatchConfig{MaxContentsPerRequest: 3, Concurrency: 2}
+		resp, err := m.EmbedContentAutoBatch(ctx, "gemini-embedding-001", contents, autoBatch, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"0", "1", "2", "3", "4", "5", "6"}, values(resp)); diff != "" {
+			t.Errorf("embeddings mismatch (-want +got):\n%s", diff)
+		}
+		if len(requestSizes) != 3 {
+			t.Errorf("got %d requests, want 3", len(requestSizes))
+		}
+	})
+
+	t.Run("PartialFailure", func(t *testing.T) {
+		contents := texts("0", "1", "fail", "3", "4")
+		autoBatch := &EmbedAutoBatchConfig{MaxContentsPerRequest: 2}
+		resp, err := m.EmbedContentAutoBatch(ctx, "gemini-embedding-001", contents, autoBatch, nil)
+		var batchErr *EmbedContentBatchError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf("EmbedContentAutoBatch() error = %v, want an *EmbedContentBatchError", err)
+		}
+		if len(batchErr.Failures) != 1 || batchErr.Failures[0].Start != 2 || batchErr.Failures[0].End != 4 || batchErr.Requests != 3 {
+			t.Errorf("EmbedContentBatchError = %+v, want the failure of contents 2 to 3 among 3 requests", batchErr)
+		}
+		var apiErr APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+			t.Errorf("EmbedContent() error = %v, want to wrap the APIError", err)
+		}
+		if diff := cmp.Diff([]string{"0", "1", "nil", "nil", "4"}, values(resp)); diff != "" {
+			t.Errorf("embeddings mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
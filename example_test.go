@@ -557,6 +557,37 @@ func ExampleModels_GenerateContent_googleSearchRetrieval_geminiapi() {
 	debugPrint(result)
 }
 
+// This example shows how to call the GenerateContent method with Enterprise Web Search to Vertex AI.
+func ExampleModels_GenerateContent_enterpriseWebSearch_vertexai() {
+	ctx := context.Background()
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+		Project:  project,
+		Location: location,
+		Backend:  genai.BackendVertexAI,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Call the GenerateContent method.
+	result, err := client.Models.GenerateContent(ctx,
+		"gemini-2.5-flash",
+		genai.Text("What are the latest capital requirements for European banks?"),
+		&genai.GenerateContentConfig{
+			Tools: []*genai.Tool{
+				{EnterpriseWebSearch: &genai.EnterpriseWebSearch{
+					ExcludeDomains:     []string{"example.com"},
+					BlockingConfidence: genai.PhishBlockThresholdBlockMediumAndAbove,
+				}},
+			},
+		},
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	debugPrint(result)
+}
+
 // This example shows how to call the GenerateContent method with code execution to Vertex AI.
 func ExampleModels_GenerateContent_codeExecution_vertexai() {
 	ctx := context.Background()
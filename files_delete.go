@@ -0,0 +1,126 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+const defaultDeleteFilesConcurrency = 8
+
+// DeleteIfExists deletes a file like [Files.Delete], but also succeeds if the
+// file doesn't exist, for example because it already expired, so that cleanup
+// jobs don't fail when they race the expiration of files. deleted is false if
+// the file didn't exist, in which case nothing was deleted.
+func (m Files) DeleteIfExists(ctx context.Context, name string, config *DeleteFileConfig) (deleted bool, err error) {
+	_, err = m.Delete(ctx, name, config)
+	var apiErr APIError
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// DeleteAllFilesConfig configures [Files.DeleteAll].
+type DeleteAllFilesConfig struct {
+	// Optional. Configuration used for every Delete call.
+	DeleteFileConfig *DeleteFileConfig
+	// Optional. Maximum number of Delete calls in flight at the same time.
+	// Defaults to 8.
+	Concurrency int
+}
+
+// DeleteAllFilesResponse is the result of [Files.DeleteAll].
+type DeleteAllFilesResponse struct {
+	// Deleted are the names of the deleted files.
+	Deleted []string
+	// AlreadyDeleted are the names of the files that were listed but no longer
+	// existed when they were deleted, for example because they expired.
+	AlreadyDeleted []string
+	// Failed are the names of the files that couldn't be deleted.
+	Failed []string
+}
+
+// DeleteAll deletes the files for which filter returns true, or all files if
+// filter is nil. Files are deleted concurrently while they are listed. A file
+// that can't be deleted doesn't stop the others from being deleted: DeleteAll
+// returns the names of the files by outcome, along with the errors of the
+// files that couldn't be deleted joined into one. The order of the names is
+// unspecified.
+//
+//	// Delete the files uploaded more than a day ago.
+//	resp, err := client.Files.DeleteAll(ctx, func(f *genai.File) bool {
+//		return time.Since(f.CreateTime) > 24*time.Hour
+//	}, nil)
+func (m Files) DeleteAll(ctx context.Context, filter func(*File) bool, config *DeleteAllFilesConfig) (*DeleteAllFilesResponse, error) {
+	cfg := DeleteAllFilesConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.Concurrency < 0 {
+		return nil, fmt.Errorf("DeleteAll: concurrency must be positive, got %d", cfg.Concurrency)
+	}
+	if cfg.Concurrency == 0 {
+		cfg.Concurrency = defaultDeleteFilesConcurrency
+	}
+
+	result := &DeleteAllFilesResponse{}
+	var mu sync.Mutex
+	var errs []error
+	sem := make(chan struct{}, cfg.Concurrency)
+	var wg sync.WaitGroup
+	for file, err := range m.All(ctx) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("DeleteAll: listing files: %w", err))
+			break
+		}
+		if filter != nil && !filter(file) {
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			deleted, err := m.DeleteIfExists(ctx, file.Name, cfg.DeleteFileConfig)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				result.Failed = append(result.Failed, file.Name)
+				errs = append(errs, fmt.Errorf("DeleteAll: deleting %s: %w", file.Name, err))
+			case !deleted:
+				result.AlreadyDeleted = append(result.AlreadyDeleted, file.Name)
+			default:
+				result.Deleted = append(result.Deleted, file.Name)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return result, errors.Join(errs...)
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeFilesServer lists names and deletes them. Deleting files/gone fails with
+// 404, as if it had expired, and deleting files/broken fails with 500.
+func fakeFilesServer(t *testing.T, names ...string) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/v1beta/")
+		switch {
+		case r.Method == http.MethodGet && name == "files":
+			var files []string
+			for _, n := range names {
+				files = append(files, fmt.Sprintf(`{"name": %q}`, n))
+			}
+			fmt.Fprintf(w, `{"files": [%s]}`, strings.Join(files, ","))
+		case r.Method == http.MethodDelete && name == "files/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"error": {"code": 500, "message": "internal", "status": "INTERNAL"}}`)
+		case r.Method == http.MethodDelete && name == "files/gone":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}}`)
+		case r.Method == http.MethodDelete:
+			mu.Lock()
+			deleted = append(deleted, name)
+			mu.Unlock()
+			fmt.Fprint(w, `{}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	return ts, &deleted
+}
+
+func TestFilesDeleteIfExists(t *testing.T) {
+	ctx := context.Background()
+	ts, _ := fakeFilesServer(t, "files/a")
+	defer ts.Close()
+	files := Files{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta"}, HTTPClient: ts.Client()}}}
+
+	deleted, err := files.DeleteIfExists(ctx, "files/a", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !deleted {
+		t.Error("DeleteIfExists() of an existing file = false, want true")
+	}
+	deleted, err = files.DeleteIfExists(ctx, "files/gone", nil)
+	if err != nil {
+		t.Fatalf("DeleteIfExists() of a missing file = %v, want nil", err)
+	}
+	if deleted {
+		t.Error("DeleteIfExists() of a missing file = true, want false")
+	}
+	if _, err := files.DeleteIfExists(ctx, "files/broken", nil); err == nil {
+		t.Error("DeleteIfExists() failing with 500 succeeded, want error")
+	}
+	if _, err := files.Delete(ctx, "files/gone", nil); err == nil {
+		t.Error("Delete() of a missing file succeeded, want error")
+	}
+}
+
+func TestFilesDeleteAll(t *testing.T) {
+	ctx := context.Background()
+	ts, deleted := fakeFilesServer(t, "files/a", "files/gone", "files/b", "files/broken", "files/keep")
+	defer ts.Close()
+	files := Files{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta"}, HTTPClient: ts.Client()}}}
+
+	resp, err := files.DeleteAll(ctx, func(f *File) bool { return f.Name != "files/keep" }, &DeleteAllFilesConfig{Concurrency: 2})
+	if err == nil || !strings.Contains(err.Error(), "files/broken") {
+		t.Errorf("DeleteAll() error = %v, want error about files/broken", err)
+	}
+	for _, names := range [][]string{resp.Deleted, resp.AlreadyDeleted, resp.Failed, *deleted} {
+		slices.Sort(names)
+	}
+	want := &DeleteAllFilesResponse{
+		Deleted:        []string{"files/a", "files/b"},
+		AlreadyDeleted: []string{"files/gone"},
+		Failed:         []string{"files/broken"},
+	}
+	if diff := cmp.Diff(want, resp); diff != "" {
+		t.Errorf("DeleteAll() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"files/a", "files/b"}, *deleted); diff != "" {
+		t.Errorf("deleted files mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := files.DeleteAll(ctx, nil, &DeleteAllFilesConfig{Concurrency: -1}); err == nil {
+		t.Error("DeleteAll() with negative concurrency succeeded, want error")
+	}
+}
@@ -0,0 +1,319 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// FunctionCallAssembler assembles function calls whose arguments are streamed
+// across several chunks, as enabled by
+// [FunctionCallingConfig.StreamFunctionCallArguments]. The zero value is ready
+// to use.
+type FunctionCallAssembler struct {
+	pending *FunctionCall
+	// continuing holds the JSON paths of string arguments whose next partial
+	// value is to be appended.
+	continuing map[string]bool
+}
+
+// Add adds a function call of a streamed chunk. It returns the complete
+// function call, with all of its arguments in Args, once its last part was
+// added, and nil before that. A function call that isn't streamed is returned
+// as it is.
+func (a *FunctionCallAssembler) Add(call *FunctionCall) (*FunctionCall, error) {
+	if a.pending == nil {
+		if len(call.PartialArgs) == 0 && !isTrue(call.WillContinue) {
+			return call, nil
+		}
+		a.pending = &FunctionCall{ID: call.ID, Name: call.Name, Args: map[string]any{}}
+		for k, v := range call.Args {
+			a.pending.Args[k] = v
+		}
+		a.continuing = map[string]bool{}
+	} else {
+		if call.Name != "" && call.Name != a.pending.Name {
+			return nil, fmt.Errorf("function call %q started before function call %q was complete", call.Name, a.pending.Name)
+		}
+		if a.pending.ID == "" {
+			a.pending.ID = call.ID
+		}
+	}
+	for _, arg := range call.PartialArgs {
+		if err := a.addPartialArg(arg); err != nil {
+			return nil, fmt.Errorf("function call %q: %w", a.pending.Name, err)
+		}
+	}
+	if isTrue(call.WillContinue) {
+		return nil, nil
+	}
+	done := a.pending
+	a.pending = nil
+	a.continuing = nil
+	return done, nil
+}
+
+// Pending returns the name of the function call being assembled, if any.
+func (a *FunctionCallAssembler) Pending() (string, bool) {
+	if a.pending == nil {
+		return "", false
+	}
+	return a.pending.Name, true
+}
+
+func (a *FunctionCallAssembler) addPartialArg(arg *PartialArg) error {
+	var value any
+	switch {
+	case arg.BoolValue != nil:
+		value = *arg.BoolValue
+	case arg.NumberValue != nil:
+		value = *arg.NumberValue
+	case arg.NULLValue != "":
+		value = nil
+	default:
+		value = arg.StringValue
+	}
+	if s, ok := value.(string); ok && a.continuing[arg.JsonPath] {
+		prev, err := getJSONPath(a.pending.Args, arg.JsonPath)
+		if err != nil {
+			return err
+		}
+		prevString, _ := prev.(string)
+		value = prevString + s
+	}
+	a.continuing[arg.JsonPath] = isTrue(arg.WillContinue)
+	return setJSONPath(a.pending.Args, arg.JsonPath, value)
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+// AssembleFunctionCalls returns an iterator over the chunks of stream in which
+// function calls whose arguments are streamed are replaced by the complete
+// function call, in the chunk that ends it. Chunks are yielded as they arrive,
+// so text is still streamed, and other parts are left as they are. Use it so
+// that agent loops built on [Models.GenerateContentStream] only see complete
+// arguments.
+//
+//	for chunk, err := range genai.AssembleFunctionCalls(client.Models.GenerateContentStream(ctx, model, contents, config)) {
+//		if err != nil {
+//			return err
+//		}
+//		for _, call := range chunk.FunctionCalls() {
+//			// call.Args is complete.
+//		}
+//	}
+func AssembleFunctionCalls(stream iter.Seq2[*GenerateContentResponse, error]) iter.Seq2[*GenerateContentResponse, error] {
+	return func(yield func(*GenerateContentResponse, error) bool) {
+		assemblers := map[int32]*FunctionCallAssembler{}
+		for chunk, err := range stream {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			chunk, err = assembleChunk(chunk, assemblers)
+			if !yield(chunk, err) || err != nil {
+				return
+			}
+		}
+		for _, a := range assemblers {
+			if name, ok := a.Pending(); ok {
+				yield(nil, fmt.Errorf("stream ended before function call %q was complete", name))
+				return
+			}
+		}
+	}
+}
+
+// assembleChunk returns a copy of chunk in which function call parts are
+// replaced by the function calls they complete.
+func assembleChunk(chunk *GenerateContentResponse, assemblers map[int32]*FunctionCallAssembler) (*GenerateContentResponse, error) {
+	if chunk == nil {
+		return nil, nil
+	}
+	c := *chunk
+	c.Candidates = make([]*Candidate, len(chunk.Candidates))
+	for i, candidate := range chunk.Candidates {
+		c.Candidates[i] = candidate
+		if candidate == nil || candidate.Content == nil {
+			continue
+		}
+		a := assemblers[candidate.Index]
+		if a == nil {
+			a = &FunctionCallAssembler{}
+			assemblers[candidate.Index] = a
+		}
+		var parts []*Part
+		for _, p := range candidate.Content.Parts {
+			if p == nil || p.FunctionCall == nil {
+				parts = append(parts, p)
+				continue
+			}
+			call, err := a.Add(p.FunctionCall)
+			if err != nil {
+				return nil, err
+			}
+			if call == p.FunctionCall {
+				parts = append(parts, p)
+			} else if call != nil {
+				cp := *p
+				cp.FunctionCall = call
+				parts = append(parts, &cp)
+			}
+		}
+		cc := *candidate
+		content := *candidate.Content
+		content.Parts = parts
+		cc.Content = &content
+		c.Candidates[i] = &cc
+	}
+	return &c, nil
+}
+
+// jsonPathSegment is a member name or an array index of a JSON path.
+type jsonPathSegment struct {
+	name  string
+	index int
+	isIdx bool
+}
+
+// parseJSONPath parses the normalized JSON paths of streamed arguments, such
+// as $.foo.bar[0]['baz'].
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	rest, ok := strings.CutPrefix(path, "$")
+	if !ok {
+		return nil, fmt.Errorf("invalid JSON path %q", path)
+	}
+	var segments []jsonPathSegment
+	for rest != "" {
+		switch {
+		case rest[0] == '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid JSON path %q", path)
+			}
+			segments = append(segments, jsonPathSegment{name: rest[:end]})
+			rest = rest[end:]
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q", path)
+			}
+			segments = append(segments, jsonPathSegment{name: rest[2:end]})
+			rest = rest[end+2:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q", path)
+			}
+			i, err := strconv.Atoi(rest[1:end])
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid JSON path %q", path)
+			}
+			segments = append(segments, jsonPathSegment{index: i, isIdx: true})
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid JSON path %q", path)
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("JSON path %q doesn't name an argument", path)
+	}
+	return segments, nil
+}
+
+// getJSONPath returns the value at path in root, or nil if there is none.
+func getJSONPath(root map[string]any, path string) (any, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	var v any = root
+	for _, s := range segments {
+		switch c := v.(type) {
+		case map[string]any:
+			if s.isIdx {
+				return nil, nil
+			}
+			v = c[s.name]
+		case []any:
+			if !s.isIdx || s.index >= len(c) {
+				return nil, nil
+			}
+			v = c[s.index]
+		default:
+			return nil, nil
+		}
+	}
+	return v, nil
+}
+
+// setJSONPath sets the value at path in root, creating the objects and arrays
+// on the way.
+func setJSONPath(root map[string]any, path string, value any) error {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return err
+	}
+	// root is updated in place since it's never nil.
+	if _, err := setJSONPathSegments(root, segments, value); err != nil {
+		return fmt.Errorf("JSON path %q: %w", path, err)
+	}
+	return nil
+}
+
+// setJSONPathSegments returns container with value set at segments.
+func setJSONPathSegments(container any, segments []jsonPathSegment, value any) (any, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+	s := segments[0]
+	if s.isIdx {
+		arr, ok := container.([]any)
+		if container != nil && !ok {
+			return nil, fmt.Errorf("index %d of a non-array value", s.index)
+		}
+		for len(arr) <= s.index {
+			arr = append(arr, nil)
+		}
+		v, err := setJSONPathSegments(arr[s.index], segments[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		arr[s.index] = v
+		return arr, nil
+	}
+	obj, ok := container.(map[string]any)
+	if container != nil && !ok {
+		return nil, fmt.Errorf("member %q of a non-object value", s.name)
+	}
+	if obj == nil {
+		obj = map[string]any{}
+	}
+	v, err := setJSONPathSegments(obj[s.name], segments[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	obj[s.name] = v
+	return obj, nil
+}
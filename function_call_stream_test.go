@@ -0,0 +1,98 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func functionCallChunk(call *FunctionCall) *GenerateContentResponse {
+	return &GenerateContentResponse{Candidates: []*Candidate{{Content: &Content{Role: RoleModel, Parts: []*Part{{FunctionCall: call}}}}}}
+}
+
+func TestAssembleFunctionCalls(t *testing.T) {
+	chunks := []*GenerateContentResponse{
+		{Candidates: []*Candidate{{Content: &Content{Role: RoleModel, Parts: []*Part{{Text: "Setting the light."}}}}}},
+		functionCallChunk(&FunctionCall{Name: "controlLight", WillContinue: Ptr(true)}),
+		functionCallChunk(&FunctionCall{WillContinue: Ptr(true), PartialArgs: []*PartialArg{
+			{JsonPath: "$.brightness", NumberValue: Ptr(50.0)},
+			{JsonPath: "$.colorTemperature", StringValue: "wa", WillContinue: Ptr(true)},
+		}}),
+		functionCallChunk(&FunctionCall{WillContinue: Ptr(true), PartialArgs: []*PartialArg{
+			{JsonPath: "$.colorTemperature", StringValue: "rm"},
+			{JsonPath: "$.rooms[1].name", StringValue: "kitchen"},
+			{JsonPath: "$.rooms[0]['on']", BoolValue: Ptr(true)},
+		}}),
+		functionCallChunk(&FunctionCall{}),
+		functionCallChunk(&FunctionCall{Name: "getTime", Args: map[string]any{"zone": "UTC"}}),
+	}
+
+	var calls []*FunctionCall
+	var texts []string
+	for chunk, err := range AssembleFunctionCalls(streamOf(chunks, nil)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		calls = append(calls, chunk.FunctionCalls()...)
+		if text := chunk.Text(); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	want := []*FunctionCall{
+		{Name: "controlLight", Args: map[string]any{
+			"brightness":       50.0,
+			"colorTemperature": "warm",
+			"rooms":            []any{map[string]any{"on": true}, map[string]any{"name": "kitchen"}},
+		}},
+		{Name: "getTime", Args: map[string]any{"zone": "UTC"}},
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Errorf("assembled function calls mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Setting the light."}, texts); diff != "" {
+		t.Errorf("texts mismatch (-want +got):\n%s", diff)
+	}
+	if chunks[1].Candidates[0].Content.Parts[0].FunctionCall.Name != "controlLight" {
+		t.Errorf("AssembleFunctionCalls() modified a chunk")
+	}
+
+	t.Run("IncompleteCall", func(t *testing.T) {
+		var gotErr error
+		for _, err := range AssembleFunctionCalls(streamOf(chunks[:3], nil)) {
+			gotErr = err
+		}
+		if gotErr == nil {
+			t.Error("got no error for a stream ending with an incomplete function call")
+		}
+	})
+}
+
+func TestParseJSONPath(t *testing.T) {
+	for _, path := range []string{"", "$", "a.b", "$.", "$[x]", "$['a'", "$[-1]"} {
+		if _, err := parseJSONPath(path); err == nil {
+			t.Errorf("parseJSONPath(%q) succeeded, want an error", path)
+		}
+	}
+	got, err := parseJSONPath("$.a['b.c'][2].d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []jsonPathSegment{{name: "a"}, {name: "b.c"}, {index: 2, isIdx: true}, {name: "d"}}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(jsonPathSegment{})); diff != "" {
+		t.Errorf("parseJSONPath() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultFunctionCallMaxIterations     = 10
+	defaultFunctionCallMaxIdenticalCalls = 3
+)
+
+// FunctionCallStopReason is the reason a function calling loop was stopped by
+// a [FunctionCallGuard].
+type FunctionCallStopReason string
+
+const (
+	// The loop ran for more iterations than allowed.
+	FunctionCallStopReasonMaxIterations FunctionCallStopReason = "MAX_ITERATIONS"
+	// A function was called more often than its call budget allows.
+	FunctionCallStopReasonFunctionBudget FunctionCallStopReason = "FUNCTION_BUDGET"
+	// The same function was called with the same arguments too many times.
+	FunctionCallStopReasonRepeatedCall FunctionCallStopReason = "REPEATED_CALL"
+)
+
+// FunctionCallGuardConfig configures the limits of a [FunctionCallGuard].
+type FunctionCallGuardConfig struct {
+	// Optional. Maximum number of iterations, that is model turns that request
+	// function calls. Defaults to 10.
+	MaxIterations int32
+	// Optional. Maximum number of calls per function name. Functions that aren't
+	// listed are unlimited.
+	MaxCallsPerFunction map[string]int32
+	// Optional. Maximum number of times a function may be called with identical
+	// arguments. Defaults to 3.
+	MaxIdenticalCalls int32
+}
+
+// FunctionCallTraceStep is a single function call made by a function calling
+// loop.
+type FunctionCallTraceStep struct {
+	// Iteration is the 1-based iteration the call was made in.
+	Iteration int32
+	// Call is the function call requested by the model.
+	Call *FunctionCall
+	// Response is the response returned to the model. It's nil if the call was
+	// never executed.
+	Response *FunctionResponse
+}
+
+// FunctionCallLoopError is returned when a [FunctionCallGuard] stops a function
+// calling loop.
+type FunctionCallLoopError struct {
+	// Reason is why the loop was stopped.
+	Reason FunctionCallStopReason
+	// Call is the function call that exceeded a limit. It's nil when the
+	// maximum number of iterations was reached.
+	Call *FunctionCall
+	// Trace holds all calls made so far, in order.
+	Trace []*FunctionCallTraceStep
+}
+
+// Error returns a string representation of the FunctionCallLoopError.
+func (e *FunctionCallLoopError) Error() string {
+	switch e.Reason {
+	case FunctionCallStopReasonMaxIterations:
+		return fmt.Sprintf("function calling stopped after %d calls: maximum number of iterations reached", len(e.Trace))
+	case FunctionCallStopReasonFunctionBudget:
+		return fmt.Sprintf("function calling stopped after %d calls: call budget of function %q exhausted", len(e.Trace), e.Call.Name)
+	default:
+		return fmt.Sprintf("function calling stopped after %d calls: function %q called repeatedly with identical arguments", len(e.Trace), e.Call.Name)
+	}
+}
+
+// FunctionCallGuard enforces the limits of a function calling loop and keeps
+// a trace of the calls. Call [FunctionCallGuard.Begin] with the function calls
+// of each model turn before executing them, and [FunctionCallGuard.Record]
+// with each result. It is safe for concurrent use.
+//
+//	guard := genai.NewFunctionCallGuard(nil)
+//	for calls := resp.FunctionCalls(); len(calls) > 0; calls = resp.FunctionCalls() {
+//		if err := guard.Begin(calls); err != nil {
+//			return err
+//		}
+//		// Execute calls, record them with guard.Record and send the responses.
+//	}
+type FunctionCallGuard struct {
+	config FunctionCallGuardConfig
+
+	mu         sync.Mutex
+	iterations int32
+	calls      map[string]int32
+	identical  map[string]int32
+	trace      []*FunctionCallTraceStep
+}
+
+// NewFunctionCallGuard returns a guard with the given limits. A nil config
+// uses the defaults.
+func NewFunctionCallGuard(config *FunctionCallGuardConfig) *FunctionCallGuard {
+	g := &FunctionCallGuard{calls: map[string]int32{}, identical: map[string]int32{}}
+	if config != nil {
+		g.config = *config
+	}
+	if g.config.MaxIterations == 0 {
+		g.config.MaxIterations = defaultFunctionCallMaxIterations
+	}
+	if g.config.MaxIdenticalCalls == 0 {
+		g.config.MaxIdenticalCalls = defaultFunctionCallMaxIdenticalCalls
+	}
+	return g
+}
+
+// Begin starts a new iteration with the function calls requested by the model.
+// It returns a [*FunctionCallLoopError] if executing the calls would exceed a
+// limit. The calls are added to the trace either way.
+func (g *FunctionCallGuard) Begin(calls []*FunctionCall) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.iterations++
+	for _, call := range calls {
+		g.trace = append(g.trace, &FunctionCallTraceStep{Iteration: g.iterations, Call: call})
+	}
+	if g.iterations > g.config.MaxIterations {
+		return g.stopLocked(FunctionCallStopReasonMaxIterations, nil)
+	}
+	for _, call := range calls {
+		g.calls[call.Name]++
+		if limit, ok := g.config.MaxCallsPerFunction[call.Name]; ok && g.calls[call.Name] > limit {
+			return g.stopLocked(FunctionCallStopReasonFunctionBudget, call)
+		}
+		key := functionCallKey(call)
+		g.identical[key]++
+		if g.identical[key] > g.config.MaxIdenticalCalls {
+			return g.stopLocked(FunctionCallStopReasonRepeatedCall, call)
+		}
+	}
+	return nil
+}
+
+// Record adds the response of a call passed to the latest Begin to the trace.
+func (g *FunctionCallGuard) Record(call *FunctionCall, response *FunctionResponse) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i := len(g.trace) - 1; i >= 0 && g.trace[i].Iteration == g.iterations; i-- {
+		if g.trace[i].Call == call {
+			g.trace[i].Response = response
+			return
+		}
+	}
+}
+
+// Trace returns the calls made so far, in order.
+func (g *FunctionCallGuard) Trace() []*FunctionCallTraceStep {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]*FunctionCallTraceStep{}, g.trace...)
+}
+
+func (g *FunctionCallGuard) stopLocked(reason FunctionCallStopReason, call *FunctionCall) error {
+	return &FunctionCallLoopError{
+		Reason: reason,
+		Call:   call,
+		Trace:  append([]*FunctionCallTraceStep{}, g.trace...),
+	}
+}
+
+// FunctionHandler executes a function call requested by the model and returns
+// the output of the function, which is sent back to the model.
+type FunctionHandler func(ctx context.Context, args map[string]any) (map[string]any, error)
+
+// AutomaticFunctionCallingConfig configures automatic function calling. When
+// the model requests calls to functions that the SDK can execute, the
+// functions are run concurrently, their outputs are sent back to the model as
+// function responses, and the request is reissued until the model answers
+// without function calls.
+//
+//	getWeather, _ := genai.NewCallableFunction(weather, "get_weather", "Returns the weather in a city.")
+//	options := &genai.GenerateContentOptions{
+//		AutomaticFunctionCalling: &genai.AutomaticFunctionCallingConfig{
+//			Tools: []genai.CallableTool{getWeather},
+//		},
+//	}
+//	resp, err := client.Models.GenerateContentWithOptions(ctx, model, genai.Text("Weather in Paris?"), nil, options)
+type AutomaticFunctionCallingConfig struct {
+	// Optional. Disables automatic function calling, so that function calls are
+	// returned to the caller.
+	Disable bool
+	// Optional. Tools that are called automatically. Their function
+	// declarations are added to the request, so they don't need to be listed
+	// in [GenerateContentConfig.Tools].
+	Tools []CallableTool
+	// Optional. Handlers of functions declared in [GenerateContentConfig.Tools],
+	// keyed by function name.
+	Functions map[string]FunctionHandler
+	// Optional. Limits of the loop. MaxIterations is the maximum number of
+	// turns that call functions and defaults to 10. When a limit is exceeded,
+	// a [*FunctionCallLoopError] is returned.
+	Limits *FunctionCallGuardConfig
+	// Optional. Limits the size of the function results sent back to the
+	// model. Results are sent whole by default.
+	ResultLimit *ToolResultLimitConfig
+}
+
+// automaticFunctionCallingHistories are the turns exchanged by automatic
+// function calling before the responses.
+var automaticFunctionCallingHistories sideTable[GenerateContentResponse, []*Content]
+
+// AutomaticFunctionCallingHistory returns the function call and function
+// response turns exchanged by automatic function calling before the response,
+// in order, see [GenerateContentOptions.AutomaticFunctionCalling]. It's empty
+// if no functions were called automatically. It's set by the SDK, never
+// returned by the API.
+func (r *GenerateContentResponse) AutomaticFunctionCallingHistory() []*Content {
+	return automaticFunctionCallingHistories.get(r)
+}
+
+func (o *GenerateContentOptions) automaticFunctionCallingEnabled() bool {
+	if o == nil || o.AutomaticFunctionCalling == nil || o.AutomaticFunctionCalling.Disable {
+		return false
+	}
+	return len(o.AutomaticFunctionCalling.Functions) > 0 || len(o.AutomaticFunctionCalling.Tools) > 0
+}
+
+// generateContentWithFunctionCalling calls generateContent and runs the
+// function calls of the response until the model answers without them. If
+// the model calls a function the SDK can't execute, the response is returned
+// to the caller. If a function returns an error, the error message is sent to
+// the model as the function response under the "error" key.
+func (m Models) generateContentWithFunctionCalling(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, afc *AutomaticFunctionCallingConfig) (*GenerateContentResponse, error) {
+	executor, err := NewToolExecutor(afc.Tools...)
+	if err != nil {
+		return nil, err
+	}
+	if err := executor.addHandlers(afc.Functions); err != nil {
+		return nil, err
+	}
+	var requestConfig GenerateContentConfig
+	if config != nil {
+		requestConfig = *config
+	}
+	if declarations := executor.Declarations(); len(declarations) > 0 {
+		requestConfig.Tools = append(append([]*Tool{}, requestConfig.Tools...), executor.Tool())
+	}
+
+	guard := NewFunctionCallGuard(afc.Limits)
+	history := append([]*Content{}, contents...)
+	var afcHistory []*Content
+	for {
+		resp, err := m.generateContentWithBudget(ctx, model, history, &requestConfig)
+		if err != nil {
+			return nil, err
+		}
+		automaticFunctionCallingHistories.set(resp, afcHistory)
+		calls := resp.FunctionCalls()
+		if len(calls) == 0 || !handlesAll(executor, calls) {
+			return resp, nil
+		}
+		if err := guard.Begin(calls); err != nil {
+			return nil, err
+		}
+		responses := m.limitFunctionResponses(ctx, afc.ResultLimit, calls, executor.Execute(ctx, calls))
+		parts := make([]*Part, len(calls))
+		for i, response := range responses {
+			guard.Record(calls[i], response)
+			parts[i] = &Part{FunctionResponse: response}
+		}
+		turns := []*Content{resp.Candidates[0].Content, NewContentFromParts(parts, RoleUser)}
+		history = append(history, turns...)
+		afcHistory = append(afcHistory, turns...)
+	}
+}
+
+func handlesAll(executor *ToolExecutor, calls []*FunctionCall) bool {
+	for _, call := range calls {
+		if !executor.Handles(call.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// functionCallKey identifies calls of the same function with the same
+// arguments. encoding/json sorts map keys, so equal arguments give equal keys.
+func functionCallKey(call *FunctionCall) string {
+	args, err := json.Marshal(call.Args)
+	if err != nil {
+		args = fmt.Appendf(nil, "%v", call.Args)
+	}
+	return call.Name + "\x00" + string(args)
+}
@@ -0,0 +1,236 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFunctionCallGuard(t *testing.T) {
+	call := func(name string, args map[string]any) []*FunctionCall {
+		return []*FunctionCall{{Name: name, Args: args}}
+	}
+	tests := []struct {
+		name       string
+		config     *FunctionCallGuardConfig
+		iterations [][]*FunctionCall
+		wantReason FunctionCallStopReason
+		wantTrace  int
+	}{
+		{
+			name:   "MaxIterations",
+			config: &FunctionCallGuardConfig{MaxIterations: 2},
+			iterations: [][]*FunctionCall{
+				call("a", map[string]any{"n": 1}),
+				call("a", map[string]any{"n": 2}),
+				call("a", map[string]any{"n": 3}),
+			},
+			wantReason: FunctionCallStopReasonMaxIterations,
+			wantTrace:  3,
+		},
+		{
+			name:   "FunctionBudget",
+			config: &FunctionCallGuardConfig{MaxCallsPerFunction: map[string]int32{"search": 1}},
+			iterations: [][]*FunctionCall{
+				call("search", map[string]any{"q": "x"}),
+				call("lookup", map[string]any{"q": "x"}),
+				call("search", map[string]any{"q": "y"}),
+			},
+			wantReason: FunctionCallStopReasonFunctionBudget,
+			wantTrace:  3,
+		},
+		{
+			name:   "RepeatedCall",
+			config: &FunctionCallGuardConfig{MaxIdenticalCalls: 2},
+			iterations: [][]*FunctionCall{
+				call("get", map[string]any{"a": 1, "b": 2}),
+				call("get", map[string]any{"b": 2, "a": 1}),
+				call("get", map[string]any{"a": 1, "b": 3}),
+				call("get", map[string]any{"a": 1, "b": 2}),
+			},
+			wantReason: FunctionCallStopReasonRepeatedCall,
+			wantTrace:  4,
+		},
+		{
+			name: "WithinLimits",
+			iterations: [][]*FunctionCall{
+				call("get", map[string]any{"a": 1}),
+				{{Name: "get", Args: map[string]any{"a": 2}}, {Name: "put"}},
+			},
+			wantTrace: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewFunctionCallGuard(tt.config)
+			var err error
+			for _, calls := range tt.iterations {
+				if err = g.Begin(calls); err != nil {
+					break
+				}
+				for _, c := range calls {
+					g.Record(c, &FunctionResponse{Name: c.Name})
+				}
+			}
+			var loopErr *FunctionCallLoopError
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Fatalf("Begin() error = %v, want nil", err)
+				}
+			} else if !errors.As(err, &loopErr) || loopErr.Reason != tt.wantReason {
+				t.Fatalf("Begin() error = %v, want %s", err, tt.wantReason)
+			}
+			trace := g.Trace()
+			if len(trace) != tt.wantTrace {
+				t.Errorf("got %d trace steps, want %d", len(trace), tt.wantTrace)
+			}
+			if trace[0].Response == nil {
+				t.Errorf("first trace step has no response")
+			}
+			if loopErr != nil && len(loopErr.Trace) != tt.wantTrace {
+				t.Errorf("error has %d trace steps, want %d", len(loopErr.Trace), tt.wantTrace)
+			}
+		})
+	}
+}
+
+// newFunctionCallingTestConfig returns a ClientConfig for a test server that
+// answers with a call to get_weather until the request contains n function
+// responses, and with a text answer afterwards.
+func newFunctionCallingTestConfig(t *testing.T, n int, requests *[]map[string]any) *ClientConfig {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		*requests = append(*requests, body)
+		if responses := strings.Count(fmt.Sprint(body["contents"]), "functionResponse"); responses < n {
+			fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"functionCall": {"name": "get_weather", "args": {"city": "Paris", "n": %d}}}]}, "finishReason": "STOP"}]}`, responses)
+			return
+		}
+		fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "Sunny."}]}, "finishReason": "STOP"}]}`)
+	}))
+	t.Cleanup(ts.Close)
+	return &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}
+}
+
+func TestAutomaticFunctionCalling(t *testing.T) {
+	ctx := context.Background()
+	config := &GenerateContentConfig{
+		Tools: []*Tool{{FunctionDeclarations: []*FunctionDeclaration{{Name: "get_weather"}}}},
+	}
+	newOptions := func(handler FunctionHandler, limits *FunctionCallGuardConfig) *GenerateContentOptions {
+		return &GenerateContentOptions{
+			AutomaticFunctionCalling: &AutomaticFunctionCallingConfig{
+				Functions: map[string]FunctionHandler{"get_weather": handler},
+				Limits:    limits,
+			},
+		}
+	}
+	weather := func(ctx context.Context, args map[string]any) (map[string]any, error) {
+		return map[string]any{"forecast": "sunny in " + args["city"].(string)}, nil
+	}
+
+	t.Run("GenerateContent", func(t *testing.T) {
+		var requests []map[string]any
+		m := Models{apiClient: &apiClient{clientConfig: newFunctionCallingTestConfig(t, 1, &requests)}}
+		resp, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("Weather in Paris?"), config, newOptions(weather, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Text() != "Sunny." || len(requests) != 2 {
+			t.Fatalf("got %q after %d requests, want %q after 2", resp.Text(), len(requests), "Sunny.")
+		}
+		if len(resp.AutomaticFunctionCallingHistory()) != 2 {
+			t.Fatalf("got %d history turns, want 2", len(resp.AutomaticFunctionCallingHistory()))
+		}
+		got := resp.AutomaticFunctionCallingHistory()[1].Parts[0].FunctionResponse
+		if got == nil || got.Response["forecast"] != "sunny in Paris" {
+			t.Errorf("function response = %+v, want forecast for Paris", got)
+		}
+	})
+
+	t.Run("Chat", func(t *testing.T) {
+		var requests []map[string]any
+		chats := &Chats{apiClient: &apiClient{clientConfig: newFunctionCallingTestConfig(t, 1, &requests)}}
+		chat, err := chats.Create(ctx, "gemini-2.5-flash", config, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chat.SetOptions(newOptions(weather, nil))
+		if _, err := chat.SendMessage(ctx, Part{Text: "Weather in Paris?"}); err != nil {
+			t.Fatal(err)
+		}
+		history := chat.History(true)
+		if len(history) != 4 || history[1].Parts[0].FunctionCall == nil || history[2].Parts[0].FunctionResponse == nil {
+			t.Errorf("unexpected chat history: %+v", history)
+		}
+	})
+
+	t.Run("HandlerError", func(t *testing.T) {
+		var requests []map[string]any
+		m := Models{apiClient: &apiClient{clientConfig: newFunctionCallingTestConfig(t, 1, &requests)}}
+		failing := func(ctx context.Context, args map[string]any) (map[string]any, error) {
+			return nil, errors.New("service unavailable")
+		}
+		resp, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("Weather in Paris?"), config, newOptions(failing, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := resp.AutomaticFunctionCallingHistory()[1].Parts[0].FunctionResponse
+		if got.Response["error"] != "service unavailable" {
+			t.Errorf("function response = %+v, want error", got)
+		}
+	})
+
+	t.Run("MaxIterations", func(t *testing.T) {
+		var requests []map[string]any
+		m := Models{apiClient: &apiClient{clientConfig: newFunctionCallingTestConfig(t, 5, &requests)}}
+		_, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("Weather in Paris?"), config, newOptions(weather, &FunctionCallGuardConfig{MaxIterations: 2}))
+		var loopErr *FunctionCallLoopError
+		if !errors.As(err, &loopErr) || loopErr.Reason != FunctionCallStopReasonMaxIterations {
+			t.Fatalf("got error %v, want max iterations error", err)
+		}
+		if len(requests) != 3 || len(loopErr.Trace) != 3 {
+			t.Errorf("got %d requests and %d trace steps, want 3 and 3", len(requests), len(loopErr.Trace))
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		var requests []map[string]any
+		m := Models{apiClient: &apiClient{clientConfig: newFunctionCallingTestConfig(t, 1, &requests)}}
+		options := newOptions(weather, nil)
+		options.AutomaticFunctionCalling.Disable = true
+		resp, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("Weather in Paris?"), config, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.FunctionCalls()) != 1 || len(requests) != 1 {
+			t.Errorf("got %d function calls after %d requests, want 1 after 1", len(resp.FunctionCalls()), len(requests))
+		}
+	})
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"iter"
+)
+
+// GenerateContentOptions are the features of the SDK applied around a request
+// by [Models.GenerateContentWithOptions] and
+// [Models.GenerateContentStreamWithOptions]. None of them is sent to the API.
+type GenerateContentOptions struct {
+	// Optional. Executes function calls requested by the model automatically.
+	// Streamed requests don't run them.
+	AutomaticFunctionCalling *AutomaticFunctionCallingConfig
+	// Optional. Retries streamed requests whose stream fails with a transient
+	// error.
+	StreamRetry *StreamRetryConfig
+	// Optional. Uploads inline data that would make the request too large and
+	// refers to the uploaded files instead.
+	AutoUpload *AutoUploadConfig
+	// Optional. Truncates contents that exceed the input token limit of the
+	// model.
+	Truncation *TruncationConfig
+	// Optional. If true, generations blocked for safety return a
+	// [*SafetyBlockedError] along with the response.
+	SafetyBlockedErrors bool
+	// Optional. If true, generations whose prompt was blocked, for any reason,
+	// return a [*PromptBlockedError] along with the response.
+	PromptBlockedErrors bool
+	// Optional. Pins the language of the responses. Streamed responses are
+	// asked for the language, but not checked.
+	ResponseLanguage *ResponseLanguageConfig
+}
+
+// GenerateContentWithOptions generates content like [Models.GenerateContent],
+// with the features of the SDK set in options, which may be nil.
+//
+// Before the request is sent, the contents are truncated according to
+// [GenerateContentOptions.Truncation], oversized inline data is uploaded, and
+// a ResponseJsonSchema given as a string or []byte is sent as a JSON object,
+// once it's checked to be supported by the model.
+// The call is charged to the budgets of the client and of ctx, runs automatic
+// function calling when enabled, and its response is checked against
+// [GenerateContentOptions.ResponseLanguage] and the blocked-response options.
+func (m Models) GenerateContentWithOptions(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, options *GenerateContentOptions) (*GenerateContentResponse, error) {
+	config.setDefaults()
+	if options == nil {
+		options = &GenerateContentOptions{}
+	}
+	config, err := config.withRawJSONSchema().checkResponseJsonSchema(model)
+	if err != nil {
+		return nil, err
+	}
+	if options.ResponseLanguage != nil {
+		config = config.withLanguageInstruction(options.ResponseLanguage.Language)
+	}
+	contents, err = m.truncateForRequest(ctx, model, contents, config, options.Truncation)
+	if err != nil {
+		return nil, err
+	}
+	contents, err = m.uploadOversizedInlineData(ctx, contents, options.AutoUpload)
+	if err != nil {
+		return nil, err
+	}
+	generate := func(contents []*Content) (*GenerateContentResponse, error) {
+		if options.automaticFunctionCallingEnabled() {
+			return m.generateContentWithFunctionCalling(ctx, model, contents, config, options.AutomaticFunctionCalling)
+		}
+		return m.generateContentWithBudget(ctx, model, contents, config)
+	}
+	resp, err := generate(contents)
+	if err != nil {
+		err = m.explainTunedModelNotFound(ctx, model, err)
+	}
+	if err == nil && options.ResponseLanguage != nil {
+		resp, err = m.checkResponseLanguage(ctx, contents, options.ResponseLanguage, resp, generate)
+	}
+	if resp != nil {
+		m.annotateCacheProvenance(ctx, resp, config)
+		if err == nil {
+			err = options.blockError(resp)
+		}
+	}
+	return resp, err
+}
+
+// GenerateContentStreamWithOptions generates a stream of content like
+// [Models.GenerateContentStream], with the features of the SDK set in options,
+// which may be nil.
+//
+// The contents are prepared as by [Models.GenerateContentWithOptions], and the
+// system instruction asks for [GenerateContentOptions.ResponseLanguage], but
+// the language of the response isn't checked. The stream is charged to the
+// budgets of the client and of ctx, retried according to
+// [GenerateContentOptions.StreamRetry] and stops at the first blocked chunk if
+// the blocked-response options are set.
+func (m Models) GenerateContentStreamWithOptions(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, options *GenerateContentOptions) iter.Seq2[*GenerateContentResponse, error] {
+	config.setDefaults()
+	if options == nil {
+		options = &GenerateContentOptions{}
+	}
+	config, err := config.withRawJSONSchema().checkResponseJsonSchema(model)
+	if err != nil {
+		return yieldErrorAndEndIterator[GenerateContentResponse](err)
+	}
+	if options.ResponseLanguage != nil {
+		config = config.withLanguageInstruction(options.ResponseLanguage.Language)
+	}
+	if err := m.budgets(ctx).check(); err != nil {
+		return yieldErrorAndEndIterator[GenerateContentResponse](err)
+	}
+	contents, err = m.truncateForRequest(ctx, model, contents, config, options.Truncation)
+	if err != nil {
+		return yieldErrorAndEndIterator[GenerateContentResponse](err)
+	}
+	contents, err = m.uploadOversizedInlineData(ctx, contents, options.AutoUpload)
+	if err != nil {
+		return yieldErrorAndEndIterator[GenerateContentResponse](err)
+	}
+	stream := m.generateContentStreamWithRetry(ctx, model, contents, config, options.StreamRetry)
+	if config != nil && config.CachedContent != "" {
+		stream = m.annotateStreamCacheProvenance(ctx, stream, config)
+	}
+	if options.SafetyBlockedErrors || options.PromptBlockedErrors {
+		stream = stopOnBlock(stream, options.blockError)
+	}
+	return stream
+}
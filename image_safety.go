@@ -0,0 +1,152 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+const defaultImageVerificationModel = "imageverification@001"
+
+// Score returns the score of category, and whether the attributes have one.
+func (s *SafetyAttributes) Score(category string) (float32, bool) {
+	if s == nil {
+		return 0, false
+	}
+	for i, c := range s.Categories {
+		if c == category && i < len(s.Scores) {
+			return s.Scores[i], true
+		}
+	}
+	return 0, false
+}
+
+// ScoreMap returns the scores by category.
+func (s *SafetyAttributes) ScoreMap() map[string]float32 {
+	scores := map[string]float32{}
+	if s == nil {
+		return scores
+	}
+	for i, c := range s.Categories {
+		if i < len(s.Scores) {
+			scores[c] = s.Scores[i]
+		}
+	}
+	return scores
+}
+
+// Exceeding returns the categories whose score is above threshold, in
+// alphabetical order, so that publishing pipelines can hold back images that
+// score too high.
+func (s *SafetyAttributes) Exceeding(threshold float32) []string {
+	var categories []string
+	for c, score := range s.ScoreMap() {
+		if score > threshold {
+			categories = append(categories, c)
+		}
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// Filtered reports whether the image was filtered out of the response by the
+// Responsible AI filters. RAIFilteredReason then tells why, if IncludeRAIReason
+// was set.
+func (i *GeneratedImage) Filtered() bool {
+	return i.Image == nil || (len(i.Image.ImageBytes) == 0 && i.Image.GCSURI == "")
+}
+
+// Images returns the images that weren't filtered out.
+func (r *GenerateImagesResponse) Images() []*GeneratedImage {
+	var images []*GeneratedImage
+	for _, img := range r.GeneratedImages {
+		if img != nil && !img.Filtered() {
+			images = append(images, img)
+		}
+	}
+	return images
+}
+
+// VerifyImageWatermarkConfig configures [Models.VerifyImageWatermark].
+type VerifyImageWatermarkConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions
+	// Optional. Verification model. Defaults to imageverification@001.
+	Model string
+}
+
+// WatermarkVerification is the result of [Models.VerifyImageWatermark].
+type WatermarkVerification struct {
+	// Decision is the decision of the verification model: ACCEPT if the image
+	// has a SynthID watermark, REJECT otherwise.
+	Decision string
+}
+
+// Watermarked reports whether the image has a SynthID watermark.
+func (v *WatermarkVerification) Watermarked() bool {
+	return v.Decision == "ACCEPT"
+}
+
+// VerifyImageWatermark checks whether image has the SynthID digital watermark
+// that Imagen adds to generated images, unless AddWatermark is false. The
+// image is given either as bytes or as a Cloud Storage URI.
+//
+// It's only supported in Vertex AI.
+func (m Models) VerifyImageWatermark(ctx context.Context, image *Image, config *VerifyImageWatermarkConfig) (*WatermarkVerification, error) {
+	cfg := VerifyImageWatermarkConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if m.apiClient.clientConfig.Backend != BackendVertexAI {
+		return nil, fmt.Errorf("VerifyImageWatermark: watermark verification is only supported in Vertex AI")
+	}
+	if image == nil || (len(image.ImageBytes) == 0 && image.GCSURI == "") {
+		return nil, fmt.Errorf("VerifyImageWatermark: the image must have bytes or a Cloud Storage URI")
+	}
+	if cfg.Model == "" {
+		cfg.Model = defaultImageVerificationModel
+	}
+	httpOptions := cfg.HTTPOptions
+	if httpOptions == nil {
+		httpOptions = &HTTPOptions{}
+	}
+
+	instance := map[string]any{}
+	if len(image.ImageBytes) > 0 {
+		instance["image"] = map[string]any{"bytesBase64Encoded": base64.StdEncoding.EncodeToString(image.ImageBytes)}
+	} else {
+		instance["image"] = map[string]any{"gcsUri": image.GCSURI}
+	}
+	body := map[string]any{"instances": []any{instance}}
+	path := fmt.Sprintf("publishers/google/models/%s:predict", cfg.Model)
+	resp, err := sendRequest(ctx, m.apiClient, path, http.MethodPost, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	predictions, _ := resp["predictions"].([]any)
+	if len(predictions) == 0 {
+		return nil, fmt.Errorf("VerifyImageWatermark: the response has no prediction")
+	}
+	prediction, _ := predictions[0].(map[string]any)
+	decision, _ := prediction["decision"].(string)
+	if decision == "" {
+		return nil, fmt.Errorf("VerifyImageWatermark: the prediction has no decision")
+	}
+	return &WatermarkVerification{Decision: decision}, nil
+}
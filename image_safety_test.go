@@ -0,0 +1,98 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSafetyAttributes(t *testing.T) {
+	s := &SafetyAttributes{
+		Categories: []string{"Violence", "Sexual", "Death, Harm & Tragedy"},
+		Scores:     []float32{0.8, 0.1, 0.5},
+	}
+	if got, ok := s.Score("Sexual"); !ok || got != 0.1 {
+		t.Errorf("Score(Sexual) = %v, %v, want 0.1, true", got, ok)
+	}
+	if _, ok := s.Score("Firearms"); ok {
+		t.Error("Score(Firearms) found a score")
+	}
+	want := map[string]float32{"Violence": 0.8, "Sexual": 0.1, "Death, Harm & Tragedy": 0.5}
+	if diff := cmp.Diff(want, s.ScoreMap()); diff != "" {
+		t.Errorf("ScoreMap() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Death, Harm & Tragedy", "Violence"}, s.Exceeding(0.4)); diff != "" {
+		t.Errorf("Exceeding() mismatch (-want +got):\n%s", diff)
+	}
+	var none *SafetyAttributes
+	if got := none.Exceeding(0); got != nil {
+		t.Errorf("Exceeding() of nil attributes = %v, want nil", got)
+	}
+}
+
+func TestGenerateImagesResponseImages(t *testing.T) {
+	kept := &GeneratedImage{Image: &Image{ImageBytes: []byte("png")}}
+	r := &GenerateImagesResponse{GeneratedImages: []*GeneratedImage{
+		kept,
+		{RAIFilteredReason: "Your current safety filter threshold filtered out the generated image."},
+		{Image: &Image{}, RAIFilteredReason: "filtered"},
+	}}
+	if diff := cmp.Diff([]*GeneratedImage{kept}, r.Images()); diff != "" {
+		t.Errorf("Images() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestVerifyImageWatermark(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/v1beta1/projects/my-project/locations/us-central1/publishers/google/models/imageverification@001:predict"; r.URL.Path != want {
+			t.Errorf("path = %s, want %s", r.URL.Path, want)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		want := map[string]any{"instances": []any{map[string]any{"image": map[string]any{"bytesBase64Encoded": "cG5n"}}}}
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Errorf("body mismatch (-want +got):\n%s", diff)
+		}
+		fmt.Fprint(w, `{"predictions": [{"decision": "ACCEPT"}]}`)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+	ctx := context.Background()
+
+	got, err := m.VerifyImageWatermark(ctx, &Image{ImageBytes: []byte("png")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Watermarked() {
+		t.Errorf("Watermarked() = false for decision %q", got.Decision)
+	}
+
+	if _, err := m.VerifyImageWatermark(ctx, &Image{}, nil); err == nil {
+		t.Error("VerifyImageWatermark() of an empty image succeeded, want error")
+	}
+	gemini := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI}}}
+	if _, err := gemini.VerifyImageWatermark(ctx, &Image{ImageBytes: []byte("png")}, nil); err == nil {
+		t.Error("VerifyImageWatermark() in the Gemini API succeeded, want error")
+	}
+}
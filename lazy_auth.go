@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"cloud.google.com/go/auth"
+)
+
+// lazyAuth is an http.RoundTripper that authenticates requests with
+// credentials resolved on first use. Looking up Application Default
+// Credentials and the quota project can take hundreds of milliseconds, for
+// example on the metadata server, so NewClient leaves it to the first request.
+type lazyAuth struct {
+	mu sync.Mutex
+	// cred is the credentials given to the client, or the resolved default
+	// credentials once transport is set.
+	cred      *auth.Credentials
+	transport http.RoundTripper
+}
+
+// resolve looks up the credentials, if not given, and creates the
+// authenticated transport. Only a successful result is kept: after an error,
+// such as a transient failure of the metadata server, the next call tries
+// again. The lookup isn't canceled with ctx, since its result is shared by
+// all the requests of the client.
+func (l *lazyAuth) resolve(ctx context.Context) (*auth.Credentials, http.RoundTripper, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.transport != nil {
+		return l.cred, l.transport, nil
+	}
+	ctx = context.WithoutCancel(ctx)
+	cred := l.cred
+	if cred == nil {
+		var err error
+		cred, err = detectDefaultCredentials()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find default credentials: %w", err)
+		}
+	}
+	quotaProjectID, err := cred.QuotaProjectID(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get quota project ID: %w", err)
+	}
+	client, err := newAuthenticatedHTTPClient(cred, http.Header{
+		"X-Goog-User-Project": []string{quotaProjectID},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	l.cred, l.transport = cred, client.Transport
+	return l.cred, l.transport, nil
+}
+
+func (l *lazyAuth) RoundTrip(req *http.Request) (*http.Response, error) {
+	_, transport, err := l.resolve(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	return transport.RoundTrip(req)
+}
+
+// credentials returns the credentials of the client, resolving them if
+// needed. It returns nil if the client doesn't use credentials.
+func (ac *apiClient) credentials(ctx context.Context) (*auth.Credentials, error) {
+	if ac.auth == nil {
+		return ac.clientConfig.Credentials, nil
+	}
+	cred, _, err := ac.auth.resolve(ctx)
+	return cred, err
+}
@@ -23,8 +23,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
-
-	"github.com/gorilla/websocket"
+	"sync"
 )
 
 // Preview. Live serves as the entry point for establishing real-time WebSocket
@@ -44,10 +43,13 @@ type Live struct {
 // Generative AI API. It provides methods for sending client messages and
 // receiving server messages over the established connection.
 type Session struct {
-	conn            *websocket.Conn
+	conn            LiveConn
 	apiClient       *apiClient
 	SetupComplete   *LiveServerSetupComplete
 	bufferedMessage *LiveServerMessage
+	// writeMu serializes writes, so that messages can be sent from several
+	// goroutines, for example audio input alongside tool responses.
+	writeMu sync.Mutex
 }
 
 // Preview. Connect establishes a WebSocket connection to the specified
@@ -76,8 +78,12 @@ func (r *Live) Connect(context context.Context, model string, config *LiveConnec
 	var header http.Header = mergeHeaders(&httpOptions, nil)
 	if r.apiClient.clientConfig.Backend == BackendVertexAI {
 		hasStandardAuth := r.apiClient.clientConfig.Project != "" && r.apiClient.clientConfig.Location != ""
-		if r.apiClient.clientConfig.Credentials != nil {
-			token, err := r.apiClient.clientConfig.Credentials.Token(context)
+		cred, err := r.apiClient.credentials(context)
+		if err != nil {
+			return nil, err
+		}
+		if cred != nil {
+			token, err := cred.Token(context)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get token: %w", err)
 			}
@@ -120,7 +126,14 @@ func (r *Live) Connect(context context.Context, model string, config *LiveConnec
 		}
 	}
 
-	conn, _, err := websocket.DefaultDialer.Dial(u.String(), header)
+	if err := checkDataResidency(r.apiClient.clientConfig.AllowedLocations, &u, map[string]any{"model": model}); err != nil {
+		return nil, err
+	}
+	dial := r.apiClient.clientConfig.LiveDialer
+	if dial == nil {
+		dial = defaultLiveDialer
+	}
+	conn, err := dial(context, u.String(), header)
 	if err != nil {
 		return nil, fmt.Errorf("Connect to %s failed: %w", u.String(), err)
 	}
@@ -155,7 +168,7 @@ func (r *Live) Connect(context context.Context, model string, config *LiveConnec
 	if err != nil {
 		return nil, fmt.Errorf("marshal LiveClientSetup failed: %w", err)
 	}
-	err = s.conn.WriteMessage(websocket.TextMessage, clientBytes)
+	err = s.write(clientBytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to write LiveClientSetup: %w", err)
 	}
@@ -253,7 +266,7 @@ func (s *Session) SendRealtimeInput(input LiveRealtimeInput) error {
 	if err != nil {
 		return fmt.Errorf("marshal client message error: %w", err)
 	}
-	return s.conn.WriteMessage(websocket.TextMessage, []byte(data))
+	return s.write(data)
 }
 
 // Preview. LiveToolResponseInput is the input for [SendToolResponse].
@@ -297,7 +310,15 @@ func (s *Session) send(input *LiveClientMessage) error {
 	if err != nil {
 		return fmt.Errorf("marshal client message error: %w", err)
 	}
-	return s.conn.WriteMessage(websocket.TextMessage, []byte(data))
+	return s.write(data)
+}
+
+// write sends data as a text message. All the writes of the session go
+// through it, since [LiveConn.WriteMessage] must never be called concurrently.
+func (s *Session) write(data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.conn.WriteMessage(LiveTextMessage, data)
 }
 
 // Preview. Receive reads a LiveServerMessage from the connection.
@@ -0,0 +1,48 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+)
+
+// Message types of a [LiveConn], with the values of RFC 6455.
+const (
+	LiveTextMessage   = 1
+	LiveBinaryMessage = 2
+)
+
+// LiveConn is a WebSocket connection used by a Live [Session]. A
+// *websocket.Conn of github.com/gorilla/websocket implements it.
+type LiveConn interface {
+	// ReadMessage blocks until a message is received and returns its type
+	// and data.
+	ReadMessage() (messageType int, data []byte, err error)
+	// WriteMessage sends a message. It's never called concurrently.
+	WriteMessage(messageType int, data []byte) error
+	// Close closes the connection.
+	Close() error
+}
+
+// LiveDialer opens the WebSocket connection of a Live [Session]. header holds
+// the authentication and custom headers of the request.
+//
+// The default dialer uses github.com/gorilla/websocket, except with
+// GOOS=js GOARCH=wasm where it uses the WebSocket API of the browser. Since
+// browsers don't allow setting headers on WebSocket requests, the browser
+// dialer sends API keys and ephemeral tokens as query parameters and ignores
+// other headers. Use ephemeral tokens rather than API keys in web apps.
+type LiveDialer func(ctx context.Context, url string, header http.Header) (LiveConn, error)
@@ -0,0 +1,190 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build js && wasm
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall/js"
+)
+
+// browserConn is a LiveConn backed by the WebSocket API of the browser.
+type browserConn struct {
+	ws        js.Value
+	listeners map[string]js.Func
+
+	mu       sync.Mutex
+	messages []browserMessage
+	// received is signaled when a message is appended to messages.
+	received chan struct{}
+
+	closed    chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+type browserMessage struct {
+	messageType int
+	data        []byte
+}
+
+func defaultLiveDialer(ctx context.Context, rawURL string, header http.Header) (LiveConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	// Browsers can't set headers on WebSocket requests, so credentials are
+	// sent as query parameters.
+	q := u.Query()
+	if key := header.Get("x-goog-api-key"); key != "" {
+		q.Set("key", key)
+	}
+	if auth := header.Get("Authorization"); auth != "" {
+		token, ok := strings.CutPrefix(auth, "Token ")
+		if !ok {
+			return nil, fmt.Errorf("the browser WebSocket API doesn't support the Authorization header, use an API key or an ephemeral token")
+		}
+		q.Set("access_token", token)
+	}
+	u.RawQuery = q.Encode()
+
+	c := &browserConn{
+		ws:        js.Global().Get("WebSocket").New(u.String()),
+		listeners: map[string]js.Func{},
+		received:  make(chan struct{}, 1),
+		closed:    make(chan struct{}),
+	}
+	c.ws.Set("binaryType", "arraybuffer")
+	opened := make(chan struct{})
+	c.on("open", func(js.Value) { close(opened) })
+	c.on("message", func(event js.Value) {
+		data := event.Get("data")
+		if data.Type() == js.TypeString {
+			c.push(browserMessage{LiveTextMessage, []byte(data.String())})
+			return
+		}
+		b := make([]byte, data.Get("byteLength").Int())
+		js.CopyBytesToGo(b, js.Global().Get("Uint8Array").New(data))
+		c.push(browserMessage{LiveBinaryMessage, b})
+	})
+	c.on("error", func(js.Value) { c.shutdown(errors.New("websocket error")) })
+	c.on("close", func(event js.Value) {
+		c.shutdown(fmt.Errorf("websocket closed: %d %s", event.Get("code").Int(), event.Get("reason").String()))
+	})
+
+	select {
+	case <-opened:
+		return c, nil
+	case <-c.closed:
+		c.Close()
+		return nil, c.err
+	case <-ctx.Done():
+		c.Close()
+		return nil, ctx.Err()
+	}
+}
+
+// on registers a handler for a WebSocket event. Handlers run on the
+// JavaScript event loop and must not block.
+func (c *browserConn) on(event string, handler func(js.Value)) {
+	f := js.FuncOf(func(this js.Value, args []js.Value) any {
+		handler(args[0])
+		return nil
+	})
+	c.listeners[event] = f
+	c.ws.Call("addEventListener", event, f)
+}
+
+// push queues a received message without blocking.
+func (c *browserConn) push(m browserMessage) {
+	c.mu.Lock()
+	c.messages = append(c.messages, m)
+	c.mu.Unlock()
+	select {
+	case c.received <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the oldest queued message, if any.
+func (c *browserConn) pop() (browserMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.messages) == 0 {
+		return browserMessage{}, false
+	}
+	m := c.messages[0]
+	c.messages = c.messages[1:]
+	return m, true
+}
+
+func (c *browserConn) shutdown(err error) {
+	c.closeOnce.Do(func() {
+		c.err = err
+		close(c.closed)
+	})
+}
+
+func (c *browserConn) ReadMessage() (int, []byte, error) {
+	for {
+		// Messages received before the connection was closed are delivered
+		// first.
+		if m, ok := c.pop(); ok {
+			return m.messageType, m.data, nil
+		}
+		select {
+		case <-c.received:
+		case <-c.closed:
+			if m, ok := c.pop(); ok {
+				return m.messageType, m.data, nil
+			}
+			return 0, nil, c.err
+		}
+	}
+}
+
+func (c *browserConn) WriteMessage(messageType int, data []byte) error {
+	select {
+	case <-c.closed:
+		return c.err
+	default:
+	}
+	if messageType == LiveTextMessage {
+		c.ws.Call("send", string(data))
+		return nil
+	}
+	b := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(b, data)
+	c.ws.Call("send", b)
+	return nil
+}
+
+func (c *browserConn) Close() error {
+	c.shutdown(errors.New("websocket closed"))
+	for event, f := range c.listeners {
+		c.ws.Call("removeEventListener", event, f)
+		f.Release()
+	}
+	c.listeners = nil
+	c.ws.Call("close")
+	return nil
+}
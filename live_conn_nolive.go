@@ -0,0 +1,31 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !(js && wasm) && genai_nolive
+
+// With the genai_nolive build tag, the SDK has no default WebSocket
+// implementation, which removes github.com/gorilla/websocket from the binary.
+// Live sessions still work with ClientConfig.LiveDialer.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+func defaultLiveDialer(ctx context.Context, url string, header http.Header) (LiveConn, error) {
+	return nil, errors.New("the Live API has no default WebSocket dialer in builds with the genai_nolive tag, set ClientConfig.LiveDialer")
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import "time"
+
+// LiveEventType is the type of a [LiveEvent].
+type LiveEventType string
+
+const (
+	// The server detected that the user started speaking. When the model is
+	// speaking, this is the earliest sign of a barge-in.
+	LiveEventTypeUserSpeechStarted LiveEventType = "USER_SPEECH_STARTED"
+	// The server detected that the user stopped speaking.
+	LiveEventTypeUserSpeechEnded LiveEventType = "USER_SPEECH_ENDED"
+	// The model's generation was interrupted, usually because the user barged
+	// in. Audio of the interrupted turn that is still buffered for playback
+	// should be discarded.
+	LiveEventTypeInterrupted LiveEventType = "INTERRUPTED"
+	// Pending tool calls were cancelled. Their results should not be sent.
+	LiveEventTypeToolCallCancelled LiveEventType = "TOOL_CALL_CANCELLED"
+	// The model finished generating its turn. Playback of buffered audio may
+	// still be in progress.
+	LiveEventTypeGenerationComplete LiveEventType = "GENERATION_COMPLETE"
+	// The model's turn is complete.
+	LiveEventTypeTurnComplete LiveEventType = "TURN_COMPLETE"
+	// The server will close the connection soon.
+	LiveEventTypeGoAway LiveEventType = "GO_AWAY"
+)
+
+// LiveEvent is a control event carried by a [LiveServerMessage], such as an
+// interruption of the model by the user.
+type LiveEvent struct {
+	// Type is the type of the event.
+	Type LiveEventType
+	// AudioOffset is the position of a user speech event in the input audio
+	// stream, if the server reported it.
+	AudioOffset time.Duration
+	// ToolCallIDs are the IDs of the cancelled tool calls of a
+	// LiveEventTypeToolCallCancelled event.
+	ToolCallIDs []string
+	// TurnCompleteReason is the reason of a LiveEventTypeTurnComplete event,
+	// if any.
+	TurnCompleteReason TurnCompleteReason
+	// TimeLeft is the time until the connection is closed for a
+	// LiveEventTypeGoAway event.
+	TimeLeft time.Duration
+}
+
+// Events returns the control events carried by the message, in the order they
+// should be handled. Interruptions come first so that playback can be stopped
+// before any other processing.
+//
+//	for {
+//		msg, err := session.Receive()
+//		if err != nil {
+//			return err
+//		}
+//		if msg.Interrupted() {
+//			player.Flush()
+//		}
+//		// Handle the content of msg.
+//	}
+func (m *LiveServerMessage) Events() []*LiveEvent {
+	if m == nil {
+		return nil
+	}
+	var events []*LiveEvent
+	if m.ServerContent != nil && m.ServerContent.Interrupted {
+		events = append(events, &LiveEvent{Type: LiveEventTypeInterrupted})
+	}
+	if m.VoiceActivity != nil {
+		switch m.VoiceActivity.VoiceActivityType {
+		case VoiceActivityTypeActivityStart:
+			events = append(events, &LiveEvent{Type: LiveEventTypeUserSpeechStarted, AudioOffset: m.VoiceActivity.AudioOffset})
+		case VoiceActivityTypeActivityEnd:
+			events = append(events, &LiveEvent{Type: LiveEventTypeUserSpeechEnded, AudioOffset: m.VoiceActivity.AudioOffset})
+		}
+	}
+	if m.VoiceActivityDetectionSignal != nil {
+		switch m.VoiceActivityDetectionSignal.VADSignalType {
+		case VADSignalTypeSos:
+			events = append(events, &LiveEvent{Type: LiveEventTypeUserSpeechStarted})
+		case VADSignalTypeEos:
+			events = append(events, &LiveEvent{Type: LiveEventTypeUserSpeechEnded})
+		}
+	}
+	if m.ToolCallCancellation != nil {
+		events = append(events, &LiveEvent{Type: LiveEventTypeToolCallCancelled, ToolCallIDs: m.ToolCallCancellation.IDs})
+	}
+	if m.ServerContent != nil && m.ServerContent.GenerationComplete {
+		events = append(events, &LiveEvent{Type: LiveEventTypeGenerationComplete})
+	}
+	if m.ServerContent != nil && m.ServerContent.TurnComplete {
+		events = append(events, &LiveEvent{Type: LiveEventTypeTurnComplete, TurnCompleteReason: m.ServerContent.TurnCompleteReason})
+	}
+	if m.GoAway != nil {
+		events = append(events, &LiveEvent{Type: LiveEventTypeGoAway, TimeLeft: m.GoAway.TimeLeft})
+	}
+	return events
+}
+
+// Interrupted reports whether the message tells that the model's generation
+// was interrupted, in which case audio playback should stop immediately.
+func (m *LiveServerMessage) Interrupted() bool {
+	return m != nil && m.ServerContent != nil && m.ServerContent.Interrupted
+}
@@ -16,10 +16,15 @@ package genai
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/auth"
 	"github.com/google/go-cmp/cmp"
@@ -596,3 +601,181 @@ func setupTestWebsocketServer(t *testing.T, wantRequestBodySlice []string, fakeR
 
 	return ts
 }
+
+func TestLiveServerMessageEvents(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  *LiveServerMessage
+		want []*LiveEvent
+	}{
+		{
+			name: "Nil",
+		},
+		{
+			name: "BargeIn",
+			msg: &LiveServerMessage{
+				ServerContent: &LiveServerContent{Interrupted: true},
+				VoiceActivity: &VoiceActivity{VoiceActivityType: VoiceActivityTypeActivityStart, AudioOffset: time.Second},
+			},
+			want: []*LiveEvent{
+				{Type: LiveEventTypeInterrupted},
+				{Type: LiveEventTypeUserSpeechStarted, AudioOffset: time.Second},
+			},
+		},
+		{
+			name: "TurnEnd",
+			msg: &LiveServerMessage{
+				ServerContent:        &LiveServerContent{GenerationComplete: true, TurnComplete: true, TurnCompleteReason: TurnCompleteReasonNeedMoreInput},
+				ToolCallCancellation: &LiveServerToolCallCancellation{IDs: []string{"a"}},
+				GoAway:               &LiveServerGoAway{TimeLeft: time.Minute},
+			},
+			want: []*LiveEvent{
+				{Type: LiveEventTypeToolCallCancelled, ToolCallIDs: []string{"a"}},
+				{Type: LiveEventTypeGenerationComplete},
+				{Type: LiveEventTypeTurnComplete, TurnCompleteReason: TurnCompleteReasonNeedMoreInput},
+				{Type: LiveEventTypeGoAway, TimeLeft: time.Minute},
+			},
+		},
+		{
+			name: "VADSignal",
+			msg:  &LiveServerMessage{VoiceActivityDetectionSignal: &VoiceActivityDetectionSignal{VADSignalType: VADSignalTypeEos}},
+			want: []*LiveEvent{{Type: LiveEventTypeUserSpeechEnded}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.msg.Events()); diff != "" {
+				t.Errorf("Events() mismatch (-want +got):\n%s", diff)
+			}
+			wantInterrupted := len(tt.want) > 0 && tt.want[0].Type == LiveEventTypeInterrupted
+			if got := tt.msg.Interrupted(); got != wantInterrupted {
+				t.Errorf("Interrupted() = %v, want %v", got, wantInterrupted)
+			}
+		})
+	}
+}
+
+type fakeLiveConn struct {
+	written  [][]byte
+	received []string
+	// writing counts the calls to WriteMessage in progress, and overlapped
+	// records whether two of them ever ran at the same time.
+	writing    atomic.Int32
+	overlapped atomic.Bool
+}
+
+func (c *fakeLiveConn) ReadMessage() (int, []byte, error) {
+	if len(c.received) == 0 {
+		return 0, nil, io.EOF
+	}
+	msg := c.received[0]
+	c.received = c.received[1:]
+	return LiveTextMessage, []byte(msg), nil
+}
+
+func (c *fakeLiveConn) WriteMessage(messageType int, data []byte) error {
+	if c.writing.Add(1) > 1 {
+		c.overlapped.Store(true)
+	}
+	defer c.writing.Add(-1)
+	runtime.Gosched()
+	c.written = append(c.written, data)
+	return nil
+}
+
+func (c *fakeLiveConn) Close() error { return nil }
+
+func TestLiveConnectDialer(t *testing.T) {
+	ctx := context.Background()
+	conn := &fakeLiveConn{received: []string{`{"setupComplete":{}}`, `{"serverContent":{"turnComplete":true}}`}}
+	var gotURL string
+	var gotHeader http.Header
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		APIKey:      "auth_tokens/123",
+		HTTPOptions: HTTPOptions{APIVersion: "v1alpha"},
+		LiveDialer: func(ctx context.Context, url string, header http.Header) (LiveConn, error) {
+			gotURL, gotHeader = url, header
+			return conn, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := client.Live.Connect(ctx, "test-model", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	if want := "wss://generativelanguage.googleapis.com/ws/google.ai.generativelanguage.v1alpha.GenerativeService.BidiGenerateContentConstrained"; gotURL != want {
+		t.Errorf("dialed URL = %q, want %q", gotURL, want)
+	}
+	if got, want := gotHeader.Get("Authorization"), "Token auth_tokens/123"; got != want {
+		t.Errorf("Authorization header = %q, want %q", got, want)
+	}
+	if len(conn.written) != 1 || !strings.Contains(string(conn.written[0]), `"models/test-model"`) {
+		t.Errorf("setup message = %q, want the setup of models/test-model", conn.written)
+	}
+	// The first message is the buffered setup message.
+	if _, err := session.Receive(); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := session.Receive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.ServerContent == nil || !msg.ServerContent.TurnComplete {
+		t.Errorf("Receive() = %+v, want a turn complete message", msg)
+	}
+}
+
+func TestLiveSessionConcurrentSends(t *testing.T) {
+	ctx := context.Background()
+	conn := &fakeLiveConn{received: []string{`{"setupComplete":{}}`}}
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend: BackendGeminiAPI,
+		APIKey:  "test-api-key",
+		LiveDialer: func(ctx context.Context, url string, header http.Header) (LiveConn, error) {
+			return conn, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := client.Live.Connect(ctx, "test-model", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	const n = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*n)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range n {
+			errs <- session.SendRealtimeInput(LiveRealtimeInput{Audio: &Blob{Data: []byte{0, 1}, MIMEType: "audio/pcm;rate=16000"}})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range n {
+			errs <- session.SendToolResponse(LiveToolResponseInput{FunctionResponses: []*FunctionResponse{{ID: "1", Name: "f", Response: map[string]any{"ok": true}}}})
+		}
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if conn.overlapped.Load() {
+		t.Error("WriteMessage was called concurrently")
+	}
+	if got, want := len(conn.written), 1+2*n; got != want {
+		t.Errorf("got %d messages written, want %d", got, want)
+	}
+}
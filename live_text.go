@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Preview. LiveTextChat is a half-duplex text chat over a Live [Session]: a
+// message is sent, then the reply of the model is read, and so on. It takes
+// care of the turns of the session, so that it can be used like a [Chat]
+// while keeping the low latency and the session features of the Live API,
+// such as session resumption and context window compression.
+//
+// Tool calls are run with the [ToolExecutor] given to [NewLiveTextChat], if
+// any, while the reply is read. A LiveTextChat must not be used concurrently.
+//
+//	chat, err := client.Live.ConnectText(ctx, model, config, nil)
+//	if err != nil {
+//		return err
+//	}
+//	defer chat.Close()
+//	reply, err := chat.Ask(ctx, "Hello!")
+type LiveTextChat struct {
+	session  *Session
+	executor *ToolExecutor
+	// pending is true when a message was sent and its reply wasn't read.
+	pending bool
+}
+
+// Preview. NewLiveTextChat returns a text chat over session, which must have
+// been connected with the TEXT response modality. executor runs the tool calls
+// of the model and may be nil if the session has no function tools.
+func NewLiveTextChat(session *Session, executor *ToolExecutor) *LiveTextChat {
+	return &LiveTextChat{session: session, executor: executor}
+}
+
+// Preview. ConnectText connects to model like [Live.Connect], with the TEXT
+// response modality, and returns a text chat over the session. config isn't
+// modified.
+func (r *Live) ConnectText(ctx context.Context, model string, config *LiveConnectConfig, executor *ToolExecutor) (*LiveTextChat, error) {
+	cfg := LiveConnectConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	cfg.ResponseModalities = []Modality{ModalityText}
+	session, err := r.Connect(ctx, model, &cfg)
+	if err != nil {
+		return nil, err
+	}
+	return NewLiveTextChat(session, executor), nil
+}
+
+// Session returns the underlying session, for example to read its
+// [Session.SetupComplete] message.
+func (c *LiveTextChat) Session() *Session {
+	return c.session
+}
+
+// SendText sends text as a complete turn of the user. The reply must be read
+// with [LiveTextChat.ReadText] or [LiveTextChat.ReadTextStream] before the
+// next message is sent.
+func (c *LiveTextChat) SendText(text string) error {
+	if c.pending {
+		return fmt.Errorf("SendText: the reply to the previous message wasn't read")
+	}
+	turnComplete := true
+	err := c.session.SendClientContent(LiveClientContentInput{
+		Turns:        []*Content{NewContentFromText(text, RoleUser)},
+		TurnComplete: &turnComplete,
+	})
+	if err != nil {
+		return fmt.Errorf("SendText: %w", err)
+	}
+	c.pending = true
+	return nil
+}
+
+// ReadText reads the reply of the model to the last message sent and returns
+// its text once the turn of the model is complete. Thoughts are left out. ctx
+// is used to run tool calls; to stop waiting for the reply, close the chat.
+func (c *LiveTextChat) ReadText(ctx context.Context) (string, error) {
+	var b strings.Builder
+	for text, err := range c.ReadTextStream(ctx) {
+		if err != nil {
+			return b.String(), err
+		}
+		b.WriteString(text)
+	}
+	return b.String(), nil
+}
+
+// ReadTextStream returns an iterator over the text of the reply of the model
+// to the last message sent, as it's generated. It ends once the turn of the
+// model is complete or interrupted. Stopping the iteration early leaves the
+// rest of the reply unread, which fails the next message.
+func (c *LiveTextChat) ReadTextStream(ctx context.Context) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		if !c.pending {
+			yield("", errors.New("ReadTextStream: no message was sent"))
+			return
+		}
+		for {
+			msg, err := c.session.Receive()
+			if err != nil {
+				yield("", fmt.Errorf("ReadTextStream: %w", err))
+				return
+			}
+			if msg.ToolCall != nil && len(msg.ToolCall.FunctionCalls) > 0 {
+				if c.executor == nil {
+					yield("", fmt.Errorf("ReadTextStream: the model called function %q but the chat has no tool executor", msg.ToolCall.FunctionCalls[0].Name))
+					return
+				}
+				if err := c.session.ExecuteToolCall(ctx, c.executor, msg.ToolCall); err != nil {
+					yield("", fmt.Errorf("ReadTextStream: %w", err))
+					return
+				}
+				continue
+			}
+			content := msg.ServerContent
+			if content == nil {
+				continue
+			}
+			if content.ModelTurn != nil {
+				var b strings.Builder
+				for _, p := range content.ModelTurn.Parts {
+					if p != nil && !p.Thought {
+						b.WriteString(p.Text)
+					}
+				}
+				if b.Len() > 0 && !yield(b.String(), nil) {
+					return
+				}
+			}
+			if content.TurnComplete || content.Interrupted {
+				c.pending = false
+				return
+			}
+		}
+	}
+}
+
+// Ask sends text and returns the text of the reply of the model.
+func (c *LiveTextChat) Ask(ctx context.Context, text string) (string, error) {
+	if err := c.SendText(text); err != nil {
+		return "", err
+	}
+	return c.ReadText(ctx)
+}
+
+// Close closes the underlying session.
+func (c *LiveTextChat) Close() error {
+	return c.session.Close()
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLiveTextChat(t *testing.T) {
+	ctx := context.Background()
+	conn := &fakeLiveConn{received: []string{
+		`{"setupComplete":{}}`,
+		`{"serverContent":{"modelTurn":{"parts":[{"text":"Let me check.","thought":true},{"text":"It's "}]}}}`,
+		`{"toolCall":{"functionCalls":[{"id":"1","name":"weather","args":{"city":"Paris"}}]}}`,
+		`{"serverContent":{"modelTurn":{"parts":[{"text":"sunny."}]}}}`,
+		`{"serverContent":{"turnComplete":true}}`,
+		`{"serverContent":{"modelTurn":{"parts":[{"text":"Bye"}]},"turnComplete":true}}`,
+	}}
+	client, err := NewClient(ctx, &ClientConfig{
+		Backend: BackendGeminiAPI,
+		APIKey:  "key",
+		LiveDialer: func(ctx context.Context, url string, header http.Header) (LiveConn, error) {
+			return conn, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	weather, err := NewCallableFunction(func(args struct {
+		City string `json:"city"`
+	}) (string, error) {
+		return "sunny in " + args.City, nil
+	}, "weather", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	executor, err := NewToolExecutor(weather)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &LiveConnectConfig{ResponseModalities: []Modality{ModalityAudio}}
+	chat, err := client.Live.ConnectText(ctx, "test-model", config, executor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer chat.Close()
+	if !strings.Contains(string(conn.written[0]), `"responseModalities":["TEXT"]`) {
+		t.Errorf("setup message = %s, want the TEXT response modality", conn.written[0])
+	}
+	if config.ResponseModalities[0] != ModalityAudio {
+		t.Errorf("ConnectText() modified the config")
+	}
+
+	if _, err := chat.ReadText(ctx); err == nil {
+		t.Error("ReadText() before SendText() succeeded, want error")
+	}
+	if err := chat.SendText("What's the weather in Paris?"); err != nil {
+		t.Fatal(err)
+	}
+	if err := chat.SendText("Hello?"); err == nil {
+		t.Error("SendText() before reading the reply succeeded, want error")
+	}
+	reply, err := chat.ReadText(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "It's sunny."; reply != want {
+		t.Errorf("ReadText() = %q, want %q", reply, want)
+	}
+	if len(conn.written) != 3 || !strings.Contains(string(conn.written[2]), "sunny in Paris") {
+		t.Errorf("messages sent = %q, want the message and the tool response", conn.written[1:])
+	}
+
+	reply, err = chat.Ask(ctx, "Thanks!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != "Bye" {
+		t.Errorf("Ask() = %q, want %q", reply, "Bye")
+	}
+
+	if _, err := chat.Ask(ctx, "Still there?"); err == nil {
+		t.Error("Ask() on a closed connection succeeded, want error")
+	}
+}
+
+func TestLiveTextChatNoExecutor(t *testing.T) {
+	conn := &fakeLiveConn{received: []string{`{"toolCall":{"functionCalls":[{"id":"1","name":"weather"}]}}`}}
+	chat := NewLiveTextChat(&Session{conn: conn, apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI}}}, nil)
+	if _, err := chat.Ask(context.Background(), "Weather?"); err == nil || !strings.Contains(err.Error(), "weather") {
+		t.Errorf("Ask() error = %v, want error about the weather function", err)
+	}
+}
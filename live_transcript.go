@@ -0,0 +1,189 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"strings"
+	"time"
+)
+
+// TranscriptSource tells whether a [TranscriptSentence] transcribes the audio
+// of the user or of the model.
+type TranscriptSource string
+
+const (
+	// The sentence transcribes the input audio, spoken by the user.
+	TranscriptSourceInput TranscriptSource = "INPUT"
+	// The sentence transcribes the output audio, spoken by the model.
+	TranscriptSourceOutput TranscriptSource = "OUTPUT"
+)
+
+// TranscriptSentence is a finalized sentence of a Live transcription.
+type TranscriptSentence struct {
+	// Source tells who spoke the sentence.
+	Source TranscriptSource
+	// Text is the text of the sentence, without leading and trailing spaces.
+	Text string
+	// LanguageCode is the BCP-47 language code of the sentence, if the server
+	// reported it.
+	LanguageCode string
+	// Start is when the first fragment of the sentence was received.
+	Start time.Time
+	// End is when the fragment that completed the sentence was received.
+	End time.Time
+}
+
+// TranscriptAccumulator assembles the transcription fragments of
+// [LiveServerMessage]s into finalized sentences, for example to display
+// captions or to store the transcript of a session. Enable transcriptions with
+// [LiveConnectConfig.InputAudioTranscription] and
+// [LiveConnectConfig.OutputAudioTranscription]. The zero value is ready to use.
+//
+// A sentence is final once it's followed by another one, or once its
+// transcription is finished, the model's turn is complete or the model is
+// interrupted. Fragments that repeat the text received so far, as sent when a
+// transcription is finished, aren't added twice. Interim input transcriptions
+// are ignored since they're revised as the user speaks.
+//
+//	var transcript genai.TranscriptAccumulator
+//	for {
+//		msg, err := session.Receive()
+//		if err != nil {
+//			return err
+//		}
+//		for _, s := range transcript.Add(msg) {
+//			fmt.Printf("%s: %s\n", s.Source, s.Text)
+//		}
+//	}
+type TranscriptAccumulator struct {
+	input  transcriptBuffer
+	output transcriptBuffer
+	// now returns the current time. It's replaced in tests.
+	now func() time.Time
+}
+
+// transcriptBuffer holds the transcription of one source since it was last
+// finished.
+type transcriptBuffer struct {
+	// text is the text received since the transcription was last finished,
+	// and committed the length of its prefix that was returned as sentences.
+	text      string
+	committed int
+	// start is when the first fragment of the pending sentence was received.
+	start        time.Time
+	languageCode string
+}
+
+// Add adds the transcription fragments of msg and returns the sentences they
+// finalized, input sentences first.
+func (a *TranscriptAccumulator) Add(msg *LiveServerMessage) []*TranscriptSentence {
+	if msg == nil || msg.ServerContent == nil {
+		return nil
+	}
+	now := time.Now
+	if a.now != nil {
+		now = a.now
+	}
+	t := now()
+	sc := msg.ServerContent
+	var sentences []*TranscriptSentence
+	if tr := sc.InputTranscription; tr != nil {
+		sentences = append(sentences, a.input.add(TranscriptSourceInput, tr, t)...)
+	}
+	if tr := sc.OutputTranscription; tr != nil {
+		sentences = append(sentences, a.output.add(TranscriptSourceOutput, tr, t)...)
+	}
+	if sc.TurnComplete {
+		sentences = append(sentences, a.input.finish(TranscriptSourceInput, t)...)
+	}
+	if sc.TurnComplete || sc.Interrupted {
+		sentences = append(sentences, a.output.finish(TranscriptSourceOutput, t)...)
+	}
+	return sentences
+}
+
+// Flush returns the pending text of both sources as final sentences, for
+// example when the session is closed.
+func (a *TranscriptAccumulator) Flush() []*TranscriptSentence {
+	now := time.Now
+	if a.now != nil {
+		now = a.now
+	}
+	t := now()
+	return append(a.input.finish(TranscriptSourceInput, t), a.output.finish(TranscriptSourceOutput, t)...)
+}
+
+// Pending returns the text of source that isn't part of a final sentence yet,
+// for example to display it as a provisional caption.
+func (a *TranscriptAccumulator) Pending(source TranscriptSource) string {
+	b := &a.input
+	if source == TranscriptSourceOutput {
+		b = &a.output
+	}
+	return strings.TrimSpace(b.text[b.committed:])
+}
+
+func (b *transcriptBuffer) add(source TranscriptSource, tr *Transcription, t time.Time) []*TranscriptSentence {
+	if tr.LanguageCode != "" {
+		b.languageCode = tr.LanguageCode
+	}
+	if text := tr.Text; text != "" {
+		switch {
+		case b.text != "" && strings.HasPrefix(text, b.text):
+			// The fragment repeats the text received so far.
+			text = text[len(b.text):]
+		case b.text != "" && strings.TrimSpace(text) == strings.TrimSpace(b.text):
+			text = ""
+		}
+		if text != "" && strings.TrimSpace(b.text[b.committed:]) == "" {
+			b.start = t
+		}
+		b.text += text
+	}
+	var sentences []*TranscriptSentence
+	for {
+		end := sentenceEnd(b.text[b.committed:])
+		if end < 0 {
+			break
+		}
+		if s := b.sentence(source, b.text[b.committed:b.committed+end], t); s != nil {
+			sentences = append(sentences, s)
+		}
+		b.committed += end
+		b.start = t
+	}
+	if tr.Finished {
+		sentences = append(sentences, b.finish(source, t)...)
+	}
+	return sentences
+}
+
+// finish returns the pending text as a final sentence and resets b.
+func (b *transcriptBuffer) finish(source TranscriptSource, t time.Time) []*TranscriptSentence {
+	s := b.sentence(source, b.text[b.committed:], t)
+	*b = transcriptBuffer{}
+	if s == nil {
+		return nil
+	}
+	return []*TranscriptSentence{s}
+}
+
+func (b *transcriptBuffer) sentence(source TranscriptSource, text string, t time.Time) *TranscriptSentence {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	return &TranscriptSentence{Source: source, Text: text, LanguageCode: b.languageCode, Start: b.start, End: t}
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTranscriptAccumulator(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return t0.Add(time.Duration(s) * time.Second) }
+	input := func(text string, finished bool) *LiveServerMessage {
+		return &LiveServerMessage{ServerContent: &LiveServerContent{InputTranscription: &Transcription{Text: text, Finished: finished, LanguageCode: "en-US"}}}
+	}
+	output := func(text string) *LiveServerMessage {
+		return &LiveServerMessage{ServerContent: &LiveServerContent{OutputTranscription: &Transcription{Text: text}}}
+	}
+
+	tests := []struct {
+		name     string
+		messages []*LiveServerMessage
+		want     [][]*TranscriptSentence
+	}{
+		{
+			name:     "Deltas",
+			messages: []*LiveServerMessage{input("Hello", false), input(" there. How", false), input(" are you?", false), input("", true)},
+			want: [][]*TranscriptSentence{
+				nil,
+				{{Source: TranscriptSourceInput, Text: "Hello there.", LanguageCode: "en-US", Start: at(0), End: at(1)}},
+				nil,
+				{{Source: TranscriptSourceInput, Text: "How are you?", LanguageCode: "en-US", Start: at(1), End: at(3)}},
+			},
+		},
+		{
+			name:     "FinishedRepeatsText",
+			messages: []*LiveServerMessage{input("One. Two", false), input("One. Two", true)},
+			want: [][]*TranscriptSentence{
+				{{Source: TranscriptSourceInput, Text: "One.", LanguageCode: "en-US", Start: at(0), End: at(0)}},
+				{{Source: TranscriptSourceInput, Text: "Two", LanguageCode: "en-US", Start: at(0), End: at(1)}},
+			},
+		},
+		{
+			name:     "CumulativeFragments",
+			messages: []*LiveServerMessage{input("Good", false), input("Good morning", false), input("Good morning.", true)},
+			want: [][]*TranscriptSentence{
+				nil,
+				nil,
+				{{Source: TranscriptSourceInput, Text: "Good morning.", LanguageCode: "en-US", Start: at(0), End: at(2)}},
+			},
+		},
+		{
+			name: "TurnComplete",
+			messages: []*LiveServerMessage{
+				output("Sure thing"),
+				{ServerContent: &LiveServerContent{OutputTranscription: &Transcription{Text: "!"}, TurnComplete: true}},
+				output("Next turn"),
+			},
+			want: [][]*TranscriptSentence{
+				nil,
+				{{Source: TranscriptSourceOutput, Text: "Sure thing!", Start: at(0), End: at(1)}},
+				nil,
+			},
+		},
+		{
+			name: "Interrupted",
+			messages: []*LiveServerMessage{
+				input("Wait", false),
+				output("Let me explain"),
+				{ServerContent: &LiveServerContent{Interrupted: true}},
+			},
+			want: [][]*TranscriptSentence{
+				nil,
+				nil,
+				{{Source: TranscriptSourceOutput, Text: "Let me explain", Start: at(1), End: at(2)}},
+			},
+		},
+		{
+			name: "InterimIgnored",
+			messages: []*LiveServerMessage{
+				{ServerContent: &LiveServerContent{InterimInputTranscription: &Transcription{Text: "Hel. "}}},
+				{SetupComplete: &LiveServerSetupComplete{}},
+			},
+			want: [][]*TranscriptSentence{nil, nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := 0
+			a := &TranscriptAccumulator{now: func() time.Time { return at(i) }}
+			var got [][]*TranscriptSentence
+			for _, msg := range tt.messages {
+				got = append(got, a.Add(msg))
+				i++
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Add() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTranscriptAccumulatorPendingAndFlush(t *testing.T) {
+	var a TranscriptAccumulator
+	a.Add(&LiveServerMessage{ServerContent: &LiveServerContent{
+		InputTranscription:  &Transcription{Text: " What time is it? I"},
+		OutputTranscription: &Transcription{Text: " It's noon"},
+	}})
+	if got, want := a.Pending(TranscriptSourceInput), "I"; got != want {
+		t.Errorf("Pending(input) = %q, want %q", got, want)
+	}
+	if got, want := a.Pending(TranscriptSourceOutput), "It's noon"; got != want {
+		t.Errorf("Pending(output) = %q, want %q", got, want)
+	}
+	var texts []string
+	for _, s := range a.Flush() {
+		texts = append(texts, string(s.Source)+": "+s.Text)
+	}
+	if diff := cmp.Diff([]string{"INPUT: I", "OUTPUT: It's noon"}, texts); diff != "" {
+		t.Errorf("Flush() mismatch (-want +got):\n%s", diff)
+	}
+	if got := a.Flush(); got != nil {
+		t.Errorf("second Flush() = %v, want nil", got)
+	}
+}
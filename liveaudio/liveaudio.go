@@ -0,0 +1,199 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package liveaudio connects a microphone and a speaker to a Live API
+// session. It doesn't depend on an audio library: any device that reads and
+// writes raw PCM audio, such as a PortAudio or oto stream, can be plugged in.
+// Video frames, such as camera snapshots, can be sent alongside the audio with
+// [StreamVideo].
+//
+// A minimal voice conversation looks like this:
+//
+//	session, err := client.Live.Connect(ctx, "gemini-live-2.5-flash-preview", &genai.LiveConnectConfig{
+//		ResponseModalities: []genai.Modality{genai.ModalityAudio},
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer session.Close()
+//	// mic reads 16 kHz PCM, speaker plays 24 kHz PCM.
+//	if err := liveaudio.Stream(ctx, session, mic, speaker, nil); err != nil {
+//		log.Fatal(err)
+//	}
+package liveaudio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"google.golang.org/genai"
+)
+
+const (
+	// InputSampleRate is the sample rate of the audio sent to the model.
+	InputSampleRate = 16000
+	// OutputSampleRate is the sample rate of the audio returned by the model.
+	OutputSampleRate = 24000
+	// InputMIMEType is the MIME type of the audio sent to the model: 16-bit
+	// little-endian mono PCM at InputSampleRate.
+	InputMIMEType = "audio/pcm;rate=16000"
+
+	// 100 ms of input audio.
+	defaultChunkSize = InputSampleRate * 2 / 10
+)
+
+// Session is the part of [genai.Session] used by [Stream].
+type Session interface {
+	Sender
+	Receive() (*genai.LiveServerMessage, error)
+}
+
+// Speaker plays 16-bit little-endian mono PCM audio at OutputSampleRate.
+type Speaker interface {
+	// Write queues audio for playback. It should not block until the audio
+	// has been played.
+	io.Writer
+	// Flush discards queued audio that hasn't been played yet. It's called
+	// when the user interrupts the model.
+	Flush() error
+}
+
+// Config configures [Stream].
+type Config struct {
+	// Optional. Number of bytes of microphone audio sent per message. Defaults
+	// to 3200, which is 100 ms of audio.
+	ChunkSize int
+	// Optional. Called for every message received from the session, after its
+	// audio has been queued on the speaker. Use it to handle transcriptions or
+	// tool calls. If it returns an error, Stream stops and returns the error.
+	OnMessage func(msg *genai.LiveServerMessage) error
+	// Optional. Video sent to the model alongside the audio, see
+	// [StreamVideo].
+	Video FrameSource
+	// Optional. Configures how Video is sent.
+	VideoConfig *VideoConfig
+}
+
+// Stream sends audio from mic to the session and plays the audio of the model
+// on speaker. mic must provide 16-bit little-endian mono PCM audio at
+// InputSampleRate, as produced by most microphone APIs when configured for
+// it. When the model is interrupted, audio queued on the speaker is flushed so
+// that playback stops immediately.
+//
+// Stream runs until ctx is canceled, the session fails or OnMessage returns an
+// error. When mic reaches io.EOF, the end of the audio stream is signaled to
+// the model and Stream keeps playing its answers. Since [genai.Session.Receive]
+// can't be canceled, close the session after Stream returns.
+func Stream(ctx context.Context, session Session, mic io.Reader, speaker Speaker, config *Config) error {
+	cfg := Config{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.ChunkSize <= 0 {
+		cfg.ChunkSize = defaultChunkSize
+	}
+
+	// Audio and video are sent from separate goroutines.
+	sender := &lockedSender{sender: session}
+	errs := make(chan error, 3)
+	go func() {
+		errs <- sendAudio(ctx, sender, mic, cfg.ChunkSize)
+	}()
+	if cfg.Video != nil {
+		go func() {
+			errs <- StreamVideo(ctx, sender, cfg.Video, cfg.VideoConfig)
+		}()
+	}
+	go func() {
+		errs <- receiveAudio(session, speaker, cfg.OnMessage)
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-errs:
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// lockedSender serializes the sends of a Sender, so that sessions that aren't
+// safe for concurrent use can be shared by the audio and video goroutines.
+type lockedSender struct {
+	mu     sync.Mutex
+	sender Sender
+}
+
+func (s *lockedSender) SendRealtimeInput(input genai.LiveRealtimeInput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sender.SendRealtimeInput(input)
+}
+
+// sendAudio sends mic audio until mic is exhausted. It returns nil at the end
+// of the input.
+func sendAudio(ctx context.Context, session Sender, mic io.Reader, chunkSize int) error {
+	buf := make([]byte, chunkSize)
+	for ctx.Err() == nil {
+		n, err := io.ReadFull(mic, buf)
+		if n > 0 {
+			audio := &genai.Blob{Data: append([]byte{}, buf[:n]...), MIMEType: InputMIMEType}
+			if sendErr := session.SendRealtimeInput(genai.LiveRealtimeInput{Audio: audio}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return session.SendRealtimeInput(genai.LiveRealtimeInput{AudioStreamEnd: true})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// receiveAudio plays the audio of received messages until the session fails.
+func receiveAudio(session Session, speaker Speaker, onMessage func(*genai.LiveServerMessage) error) error {
+	for {
+		msg, err := session.Receive()
+		if err != nil {
+			return err
+		}
+		if msg.Interrupted() {
+			if err := speaker.Flush(); err != nil {
+				return err
+			}
+		}
+		if msg.ServerContent != nil && msg.ServerContent.ModelTurn != nil {
+			for _, part := range msg.ServerContent.ModelTurn.Parts {
+				if part.InlineData == nil || !strings.HasPrefix(part.InlineData.MIMEType, "audio/") {
+					continue
+				}
+				if _, err := speaker.Write(part.InlineData.Data); err != nil {
+					return err
+				}
+			}
+		}
+		if onMessage != nil {
+			if err := onMessage(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package liveaudio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+// fakeSession records sent audio and replays a fixed list of messages. Once
+// all messages are replayed, it fails after the end of the audio stream.
+type fakeSession struct {
+	mu        sync.Mutex
+	sent      []genai.LiveRealtimeInput
+	messages  []*genai.LiveServerMessage
+	streamEnd chan struct{}
+}
+
+func (s *fakeSession) SendRealtimeInput(input genai.LiveRealtimeInput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, input)
+	if input.AudioStreamEnd {
+		close(s.streamEnd)
+	}
+	return nil
+}
+
+var errSessionClosed = errors.New("session closed")
+
+func (s *fakeSession) Receive() (*genai.LiveServerMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.messages) == 0 {
+		s.mu.Unlock()
+		<-s.streamEnd
+		s.mu.Lock()
+		return nil, errSessionClosed
+	}
+	msg := s.messages[0]
+	s.messages = s.messages[1:]
+	return msg, nil
+}
+
+type fakeSpeaker struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (s *fakeSpeaker) Flush() error {
+	s.flushes++
+	s.Reset()
+	return nil
+}
+
+func audioMessage(data string) *genai.LiveServerMessage {
+	return &genai.LiveServerMessage{ServerContent: &genai.LiveServerContent{
+		ModelTurn: &genai.Content{Parts: []*genai.Part{{InlineData: &genai.Blob{Data: []byte(data), MIMEType: "audio/pcm;rate=24000"}}}},
+	}}
+}
+
+func TestStream(t *testing.T) {
+	session := &fakeSession{streamEnd: make(chan struct{}), messages: []*genai.LiveServerMessage{
+		audioMessage("hello"),
+		{ServerContent: &genai.LiveServerContent{Interrupted: true}},
+		audioMessage("ok"),
+		{ServerContent: &genai.LiveServerContent{TurnComplete: true}},
+	}}
+	speaker := &fakeSpeaker{}
+	received := 0
+	err := Stream(context.Background(), session, bytes.NewReader([]byte("abcdefg")), speaker, &Config{
+		ChunkSize: 3,
+		OnMessage: func(msg *genai.LiveServerMessage) error {
+			received++
+			return nil
+		},
+	})
+	if !errors.Is(err, errSessionClosed) {
+		t.Fatalf("Stream() error = %v, want %v", err, errSessionClosed)
+	}
+	if speaker.String() != "ok" || speaker.flushes != 1 {
+		t.Errorf("speaker has %q after %d flushes, want %q after 1", speaker.String(), speaker.flushes, "ok")
+	}
+	if received != 4 {
+		t.Errorf("OnMessage called %d times, want 4", received)
+	}
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	var audio []byte
+	for _, input := range session.sent {
+		if input.Audio != nil {
+			if input.Audio.MIMEType != InputMIMEType {
+				t.Errorf("MIMEType = %q, want %q", input.Audio.MIMEType, InputMIMEType)
+			}
+			audio = append(audio, input.Audio.Data...)
+		}
+	}
+	if string(audio) != "abcdefg" {
+		t.Errorf("sent audio %q, want %q", audio, "abcdefg")
+	}
+	if len(session.sent) != 4 || !session.sent[3].AudioStreamEnd {
+		t.Errorf("got %d inputs, want 3 audio chunks and an end of stream", len(session.sent))
+	}
+}
+
+// unsyncedSession records sent inputs without locking, and whether two sends
+// ever ran at the same time.
+type unsyncedSession struct {
+	sent       []genai.LiveRealtimeInput
+	sending    atomic.Int32
+	overlapped atomic.Bool
+	streamEnd  chan struct{}
+}
+
+func (s *unsyncedSession) SendRealtimeInput(input genai.LiveRealtimeInput) error {
+	if s.sending.Add(1) > 1 {
+		s.overlapped.Store(true)
+	}
+	defer s.sending.Add(-1)
+	runtime.Gosched()
+	s.sent = append(s.sent, input)
+	if input.AudioStreamEnd {
+		close(s.streamEnd)
+	}
+	return nil
+}
+
+func (s *unsyncedSession) Receive() (*genai.LiveServerMessage, error) {
+	<-s.streamEnd
+	return nil, errSessionClosed
+}
+
+func TestStreamWithVideo(t *testing.T) {
+	session := &unsyncedSession{streamEnd: make(chan struct{})}
+	camera := &fakeCamera{frames: 20}
+	mic := bytes.NewReader(bytes.Repeat([]byte("a"), 40))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := Stream(ctx, session, mic, &fakeSpeaker{}, &Config{
+		ChunkSize:   2,
+		Video:       camera,
+		VideoConfig: &VideoConfig{FramesPerSecond: 1e6},
+	})
+	if !errors.Is(err, errSessionClosed) {
+		t.Fatalf("Stream() error = %v, want %v", err, errSessionClosed)
+	}
+	if session.overlapped.Load() {
+		t.Error("SendRealtimeInput was called concurrently")
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package liveaudio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"time"
+
+	"google.golang.org/genai"
+)
+
+const (
+	// VideoMIMEType is the MIME type of the video frames sent to the model.
+	VideoMIMEType = "image/jpeg"
+
+	// The Live API processes video at 1 frame per second.
+	defaultFramesPerSecond = 1
+)
+
+// Sender is the part of [genai.Session] used to send realtime input.
+type Sender interface {
+	SendRealtimeInput(input genai.LiveRealtimeInput) error
+}
+
+// FrameSource provides video frames, such as camera snapshots or screen
+// captures.
+type FrameSource interface {
+	// Frame returns the current frame. It's called at the frame rate of the
+	// stream, so it should return the latest frame rather than queue them.
+	// Returning io.EOF ends the video stream.
+	Frame(ctx context.Context) (image.Image, error)
+}
+
+// VideoConfig configures [StreamVideo].
+type VideoConfig struct {
+	// Optional. Number of frames sent per second. Defaults to 1, which is the
+	// rate at which the Live API processes video. Higher rates only add
+	// latency and cost.
+	FramesPerSecond float64
+	// Optional. JPEG quality of the frames, from 1 to 100. Defaults to 75.
+	Quality int
+}
+
+// SendVideoFrame encodes frame as JPEG and sends it to the session as
+// realtime video input. quality is the JPEG quality, from 1 to 100, or 0 for
+// the default quality.
+func SendVideoFrame(session Sender, frame image.Image, quality int) error {
+	if quality == 0 {
+		quality = jpeg.DefaultQuality
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, frame, &jpeg.Options{Quality: quality}); err != nil {
+		return fmt.Errorf("SendVideoFrame: encoding frame: %w", err)
+	}
+	return session.SendRealtimeInput(genai.LiveRealtimeInput{Video: &genai.Blob{Data: buf.Bytes(), MIMEType: VideoMIMEType}})
+}
+
+// StreamVideo sends the frames of source to the session at the configured
+// frame rate. Frames are taken when they're due, so a slow source or session
+// lowers the frame rate instead of building up latency. Run it alongside
+// [Stream], or set [Config.Video], to talk with the model about what a camera
+// sees.
+//
+// StreamVideo runs until ctx is canceled, the session fails or source returns
+// an error. It returns nil when source returns io.EOF.
+func StreamVideo(ctx context.Context, session Sender, source FrameSource, config *VideoConfig) error {
+	cfg := VideoConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.FramesPerSecond < 0 {
+		return fmt.Errorf("StreamVideo: FramesPerSecond must be positive, got %v", cfg.FramesPerSecond)
+	}
+	if cfg.FramesPerSecond == 0 {
+		cfg.FramesPerSecond = defaultFramesPerSecond
+	}
+	if cfg.Quality < 0 || cfg.Quality > 100 {
+		return fmt.Errorf("StreamVideo: Quality must be between 1 and 100, got %d", cfg.Quality)
+	}
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / cfg.FramesPerSecond))
+	defer ticker.Stop()
+	for {
+		frame, err := source.Frame(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := SendVideoFrame(session, frame, cfg.Quality); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
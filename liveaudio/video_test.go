@@ -0,0 +1,95 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package liveaudio
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+type recordingSender struct {
+	sent []genai.LiveRealtimeInput
+}
+
+func (s *recordingSender) SendRealtimeInput(input genai.LiveRealtimeInput) error {
+	s.sent = append(s.sent, input)
+	return nil
+}
+
+// fakeCamera returns frames of growing width and then io.EOF.
+type fakeCamera struct {
+	frames int
+	calls  int
+}
+
+func (c *fakeCamera) Frame(ctx context.Context) (image.Image, error) {
+	if c.calls == c.frames {
+		return nil, io.EOF
+	}
+	c.calls++
+	img := image.NewRGBA(image.Rect(0, 0, c.calls, 1))
+	img.Set(0, 0, color.White)
+	return img, nil
+}
+
+func TestStreamVideo(t *testing.T) {
+	session := &recordingSender{}
+	camera := &fakeCamera{frames: 3}
+	if err := StreamVideo(context.Background(), session, camera, &VideoConfig{FramesPerSecond: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	if len(session.sent) != 3 {
+		t.Fatalf("sent %d inputs, want 3", len(session.sent))
+	}
+	for i, input := range session.sent {
+		if input.Video == nil || input.Video.MIMEType != VideoMIMEType {
+			t.Fatalf("input %d = %+v, want a %s video frame", i, input, VideoMIMEType)
+		}
+		img, err := jpeg.Decode(bytes.NewReader(input.Video.Data))
+		if err != nil {
+			t.Fatalf("decoding frame %d: %v", i, err)
+		}
+		if got := img.Bounds().Dx(); got != i+1 {
+			t.Errorf("frame %d has width %d, want %d", i, got, i+1)
+		}
+	}
+}
+
+func TestStreamVideoConfigErrors(t *testing.T) {
+	for _, config := range []*VideoConfig{{FramesPerSecond: -1}, {Quality: 101}} {
+		if err := StreamVideo(context.Background(), &recordingSender{}, &fakeCamera{}, config); err == nil {
+			t.Errorf("StreamVideo(%+v) succeeded, want error", config)
+		}
+	}
+}
+
+func TestStreamVideoCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	session := &recordingSender{}
+	if err := StreamVideo(ctx, session, &fakeCamera{frames: 10}, &VideoConfig{FramesPerSecond: 0.001}); err != context.Canceled {
+		t.Errorf("StreamVideo() error = %v, want %v", err, context.Canceled)
+	}
+	if len(session.sent) != 1 {
+		t.Errorf("sent %d inputs, want 1", len(session.sent))
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import "math"
+
+// TokenLogprob is a generated token with its log probability and the most
+// likely tokens at its decoding step.
+type TokenLogprob struct {
+	// Token is the string representation of the token.
+	Token string
+	// TokenID is the ID of the token in the vocabulary of the model.
+	TokenID int32
+	// LogProbability is the log probability of the token.
+	LogProbability float64
+	// TopCandidates are the most likely tokens at the decoding step, sorted by
+	// decreasing log probability. They may not include the generated token.
+	TopCandidates []*LogprobsResultCandidate
+}
+
+// Probability returns the probability of the token.
+func (t *TokenLogprob) Probability() float64 {
+	return math.Exp(t.LogProbability)
+}
+
+// Tokens returns the generated tokens with their log probabilities and their
+// top candidates, pairing up the chosen and top candidates of each decoding
+// step. Top candidates are only set if they were requested with
+// [GenerateContentConfig.Logprobs].
+func (r *LogprobsResult) Tokens() []*TokenLogprob {
+	if r == nil {
+		return nil
+	}
+	tokens := make([]*TokenLogprob, 0, len(r.ChosenCandidates))
+	for i, c := range r.ChosenCandidates {
+		if c == nil {
+			continue
+		}
+		t := &TokenLogprob{Token: c.Token, TokenID: c.TokenID, LogProbability: float64(c.LogProbability)}
+		if i < len(r.TopCandidates) && r.TopCandidates[i] != nil {
+			t.TopCandidates = r.TopCandidates[i].Candidates
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
+
+// SumLogProbability returns the sum of the log probabilities of the generated
+// tokens, which is the log probability of the whole sequence. If the chosen
+// candidates weren't returned, it returns LogProbabilitySum, if set.
+func (r *LogprobsResult) SumLogProbability() float64 {
+	if r == nil {
+		return 0
+	}
+	if len(r.ChosenCandidates) == 0 && r.LogProbabilitySum != nil {
+		return float64(*r.LogProbabilitySum)
+	}
+	var sum float64
+	for _, c := range r.ChosenCandidates {
+		if c != nil {
+			sum += float64(c.LogProbability)
+		}
+	}
+	return sum
+}
+
+// SequenceProbability returns the probability of the whole generated
+// sequence, that is the product of the probabilities of its tokens. It
+// underflows to 0 for long sequences; compare SumLogProbability instead.
+func (r *LogprobsResult) SequenceProbability() float64 {
+	return math.Exp(r.SumLogProbability())
+}
+
+// MeanLogProbability returns the average log probability of the generated
+// tokens, or 0 if there are none.
+func (r *LogprobsResult) MeanLogProbability() float64 {
+	n := r.numTokens()
+	if n == 0 {
+		return 0
+	}
+	return r.SumLogProbability() / float64(n)
+}
+
+// Perplexity returns the perplexity of the generated tokens, the exponential
+// of their negative average log probability. It's 1 when the model was
+// certain of every token and grows with its uncertainty. It returns 0 if there
+// are no tokens.
+func (r *LogprobsResult) Perplexity() float64 {
+	if r.numTokens() == 0 {
+		return 0
+	}
+	return math.Exp(-r.MeanLogProbability())
+}
+
+// LowConfidenceTokens returns the generated tokens whose probability is below
+// threshold, for example to highlight the parts of an answer that the model
+// was unsure of.
+func (r *LogprobsResult) LowConfidenceTokens(threshold float64) []*TokenLogprob {
+	var low []*TokenLogprob
+	for _, t := range r.Tokens() {
+		if t.Probability() < threshold {
+			low = append(low, t)
+		}
+	}
+	return low
+}
+
+func (r *LogprobsResult) numTokens() int {
+	if r == nil {
+		return 0
+	}
+	n := 0
+	for _, c := range r.ChosenCandidates {
+		if c != nil {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLogprobsResult(t *testing.T) {
+	r := &LogprobsResult{
+		ChosenCandidates: []*LogprobsResultCandidate{
+			{Token: "The", TokenID: 1, LogProbability: -0.1},
+			{Token: " cat", TokenID: 2, LogProbability: -2.0},
+			{Token: ".", TokenID: 3, LogProbability: -0.3},
+		},
+		TopCandidates: []*LogprobsResultTopCandidates{
+			{Candidates: []*LogprobsResultCandidate{{Token: "The", LogProbability: -0.1}, {Token: "A", LogProbability: -2.4}}},
+			{Candidates: []*LogprobsResultCandidate{{Token: " dog", LogProbability: -1.5}, {Token: " cat", LogProbability: -2.0}}},
+		},
+	}
+	const eps = 1e-5
+	near := func(a, b float64) bool { return math.Abs(a-b) < eps }
+
+	tokens := r.Tokens()
+	if len(tokens) != 3 {
+		t.Fatalf("Tokens() returned %d tokens, want 3", len(tokens))
+	}
+	if tokens[1].Token != " cat" || tokens[1].TokenID != 2 || len(tokens[1].TopCandidates) != 2 || tokens[1].TopCandidates[0].Token != " dog" {
+		t.Errorf("Tokens()[1] = %+v, want \" cat\" with its top candidates", tokens[1])
+	}
+	if tokens[2].TopCandidates != nil {
+		t.Errorf("Tokens()[2].TopCandidates = %v, want none", tokens[2].TopCandidates)
+	}
+	if got := r.SumLogProbability(); !near(got, -2.4) {
+		t.Errorf("SumLogProbability() = %v, want -2.4", got)
+	}
+	if got := r.SequenceProbability(); !near(got, math.Exp(-2.4)) {
+		t.Errorf("SequenceProbability() = %v, want %v", got, math.Exp(-2.4))
+	}
+	if got := r.MeanLogProbability(); !near(got, -0.8) {
+		t.Errorf("MeanLogProbability() = %v, want -0.8", got)
+	}
+	if got := r.Perplexity(); !near(got, math.Exp(0.8)) {
+		t.Errorf("Perplexity() = %v, want %v", got, math.Exp(0.8))
+	}
+	if low := r.LowConfidenceTokens(0.5); len(low) != 1 || low[0].Token != " cat" {
+		t.Errorf("LowConfidenceTokens(0.5) = %v, want [\" cat\"]", low)
+	}
+
+	sumOnly := &LogprobsResult{LogProbabilitySum: Ptr[float32](-1.5)}
+	if got := sumOnly.SumLogProbability(); !near(got, -1.5) {
+		t.Errorf("SumLogProbability() without chosen candidates = %v, want -1.5", got)
+	}
+
+	var empty *LogprobsResult
+	if empty.Tokens() != nil || empty.SumLogProbability() != 0 || empty.Perplexity() != 0 || empty.MeanLogProbability() != 0 {
+		t.Error("nil LogprobsResult returned non-zero values")
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MCPToolInfo describes a tool of a Model Context Protocol (MCP) server, as
+// returned by the tools/list method.
+type MCPToolInfo struct {
+	// Name is the name of the tool.
+	Name string
+	// Description is the human readable description of the tool.
+	Description string
+	// InputSchema is the JSON Schema of the tool arguments, for example a
+	// map[string]any or a json.RawMessage.
+	InputSchema any
+}
+
+// MCPContent is a content item of an MCP tool result.
+type MCPContent struct {
+	// Type is the content type, such as "text", "image" or "audio".
+	Type string
+	// Text is the text of a "text" content item.
+	Text string
+	// Data is the decoded data of an "image" or "audio" content item.
+	Data []byte
+	// MIMEType is the MIME type of Data.
+	MIMEType string
+}
+
+// MCPToolResult is the result of an MCP tools/call request.
+type MCPToolResult struct {
+	// Content is the unstructured result of the tool.
+	Content []*MCPContent
+	// StructuredContent is the structured result of the tool, if any.
+	StructuredContent map[string]any
+	// IsError reports whether the tool failed.
+	IsError bool
+}
+
+// MCPClient is a connection to an MCP server. The SDK doesn't depend on an MCP
+// implementation; wrap the client session of your MCP library to implement
+// it.
+type MCPClient interface {
+	// ListTools returns all tools of the server.
+	ListTools(ctx context.Context) ([]*MCPToolInfo, error)
+	// CallTool calls the named tool with the given arguments.
+	CallTool(ctx context.Context, name string, args map[string]any) (*MCPToolResult, error)
+}
+
+// MCPTool is a [CallableTool] that routes function calls to the tools of an
+// MCP server.
+type MCPTool struct {
+	client       MCPClient
+	declarations []*FunctionDeclaration
+}
+
+// NewMCPTool lists the tools of the MCP server and returns a [CallableTool]
+// that declares them to the model, with their input schemas as
+// [FunctionDeclaration.ParametersJsonSchema], and calls them through client.
+// Use it with [AutomaticFunctionCallingConfig.Tools] or a [ToolExecutor].
+//
+// Text results are returned to the model under the "output" key, and
+// structured results as they are. Tool errors are returned under the "error"
+// key.
+func NewMCPTool(ctx context.Context, client MCPClient) (*MCPTool, error) {
+	tools, err := client.ListTools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("NewMCPTool: listing tools: %w", err)
+	}
+	t := &MCPTool{client: client}
+	for _, tool := range tools {
+		decl := &FunctionDeclaration{
+			Name:                 tool.Name,
+			Description:          tool.Description,
+			ParametersJsonSchema: rawJSONSchema(tool.InputSchema),
+		}
+		t.declarations = append(t.declarations, decl)
+	}
+	return t, nil
+}
+
+// Declarations returns the declarations of the tools of the MCP server.
+func (t *MCPTool) Declarations() []*FunctionDeclaration {
+	return t.declarations
+}
+
+// Call calls the MCP tool named by call.
+func (t *MCPTool) Call(ctx context.Context, call *FunctionCall) (*FunctionResponse, error) {
+	result, err := t.client.CallTool(ctx, call.Name, call.Args)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, fmt.Errorf("MCP tool %s returned no result", call.Name)
+	}
+	text, parts := mcpResultContent(result)
+	if result.IsError {
+		if text == "" {
+			text = "tool call failed"
+		}
+		return nil, errors.New(text)
+	}
+	response := &FunctionResponse{ID: call.ID, Name: call.Name}
+	if result.StructuredContent != nil {
+		response.Response = result.StructuredContent
+	} else {
+		response.Response = map[string]any{"output": text}
+	}
+	response.Parts = parts
+	return response, nil
+}
+
+// mcpResultContent returns the concatenated text of a result and its media
+// content as function response parts.
+func mcpResultContent(result *MCPToolResult) (string, []*FunctionResponsePart) {
+	var texts []string
+	var parts []*FunctionResponsePart
+	for _, c := range result.Content {
+		switch {
+		case c.Type == "text":
+			texts = append(texts, c.Text)
+		case len(c.Data) > 0:
+			parts = append(parts, &FunctionResponsePart{InlineData: &FunctionResponseBlob{Data: c.Data, MIMEType: c.MIMEType}})
+		}
+	}
+	return strings.Join(texts, "\n"), parts
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeMCPClient struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (c *fakeMCPClient) ListTools(ctx context.Context) ([]*MCPToolInfo, error) {
+	return []*MCPToolInfo{
+		{Name: "echo", Description: "Echoes the input.", InputSchema: `{"type": "object", "properties": {"text": {"type": "string"}}}`},
+		{Name: "stats", InputSchema: map[string]any{"type": "object"}},
+		{Name: "fail"},
+		{Name: "empty"},
+	}, nil
+}
+
+func (c *fakeMCPClient) CallTool(ctx context.Context, name string, args map[string]any) (*MCPToolResult, error) {
+	c.mu.Lock()
+	c.calls = append(c.calls, name)
+	c.mu.Unlock()
+	switch name {
+	case "echo":
+		return &MCPToolResult{Content: []*MCPContent{
+			{Type: "text", Text: args["text"].(string)},
+			{Type: "image", Data: []byte("png"), MIMEType: "image/png"},
+		}}, nil
+	case "stats":
+		return &MCPToolResult{StructuredContent: map[string]any{"count": 2}}, nil
+	case "empty":
+		return nil, nil
+	default:
+		return &MCPToolResult{IsError: true, Content: []*MCPContent{{Type: "text", Text: "disk full"}}}, nil
+	}
+}
+
+func TestMCPTool(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeMCPClient{}
+	tool, err := NewMCPTool(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decls := tool.Declarations()
+	if len(decls) != 4 || decls[0].Name != "echo" || decls[0].Description != "Echoes the input." {
+		t.Fatalf("unexpected declarations: %+v", decls)
+	}
+	schema, err := json.Marshal(decls[0].ParametersJsonSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"object","properties":{"text":{"type":"string"}}}`; string(schema) != want {
+		t.Errorf("ParametersJsonSchema = %s, want %s", schema, want)
+	}
+
+	executor, err := NewToolExecutor(tool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := executor.Execute(ctx, []*FunctionCall{
+		{ID: "1", Name: "echo", Args: map[string]any{"text": "hi"}},
+		{ID: "2", Name: "stats"},
+		{ID: "3", Name: "fail"},
+		{ID: "4", Name: "empty"},
+	})
+	want := []*FunctionResponse{
+		{
+			ID: "1", Name: "echo", Response: map[string]any{"output": "hi"},
+			Parts: []*FunctionResponsePart{{InlineData: &FunctionResponseBlob{Data: []byte("png"), MIMEType: "image/png"}}},
+		},
+		{ID: "2", Name: "stats", Response: map[string]any{"count": 2}},
+		{ID: "3", Name: "fail", Response: map[string]any{"error": "disk full"}},
+		{ID: "4", Name: "empty", Response: map[string]any{"error": "MCP tool empty returned no result"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Execute() mismatch (-want +got):\n%s", diff)
+	}
+	slices.Sort(client.calls)
+	if diff := cmp.Diff([]string{"echo", "empty", "fail", "stats"}, client.calls); diff != "" {
+		t.Errorf("CallTool() calls mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ModelFeature is a feature that a model may support, see [Models.Supports].
+type ModelFeature string
+
+const (
+	// ModelFeatureGenerateContent is content generation with
+	// [Models.GenerateContent].
+	ModelFeatureGenerateContent ModelFeature = "GENERATE_CONTENT"
+	// ModelFeatureCountTokens is token counting with [Models.CountTokens].
+	ModelFeatureCountTokens ModelFeature = "COUNT_TOKENS"
+	// ModelFeatureCaching is context caching with [Caches.Create].
+	ModelFeatureCaching ModelFeature = "CACHING"
+	// ModelFeatureBatch is batch prediction with [Batches.Create].
+	ModelFeatureBatch ModelFeature = "BATCH"
+	// ModelFeatureLive is the Live API, see [Live.Connect].
+	ModelFeatureLive ModelFeature = "LIVE"
+	// ModelFeatureEmbedding is embedding with [Models.EmbedContent].
+	ModelFeatureEmbedding ModelFeature = "EMBEDDING"
+	// ModelFeatureThinking is thinking, configured with
+	// [GenerateContentConfig.ThinkingConfig].
+	ModelFeatureThinking ModelFeature = "THINKING"
+	// ModelFeatureImageInput is images in the input.
+	ModelFeatureImageInput ModelFeature = "IMAGE_INPUT"
+	// ModelFeatureAudioInput is audio in the input.
+	ModelFeatureAudioInput ModelFeature = "AUDIO_INPUT"
+	// ModelFeatureVideoInput is videos in the input.
+	ModelFeatureVideoInput ModelFeature = "VIDEO_INPUT"
+	// ModelFeatureDocumentInput is documents, such as PDFs, in the input.
+	ModelFeatureDocumentInput ModelFeature = "DOCUMENT_INPUT"
+	// ModelFeatureImageOutput is image generation.
+	ModelFeatureImageOutput ModelFeature = "IMAGE_OUTPUT"
+	// ModelFeatureAudioOutput is audio generation, such as speech.
+	ModelFeatureAudioOutput ModelFeature = "AUDIO_OUTPUT"
+	// ModelFeatureVideoOutput is video generation.
+	ModelFeatureVideoOutput ModelFeature = "VIDEO_OUTPUT"
+	// ModelFeatureResponseJSONSchema is structured output constrained by a JSON
+	// Schema given as [GenerateContentConfig.ResponseJsonSchema].
+	ModelFeatureResponseJSONSchema ModelFeature = "RESPONSE_JSON_SCHEMA"
+)
+
+// The generation methods reported by the API in [Model.SupportedActions].
+const (
+	generationMethodGenerateContent      = "generateContent"
+	generationMethodCountTokens          = "countTokens"
+	generationMethodCreateCachedContent  = "createCachedContent"
+	generationMethodBatchGenerateContent = "batchGenerateContent"
+	generationMethodBidiGenerateContent  = "bidiGenerateContent"
+	generationMethodEmbedContent         = "embedContent"
+	generationMethodPredict              = "predict"
+	generationMethodPredictLongRunning   = "predictLongRunning"
+)
+
+// ModelCapabilities describes what a model supports.
+type ModelCapabilities struct {
+	// GenerationMethods are the API methods supported by the model, such as
+	// "generateContent" or "embedContent".
+	GenerationMethods []string
+	// InputModalities are the modalities the model accepts.
+	InputModalities []MediaModality
+	// OutputModalities are the modalities the model generates.
+	OutputModalities []Modality
+	// InputTokenLimit is the maximum number of input tokens, or 0 if unknown.
+	InputTokenLimit int32
+	// OutputTokenLimit is the maximum number of output tokens, or 0 if unknown.
+	OutputTokenLimit int32
+	// Thinking reports whether the model supports thinking.
+	Thinking bool
+	// ResponseJSONSchema reports whether the model supports
+	// [GenerateContentConfig.ResponseJsonSchema].
+	ResponseJSONSchema bool
+	// KnownFamily reports whether the model belongs to a model family known to
+	// the SDK. The modalities, Thinking and ResponseJSONSchema of models of
+	// unknown families are unknown rather than unsupported.
+	KnownFamily bool
+}
+
+// Supports reports whether the capabilities include feature. A feature is
+// only reported as unsupported if the API or the known capabilities of the
+// model family say so: generation methods that the API didn't report, and the
+// other features of models of unknown families, are assumed to be supported,
+// leaving it to the API to reject a request.
+func (c *ModelCapabilities) Supports(feature ModelFeature) bool {
+	switch feature {
+	case ModelFeatureGenerateContent:
+		return c.supportsMethod(generationMethodGenerateContent)
+	case ModelFeatureCountTokens:
+		return c.supportsMethod(generationMethodCountTokens)
+	case ModelFeatureCaching:
+		return c.supportsMethod(generationMethodCreateCachedContent)
+	case ModelFeatureBatch:
+		return c.supportsMethod(generationMethodBatchGenerateContent)
+	case ModelFeatureLive:
+		return c.supportsMethod(generationMethodBidiGenerateContent)
+	case ModelFeatureEmbedding:
+		return c.supportsMethod(generationMethodEmbedContent)
+	case ModelFeatureThinking:
+		return c.Thinking || !c.KnownFamily
+	case ModelFeatureResponseJSONSchema:
+		return c.ResponseJSONSchema || !c.KnownFamily
+	case ModelFeatureImageInput:
+		return !c.KnownFamily || slices.Contains(c.InputModalities, MediaModalityImage)
+	case ModelFeatureAudioInput:
+		return !c.KnownFamily || slices.Contains(c.InputModalities, MediaModalityAudio)
+	case ModelFeatureVideoInput:
+		return !c.KnownFamily || slices.Contains(c.InputModalities, MediaModalityVideo)
+	case ModelFeatureDocumentInput:
+		return !c.KnownFamily || slices.Contains(c.InputModalities, MediaModalityDocument)
+	case ModelFeatureImageOutput:
+		return !c.KnownFamily || slices.Contains(c.OutputModalities, ModalityImage)
+	case ModelFeatureAudioOutput:
+		return !c.KnownFamily || slices.Contains(c.OutputModalities, ModalityAudio)
+	case ModelFeatureVideoOutput:
+		return !c.KnownFamily || slices.Contains(c.OutputModalities, ModalityVideo)
+	}
+	return false
+}
+
+// supportsMethod reports whether the model supports the generation method,
+// which is assumed if the generation methods are unknown.
+func (c *ModelCapabilities) supportsMethod(method string) bool {
+	return len(c.GenerationMethods) == 0 || slices.Contains(c.GenerationMethods, method)
+}
+
+// knownModelFamily holds the capabilities of the models whose ID starts with
+// prefix.
+type knownModelFamily struct {
+	prefix       string
+	capabilities ModelCapabilities
+}
+
+var (
+	geminiInputModalities = []MediaModality{MediaModalityText, MediaModalityImage, MediaModalityAudio, MediaModalityVideo, MediaModalityDocument}
+	geminiMethods         = []string{generationMethodGenerateContent, generationMethodCountTokens, generationMethodCreateCachedContent, generationMethodBatchGenerateContent}
+	liveMethods           = []string{generationMethodBidiGenerateContent, generationMethodCountTokens}
+)
+
+// knownModelFamilies are the capabilities of the model families, used where
+// the API doesn't report them, which is always the case for modalities and in
+// Vertex AI. They're advisory: the API has the final say, and models missing
+// from the table aren't assumed to lack any feature. More specific prefixes
+// come first.
+var knownModelFamilies = []knownModelFamily{
+	{"gemini-2.5-flash-preview-tts", ModelCapabilities{
+		GenerationMethods: []string{generationMethodGenerateContent, generationMethodCountTokens, generationMethodBatchGenerateContent},
+		InputModalities:   []MediaModality{MediaModalityText},
+		OutputModalities:  []Modality{ModalityAudio},
+	}},
+	{"gemini-2.5-pro-preview-tts", ModelCapabilities{
+		GenerationMethods: []string{generationMethodGenerateContent, generationMethodCountTokens, generationMethodBatchGenerateContent},
+		InputModalities:   []MediaModality{MediaModalityText},
+		OutputModalities:  []Modality{ModalityAudio},
+	}},
+	{"gemini-2.5-flash-native-audio", ModelCapabilities{
+		GenerationMethods: liveMethods,
+		InputModalities:   []MediaModality{MediaModalityText, MediaModalityAudio, MediaModalityVideo},
+		OutputModalities:  []Modality{ModalityText, ModalityAudio},
+		Thinking:          true,
+	}},
+	{"gemini-live-", ModelCapabilities{
+		GenerationMethods: liveMethods,
+		InputModalities:   []MediaModality{MediaModalityText, MediaModalityAudio, MediaModalityVideo},
+		OutputModalities:  []Modality{ModalityText, ModalityAudio},
+	}},
+	{"gemini-2.0-flash-live", ModelCapabilities{
+		GenerationMethods: liveMethods,
+		InputModalities:   []MediaModality{MediaModalityText, MediaModalityAudio, MediaModalityVideo},
+		OutputModalities:  []Modality{ModalityText, ModalityAudio},
+	}},
+	{"gemini-2.5-flash-image", ModelCapabilities{
+		GenerationMethods: geminiMethods,
+		InputModalities:   []MediaModality{MediaModalityText, MediaModalityImage},
+		OutputModalities:  []Modality{ModalityText, ModalityImage},
+		InputTokenLimit:   32768,
+		OutputTokenLimit:  32768,
+	}},
+	{"gemini-3-pro-image", ModelCapabilities{
+		GenerationMethods: geminiMethods,
+		InputModalities:   []MediaModality{MediaModalityText, MediaModalityImage},
+		OutputModalities:  []Modality{ModalityText, ModalityImage},
+		Thinking:          true,
+	}},
+	{"gemini-2.0-flash-preview-image-generation", ModelCapabilities{
+		GenerationMethods: geminiMethods,
+		InputModalities:   []MediaModality{MediaModalityText, MediaModalityImage},
+		OutputModalities:  []Modality{ModalityText, ModalityImage},
+	}},
+	{"gemini-embedding", ModelCapabilities{
+		GenerationMethods: []string{generationMethodEmbedContent, generationMethodCountTokens},
+		InputModalities:   []MediaModality{MediaModalityText},
+	}},
+	{"gemini-3", ModelCapabilities{
+		GenerationMethods:  geminiMethods,
+		InputModalities:    geminiInputModalities,
+		OutputModalities:   []Modality{ModalityText},
+		InputTokenLimit:    1048576,
+		OutputTokenLimit:   65536,
+		Thinking:           true,
+		ResponseJSONSchema: true,
+	}},
+	{"gemini-2.5", ModelCapabilities{
+		GenerationMethods:  geminiMethods,
+		InputModalities:    geminiInputModalities,
+		OutputModalities:   []Modality{ModalityText},
+		InputTokenLimit:    1048576,
+		OutputTokenLimit:   65536,
+		Thinking:           true,
+		ResponseJSONSchema: true,
+	}},
+	{"gemini-2.0", ModelCapabilities{
+		GenerationMethods: geminiMethods,
+		InputModalities:   geminiInputModalities,
+		OutputModalities:  []Modality{ModalityText},
+		InputTokenLimit:   1048576,
+		OutputTokenLimit:  8192,
+	}},
+	{"text-embedding", ModelCapabilities{
+		GenerationMethods: []string{generationMethodEmbedContent, generationMethodCountTokens},
+		InputModalities:   []MediaModality{MediaModalityText},
+	}},
+	{"imagen-", ModelCapabilities{
+		GenerationMethods: []string{generationMethodPredict},
+		InputModalities:   []MediaModality{MediaModalityText},
+		OutputModalities:  []Modality{ModalityImage},
+	}},
+	{"veo-", ModelCapabilities{
+		GenerationMethods: []string{generationMethodPredictLongRunning},
+		InputModalities:   []MediaModality{MediaModalityText, MediaModalityImage},
+		OutputModalities:  []Modality{ModalityVideo},
+	}},
+}
+
+// lookupModelFamily returns the capabilities of the family of the model with
+// the given ID, or nil if it's unknown.
+func lookupModelFamily(id string) *ModelCapabilities {
+	for _, f := range knownModelFamilies {
+		if strings.HasPrefix(id, f.prefix) {
+			return &f.capabilities
+		}
+	}
+	return nil
+}
+
+// Capabilities returns what the model supports. The values reported by the
+// API, such as the token limits of [Models.Get], which differ between
+// backends, take precedence, and the others come from the known capabilities
+// of the model family, or of the base model for tuned models, as a hint.
+// Modalities and support for JSON Schema always come from the model family,
+// and are unknown for models of unknown families, see
+// [ModelCapabilities.KnownFamily].
+func (m *Model) Capabilities() *ModelCapabilities {
+	c := &ModelCapabilities{
+		GenerationMethods: slices.Clone(m.SupportedActions),
+		InputTokenLimit:   m.InputTokenLimit,
+		OutputTokenLimit:  m.OutputTokenLimit,
+		Thinking:          m.Thinking,
+	}
+	id := m.ID()
+	if m.TunedModelInfo != nil && m.TunedModelInfo.BaseModel != "" {
+		base := m.TunedModelInfo.BaseModel
+		id = base[strings.LastIndexByte(base, '/')+1:]
+	}
+	known := lookupModelFamily(id)
+	if known == nil {
+		return c
+	}
+	if len(c.GenerationMethods) == 0 {
+		c.GenerationMethods = slices.Clone(known.GenerationMethods)
+	}
+	c.InputModalities = slices.Clone(known.InputModalities)
+	c.OutputModalities = slices.Clone(known.OutputModalities)
+	if c.InputTokenLimit == 0 {
+		c.InputTokenLimit = known.InputTokenLimit
+	}
+	if c.OutputTokenLimit == 0 {
+		c.OutputTokenLimit = known.OutputTokenLimit
+	}
+	c.Thinking = c.Thinking || known.Thinking
+	c.ResponseJSONSchema = known.ResponseJSONSchema
+	c.KnownFamily = true
+	return c
+}
+
+// Supports reports whether model supports feature, as described by the
+// [Model.Capabilities] of the model returned by [Models.Get]. Use it to gate
+// features at runtime, such as audio output. Features that can't be determined
+// for the model are reported as supported, see [ModelCapabilities.Supports].
+func (m Models) Supports(ctx context.Context, model string, feature ModelFeature) (bool, error) {
+	info, err := m.Get(ctx, model, nil)
+	if err != nil {
+		return false, fmt.Errorf("Supports: %w", err)
+	}
+	return info.Capabilities().Supports(feature), nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModelCapabilities(t *testing.T) {
+	tests := []struct {
+		name  string
+		model *Model
+		want  *ModelCapabilities
+	}{
+		{
+			name: "GeminiAPI",
+			model: &Model{
+				Name:             "models/gemini-2.5-flash",
+				SupportedActions: []string{"generateContent", "countTokens"},
+				InputTokenLimit:  1000000,
+				OutputTokenLimit: 64000,
+				Thinking:         true,
+			},
+			want: &ModelCapabilities{
+				GenerationMethods:  []string{"generateContent", "countTokens"},
+				InputModalities:    geminiInputModalities,
+				OutputModalities:   []Modality{ModalityText},
+				InputTokenLimit:    1000000,
+				OutputTokenLimit:   64000,
+				Thinking:           true,
+				ResponseJSONSchema: true,
+				KnownFamily:        true,
+			},
+		},
+		{
+			name:  "VertexAIPublisherModel",
+			model: &Model{Name: "publishers/google/models/gemini-2.5-flash-preview-tts"},
+			want: &ModelCapabilities{
+				GenerationMethods: []string{"generateContent", "countTokens", "batchGenerateContent"},
+				InputModalities:   []MediaModality{MediaModalityText},
+				OutputModalities:  []Modality{ModalityAudio},
+				KnownFamily:       true,
+			},
+		},
+		{
+			name:  "TunedModel",
+			model: &Model{Name: "projects/p/locations/l/models/123", TunedModelInfo: &TunedModelInfo{BaseModel: "gemini-2.0-flash-001"}},
+			want: &ModelCapabilities{
+				GenerationMethods: geminiMethods,
+				InputModalities:   geminiInputModalities,
+				OutputModalities:  []Modality{ModalityText},
+				InputTokenLimit:   1048576,
+				OutputTokenLimit:  8192,
+				KnownFamily:       true,
+			},
+		},
+		{
+			name:  "UnknownModel",
+			model: &Model{Name: "models/some-model", SupportedActions: []string{"generateContent"}},
+			want:  &ModelCapabilities{GenerationMethods: []string{"generateContent"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.model.Capabilities()); diff != "" {
+				t.Errorf("Capabilities() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModelCapabilitiesSupports(t *testing.T) {
+	c := &ModelCapabilities{
+		GenerationMethods: []string{"generateContent", "bidiGenerateContent"},
+		InputModalities:   []MediaModality{MediaModalityText, MediaModalityAudio},
+		OutputModalities:  []Modality{ModalityAudio},
+		KnownFamily:       true,
+	}
+	for feature, want := range map[ModelFeature]bool{
+		ModelFeatureGenerateContent: true,
+		ModelFeatureLive:            true,
+		ModelFeatureEmbedding:       false,
+		ModelFeatureAudioInput:      true,
+		ModelFeatureImageInput:      false,
+		ModelFeatureAudioOutput:     true,
+		ModelFeatureThinking:        false,
+		ModelFeature("UNKNOWN"):     false,
+	} {
+		if got := c.Supports(feature); got != want {
+			t.Errorf("Supports(%s) = %v, want %v", feature, got, want)
+		}
+	}
+
+	// Models of unknown families are never reported as lacking a feature
+	// that only the table of model families could tell.
+	unknown := (&Model{Name: "models/some-model", SupportedActions: []string{"generateContent"}}).Capabilities()
+	for feature, want := range map[ModelFeature]bool{
+		ModelFeatureGenerateContent:    true,
+		ModelFeatureEmbedding:          false,
+		ModelFeatureThinking:           true,
+		ModelFeatureResponseJSONSchema: true,
+		ModelFeatureVideoInput:         true,
+		ModelFeatureAudioOutput:        true,
+	} {
+		if got := unknown.Supports(feature); got != want {
+			t.Errorf("unknown model Supports(%s) = %v, want %v", feature, got, want)
+		}
+	}
+	if !(&ModelCapabilities{}).Supports(ModelFeatureBatch) {
+		t.Error("Supports(BATCH) without generation methods = false, want true")
+	}
+}
+
+func TestModelsSupports(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1beta1/publishers/google/models/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"name": "publishers/google/models/gemini-2.5-flash"}`)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "p", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+	ctx := context.Background()
+
+	for feature, want := range map[ModelFeature]bool{
+		ModelFeatureThinking:    true,
+		ModelFeatureVideoInput:  true,
+		ModelFeatureAudioOutput: false,
+	} {
+		got, err := m.Supports(ctx, "gemini-2.5-flash", feature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("Supports(%s) = %v, want %v", feature, got, want)
+		}
+	}
+	if _, err := m.Supports(ctx, "missing", ModelFeatureThinking); err == nil {
+		t.Error("Supports() of a missing model succeeded, want error")
+	}
+}
@@ -14,6 +14,10 @@
 
 package genai
 
+import (
+	"encoding/json"
+)
+
 // Text returns a slice of Content with a single Part with the given text.
 func Text(text string) []*Content {
 	return []*Content{{
@@ -31,6 +35,35 @@ func (c *GenerateContentConfig) setDefaults() {
 	}
 }
 
+// withRawJSONSchema returns a copy of c whose ResponseJsonSchema is sent as a
+// JSON object when it's given as a string or []byte, so that the config of the
+// caller isn't modified. Otherwise, c is returned.
+func (c *GenerateContentConfig) withRawJSONSchema() *GenerateContentConfig {
+	if c == nil {
+		return nil
+	}
+	switch c.ResponseJsonSchema.(type) {
+	case string, []byte:
+		cc := *c
+		cc.ResponseJsonSchema = rawJSONSchema(c.ResponseJsonSchema)
+		return &cc
+	}
+	return c
+}
+
+// rawJSONSchema returns a JSON Schema document given as a string or []byte as
+// a json.RawMessage, so that it is sent as a JSON object rather than as a JSON
+// string or base64 data. Other values are returned unchanged.
+func rawJSONSchema(schema any) any {
+	switch s := schema.(type) {
+	case string:
+		return json.RawMessage(s)
+	case []byte:
+		return json.RawMessage(s)
+	}
+	return schema
+}
+
 func (c *Content) setDefaults() {
 	if c == nil {
 		return
@@ -15,6 +15,7 @@
 package genai
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -49,4 +50,21 @@ func TestContentHelpers(t *testing.T) {
 			t.Errorf("GenerateContentConfig.setDefaults mismatch (-want +got):\n%s", diff)
 		}
 	})
+
+	t.Run("GenerateContentConfig_withRawJSONSchema", func(t *testing.T) {
+		for _, schema := range []any{`{"type": "string"}`, []byte(`{"type": "string"}`), json.RawMessage(`{"type": "string"}`)} {
+			config := &GenerateContentConfig{ResponseJsonSchema: schema}
+			got := config.withRawJSONSchema()
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := `{"responseJsonSchema":{"type":"string"}}`; string(b) != want {
+				t.Errorf("withRawJSONSchema(%T) marshaled to %s, want %s", schema, b, want)
+			}
+			if diff := cmp.Diff(schema, config.ResponseJsonSchema); diff != "" {
+				t.Errorf("withRawJSONSchema(%T) modified the config (-want +got):\n%s", schema, diff)
+			}
+		}
+	})
 }
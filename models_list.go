@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"iter"
+	"strings"
+)
+
+// ListAll returns an iterator over the models of all the pages listed with
+// config, which selects base or tuned models with QueryBase, filters them
+// server side with Filter, and sets the size of the pages fetched with
+// PageSize. Unlike [Models.All], it honors config, and the models of both
+// backends have the same shape: their DisplayName defaults to their
+// [Model.ID], which Vertex AI publisher models don't set.
+func (m Models) ListAll(ctx context.Context, config *ListModelsConfig) iter.Seq2[*Model, error] {
+	page, err := m.List(ctx, config)
+	if err != nil {
+		return yieldErrorAndEndIterator[Model](err)
+	}
+	return func(yield func(*Model, error) bool) {
+		for model, err := range page.all(ctx) {
+			if model != nil && model.DisplayName == "" {
+				model.DisplayName = model.ID()
+			}
+			if !yield(model, err) {
+				return
+			}
+		}
+	}
+}
+
+// ID returns the ID of the model, the last segment of its name, which is the
+// same for both backends: "gemini-2.5-flash" for both
+// "models/gemini-2.5-flash" and "publishers/google/models/gemini-2.5-flash".
+func (m *Model) ID() string {
+	return m.Name[strings.LastIndexByte(m.Name, '/')+1:]
+}
+
+// IsTuned reports whether the model is a tuned model rather than a base
+// model.
+func (m *Model) IsTuned() bool {
+	if m.TunedModelInfo != nil || strings.HasPrefix(m.Name, "tunedModels/") {
+		return true
+	}
+	n, err := ParseResourceName(m.Name)
+	return err == nil && n.Project != "" && n.Publisher == "" && n.Collection == ResourceCollectionModels
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModelsListAll(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Path+"?"+r.URL.RawQuery)
+		switch r.URL.Query().Get("pageToken") {
+		case "":
+			fmt.Fprint(w, `{"models": [{"name": "models/gemini-2.5-flash", "displayName": "Gemini 2.5 Flash"}], "nextPageToken": "p2"}`)
+		default:
+			fmt.Fprint(w, `{"models": [{"name": "publishers/google/models/gemini-2.5-pro"}]}`)
+		}
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta"}, HTTPClient: ts.Client()}}}
+
+	var got []string
+	for model, err := range m.ListAll(context.Background(), &ListModelsConfig{PageSize: 1, Filter: "gemini"}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, model.ID()+": "+model.DisplayName)
+	}
+	if diff := cmp.Diff([]string{"gemini-2.5-flash: Gemini 2.5 Flash", "gemini-2.5-pro: gemini-2.5-pro"}, got); diff != "" {
+		t.Errorf("ListAll() mismatch (-want +got):\n%s", diff)
+	}
+	want := []string{
+		"/v1beta/models?filter=gemini&pageSize=1",
+		"/v1beta/models?filter=gemini&pageSize=1&pageToken=p2",
+	}
+	if diff := cmp.Diff(want, queries); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestModelsListAllError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": 403, "message": "denied", "status": "PERMISSION_DENIED"}}`, http.StatusForbidden)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+	for model, err := range m.ListAll(context.Background(), nil) {
+		if err == nil || model != nil {
+			t.Errorf("ListAll() = %v, %v, want an error", model, err)
+		}
+	}
+}
+
+func TestModelIsTuned(t *testing.T) {
+	tests := []struct {
+		model *Model
+		want  bool
+	}{
+		{&Model{Name: "models/gemini-2.5-flash"}, false},
+		{&Model{Name: "publishers/google/models/gemini-2.5-flash"}, false},
+		{&Model{Name: "tunedModels/my-model"}, true},
+		{&Model{Name: "projects/p/locations/us-central1/models/123"}, true},
+		{&Model{Name: "models/x", TunedModelInfo: &TunedModelInfo{}}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.model.IsTuned(); got != tt.want {
+			t.Errorf("IsTuned(%q) = %v, want %v", tt.model.Name, got, tt.want)
+		}
+	}
+}
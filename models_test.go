@@ -1025,3 +1025,350 @@ func TestModelsAllEmptyResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestModelsUpdate(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		backend   Backend
+		model     string
+		defaults  *TunedModelDefaults
+		config    *UpdateModelConfig
+		wantPath  string
+		wantMask  string
+		wantBody  map[string]any
+		wantError bool
+	}{
+		{
+			name:     "GeminiAPI",
+			backend:  BackendGeminiAPI,
+			model:    "tunedModels/my-model",
+			defaults: &TunedModelDefaults{Temperature: Ptr[float32](0.5), TopK: Ptr[int32](20)},
+			config:   &UpdateModelConfig{DisplayName: "My model"},
+			wantPath: "/v1beta/tunedModels/my-model",
+			wantMask: "displayName,temperature,topK",
+			wantBody: map[string]any{"displayName": "My model", "temperature": 0.5, "topK": 20.0},
+		},
+		{
+			name:     "VertexAI",
+			backend:  BackendVertexAI,
+			model:    "projects/my-project/locations/us-central1/models/123",
+			config:   &UpdateModelConfig{Description: "Tuned on support tickets", DefaultCheckpointID: "2"},
+			wantPath: "/v1beta1/projects/my-project/locations/us-central1/models/123",
+			wantMask: "defaultCheckpointId,description",
+			wantBody: map[string]any{"description": "Tuned on support tickets", "defaultCheckpointId": "2"},
+		},
+		{
+			name:      "VertexAIGenerationConfig",
+			backend:   BackendVertexAI,
+			model:     "projects/my-project/locations/us-central1/models/123",
+			defaults:  &TunedModelDefaults{TopP: Ptr[float32](0.9)},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch {
+					t.Errorf("method = %s, want PATCH", r.Method)
+				}
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %s, want %s", r.URL.Path, tt.wantPath)
+				}
+				if got := r.URL.Query().Get("updateMask"); got != tt.wantMask {
+					t.Errorf("updateMask = %q, want %q", got, tt.wantMask)
+				}
+				var body map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				if diff := cmp.Diff(tt.wantBody, body); diff != "" {
+					t.Errorf("body mismatch (-want +got):\n%s", diff)
+				}
+				fmt.Fprintf(w, `{"name": %q, "displayName": "My model"}`, tt.model)
+			}))
+			defer ts.Close()
+			apiVersion := "v1beta"
+			if tt.backend == BackendVertexAI {
+				apiVersion = "v1beta1"
+			}
+			m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: tt.backend, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: apiVersion}, HTTPClient: ts.Client()}}}
+
+			got, err := m.UpdateWithDefaults(ctx, tt.model, tt.defaults, tt.config)
+			if tt.wantError {
+				if err == nil {
+					t.Fatal("UpdateWithDefaults() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != tt.model || got.DisplayName != "My model" {
+				t.Errorf("UpdateWithDefaults() = %+v, want the updated model", got)
+			}
+		})
+	}
+}
+
+func TestModelsDelete(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %s, want DELETE", r.Method)
+		}
+		if want := "/v1beta/tunedModels/my-model"; r.URL.Path != want {
+			t.Errorf("path = %s, want %s", r.URL.Path, want)
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta"}, HTTPClient: ts.Client()}}}
+
+	if _, err := m.Delete(context.Background(), "tunedModels/my-model", nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestModelsEditImage(t *testing.T) {
+	ctx := context.Background()
+	base := &Image{ImageBytes: []byte("base"), MIMEType: "image/png"}
+	mask := &Image{ImageBytes: []byte("mask"), MIMEType: "image/png"}
+	tests := []struct {
+		name            string
+		referenceImages []ReferenceImage
+		config          *EditImageConfig
+		wantInstance    map[string]any
+		wantParameters  map[string]any
+	}{
+		{
+			name: "Inpaint",
+			referenceImages: []ReferenceImage{
+				NewRawReferenceImage(base, 1),
+				NewMaskReferenceImage(mask, 2, &MaskReferenceConfig{MaskMode: MaskReferenceModeMaskModeUserProvided, MaskDilation: Ptr[float32](0.1)}),
+			},
+			config: &EditImageConfig{EditMode: EditModeInpaintInsertion, NumberOfImages: 1},
+			wantInstance: map[string]any{
+				"prompt": "a red hat",
+				"referenceImages": []any{
+					map[string]any{"referenceId": 1.0, "referenceType": "REFERENCE_TYPE_RAW", "referenceImage": map[string]any{"bytesBase64Encoded": "YmFzZQ==", "mimeType": "image/png"}},
+					map[string]any{"referenceId": 2.0, "referenceType": "REFERENCE_TYPE_MASK", "referenceImage": map[string]any{"bytesBase64Encoded": "bWFzaw==", "mimeType": "image/png"}, "maskImageConfig": map[string]any{"maskMode": "MASK_MODE_USER_PROVIDED", "dilation": 0.1}},
+				},
+			},
+			wantParameters: map[string]any{"editMode": "EDIT_MODE_INPAINT_INSERTION", "sampleCount": 1.0},
+		},
+		{
+			name:            "MaskFree",
+			referenceImages: []ReferenceImage{NewRawReferenceImage(base, 1)},
+			config:          &EditImageConfig{EditMode: EditModeDefault, BaseSteps: Ptr[int32](32), GuidanceScale: Ptr[float32](15)},
+			wantInstance: map[string]any{
+				"prompt": "a red hat",
+				"referenceImages": []any{
+					map[string]any{"referenceId": 1.0, "referenceType": "REFERENCE_TYPE_RAW", "referenceImage": map[string]any{"bytesBase64Encoded": "YmFzZQ==", "mimeType": "image/png"}},
+				},
+			},
+			wantParameters: map[string]any{"editMode": "EDIT_MODE_DEFAULT", "editConfig": map[string]any{"baseSteps": 32.0}, "guidanceScale": 15.0},
+		},
+		{
+			name: "Customization",
+			referenceImages: []ReferenceImage{
+				NewSubjectReferenceImage(base, 1, &SubjectReferenceConfig{SubjectType: SubjectReferenceTypeSubjectTypeProduct, SubjectDescription: "a mug"}),
+				NewStyleReferenceImage(mask, 2, &StyleReferenceConfig{StyleDescription: "watercolor"}),
+				NewControlReferenceImage(mask, 3, &ControlReferenceConfig{ControlType: ControlReferenceTypeCanny}),
+			},
+			config: &EditImageConfig{EditMode: EditModeDefault},
+			wantInstance: map[string]any{
+				"prompt": "a red hat",
+				"referenceImages": []any{
+					map[string]any{"referenceId": 1.0, "referenceType": "REFERENCE_TYPE_SUBJECT", "referenceImage": map[string]any{"bytesBase64Encoded": "YmFzZQ==", "mimeType": "image/png"}, "subjectImageConfig": map[string]any{"subjectType": "SUBJECT_TYPE_PRODUCT", "subjectDescription": "a mug"}},
+					map[string]any{"referenceId": 2.0, "referenceType": "REFERENCE_TYPE_STYLE", "referenceImage": map[string]any{"bytesBase64Encoded": "bWFzaw==", "mimeType": "image/png"}, "styleImageConfig": map[string]any{"styleDescription": "watercolor"}},
+					map[string]any{"referenceId": 3.0, "referenceType": "REFERENCE_TYPE_CONTROL", "referenceImage": map[string]any{"bytesBase64Encoded": "bWFzaw==", "mimeType": "image/png"}, "controlImageConfig": map[string]any{"controlType": "CONTROL_TYPE_CANNY"}},
+				},
+			},
+			wantParameters: map[string]any{"editMode": "EDIT_MODE_DEFAULT"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if want := "/v1beta1/projects/my-project/locations/us-central1/publishers/google/models/imagen-3.0-capability-001:predict"; r.URL.Path != want {
+					t.Errorf("path = %s, want %s", r.URL.Path, want)
+				}
+				var body struct {
+					Instances  []map[string]any `json:"instances"`
+					Parameters map[string]any   `json:"parameters"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				if len(body.Instances) != 1 {
+					t.Fatalf("got %d instances, want 1", len(body.Instances))
+				}
+				if diff := cmp.Diff(tt.wantInstance, body.Instances[0]); diff != "" {
+					t.Errorf("instance mismatch (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tt.wantParameters, body.Parameters); diff != "" {
+					t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+				}
+				fmt.Fprint(w, `{"predictions": [{"bytesBase64Encoded": "ZWRpdGVk", "mimeType": "image/png"}]}`)
+			}))
+			defer ts.Close()
+			m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+
+			resp, err := m.EditImage(ctx, "imagen-3.0-capability-001", "a red hat", tt.referenceImages, tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.GeneratedImages) != 1 || string(resp.GeneratedImages[0].Image.ImageBytes) != "edited" {
+				t.Errorf("GeneratedImages = %+v, want one edited image", resp.GeneratedImages)
+			}
+		})
+	}
+}
+
+func TestModelsUpscaleImage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/v1beta1/projects/my-project/locations/us-central1/publishers/google/models/imagen-4.0-upscale-preview:predict"; r.URL.Path != want {
+			t.Errorf("path = %s, want %s", r.URL.Path, want)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		want := map[string]any{
+			"instances": []any{map[string]any{"image": map[string]any{"bytesBase64Encoded": "aW1hZ2U=", "mimeType": "image/png"}}},
+			"parameters": map[string]any{
+				"mode":          "upscale",
+				"sampleCount":   1.0,
+				"outputOptions": map[string]any{"mimeType": "image/jpeg", "compressionQuality": 80.0},
+				"upscaleConfig": map[string]any{"upscaleFactor": "x4", "enhanceInputImage": true},
+			},
+		}
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Errorf("body mismatch (-want +got):\n%s", diff)
+		}
+		fmt.Fprint(w, `{"predictions": [{"bytesBase64Encoded": "dXBzY2FsZWQ=", "mimeType": "image/jpeg"}]}`)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+
+	image := &Image{ImageBytes: []byte("image"), MIMEType: "image/png"}
+	resp, err := m.UpscaleImage(context.Background(), "imagen-4.0-upscale-preview", image, "x4", &UpscaleImageConfig{
+		OutputMIMEType:           "image/jpeg",
+		OutputCompressionQuality: Ptr[int32](80),
+		EnhanceInputImage:        true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GeneratedImages) != 1 || string(resp.GeneratedImages[0].Image.ImageBytes) != "upscaled" {
+		t.Errorf("GeneratedImages = %+v, want one upscaled image", resp.GeneratedImages)
+	}
+}
+
+func TestModelsRecontextImage(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/v1beta1/projects/my-project/locations/us-central1/publishers/google/models/imagen-product-recontext-preview-06-30:predict"; r.URL.Path != want {
+			t.Errorf("path = %s, want %s", r.URL.Path, want)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		want := map[string]any{
+			"instances": []any{map[string]any{
+				"prompt": "on a kitchen counter",
+				"productImages": []any{
+					map[string]any{"image": map[string]any{"gcsUri": "gs://bucket/front.png"}},
+					map[string]any{"image": map[string]any{"gcsUri": "gs://bucket/side.png"}},
+				},
+			}},
+			"parameters": map[string]any{
+				"sampleCount":      2.0,
+				"personGeneration": "DONT_ALLOW",
+				"outputOptions":    map[string]any{"mimeType": "image/jpeg", "compressionQuality": 90.0},
+			},
+		}
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Errorf("body mismatch (-want +got):\n%s", diff)
+		}
+		fmt.Fprint(w, `{"predictions": [{"bytesBase64Encoded": "b25l", "mimeType": "image/jpeg"}, {"bytesBase64Encoded": "dHdv", "mimeType": "image/jpeg"}]}`)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+
+	source := &RecontextImageSource{
+		Prompt: "on a kitchen counter",
+		ProductImages: []*ProductImage{
+			{ProductImage: &Image{GCSURI: "gs://bucket/front.png"}},
+			{ProductImage: &Image{GCSURI: "gs://bucket/side.png"}},
+		},
+	}
+	resp, err := m.RecontextImage(context.Background(), "imagen-product-recontext-preview-06-30", source, &RecontextImageConfig{
+		NumberOfImages:           Ptr[int32](2),
+		PersonGeneration:         PersonGenerationDontAllow,
+		OutputMIMEType:           "image/jpeg",
+		OutputCompressionQuality: Ptr[int32](90),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GeneratedImages) != 2 || string(resp.GeneratedImages[1].Image.ImageBytes) != "two" {
+		t.Errorf("GeneratedImages = %+v, want two images", resp.GeneratedImages)
+	}
+
+	m.apiClient.clientConfig.Backend = BackendGeminiAPI
+	if _, err := m.RecontextImage(context.Background(), "imagen-product-recontext-preview-06-30", source, nil); err == nil {
+		t.Error("RecontextImage() on the Gemini API succeeded, want error")
+	}
+}
+
+func TestModelsGenerateVideosFirstAndLastFrame(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		backend    Backend
+		apiVersion string
+		wantPath   string
+	}{
+		{"GeminiAPI", BackendGeminiAPI, "v1beta", "/v1beta/models/veo-3.1-generate-preview:predictLongRunning"},
+		{"VertexAI", BackendVertexAI, "v1beta1", "/v1beta1/projects/my-project/locations/us-central1/publishers/google/models/veo-3.1-generate-preview:predictLongRunning"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %s, want %s", r.URL.Path, tt.wantPath)
+				}
+				var body map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				want := map[string]any{
+					"prompt":    "The flower blooms.",
+					"image":     map[string]any{"bytesBase64Encoded": "Zmlyc3Q=", "mimeType": "image/png"},
+					"lastFrame": map[string]any{"bytesBase64Encoded": "bGFzdA==", "mimeType": "image/png"},
+				}
+				if diff := cmp.Diff(want, body["instances"].([]any)[0]); diff != "" {
+					t.Errorf("instance mismatch (-want +got):\n%s", diff)
+				}
+				fmt.Fprint(w, `{"name": "operations/123"}`)
+			}))
+			defer ts.Close()
+			cc := &ClientConfig{Backend: tt.backend, HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: tt.apiVersion}, HTTPClient: ts.Client()}
+			if tt.backend == BackendVertexAI {
+				cc.Project, cc.Location = "my-project", "us-central1"
+			}
+			m := Models{apiClient: &apiClient{clientConfig: cc}}
+
+			source := &GenerateVideosSource{Prompt: "The flower blooms.", Image: &Image{ImageBytes: []byte("first"), MIMEType: "image/png"}}
+			config := &GenerateVideosConfig{LastFrame: &Image{ImageBytes: []byte("last"), MIMEType: "image/png"}}
+			op, err := m.GenerateVideosFromSource(ctx, "veo-3.1-generate-preview", source, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if op.Name != "operations/123" {
+				t.Errorf("operation name = %q, want %q", op.Name, "operations/123")
+			}
+		})
+	}
+}
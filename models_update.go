@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// TunedModelDefaults is the default generation config of a tuned model. It is
+// only supported by the Gemini API.
+type TunedModelDefaults struct {
+	// Optional. Default temperature used when generating content with the tuned
+	// model.
+	Temperature *float32 `json:"temperature,omitempty"`
+	// Optional. Default nucleus sampling threshold used when generating content
+	// with the tuned model.
+	TopP *float32 `json:"topP,omitempty"`
+	// Optional. Default top-k sampling threshold used when generating content
+	// with the tuned model.
+	TopK *int32 `json:"topK,omitempty"`
+}
+
+// UpdateWithDefaults updates a specific model resource like [Models.Update],
+// and also updates the set fields of the default generation config of a tuned
+// model. defaults may be nil. Only the fields that are set are updated, using
+// an update mask.
+func (m Models) UpdateWithDefaults(ctx context.Context, model string, defaults *TunedModelDefaults, config *UpdateModelConfig) (*Model, error) {
+	vertex := m.apiClient.clientConfig.Backend == BackendVertexAI
+	extra := map[string]any{}
+	if defaults != nil {
+		if defaults.Temperature != nil {
+			extra["temperature"] = *defaults.Temperature
+		}
+		if defaults.TopP != nil {
+			extra["topP"] = *defaults.TopP
+		}
+		if defaults.TopK != nil {
+			extra["topK"] = *defaults.TopK
+		}
+	}
+	if vertex && len(extra) > 0 {
+		return nil, fmt.Errorf("UpdateWithDefaults: temperature, topP and topK are only supported in Gemini Developer API mode, not in Gemini Enterprise Agent Platform mode")
+	}
+
+	parameterMap := make(map[string]any)
+	InternalDeepMarshal(map[string]any{"model": model, "config": config}, &parameterMap)
+	toConverter, fromConverter, pathTemplate := updateModelParametersToMldev, modelFromMldev, "{name}"
+	if vertex {
+		toConverter, fromConverter, pathTemplate = updateModelParametersToVertex, modelFromVertex, "{model}"
+	}
+	body, err := toConverter(m.apiClient, parameterMap, nil, parameterMap)
+	if err != nil {
+		return nil, err
+	}
+	urlParams, _ := body["_url"].(map[string]any)
+	delete(body, "_url")
+	path, err := InternalFormatMap(pathTemplate, urlParams)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url params: %#v.\n%w", urlParams, err)
+	}
+	for k, v := range extra {
+		body[k] = v
+	}
+	if mask := updateMask(body); mask != "" {
+		query, err := InternalCreateURLQuery(map[string]any{"updateMask": mask})
+		if err != nil {
+			return nil, err
+		}
+		path += "?" + query
+	}
+
+	httpOptions := &HTTPOptions{}
+	if config != nil && config.HTTPOptions != nil {
+		httpOptions = config.HTTPOptions
+	}
+	if httpOptions.Headers == nil {
+		httpOptions.Headers = http.Header{}
+	}
+	responseMap, err := sendRequest(ctx, m.apiClient, path, http.MethodPatch, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	responseMap, err = fromConverter(responseMap, nil, parameterMap)
+	if err != nil {
+		return nil, err
+	}
+	response := new(Model)
+	if err := InternalMapToStruct(responseMap, response); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// multimodalEmbeddingDimensions are the supported dimensions of multimodal
+// embeddings. Video embeddings only support the largest one.
+var multimodalEmbeddingDimensions = []int32{128, 256, 512, 1408}
+
+// MultimodalEmbeddingInput is the input of [Models.EmbedMultimodal]. Any
+// combination of text, image and video may be given, and each gets its own
+// embedding, in the same space.
+type MultimodalEmbeddingInput struct {
+	// Optional. Text to embed.
+	Text string
+	// Optional. Image to embed, given as bytes or as a Cloud Storage URI.
+	Image *Image
+	// Optional. Video to embed, given as bytes or as a Cloud Storage URI.
+	Video *Video
+	// Optional. Segment of the video to embed and length of the intervals that
+	// get an embedding each.
+	VideoSegmentConfig *VideoSegmentConfig
+}
+
+// VideoSegmentConfig selects the segment of a video to embed. Offsets and
+// intervals are sent in whole seconds.
+type VideoSegmentConfig struct {
+	// Optional. Start of the segment. Defaults to the start of the video.
+	StartOffset time.Duration
+	// Optional. End of the segment. Defaults to 120 seconds after the start.
+	EndOffset time.Duration
+	// Optional. Length of the intervals of the segment that get an embedding
+	// each, at least 4 seconds. Defaults to 16 seconds.
+	Interval time.Duration
+}
+
+// EmbedMultimodalConfig configures [Models.EmbedMultimodal].
+type EmbedMultimodalConfig struct {
+	// Optional. Used to override HTTP request options.
+	HTTPOptions *HTTPOptions
+	// Optional. Dimension of the text and image embeddings: 128, 256, 512 or
+	// 1408. Defaults to 1408, which is the only dimension supported with a
+	// video.
+	Dimension int32
+}
+
+// VideoSegmentEmbedding is the embedding of an interval of a video.
+type VideoSegmentEmbedding struct {
+	// StartOffset is the start of the interval.
+	StartOffset time.Duration
+	// EndOffset is the end of the interval.
+	EndOffset time.Duration
+	// Embedding is the embedding of the interval.
+	Embedding []float32
+}
+
+// EmbedMultimodalResponse is the result of [Models.EmbedMultimodal].
+type EmbedMultimodalResponse struct {
+	// TextEmbedding is the embedding of the text, if any.
+	TextEmbedding []float32
+	// ImageEmbedding is the embedding of the image, if any.
+	ImageEmbedding []float32
+	// VideoEmbeddings are the embeddings of the intervals of the video, if any.
+	VideoEmbeddings []*VideoSegmentEmbedding
+}
+
+// EmbedMultimodal embeds text, an image and a video with a multimodal
+// embedding model, such as multimodalembedding@001, for multimodal retrieval,
+// for example searching images with a text query.
+//
+// It's only supported in Vertex AI.
+func (m Models) EmbedMultimodal(ctx context.Context, model string, input *MultimodalEmbeddingInput, config *EmbedMultimodalConfig) (*EmbedMultimodalResponse, error) {
+	cfg := EmbedMultimodalConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if m.apiClient.clientConfig.Backend != BackendVertexAI {
+		return nil, fmt.Errorf("EmbedMultimodal: multimodal embeddings are only supported in Vertex AI")
+	}
+	if input == nil || (input.Text == "" && input.Image == nil && input.Video == nil) {
+		return nil, fmt.Errorf("EmbedMultimodal: the input must have a text, an image or a video")
+	}
+	if cfg.Dimension != 0 && !slices.Contains(multimodalEmbeddingDimensions, cfg.Dimension) {
+		return nil, fmt.Errorf("EmbedMultimodal: dimension must be one of %v, got %d", multimodalEmbeddingDimensions, cfg.Dimension)
+	}
+	if input.Video != nil && cfg.Dimension != 0 && cfg.Dimension != 1408 {
+		return nil, fmt.Errorf("EmbedMultimodal: video embeddings only support dimension 1408, got %d", cfg.Dimension)
+	}
+	if input.VideoSegmentConfig != nil && input.Video == nil {
+		return nil, fmt.Errorf("EmbedMultimodal: VideoSegmentConfig requires a video")
+	}
+
+	instance := map[string]any{}
+	if input.Text != "" {
+		instance["text"] = input.Text
+	}
+	if img := input.Image; img != nil {
+		data, err := mediaInstance(img.ImageBytes, img.GCSURI, img.MIMEType)
+		if err != nil {
+			return nil, fmt.Errorf("EmbedMultimodal: image: %w", err)
+		}
+		instance["image"] = data
+	}
+	if v := input.Video; v != nil {
+		data, err := mediaInstance(v.VideoBytes, v.URI, v.MIMEType)
+		if err != nil {
+			return nil, fmt.Errorf("EmbedMultimodal: video: %w", err)
+		}
+		if s := input.VideoSegmentConfig; s != nil {
+			segment := map[string]any{}
+			if s.StartOffset > 0 {
+				segment["startOffsetSec"] = int64(s.StartOffset / time.Second)
+			}
+			if s.EndOffset > 0 {
+				segment["endOffsetSec"] = int64(s.EndOffset / time.Second)
+			}
+			if s.Interval > 0 {
+				segment["intervalSec"] = int64(s.Interval / time.Second)
+			}
+			data["videoSegmentConfig"] = segment
+		}
+		instance["video"] = data
+	}
+	body := map[string]any{"instances": []any{instance}}
+	if cfg.Dimension != 0 {
+		body["parameters"] = map[string]any{"dimension": cfg.Dimension}
+	}
+
+	name, err := tModel(m.apiClient, model)
+	if err != nil {
+		return nil, fmt.Errorf("EmbedMultimodal: %w", err)
+	}
+	httpOptions := cfg.HTTPOptions
+	if httpOptions == nil {
+		httpOptions = &HTTPOptions{}
+	}
+	resp, err := sendRequest(ctx, m.apiClient, name+":predict", http.MethodPost, body, httpOptions)
+	if err != nil {
+		return nil, err
+	}
+	predictions, _ := resp["predictions"].([]any)
+	if len(predictions) == 0 {
+		return nil, fmt.Errorf("EmbedMultimodal: the response has no prediction")
+	}
+	p, _ := predictions[0].(map[string]any)
+	var prediction struct {
+		TextEmbedding   []float32 `json:"textEmbedding"`
+		ImageEmbedding  []float32 `json:"imageEmbedding"`
+		VideoEmbeddings []struct {
+			StartOffsetSec int64     `json:"startOffsetSec"`
+			EndOffsetSec   int64     `json:"endOffsetSec"`
+			Embedding      []float32 `json:"embedding"`
+		} `json:"videoEmbeddings"`
+	}
+	if err := mapToStruct(p, &prediction); err != nil {
+		return nil, fmt.Errorf("EmbedMultimodal: %w", err)
+	}
+	result := &EmbedMultimodalResponse{TextEmbedding: prediction.TextEmbedding, ImageEmbedding: prediction.ImageEmbedding}
+	for _, e := range prediction.VideoEmbeddings {
+		result.VideoEmbeddings = append(result.VideoEmbeddings, &VideoSegmentEmbedding{
+			StartOffset: time.Duration(e.StartOffsetSec) * time.Second,
+			EndOffset:   time.Duration(e.EndOffsetSec) * time.Second,
+			Embedding:   e.Embedding,
+		})
+	}
+	return result, nil
+}
+
+// mediaInstance returns the instance of an image or a video given as bytes or
+// as a Cloud Storage URI.
+func mediaInstance(data []byte, gcsURI, mimeType string) (map[string]any, error) {
+	instance := map[string]any{}
+	switch {
+	case len(data) > 0 && gcsURI != "":
+		return nil, fmt.Errorf("bytes and a URI can't both be set")
+	case len(data) > 0:
+		instance["bytesBase64Encoded"] = base64.StdEncoding.EncodeToString(data)
+	case gcsURI != "":
+		instance["gcsUri"] = gcsURI
+	default:
+		return nil, fmt.Errorf("bytes or a URI must be set")
+	}
+	if mimeType != "" {
+		instance["mimeType"] = mimeType
+	}
+	return instance, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEmbedMultimodal(t *testing.T) {
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/v1beta1/projects/my-project/locations/us-central1/publishers/google/models/multimodalembedding@001:predict"; r.URL.Path != want {
+			t.Errorf("path = %s, want %s", r.URL.Path, want)
+		}
+		body = nil
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		fmt.Fprint(w, `{"predictions": [{
+			"textEmbedding": [0.1, 0.2],
+			"imageEmbedding": [0.3, 0.4],
+			"videoEmbeddings": [
+				{"startOffsetSec": 0, "endOffsetSec": 4, "embedding": [0.5]},
+				{"startOffsetSec": 4, "endOffsetSec": 8, "embedding": [0.6]}
+			]
+		}]}`)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+	ctx := context.Background()
+
+	input := &MultimodalEmbeddingInput{
+		Text:               "a cat",
+		Image:              &Image{ImageBytes: []byte("png"), MIMEType: "image/png"},
+		Video:              &Video{URI: "gs://bucket/cat.mp4"},
+		VideoSegmentConfig: &VideoSegmentConfig{EndOffset: 8 * time.Second, Interval: 4 * time.Second},
+	}
+	got, err := m.EmbedMultimodal(ctx, "multimodalembedding@001", input, &EmbedMultimodalConfig{Dimension: 1408})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantBody := map[string]any{
+		"instances": []any{map[string]any{
+			"text":  "a cat",
+			"image": map[string]any{"bytesBase64Encoded": "cG5n", "mimeType": "image/png"},
+			"video": map[string]any{
+				"gcsUri":             "gs://bucket/cat.mp4",
+				"videoSegmentConfig": map[string]any{"endOffsetSec": 8.0, "intervalSec": 4.0},
+			},
+		}},
+		"parameters": map[string]any{"dimension": 1408.0},
+	}
+	if diff := cmp.Diff(wantBody, body); diff != "" {
+		t.Errorf("body mismatch (-want +got):\n%s", diff)
+	}
+	want := &EmbedMultimodalResponse{
+		TextEmbedding:  []float32{0.1, 0.2},
+		ImageEmbedding: []float32{0.3, 0.4},
+		VideoEmbeddings: []*VideoSegmentEmbedding{
+			{StartOffset: 0, EndOffset: 4 * time.Second, Embedding: []float32{0.5}},
+			{StartOffset: 4 * time.Second, EndOffset: 8 * time.Second, Embedding: []float32{0.6}},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("EmbedMultimodal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEmbedMultimodalErrors(t *testing.T) {
+	vertex := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "p", Location: "l"}}}
+	gemini := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI}}}
+	video := &Video{URI: "gs://bucket/cat.mp4"}
+	tests := []struct {
+		name   string
+		m      Models
+		input  *MultimodalEmbeddingInput
+		config *EmbedMultimodalConfig
+	}{
+		{name: "GeminiAPI", m: gemini, input: &MultimodalEmbeddingInput{Text: "a cat"}},
+		{name: "EmptyInput", m: vertex, input: &MultimodalEmbeddingInput{}},
+		{name: "InvalidDimension", m: vertex, input: &MultimodalEmbeddingInput{Text: "a cat"}, config: &EmbedMultimodalConfig{Dimension: 100}},
+		{name: "VideoDimension", m: vertex, input: &MultimodalEmbeddingInput{Video: video}, config: &EmbedMultimodalConfig{Dimension: 512}},
+		{name: "SegmentWithoutVideo", m: vertex, input: &MultimodalEmbeddingInput{Text: "a cat", VideoSegmentConfig: &VideoSegmentConfig{}}},
+		{name: "ImageWithoutData", m: vertex, input: &MultimodalEmbeddingInput{Image: &Image{}}},
+		{name: "VideoWithBytesAndURI", m: vertex, input: &MultimodalEmbeddingInput{Video: &Video{URI: "gs://b/v.mp4", VideoBytes: []byte("mp4")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.m.EmbedMultimodal(context.Background(), "multimodalembedding@001", tt.input, tt.config); err == nil {
+				t.Error("EmbedMultimodal() succeeded, want error")
+			}
+		})
+	}
+}
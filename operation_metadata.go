@@ -0,0 +1,171 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// OperationMetadata is the metadata of a long-running operation decoded into
+// typed fields. Services report different subsets of the fields, and the ones
+// that aren't reported are left to their zero value.
+type OperationMetadata struct {
+	// Type is the type URL of the metadata, such as
+	// type.googleapis.com/google.cloud.aiplatform.v1.GenericOperationMetadata.
+	Type string
+	// ProgressPercent is the completion of the operation from 0 to 100. It's nil
+	// if the service doesn't report progress.
+	ProgressPercent *float64
+	// CreateTime is the time the operation was created.
+	CreateTime time.Time
+	// UpdateTime is the time the operation was last updated.
+	UpdateTime time.Time
+	// EndTime is the time the operation finished running.
+	EndTime time.Time
+	// Target is the resource the operation acts on, such as the tuned model
+	// being created.
+	Target string
+	// Verb is the name of the action the operation runs.
+	Verb string
+	// StatusMessage is the human-readable status of the operation.
+	StatusMessage string
+	// CompletedSteps is the number of steps completed, for operations made of
+	// steps such as tuning.
+	CompletedSteps int64
+	// TotalSteps is the total number of steps of the operation.
+	TotalSteps int64
+}
+
+// TypedMetadata decodes the metadata of the operation. It returns nil if the
+// operation has no metadata.
+func (op *GenerateVideosOperation) TypedMetadata() (*OperationMetadata, error) {
+	return decodeOperationMetadata(op.Metadata)
+}
+
+// TypedMetadata decodes the metadata of the operation. It returns nil if the
+// operation has no metadata.
+func (op *TuningOperation) TypedMetadata() (*OperationMetadata, error) {
+	return decodeOperationMetadata(op.Metadata)
+}
+
+// TypedMetadata decodes the metadata of the operation. It returns nil if the
+// operation has no metadata.
+func (op *ImportFileOperation) TypedMetadata() (*OperationMetadata, error) {
+	return decodeOperationMetadata(op.Metadata)
+}
+
+// TypedMetadata decodes the metadata of the operation. It returns nil if the
+// operation has no metadata.
+func (op *UploadToFileSearchStoreOperation) TypedMetadata() (*OperationMetadata, error) {
+	return decodeOperationMetadata(op.Metadata)
+}
+
+// decodeOperationMetadata decodes the fields of the common operation metadata
+// messages: google.longrunning.OperationMetadata, the GenericOperationMetadata
+// nested by Vertex AI operations and the tuning metadata of the Gemini API.
+func decodeOperationMetadata(metadata map[string]any) (*OperationMetadata, error) {
+	if metadata == nil {
+		return nil, nil
+	}
+	var raw struct {
+		Type            string `json:"@type"`
+		Target          string `json:"target"`
+		TunedModel      string `json:"tunedModel"`
+		Verb            string `json:"verb"`
+		StatusMessage   string `json:"statusMessage"`
+		CreateTime      string `json:"createTime"`
+		UpdateTime      string `json:"updateTime"`
+		EndTime         string `json:"endTime"`
+		GenericMetadata *struct {
+			CreateTime string `json:"createTime"`
+			UpdateTime string `json:"updateTime"`
+		} `json:"genericMetadata"`
+	}
+	if err := mapToStruct(metadata, &raw); err != nil {
+		return nil, fmt.Errorf("decodeOperationMetadata: %w", err)
+	}
+	md := &OperationMetadata{
+		Type:          raw.Type,
+		Target:        raw.Target,
+		Verb:          raw.Verb,
+		StatusMessage: raw.StatusMessage,
+	}
+	if md.Target == "" {
+		md.Target = raw.TunedModel
+	}
+	createTime, updateTime := raw.CreateTime, raw.UpdateTime
+	if g := raw.GenericMetadata; g != nil {
+		createTime = cmp.Or(createTime, g.CreateTime)
+		updateTime = cmp.Or(updateTime, g.UpdateTime)
+	}
+	for _, t := range []struct {
+		name  string
+		value string
+		dst   *time.Time
+	}{
+		{"createTime", createTime, &md.CreateTime},
+		{"updateTime", updateTime, &md.UpdateTime},
+		{"endTime", raw.EndTime, &md.EndTime},
+	} {
+		if t.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, t.value)
+		if err != nil {
+			return nil, fmt.Errorf("decodeOperationMetadata: invalid %s: %w", t.name, err)
+		}
+		*t.dst = parsed
+	}
+	for _, key := range []string{"progressPercent", "progressPercentage", "completedPercent"} {
+		if v, ok := metadata[key]; ok {
+			p, err := metadataNumber(key, v)
+			if err != nil {
+				return nil, err
+			}
+			md.ProgressPercent = &p
+			break
+		}
+	}
+	for key, dst := range map[string]*int64{"completedSteps": &md.CompletedSteps, "totalSteps": &md.TotalSteps} {
+		if v, ok := metadata[key]; ok {
+			n, err := metadataNumber(key, v)
+			if err != nil {
+				return nil, err
+			}
+			*dst = int64(n)
+		}
+	}
+	return md, nil
+}
+
+// metadataNumber returns the value of a numeric metadata field. 64-bit
+// integers are encoded as JSON strings.
+func metadataNumber(key string, v any) (float64, error) {
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, fmt.Errorf("decodeOperationMetadata: invalid %s: %w", key, err)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("decodeOperationMetadata: invalid %s: %v", key, v)
+	}
+}
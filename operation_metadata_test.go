@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeOperationMetadata(t *testing.T) {
+	createTime := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	updateTime := time.Date(2026, 3, 1, 10, 5, 30, 500000000, time.UTC)
+	tests := []struct {
+		name     string
+		metadata map[string]any
+		want     *OperationMetadata
+	}{
+		{
+			name: "Nil",
+		},
+		{
+			name: "VertexGeneric",
+			metadata: map[string]any{
+				"@type":              "type.googleapis.com/google.cloud.aiplatform.v1.GenericOperationMetadata",
+				"genericMetadata":    map[string]any{"createTime": "2026-03-01T10:00:00Z", "updateTime": "2026-03-01T10:05:30.5Z"},
+				"progressPercentage": 40.0,
+			},
+			want: &OperationMetadata{
+				Type:            "type.googleapis.com/google.cloud.aiplatform.v1.GenericOperationMetadata",
+				ProgressPercent: Ptr(40.0),
+				CreateTime:      createTime,
+				UpdateTime:      updateTime,
+			},
+		},
+		{
+			name: "GeminiTuning",
+			metadata: map[string]any{
+				"@type":            "type.googleapis.com/google.ai.generativelanguage.v1beta.CreateTunedModelMetadata",
+				"tunedModel":       "tunedModels/my-model",
+				"totalSteps":       "40",
+				"completedSteps":   10.0,
+				"completedPercent": 25.0,
+			},
+			want: &OperationMetadata{
+				Type:            "type.googleapis.com/google.ai.generativelanguage.v1beta.CreateTunedModelMetadata",
+				Target:          "tunedModels/my-model",
+				ProgressPercent: Ptr(25.0),
+				CompletedSteps:  10,
+				TotalSteps:      40,
+			},
+		},
+		{
+			name: "LongRunning",
+			metadata: map[string]any{
+				"target":        "fileSearchStores/store/documents/doc",
+				"verb":          "import",
+				"statusMessage": "Importing",
+				"createTime":    "2026-03-01T10:00:00Z",
+				"endTime":       "2026-03-01T10:05:30.5Z",
+			},
+			want: &OperationMetadata{
+				Target:        "fileSearchStores/store/documents/doc",
+				Verb:          "import",
+				StatusMessage: "Importing",
+				CreateTime:    createTime,
+				EndTime:       updateTime,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := &GenerateVideosOperation{Metadata: tt.metadata}
+			got, err := op.TypedMetadata()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("TypedMetadata() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeOperationMetadataErrors(t *testing.T) {
+	for _, metadata := range []map[string]any{
+		{"createTime": "yesterday"},
+		{"progressPercent": "half"},
+		{"totalSteps": true},
+	} {
+		op := &TuningOperation{Metadata: metadata}
+		if _, err := op.TypedMetadata(); err == nil {
+			t.Errorf("TypedMetadata() with metadata %v succeeded, want error", metadata)
+		}
+	}
+}
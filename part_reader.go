@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// PartFromReaderConfig configures [NewPartFromReader] and [NewPartFromPath].
+type PartFromReaderConfig struct {
+	// Optional. MIME type of the data. If empty, it's derived from the
+	// extension of Name and otherwise detected from the content, as described
+	// in [http.DetectContentType].
+	MIMEType string
+	// Optional. Name of the file the data comes from, used to derive its MIME
+	// type. Defaults to the path for [NewPartFromPath].
+	Name string
+	// Optional. Maximum size of the data, in bytes. Larger data should be
+	// uploaded with [Files.Upload] instead of sent inline. Defaults to 10 MB.
+	MaxBytes int64
+}
+
+// NewPartFromReader reads r to the end and returns an inline data Part
+// holding its content. The MIME type is detected if not set in config, and an
+// error is returned if it can't be, or if r holds more than the size limit.
+func NewPartFromReader(r io.Reader, config *PartFromReaderConfig) (*Part, error) {
+	cfg := PartFromReaderConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.MaxBytes < 0 {
+		return nil, fmt.Errorf("NewPartFromReader: MaxBytes must be positive, got %d", cfg.MaxBytes)
+	}
+	if cfg.MaxBytes == 0 {
+		cfg.MaxBytes = defaultInlineFileMaxBytes
+	}
+	data, err := io.ReadAll(io.LimitReader(r, cfg.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("NewPartFromReader: %w", err)
+	}
+	if int64(len(data)) > cfg.MaxBytes {
+		return nil, fmt.Errorf("NewPartFromReader: data is larger than %d bytes, upload it with Files.Upload instead", cfg.MaxBytes)
+	}
+	mimeType := cfg.MIMEType
+	if mimeType == "" {
+		mimeType = detectMIMEType(cfg.Name, data)
+		if mimeType == "" {
+			return nil, fmt.Errorf("NewPartFromReader: could not determine the MIME type, please set PartFromReaderConfig.MIMEType")
+		}
+	}
+	return NewPartFromBytes(data, mimeType), nil
+}
+
+// NewPartFromPath returns an inline data Part holding the content of the file
+// at path, as [NewPartFromReader] does.
+func NewPartFromPath(path string, config *PartFromReaderConfig) (*Part, error) {
+	cfg := PartFromReaderConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.Name == "" {
+		cfg.Name = path
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("NewPartFromPath: %w", err)
+	}
+	defer f.Close()
+	return NewPartFromReader(f, &cfg)
+}
+
+// detectMIMEType returns the MIME type of a file from the extension of its
+// name, or else from its content, without parameters such as the charset. It
+// returns an empty string if the type can't be determined.
+func detectMIMEType(name string, data []byte) string {
+	mimeType := mime.TypeByExtension(filepath.Ext(name))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+		if mimeType == "application/octet-stream" {
+			return ""
+		}
+	}
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil {
+		return mediaType
+	}
+	return mimeType
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewPartFromReader(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	tests := []struct {
+		name    string
+		data    []byte
+		config  *PartFromReaderConfig
+		want    *Part
+		wantErr bool
+	}{
+		{name: "SniffedPNG", data: png, want: NewPartFromBytes(png, "image/png")},
+		{name: "SniffedText", data: []byte("hello"), want: NewPartFromBytes([]byte("hello"), "text/plain")},
+		{name: "Extension", data: []byte(`{"a": 1}`), config: &PartFromReaderConfig{Name: "data.json"}, want: NewPartFromBytes([]byte(`{"a": 1}`), "application/json")},
+		{name: "ExplicitMIMEType", data: png, config: &PartFromReaderConfig{MIMEType: "image/x-custom", Name: "a.jpg"}, want: NewPartFromBytes(png, "image/x-custom")},
+		{name: "AtLimit", data: []byte("hello"), config: &PartFromReaderConfig{MaxBytes: 5}, want: NewPartFromBytes([]byte("hello"), "text/plain")},
+		{name: "OverLimit", data: []byte("hello!"), config: &PartFromReaderConfig{MaxBytes: 5}, wantErr: true},
+		{name: "UnknownType", data: []byte{0, 1, 2, 3}, wantErr: true},
+		{name: "NegativeLimit", data: png, config: &PartFromReaderConfig{MaxBytes: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPartFromReader(bytes.NewReader(tt.data), tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPartFromReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewPartFromReader() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewPartFromPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "notes.md")
+	if err := os.WriteFile(path, []byte("# Notes"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewPartFromPath(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.InlineData == nil || string(got.InlineData.Data) != "# Notes" || !strings.HasPrefix(got.InlineData.MIMEType, "text/") {
+		t.Errorf("NewPartFromPath() = %+v, want the file as text", got.InlineData)
+	}
+	if _, err := NewPartFromPath(filepath.Join(dir, "missing.png"), nil); err == nil {
+		t.Errorf("NewPartFromPath() of a missing file succeeded, want error")
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import "strings"
+
+const (
+	stableVertexAPIVersion  = "v1"
+	previewVertexAPIVersion = "v1beta1"
+)
+
+// PreviewFeature is a group of Vertex AI calls that need the preview API
+// version, see [ClientConfig.PreviewFeatures].
+type PreviewFeature string
+
+const (
+	// PreviewFeatureImageEditing routes the calls of [Models.EditImage] and
+	// [Models.UpscaleImage] to the preview API version. [Models.RecontextImage]
+	// and [Models.SegmentImage] aren't routed: set the APIVersion of their
+	// HTTPOptions to call the preview API version.
+	PreviewFeatureImageEditing PreviewFeature = "IMAGE_EDITING"
+	// PreviewFeatureTools routes the calls of [Models.GenerateContent] and
+	// [Models.GenerateContentStream] that use tools only available in preview,
+	// such as URL context, Google Maps, computer use or MCP servers, to the
+	// preview API version.
+	PreviewFeatureTools PreviewFeature = "TOOLS"
+)
+
+// previewToolFields are the fields of a tool that the stable API version
+// doesn't support.
+var previewToolFields = []string{"computerUse", "fileSearch", "googleMaps", "enterpriseWebSearch", "parallelAiSearch", "urlContext", "mcpServers", "exaAiSearch"}
+
+// previewFeatureOf returns the preview feature that a request to path with
+// body belongs to, or "" if it belongs to none.
+func previewFeatureOf(path string, body map[string]any) PreviewFeature {
+	switch customMethod(path) {
+	case "generateContent", "streamGenerateContent":
+		for _, tool := range objects(body["tools"]) {
+			for _, field := range previewToolFields {
+				if tool[field] != nil {
+					return PreviewFeatureTools
+				}
+			}
+		}
+	case "predict":
+		// Upscaling sets the mode of the parameters, and editing sends
+		// reference images.
+		if parameters, ok := body["parameters"].(map[string]any); ok && parameters["mode"] == "upscale" {
+			return PreviewFeatureImageEditing
+		}
+		if instances := objects(body["instances"]); len(instances) > 0 && instances[0]["referenceImages"] != nil {
+			return PreviewFeatureImageEditing
+		}
+	}
+	return ""
+}
+
+// customMethod returns the custom method of a request to path, such as
+// "generateContent" for "models/gemini-2.5-flash:generateContent", or "" if
+// it has none.
+func customMethod(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	if i := strings.LastIndex(path, ":"); i >= 0 {
+		return path[i+1:]
+	}
+	return ""
+}
+
+// objects returns the JSON objects of a list in a request body.
+func objects(v any) []map[string]any {
+	switch v := v.(type) {
+	case []map[string]any:
+		return v
+	case []any:
+		var objs []map[string]any
+		for _, e := range v {
+			if obj, ok := e.(map[string]any); ok {
+				objs = append(objs, obj)
+			}
+		}
+		return objs
+	}
+	return nil
+}
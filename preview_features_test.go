@@ -0,0 +1,161 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPreviewFeatures(t *testing.T) {
+	var versions []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		versions = append(versions, version)
+		body := `{"candidates": [{"content": {"role": "model", "parts": [{"text": "ok"}]}}], "predictions": []}`
+		if strings.Contains(r.URL.Path, "streamGenerateContent") {
+			body = "data: " + body + "\n\n"
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer ts.Close()
+	newClient := func(t *testing.T, features []PreviewFeature) *Client {
+		t.Helper()
+		client, err := NewClient(context.Background(), &ClientConfig{
+			Backend:         BackendVertexAI,
+			Project:         "p",
+			Location:        "us-central1",
+			HTTPClient:      ts.Client(),
+			HTTPOptions:     HTTPOptions{BaseURL: ts.URL},
+			PreviewFeatures: features,
+			envVarProvider:  func() map[string]string { return nil },
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+	ctx := context.Background()
+	previewTools := &GenerateContentConfig{Tools: []*Tool{{URLContext: &URLContext{}}}}
+	stableTools := &GenerateContentConfig{Tools: []*Tool{{GoogleSearch: &GoogleSearch{}}}}
+
+	tests := []struct {
+		name     string
+		features []PreviewFeature
+		call     func(c *Client) error
+		want     string
+	}{
+		{
+			name: "Default",
+			call: func(c *Client) error {
+				_, err := c.Models.GenerateContent(ctx, "gemini-2.5-flash", Text("hi"), nil)
+				return err
+			},
+			want: "v1beta1",
+		},
+		{
+			name:     "StableCall",
+			features: []PreviewFeature{PreviewFeatureTools},
+			call: func(c *Client) error {
+				_, err := c.Models.GenerateContent(ctx, "gemini-2.5-flash", Text("hi"), stableTools)
+				return err
+			},
+			want: "v1",
+		},
+		{
+			name:     "PreviewTools",
+			features: []PreviewFeature{PreviewFeatureTools},
+			call: func(c *Client) error {
+				_, err := c.Models.GenerateContent(ctx, "gemini-2.5-flash", Text("hi"), previewTools)
+				return err
+			},
+			want: "v1beta1",
+		},
+		{
+			name:     "PreviewToolsStream",
+			features: []PreviewFeature{PreviewFeatureTools},
+			call: func(c *Client) error {
+				for _, err := range c.Models.GenerateContentStream(ctx, "gemini-2.5-flash", Text("hi"), previewTools) {
+					return err
+				}
+				return nil
+			},
+			want: "v1beta1",
+		},
+		{
+			name:     "FeatureNotEnabled",
+			features: []PreviewFeature{PreviewFeatureImageEditing},
+			call: func(c *Client) error {
+				_, err := c.Models.GenerateContent(ctx, "gemini-2.5-flash", Text("hi"), previewTools)
+				return err
+			},
+			want: "v1",
+		},
+		{
+			name:     "ImageEditing",
+			features: []PreviewFeature{PreviewFeatureImageEditing},
+			call: func(c *Client) error {
+				_, err := c.Models.UpscaleImage(ctx, "imagen", &Image{ImageBytes: []byte("img")}, "x2", nil)
+				return err
+			},
+			want: "v1beta1",
+		},
+		{
+			name:     "EditImage",
+			features: []PreviewFeature{PreviewFeatureImageEditing},
+			call: func(c *Client) error {
+				refs := []ReferenceImage{NewRawReferenceImage(&Image{ImageBytes: []byte("img")}, 1)}
+				_, err := c.Models.EditImage(ctx, "imagen", "a cat", refs, nil)
+				return err
+			},
+			want: "v1beta1",
+		},
+		{
+			name:     "RecontextImage",
+			features: []PreviewFeature{PreviewFeatureImageEditing},
+			call: func(c *Client) error {
+				source := &RecontextImageSource{ProductImages: []*ProductImage{{ProductImage: &Image{ImageBytes: []byte("img")}}}}
+				_, err := c.Models.RecontextImage(ctx, "imagen", source, nil)
+				return err
+			},
+			want: "v1",
+		},
+		{
+			name:     "RequestAPIVersion",
+			features: []PreviewFeature{PreviewFeatureTools},
+			call: func(c *Client) error {
+				config := &GenerateContentConfig{Tools: previewTools.Tools, HTTPOptions: &HTTPOptions{APIVersion: "v1alpha"}}
+				_, err := c.Models.GenerateContent(ctx, "gemini-2.5-flash", Text("hi"), config)
+				return err
+			},
+			want: "v1alpha",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions = nil
+			if err := tt.call(newClient(t, tt.features)); err != nil {
+				t.Fatal(err)
+			}
+			if len(versions) != 1 || versions[0] != tt.want {
+				t.Errorf("got API versions %q, want [%s]", versions, tt.want)
+			}
+		})
+	}
+}
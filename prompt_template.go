@@ -0,0 +1,250 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// PromptTemplate renders contents from a text/template whose data is a map of
+// variables. A variable holding a [*Part] or a []*Part, such as an image, is
+// inserted as parts of its own where the template prints it, so that
+// multimodal prompts can be written as templates:
+//
+//	tmpl, err := genai.NewPromptTemplate("Describe {{.photo}} in the style of {{.author}}.", nil)
+//	if err != nil {
+//		return err
+//	}
+//	contents, err := tmpl.Render(map[string]any{
+//		"photo":  genai.NewPartFromURI("gs://bucket/photo.jpg", "image/jpeg"),
+//		"author": "Hemingway",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	resp, err := client.Models.GenerateContent(ctx, model, contents, nil)
+//
+// Rendering fails if a variable used by the template is missing from the data,
+// or if the data has a variable that the template doesn't use, so that no
+// tokens are spent on incomplete prompts.
+type PromptTemplate struct {
+	tmpl         *template.Template
+	role         Role
+	placeholders []string
+}
+
+// PromptTemplateConfig configures a [PromptTemplate].
+type PromptTemplateConfig struct {
+	// Optional. The variables the template may use. If set, NewPromptTemplate
+	// fails if the template uses other variables.
+	Variables []string
+	// Optional. The role of the rendered content. Defaults to [RoleUser].
+	Role Role
+}
+
+// PromptTemplateError is returned by [PromptTemplate.Render] when the data
+// doesn't match the variables of the template.
+type PromptTemplateError struct {
+	// Missing are the variables used by the template that the data lacks.
+	Missing []string
+	// Unknown are the variables of the data that the template doesn't use.
+	Unknown []string
+}
+
+func (e *PromptTemplateError) Error() string {
+	var problems []string
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing variables "+strings.Join(e.Missing, ", "))
+	}
+	if len(e.Unknown) > 0 {
+		problems = append(problems, "unknown variables "+strings.Join(e.Unknown, ", "))
+	}
+	return "prompt template: " + strings.Join(problems, "; ")
+}
+
+// NewPromptTemplate parses text as a text/template. Variables are referred to
+// as fields of the data, such as {{.name}}, or as {{$.name}} inside range and
+// with actions.
+func NewPromptTemplate(text string, config *PromptTemplateConfig) (*PromptTemplate, error) {
+	cfg := PromptTemplateConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("NewPromptTemplate: %w", err)
+	}
+	placeholders := map[string]bool{}
+	// Associated templates, defined with {{define}}, are assumed to be executed
+	// with the data of the template.
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			collectPlaceholders(t.Tree.Root, true, placeholders)
+		}
+	}
+	names := make([]string, 0, len(placeholders))
+	for name := range placeholders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if cfg.Variables != nil {
+		for _, name := range names {
+			if !slices.Contains(cfg.Variables, name) {
+				return nil, fmt.Errorf("NewPromptTemplate: the template uses undeclared variable %q", name)
+			}
+		}
+	}
+	return &PromptTemplate{tmpl: tmpl, role: cmp.Or(cfg.Role, RoleUser), placeholders: names}, nil
+}
+
+// Placeholders returns the names of the variables used by the template, in
+// alphabetical order.
+func (t *PromptTemplate) Placeholders() []string {
+	return slices.Clone(t.placeholders)
+}
+
+// Validate returns a [*PromptTemplateError] if data lacks a variable used by
+// the template or has one that the template doesn't use.
+func (t *PromptTemplate) Validate(data map[string]any) error {
+	var e PromptTemplateError
+	for _, name := range t.placeholders {
+		if _, ok := data[name]; !ok {
+			e.Missing = append(e.Missing, name)
+		}
+	}
+	for name := range data {
+		if !slices.Contains(t.placeholders, name) {
+			e.Unknown = append(e.Unknown, name)
+		}
+	}
+	if len(e.Missing) == 0 && len(e.Unknown) == 0 {
+		return nil
+	}
+	sort.Strings(e.Unknown)
+	return &e
+}
+
+// mediaMarker matches the markers that stand for media parts in the text
+// rendered by the template.
+var mediaMarker = regexp.MustCompile("\x00media:([0-9]+)\x00")
+
+// Render validates data and renders the template into a single content. The
+// text around media parts becomes text parts, and empty text is dropped.
+func (t *PromptTemplate) Render(data map[string]any) ([]*Content, error) {
+	if err := t.Validate(data); err != nil {
+		return nil, err
+	}
+	var media []*Part
+	marker := func(p *Part) string {
+		media = append(media, p)
+		return fmt.Sprintf("\x00media:%d\x00", len(media)-1)
+	}
+	values := make(map[string]any, len(data))
+	for name, v := range data {
+		switch v := v.(type) {
+		case *Part:
+			values[name] = marker(v)
+		case []*Part:
+			var b strings.Builder
+			for _, p := range v {
+				b.WriteString(marker(p))
+			}
+			values[name] = b.String()
+		default:
+			values[name] = v
+		}
+	}
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, values); err != nil {
+		return nil, fmt.Errorf("prompt template: %w", err)
+	}
+	text := b.String()
+	var parts []*Part
+	addText := func(s string) {
+		if strings.TrimSpace(s) != "" {
+			parts = append(parts, NewPartFromText(s))
+		}
+	}
+	last := 0
+	for _, m := range mediaMarker.FindAllStringSubmatchIndex(text, -1) {
+		addText(text[last:m[0]])
+		i, _ := strconv.Atoi(text[m[2]:m[3]])
+		parts = append(parts, media[i])
+		last = m[1]
+	}
+	addText(text[last:])
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("prompt template: the rendered prompt is empty")
+	}
+	return []*Content{NewContentFromParts(parts, t.role)}, nil
+}
+
+// collectPlaceholders adds the names of the variables used by node to names.
+// rootDot tells whether dot is the data of the template, which isn't the case
+// inside range and with actions.
+func collectPlaceholders(node parse.Node, rootDot bool, names map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			collectPlaceholders(c, rootDot, names)
+		}
+	case *parse.ActionNode:
+		collectPlaceholders(n.Pipe, rootDot, names)
+	case *parse.IfNode:
+		collectPlaceholders(n.Pipe, rootDot, names)
+		collectPlaceholders(n.List, rootDot, names)
+		collectPlaceholders(n.ElseList, rootDot, names)
+	case *parse.RangeNode:
+		collectPlaceholders(n.Pipe, rootDot, names)
+		collectPlaceholders(n.List, false, names)
+		collectPlaceholders(n.ElseList, rootDot, names)
+	case *parse.WithNode:
+		collectPlaceholders(n.Pipe, rootDot, names)
+		collectPlaceholders(n.List, false, names)
+		collectPlaceholders(n.ElseList, rootDot, names)
+	case *parse.TemplateNode:
+		collectPlaceholders(n.Pipe, rootDot, names)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				collectPlaceholders(arg, rootDot, names)
+			}
+		}
+	case *parse.ChainNode:
+		collectPlaceholders(n.Node, rootDot, names)
+	case *parse.FieldNode:
+		if rootDot {
+			names[n.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			names[n.Ident[1]] = true
+		}
+	}
+}
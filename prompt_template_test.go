@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPromptTemplateRender(t *testing.T) {
+	photo := NewPartFromURI("gs://bucket/photo.jpg", "image/jpeg")
+	clip := NewPartFromURI("gs://bucket/clip.mp4", "video/mp4")
+	tests := []struct {
+		name string
+		text string
+		data map[string]any
+		role Role
+		want []*Content
+	}{
+		{
+			name: "Text",
+			text: "Translate {{.text}} to {{.language}}.",
+			data: map[string]any{"text": "hello", "language": "French"},
+			want: []*Content{{Role: RoleUser, Parts: []*Part{{Text: "Translate hello to French."}}}},
+		},
+		{
+			name: "Media",
+			text: "Compare {{.photo}} with{{.clips}}and tell which is {{.adjective}}.",
+			data: map[string]any{"photo": photo, "clips": []*Part{clip, clip}, "adjective": "brighter"},
+			want: []*Content{{Role: RoleUser, Parts: []*Part{
+				{Text: "Compare "},
+				photo,
+				{Text: " with"},
+				clip,
+				clip,
+				{Text: "and tell which is brighter."},
+			}}},
+		},
+		{
+			name: "MediaOnly",
+			text: "{{.photo}}\n",
+			data: map[string]any{"photo": photo},
+			role: RoleModel,
+			want: []*Content{{Role: RoleModel, Parts: []*Part{photo}}},
+		},
+		{
+			name: "RangeAndRoot",
+			text: "{{range .items}}- {{.}} ({{$.unit}})\n{{end}}",
+			data: map[string]any{"items": []string{"1", "2"}, "unit": "kg"},
+			want: []*Content{{Role: RoleUser, Parts: []*Part{{Text: "- 1 (kg)\n- 2 (kg)\n"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := NewPromptTemplate(tt.text, &PromptTemplateConfig{Role: tt.role})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := tmpl.Render(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Render() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPromptTemplatePlaceholders(t *testing.T) {
+	text := `{{define "footer"}}Signed {{.author}}{{end}}{{if .urgent}}URGENT {{end}}{{with .user}}{{.name}} {{$.greeting}}{{else}}{{.fallback}}{{end}} {{.body | printf "%q"}} {{template "footer" .}}`
+	tmpl, err := NewPromptTemplate(text, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"author", "body", "fallback", "greeting", "urgent", "user"}
+	if diff := cmp.Diff(want, tmpl.Placeholders()); diff != "" {
+		t.Errorf("Placeholders() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := NewPromptTemplate(text, &PromptTemplateConfig{Variables: want}); err != nil {
+		t.Errorf("NewPromptTemplate() with all variables declared = %v, want nil", err)
+	}
+	if _, err := NewPromptTemplate(text, &PromptTemplateConfig{Variables: want[1:]}); err == nil || !strings.Contains(err.Error(), `"author"`) {
+		t.Errorf("NewPromptTemplate() with an undeclared variable = %v, want error naming it", err)
+	}
+	if _, err := NewPromptTemplate("{{.unclosed", nil); err == nil {
+		t.Error("NewPromptTemplate() with invalid syntax succeeded, want error")
+	}
+}
+
+func TestPromptTemplateValidation(t *testing.T) {
+	tmpl, err := NewPromptTemplate("Summarize {{.document}} for {{.audience}}.", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tmpl.Render(map[string]any{"document": "text", "audiance": "kids", "tone": "fun"})
+	var perr *PromptTemplateError
+	if !errors.As(err, &perr) {
+		t.Fatalf("Render() = %v, want *PromptTemplateError", err)
+	}
+	want := &PromptTemplateError{Missing: []string{"audience"}, Unknown: []string{"audiance", "tone"}}
+	if diff := cmp.Diff(want, perr); diff != "" {
+		t.Errorf("Render() error mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := err.Error(), "prompt template: missing variables audience; unknown variables audiance, tone"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	empty, err := NewPromptTemplate("{{if .show}}text{{end}}", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := empty.Render(map[string]any{"show": false}); err == nil {
+		t.Error("Render() of an empty prompt succeeded, want error")
+	}
+}
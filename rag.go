@@ -0,0 +1,170 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const defaultRAGTopK = 5
+
+// DefaultRAGTemplate is the prompt template used by [RAGPipeline] when
+// [RAGPipelineConfig.Template] is empty.
+const DefaultRAGTemplate = `Answer the question using the documents below. If they don't contain the answer, say so.
+
+{{range .Documents}}<document{{with .ID}} id="{{.}}"{{end}}>
+{{.Text}}
+</document>
+{{end}}
+Question: {{.Question}}`
+
+// RetrievedDocument is a document found by a [VectorSearcher].
+type RetrievedDocument struct {
+	// Optional. ID identifies the document in the store.
+	ID string
+	// Text is the text of the document, inserted in the prompt.
+	Text string
+	// Optional. Score is the similarity of the document to the query, as
+	// reported by the store.
+	Score float64
+	// Optional. Metadata is any data the store returns with the document.
+	Metadata map[string]any
+}
+
+// VectorSearcher searches a vector store, such as a vector database, for the
+// documents closest to an embedding.
+type VectorSearcher interface {
+	// Search returns at most topK documents, the closest first.
+	Search(ctx context.Context, embedding []float32, topK int) ([]*RetrievedDocument, error)
+}
+
+// RAGPipelineConfig configures a [RAGPipeline].
+type RAGPipelineConfig struct {
+	// Required. Model used to embed the question. It must be the model that
+	// embedded the documents of the store.
+	EmbeddingModel string
+	// Required. Model used to answer the question.
+	GenerationModel string
+	// Required. Searches the documents.
+	Searcher VectorSearcher
+	// Optional. Number of documents to retrieve. Defaults to 5.
+	TopK int
+	// Optional. A text/template rendering the prompt from a [RAGPromptData].
+	// Defaults to [DefaultRAGTemplate].
+	Template string
+	// Optional. Config of the embedding request. TaskType defaults to
+	// RETRIEVAL_QUERY.
+	EmbedConfig *EmbedContentConfig
+	// Optional. Config of the generation request.
+	GenerateConfig *GenerateContentConfig
+}
+
+// RAGPromptData is the data the prompt template of a [RAGPipeline] is
+// executed with.
+type RAGPromptData struct {
+	// Question is the question being answered.
+	Question string
+	// Documents are the retrieved documents, the closest first.
+	Documents []*RetrievedDocument
+}
+
+// RAGResult is the outcome of [RAGPipeline.Run].
+type RAGResult struct {
+	// Response is the response of the generation model.
+	Response *GenerateContentResponse
+	// Documents are the documents the answer is grounded on.
+	Documents []*RetrievedDocument
+	// Prompt is the prompt sent to the generation model.
+	Prompt string
+}
+
+// RAGPipeline answers questions with retrieval-augmented generation: the
+// question is embedded, the closest documents are retrieved from a
+// [VectorSearcher], and the generation model answers from a prompt holding
+// the question and the documents.
+//
+//	pipeline, err := genai.NewRAGPipeline(client.Models, genai.RAGPipelineConfig{
+//		EmbeddingModel:  "gemini-embedding-001",
+//		GenerationModel: "gemini-2.5-flash",
+//		Searcher:        store,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	result, err := pipeline.Run(ctx, "How do I reset my password?")
+type RAGPipeline struct {
+	models   Models
+	config   RAGPipelineConfig
+	template *template.Template
+}
+
+// NewRAGPipeline returns a [RAGPipeline] using m to call the models.
+func NewRAGPipeline(m Models, config RAGPipelineConfig) (*RAGPipeline, error) {
+	if config.EmbeddingModel == "" || config.GenerationModel == "" {
+		return nil, fmt.Errorf("NewRAGPipeline: EmbeddingModel and GenerationModel are required")
+	}
+	if config.Searcher == nil {
+		return nil, fmt.Errorf("NewRAGPipeline: Searcher is required")
+	}
+	if config.TopK < 0 {
+		return nil, fmt.Errorf("NewRAGPipeline: TopK must be positive, got %d", config.TopK)
+	}
+	if config.TopK == 0 {
+		config.TopK = defaultRAGTopK
+	}
+	if config.Template == "" {
+		config.Template = DefaultRAGTemplate
+	}
+	tmpl, err := template.New("rag").Parse(config.Template)
+	if err != nil {
+		return nil, fmt.Errorf("NewRAGPipeline: parsing template: %w", err)
+	}
+	embedConfig := EmbedContentConfig{}
+	if config.EmbedConfig != nil {
+		embedConfig = *config.EmbedConfig
+	}
+	if embedConfig.TaskType == "" {
+		embedConfig.TaskType = EmbeddingTaskRetrievalQuery
+	}
+	config.EmbedConfig = &embedConfig
+	return &RAGPipeline{models: m, config: config, template: tmpl}, nil
+}
+
+// Run answers question from the documents retrieved for it.
+func (p *RAGPipeline) Run(ctx context.Context, question string) (*RAGResult, error) {
+	embedding, err := p.models.EmbedContent(ctx, p.config.EmbeddingModel, Text(question), p.config.EmbedConfig)
+	if err != nil {
+		return nil, fmt.Errorf("RAGPipeline: embedding the question: %w", err)
+	}
+	if len(embedding.Embeddings) == 0 || embedding.Embeddings[0] == nil {
+		return nil, fmt.Errorf("RAGPipeline: the embedding model returned no embedding")
+	}
+	docs, err := p.config.Searcher.Search(ctx, embedding.Embeddings[0].Values, p.config.TopK)
+	if err != nil {
+		return nil, fmt.Errorf("RAGPipeline: searching documents: %w", err)
+	}
+	var prompt strings.Builder
+	if err := p.template.Execute(&prompt, RAGPromptData{Question: question, Documents: docs}); err != nil {
+		return nil, fmt.Errorf("RAGPipeline: executing template: %w", err)
+	}
+	resp, err := p.models.GenerateContentWithOptions(ctx, p.config.GenerationModel, Text(prompt.String()), p.config.GenerateConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RAGResult{Response: resp, Documents: docs, Prompt: prompt.String()}, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type fakeSearcher struct {
+	gotEmbedding []float32
+	gotTopK      int
+	docs         []*RetrievedDocument
+	err          error
+}
+
+func (s *fakeSearcher) Search(ctx context.Context, embedding []float32, topK int) ([]*RetrievedDocument, error) {
+	s.gotEmbedding, s.gotTopK = embedding, topK
+	return s.docs, s.err
+}
+
+func TestRAGPipeline(t *testing.T) {
+	ctx := context.Background()
+	var gotTaskType, gotPrompt string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/models/gemini-embedding-001:batchEmbedContents"):
+			gotTaskType, _ = body["requests"].([]any)[0].(map[string]any)["taskType"].(string)
+			fmt.Fprint(w, `{"embeddings": [{"values": [0.5, 0.25]}]}`)
+		case strings.HasSuffix(r.URL.Path, "/models/gemini-2.5-flash:generateContent"):
+			var contents []*Content
+			data, _ := json.Marshal(body["contents"])
+			json.Unmarshal(data, &contents)
+			gotPrompt = contents[0].Parts[0].Text
+			fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "Click Forgot password."}]}}]}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+	searcher := &fakeSearcher{docs: []*RetrievedDocument{
+		{ID: "faq-1", Text: "To reset your password, click Forgot password."},
+		{Text: "Passwords expire every 90 days."},
+	}}
+
+	t.Run("DefaultTemplate", func(t *testing.T) {
+		p, err := NewRAGPipeline(m, RAGPipelineConfig{
+			EmbeddingModel:  "gemini-embedding-001",
+			GenerationModel: "gemini-2.5-flash",
+			Searcher:        searcher,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := p.Run(ctx, "How do I reset my password?")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Response.Text() != "Click Forgot password." || len(got.Documents) != 2 {
+			t.Errorf("Run() = %q with %d documents, want the answer with 2 documents", got.Response.Text(), len(got.Documents))
+		}
+		if gotTaskType != "RETRIEVAL_QUERY" {
+			t.Errorf("taskType = %q, want RETRIEVAL_QUERY", gotTaskType)
+		}
+		if fmt.Sprint(searcher.gotEmbedding) != "[0.5 0.25]" || searcher.gotTopK != defaultRAGTopK {
+			t.Errorf("Search(%v, %d), want Search([0.5 0.25], %d)", searcher.gotEmbedding, searcher.gotTopK, defaultRAGTopK)
+		}
+		for _, want := range []string{
+			"<document id=\"faq-1\">\nTo reset your password, click Forgot password.\n</document>",
+			"<document>\nPasswords expire every 90 days.\n</document>",
+			"Question: How do I reset my password?",
+		} {
+			if !strings.Contains(gotPrompt, want) {
+				t.Errorf("prompt %q doesn't contain %q", gotPrompt, want)
+			}
+		}
+		if got.Prompt != gotPrompt {
+			t.Errorf("Prompt = %q, want the prompt sent %q", got.Prompt, gotPrompt)
+		}
+	})
+
+	t.Run("CustomTemplate", func(t *testing.T) {
+		p, err := NewRAGPipeline(m, RAGPipelineConfig{
+			EmbeddingModel:  "gemini-embedding-001",
+			GenerationModel: "gemini-2.5-flash",
+			Searcher:        searcher,
+			TopK:            1,
+			Template:        "{{range .Documents}}{{.Text}} {{end}}Q: {{.Question}}",
+			EmbedConfig:     &EmbedContentConfig{TaskType: "QUESTION_ANSWERING"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.Run(ctx, "Why?"); err != nil {
+			t.Fatal(err)
+		}
+		if want := "To reset your password, click Forgot password. Passwords expire every 90 days. Q: Why?"; gotPrompt != want {
+			t.Errorf("prompt = %q, want %q", gotPrompt, want)
+		}
+		if gotTaskType != "QUESTION_ANSWERING" || searcher.gotTopK != 1 {
+			t.Errorf("taskType = %q, topK = %d, want QUESTION_ANSWERING and 1", gotTaskType, searcher.gotTopK)
+		}
+	})
+
+	t.Run("SearchError", func(t *testing.T) {
+		p, err := NewRAGPipeline(m, RAGPipelineConfig{
+			EmbeddingModel:  "gemini-embedding-001",
+			GenerationModel: "gemini-2.5-flash",
+			Searcher:        &fakeSearcher{err: errors.New("store unavailable")},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.Run(ctx, "Why?"); err == nil || !strings.Contains(err.Error(), "store unavailable") {
+			t.Errorf("Run() error = %v, want the search error", err)
+		}
+	})
+
+	for name, config := range map[string]RAGPipelineConfig{
+		"NoModels":     {Searcher: searcher},
+		"NoSearcher":   {EmbeddingModel: "e", GenerationModel: "g"},
+		"NegativeTopK": {EmbeddingModel: "e", GenerationModel: "g", Searcher: searcher, TopK: -1},
+		"BadTemplate":  {EmbeddingModel: "e", GenerationModel: "g", Searcher: searcher, Template: "{{.Question"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewRAGPipeline(m, config); err == nil {
+				t.Errorf("NewRAGPipeline() succeeded, want error")
+			}
+		})
+	}
+}
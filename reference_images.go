@@ -0,0 +1,39 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+// setReferenceTypes sets the reference type of the style and subject
+// reference images of an image editing request to path, which
+// [StyleReferenceImage] and [SubjectReferenceImage] send as control reference
+// images. They're told apart by their configs, so the ones without a config
+// keep the control type.
+func setReferenceTypes(path string, body map[string]any) {
+	if customMethod(path) != "predict" {
+		return
+	}
+	for _, instance := range objects(body["instances"]) {
+		for _, ref := range objects(instance["referenceImages"]) {
+			if ref["referenceType"] != "REFERENCE_TYPE_CONTROL" {
+				continue
+			}
+			switch {
+			case ref["styleImageConfig"] != nil:
+				ref["referenceType"] = "REFERENCE_TYPE_STYLE"
+			case ref["subjectImageConfig"] != nil:
+				ref["referenceType"] = "REFERENCE_TYPE_SUBJECT"
+			}
+		}
+	}
+}
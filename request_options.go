@@ -0,0 +1,87 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+)
+
+// RequestSigner signs a request for gateways that verify the integrity of the
+// payload. It's called with the request once its URL, headers and body are
+// final, and with the exact bytes of the body, which may be empty. It can
+// set headers on req, such as an HMAC of the body or a JWT, but must not
+// change its body. Returning an error fails the request without sending it.
+//
+// It applies to the JSON requests of the API. Uploads of file contents and
+// Live sessions aren't signed.
+type RequestSigner = func(req *http.Request, body []byte) error
+
+// HeadersProvider computes headers at the time of a request, from its
+// context, for example the ID of the end user or tracing baggage. The headers
+// are set after the static Headers and the headers of the SDK, replacing
+// those with the same name, except for Content-Type and the authentication
+// headers. Returning an error fails the request without sending it.
+//
+// It applies to the JSON requests of the API. Uploads of file contents and
+// Live sessions don't call it.
+type HeadersProvider = func(ctx context.Context) (http.Header, error)
+
+// RequestOptions are options of the SDK for the requests made with a context,
+// see [WithRequestOptions]. They apply in addition to the [HTTPOptions] of
+// the client and of the call.
+type RequestOptions struct {
+	// Optional. CheckRedirect is called before a download, such as
+	// [Files.Download], follows a redirect. Returning an error vetoes the
+	// redirect. See [http.Client.CheckRedirect] and
+	// [RejectCrossDomainRedirects].
+	CheckRedirect func(req *http.Request, via []*http.Request) error
+	// Optional. Project of the requests on Vertex AI, overriding the project
+	// of the client, for example to serve several tenants with a single
+	// client. The credentials of the client must have access to the project.
+	// Not supported with an API key.
+	Project string
+	// Optional. Location of the requests on Vertex AI, overriding the location
+	// of the client. Unless a BaseURL was set, the requests are sent to the
+	// endpoint of the location. Not supported with an API key.
+	Location string
+	// Optional. If true, the exact payload of the responses is kept in the
+	// Body of their SDKHTTPResponse field, as with
+	// [ClientConfig.IncludeResponseBody].
+	IncludeResponseBody bool
+	// Optional. Signs the requests, in place of [ClientConfig.RequestSigner].
+	RequestSigner RequestSigner
+	// Optional. Computes headers to send with the requests. If
+	// [ClientConfig.HeadersProvider] is set too, both are called, and the
+	// headers of this one win.
+	HeadersProvider HeadersProvider
+}
+
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a copy of ctx whose requests use options,
+// replacing those set on ctx, if any.
+func WithRequestOptions(ctx context.Context, options *RequestOptions) context.Context {
+	return context.WithValue(ctx, requestOptionsKey{}, options)
+}
+
+// requestOptionsFromContext returns the options set on ctx with
+// [WithRequestOptions]. It never returns nil.
+func requestOptionsFromContext(ctx context.Context) *RequestOptions {
+	if options, _ := ctx.Value(requestOptionsKey{}).(*RequestOptions); options != nil {
+		return options
+	}
+	return &RequestOptions{}
+}
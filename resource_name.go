@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResourceCollection is the collection of a resource, such as "models" in
+// "models/gemini-2.5-flash".
+type ResourceCollection string
+
+const (
+	// ResourceCollectionModels are base models, and models registered in
+	// Vertex AI.
+	ResourceCollectionModels ResourceCollection = "models"
+	// ResourceCollectionTunedModels are the tuned models of the Gemini API.
+	ResourceCollectionTunedModels ResourceCollection = "tunedModels"
+	// ResourceCollectionCachedContents are cached contents.
+	ResourceCollectionCachedContents ResourceCollection = "cachedContents"
+	// ResourceCollectionFiles are the files of the Gemini API.
+	ResourceCollectionFiles ResourceCollection = "files"
+	// ResourceCollectionBatches are the batch jobs of the Gemini API.
+	ResourceCollectionBatches ResourceCollection = "batches"
+	// ResourceCollectionBatchPredictionJobs are the batch jobs of Vertex AI.
+	ResourceCollectionBatchPredictionJobs ResourceCollection = "batchPredictionJobs"
+	// ResourceCollectionTuningJobs are the tuning jobs of Vertex AI.
+	ResourceCollectionTuningJobs ResourceCollection = "tuningJobs"
+	// ResourceCollectionEndpoints are the endpoints of Vertex AI, which serve
+	// tuned models.
+	ResourceCollectionEndpoints ResourceCollection = "endpoints"
+)
+
+var resourceCollections = map[ResourceCollection]bool{
+	ResourceCollectionModels:              true,
+	ResourceCollectionTunedModels:         true,
+	ResourceCollectionCachedContents:      true,
+	ResourceCollectionFiles:               true,
+	ResourceCollectionBatches:             true,
+	ResourceCollectionBatchPredictionJobs: true,
+	ResourceCollectionTuningJobs:          true,
+	ResourceCollectionEndpoints:           true,
+}
+
+// ResourceName is a resource name split into its components, following
+// https://google.aip.dev/122. Gemini API names, such as "cachedContents/abc",
+// have no project and location; Vertex AI names, such as
+// "projects/p/locations/us-central1/cachedContents/123", have both. Publisher
+// models, such as "publishers/google/models/gemini-2.5-flash", have a
+// publisher.
+type ResourceName struct {
+	// Project is the Vertex AI project ID or number.
+	Project string
+	// Location is the Vertex AI location.
+	Location string
+	// Publisher is the publisher of a publisher model, such as "google".
+	Publisher string
+	// Collection is the collection of the resource.
+	Collection ResourceCollection
+	// ID is the ID of the resource within its collection.
+	ID string
+}
+
+// ParseResourceName parses a resource name of a model, tuned model, cached
+// content, file, batch job, tuning job or endpoint.
+func ParseResourceName(name string) (ResourceName, error) {
+	var n ResourceName
+	segments := strings.Split(name, "/")
+	rest := segments
+	if len(rest) >= 4 && rest[0] == "projects" && rest[2] == "locations" {
+		n.Project, n.Location = rest[1], rest[3]
+		rest = rest[4:]
+	}
+	if len(rest) >= 2 && rest[0] == "publishers" {
+		n.Publisher = rest[1]
+		rest = rest[2:]
+	}
+	if len(rest) != 2 {
+		return ResourceName{}, fmt.Errorf("ParseResourceName: %q isn't a resource name", name)
+	}
+	n.Collection, n.ID = ResourceCollection(rest[0]), rest[1]
+	if !resourceCollections[n.Collection] {
+		return ResourceName{}, fmt.Errorf("ParseResourceName: unknown collection %q in %q", n.Collection, name)
+	}
+	if n.Publisher != "" && n.Collection != ResourceCollectionModels {
+		return ResourceName{}, fmt.Errorf("ParseResourceName: publishers only have models, got %q", name)
+	}
+	for _, s := range segments {
+		if s == "" {
+			return ResourceName{}, fmt.Errorf("ParseResourceName: %q has an empty segment", name)
+		}
+	}
+	return n, nil
+}
+
+// String returns the resource name. The project and the location are only
+// included if both are set.
+func (n ResourceName) String() string {
+	var b strings.Builder
+	if n.Project != "" && n.Location != "" {
+		fmt.Fprintf(&b, "projects/%s/locations/%s/", n.Project, n.Location)
+	}
+	if n.Publisher != "" {
+		fmt.Fprintf(&b, "publishers/%s/", n.Publisher)
+	}
+	fmt.Fprintf(&b, "%s/%s", n.Collection, n.ID)
+	return b.String()
+}
+
+// ResourceName returns the name of the resource with the given collection
+// and ID for the backend of the client: with the project and location of the
+// client on Vertex AI, and without them on the Gemini API.
+func (c *Client) ResourceName(collection ResourceCollection, id string) ResourceName {
+	n := ResourceName{Collection: collection, ID: id}
+	if c.clientConfig.Backend == BackendVertexAI {
+		n.Project, n.Location = c.clientConfig.Project, c.clientConfig.Location
+	}
+	return n
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseResourceName(t *testing.T) {
+	tests := []struct {
+		name string
+		want ResourceName
+	}{
+		{"models/gemini-2.5-flash", ResourceName{Collection: ResourceCollectionModels, ID: "gemini-2.5-flash"}},
+		{"tunedModels/my-model-123", ResourceName{Collection: ResourceCollectionTunedModels, ID: "my-model-123"}},
+		{"cachedContents/abc", ResourceName{Collection: ResourceCollectionCachedContents, ID: "abc"}},
+		{"files/xyz", ResourceName{Collection: ResourceCollectionFiles, ID: "xyz"}},
+		{"batches/b1", ResourceName{Collection: ResourceCollectionBatches, ID: "b1"}},
+		{"publishers/google/models/gemini-2.5-flash", ResourceName{Publisher: "google", Collection: ResourceCollectionModels, ID: "gemini-2.5-flash"}},
+		{"projects/p/locations/us-central1/cachedContents/123", ResourceName{Project: "p", Location: "us-central1", Collection: ResourceCollectionCachedContents, ID: "123"}},
+		{"projects/p/locations/us-central1/batchPredictionJobs/456", ResourceName{Project: "p", Location: "us-central1", Collection: ResourceCollectionBatchPredictionJobs, ID: "456"}},
+		{"projects/p/locations/us-central1/models/789@1", ResourceName{Project: "p", Location: "us-central1", Collection: ResourceCollectionModels, ID: "789@1"}},
+		{"projects/p/locations/global/publishers/google/models/gemini-2.5-pro", ResourceName{Project: "p", Location: "global", Publisher: "google", Collection: ResourceCollectionModels, ID: "gemini-2.5-pro"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseResourceName(tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseResourceName() mismatch (-want +got):\n%s", diff)
+			}
+			if got.String() != tt.name {
+				t.Errorf("String() = %q, want %q", got.String(), tt.name)
+			}
+		})
+	}
+}
+
+func TestParseResourceNameErrors(t *testing.T) {
+	for _, name := range []string{
+		"",
+		"gemini-2.5-flash",
+		"models/",
+		"models/a/b",
+		"datasets/d",
+		"publishers/google/files/f",
+		"projects/p/locations//cachedContents/c",
+		"projects/p/cachedContents/c",
+	} {
+		if got, err := ParseResourceName(name); err == nil {
+			t.Errorf("ParseResourceName(%q) = %+v, want an error", name, got)
+		}
+	}
+}
+
+func TestClientResourceName(t *testing.T) {
+	vertex := &Client{clientConfig: ClientConfig{Backend: BackendVertexAI, Project: "p", Location: "europe-west4"}}
+	if got, want := vertex.ResourceName(ResourceCollectionCachedContents, "123").String(), "projects/p/locations/europe-west4/cachedContents/123"; got != want {
+		t.Errorf("ResourceName() = %q, want %q", got, want)
+	}
+	gemini := &Client{clientConfig: ClientConfig{Backend: BackendGeminiAPI}}
+	if got, want := gemini.ResourceName(ResourceCollectionFiles, "abc").String(), "files/abc"; got != want {
+		t.Errorf("ResourceName() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"log"
+	"strings"
+)
+
+// ExecutableCodes returns all executable code parts of the first candidate in
+// the GenerateContentResponse, in order.
+func (r *GenerateContentResponse) ExecutableCodes() []*ExecutableCode {
+	var codes []*ExecutableCode
+	for _, part := range r.firstCandidateParts("executable code") {
+		if part.ExecutableCode != nil {
+			codes = append(codes, part.ExecutableCode)
+		}
+	}
+	return codes
+}
+
+// CodeExecutionResults returns all code execution results of the first
+// candidate in the GenerateContentResponse, in order.
+func (r *GenerateContentResponse) CodeExecutionResults() []*CodeExecutionResult {
+	var results []*CodeExecutionResult
+	for _, part := range r.firstCandidateParts("code execution results") {
+		if part.CodeExecutionResult != nil {
+			results = append(results, part.CodeExecutionResult)
+		}
+	}
+	return results
+}
+
+// InlineImages returns the inline images of the first candidate in the
+// GenerateContentResponse, such as the output of an image generation model.
+func (r *GenerateContentResponse) InlineImages() []*Blob {
+	var images []*Blob
+	for _, part := range r.firstCandidateParts("inline images") {
+		if part.InlineData != nil && strings.HasPrefix(part.InlineData.MIMEType, "image/") {
+			images = append(images, part.InlineData)
+		}
+	}
+	return images
+}
+
+// URLMetadata returns the URLs retrieved by the URL context tool for the
+// first candidate in the GenerateContentResponse, with the status of each
+// retrieval.
+func (r *GenerateContentResponse) URLMetadata() []*URLMetadata {
+	if r == nil || len(r.Candidates) == 0 || r.Candidates[0].URLContextMetadata == nil {
+		return nil
+	}
+	if len(r.Candidates) > 1 {
+		log.Printf("Warning: there are multiple candidates in the response, returning URL metadata from the first one.")
+	}
+	return r.Candidates[0].URLContextMetadata.URLMetadata
+}
+
+// firstCandidateParts returns the parts of the first candidate, logging a
+// warning naming what is returned if there are several candidates.
+func (r *GenerateContentResponse) firstCandidateParts(what string) []*Part {
+	if r == nil || len(r.Candidates) == 0 || r.Candidates[0].Content == nil {
+		return nil
+	}
+	if len(r.Candidates) > 1 {
+		log.Printf("Warning: there are multiple candidates in the response, returning %s from the first one.", what)
+	}
+	return r.Candidates[0].Content.Parts
+}
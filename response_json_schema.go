@@ -0,0 +1,59 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// checkResponseJsonSchema checks that the ResponseJsonSchema of c is a valid
+// JSON Schema object that model supports, so that misuses fail with a clear
+// error rather than with an error of the API. It returns a copy of c whose
+// ResponseMIMEType defaults to JSON when ResponseJsonSchema is set, leaving c
+// unchanged. Models of unknown families, such as tuned models, are assumed to
+// support ResponseJsonSchema.
+func (c *GenerateContentConfig) checkResponseJsonSchema(model string) (*GenerateContentConfig, error) {
+	if c == nil || c.ResponseJsonSchema == nil {
+		return c, nil
+	}
+	if c.ResponseSchema != nil {
+		return nil, fmt.Errorf("GenerateContentConfig: ResponseSchema and ResponseJsonSchema can't both be set")
+	}
+	data, err := json.Marshal(c.ResponseJsonSchema)
+	if err != nil {
+		return nil, fmt.Errorf("GenerateContentConfig: ResponseJsonSchema can't be encoded as JSON: %w", err)
+	}
+	var schema any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("GenerateContentConfig: ResponseJsonSchema isn't valid JSON: %w", err)
+	}
+	if _, ok := schema.(map[string]any); !ok {
+		return nil, fmt.Errorf("GenerateContentConfig: ResponseJsonSchema must be a JSON object, got %s", data)
+	}
+	switch c.ResponseMIMEType {
+	case "", JSONFormat.MIMEType:
+	default:
+		return nil, fmt.Errorf("GenerateContentConfig: ResponseJsonSchema requires ResponseMIMEType %s, got %s", JSONFormat.MIMEType, c.ResponseMIMEType)
+	}
+	id := model[strings.LastIndexByte(model, '/')+1:]
+	if known := lookupModelFamily(id); known != nil && !known.ResponseJSONSchema {
+		return nil, fmt.Errorf("GenerateContentConfig: %s doesn't support ResponseJsonSchema, set ResponseSchema instead", id)
+	}
+	cc := *c
+	cc.ResponseMIMEType = JSONFormat.MIMEType
+	return &cc, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckResponseJsonSchema(t *testing.T) {
+	schema := map[string]any{"type": "object", "properties": map[string]any{"name": map[string]any{"type": "string"}}}
+	tests := []struct {
+		name         string
+		model        string
+		config       *GenerateContentConfig
+		wantMIMEType string
+		wantErr      string
+	}{
+		{name: "NilConfig"},
+		{name: "NoSchema", config: &GenerateContentConfig{}},
+		{
+			name:         "DefaultsMIMEType",
+			config:       &GenerateContentConfig{ResponseJsonSchema: schema},
+			wantMIMEType: "application/json",
+		},
+		{
+			name:         "RawSchema",
+			config:       &GenerateContentConfig{ResponseJsonSchema: json.RawMessage(`{"type": "string"}`), ResponseMIMEType: "application/json"},
+			wantMIMEType: "application/json",
+		},
+		{
+			name:         "UnknownModel",
+			model:        "tunedModels/my-model",
+			config:       &GenerateContentConfig{ResponseJsonSchema: schema},
+			wantMIMEType: "application/json",
+		},
+		{
+			name:    "UnsupportedModel",
+			model:   "publishers/google/models/gemini-2.0-flash-001",
+			config:  &GenerateContentConfig{ResponseJsonSchema: schema},
+			wantErr: "gemini-2.0-flash-001 doesn't support ResponseJsonSchema, set ResponseSchema instead",
+		},
+		{
+			name:    "BothSchemas",
+			config:  &GenerateContentConfig{ResponseJsonSchema: schema, ResponseSchema: &Schema{Type: TypeString}},
+			wantErr: "can't both be set",
+		},
+		{
+			name:    "NotAnObject",
+			config:  &GenerateContentConfig{ResponseJsonSchema: json.RawMessage(`["string"]`)},
+			wantErr: "must be a JSON object",
+		},
+		{
+			name:    "InvalidJSON",
+			config:  &GenerateContentConfig{ResponseJsonSchema: json.RawMessage(`{"type":`)},
+			wantErr: "can't be encoded as JSON",
+		},
+		{
+			name:    "WrongMIMEType",
+			config:  &GenerateContentConfig{ResponseJsonSchema: schema, ResponseMIMEType: "text/x.enum"},
+			wantErr: "requires ResponseMIMEType application/json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := tt.model
+			if model == "" {
+				model = "gemini-2.5-flash"
+			}
+			var mimeType string
+			if tt.config != nil {
+				mimeType = tt.config.ResponseMIMEType
+			}
+			got, err := tt.config.checkResponseJsonSchema(model)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("checkResponseJsonSchema() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != nil && got.ResponseMIMEType != tt.wantMIMEType {
+				t.Errorf("ResponseMIMEType = %q, want %q", got.ResponseMIMEType, tt.wantMIMEType)
+			}
+			if tt.config != nil && tt.config.ResponseMIMEType != mimeType {
+				t.Errorf("ResponseMIMEType of the config = %q, want it unchanged: %q", tt.config.ResponseMIMEType, mimeType)
+			}
+		})
+	}
+}
+
+func TestGenerateContentResponseJsonSchema(t *testing.T) {
+	var generationConfig map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			GenerationConfig map[string]any `json:"generationConfig"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		generationConfig = req.GenerationConfig
+		const resp = `{"candidates": [{"content": {"role": "model", "parts": [{"text": "{\"name\": \"Ada\"}"}]}}]}`
+		if strings.HasSuffix(r.URL.Path, ":streamGenerateContent") {
+			fmt.Fprintf(w, "data: %s\n\n", resp)
+			return
+		}
+		fmt.Fprint(w, resp)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+	ctx := context.Background()
+
+	config := &GenerateContentConfig{ResponseJsonSchema: `{"type": "object", "properties": {"name": {"type": "string"}}}`}
+	if _, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("Name a mathematician"), config, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"responseMimeType":   "application/json",
+		"responseJsonSchema": map[string]any{"type": "object", "properties": map[string]any{"name": map[string]any{"type": "string"}}},
+	}
+	if diff := cmp.Diff(want, generationConfig); diff != "" {
+		t.Errorf("generationConfig mismatch (-want +got):\n%s", diff)
+	}
+
+	if config.ResponseMIMEType != "" {
+		t.Errorf("ResponseMIMEType of the config = %q, want it unchanged", config.ResponseMIMEType)
+	}
+
+	generationConfig = nil
+	for _, err := range m.GenerateContentStreamWithOptions(ctx, "gemini-2.0-flash", Text("Name a mathematician"), config, nil) {
+		if err == nil || !strings.Contains(err.Error(), "gemini-2.0-flash doesn't support ResponseJsonSchema") {
+			t.Errorf("GenerateContentStreamWithOptions() error = %v, want an unsupported model error", err)
+		}
+	}
+	if generationConfig != nil {
+		t.Error("GenerateContentStreamWithOptions() sent a request for an unsupported model")
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const defaultLanguageDetectionModel = "gemini-2.5-flash-lite"
+
+// ResponseLanguageConfig pins the language of the responses of
+// [Models.GenerateContentWithOptions] and
+// [Models.GenerateContentStreamWithOptions], set as
+// [GenerateContentOptions.ResponseLanguage].
+//
+// An instruction to answer in Language is added to the system instruction.
+// The language of the response text of GenerateContentWithOptions is then
+// checked and, if the model answered in another language, the request is
+// retried once, asking the model to answer again in Language. If the retry is
+// in the wrong language too, it's returned along with a
+// [*ResponseLanguageError].
+// Streamed responses only get the instruction: they aren't checked, since
+// their chunks are yielded before the language of the whole response is
+// known.
+type ResponseLanguageConfig struct {
+	// Required. BCP-47 code of the language of the responses, such as "fr" or
+	// "pt-BR". Responses match if their primary language subtag matches, so
+	// "fr-CA" matches "fr".
+	Language string
+	// Optional. Returns the BCP-47 code of the language of text. Defaults to
+	// asking DetectionModel, so plug a local language detection library here
+	// to save that request.
+	Detect func(ctx context.Context, text string) (string, error)
+	// Optional. Model that detects the language of the responses if Detect
+	// isn't set. Defaults to gemini-2.5-flash-lite.
+	DetectionModel string
+	// Optional. If true, responses in the wrong language aren't retried, and
+	// are returned along with a [*ResponseLanguageError].
+	DisableRetry bool
+}
+
+// ResponseLanguageError is returned along with a response in another language
+// than [ResponseLanguageConfig.Language].
+type ResponseLanguageError struct {
+	// Want is the pinned language.
+	Want string
+	// Got is the detected language of the response.
+	Got string
+}
+
+// Error returns a string representation of the ResponseLanguageError.
+func (e *ResponseLanguageError) Error() string {
+	return fmt.Sprintf("the response is in %q instead of %q", e.Got, e.Want)
+}
+
+// sameLanguage reports whether the BCP-47 codes a and b have the same primary
+// language subtag.
+func sameLanguage(a, b string) bool {
+	primary := func(tag string) string {
+		tag, _, _ = strings.Cut(strings.TrimSpace(tag), "-")
+		tag, _, _ = strings.Cut(tag, "_")
+		return strings.ToLower(tag)
+	}
+	return primary(a) == primary(b)
+}
+
+// withLanguageInstruction returns a copy of config, which may be nil, whose
+// system instruction asks to answer in language.
+func (c *GenerateContentConfig) withLanguageInstruction(language string) *GenerateContentConfig {
+	var cc GenerateContentConfig
+	if c != nil {
+		cc = *c
+	}
+	instruction := &Content{Role: RoleUser}
+	if cc.SystemInstruction != nil {
+		instruction.Role = cc.SystemInstruction.Role
+		instruction.Parts = append(instruction.Parts, cc.SystemInstruction.Parts...)
+	}
+	instruction.Parts = append(instruction.Parts, &Part{Text: fmt.Sprintf("Always answer in the language with BCP-47 code %q, whatever the language of the question.", language)})
+	cc.SystemInstruction = instruction
+	return &cc
+}
+
+// detectLanguage returns the language of text.
+func (m Models) detectLanguage(ctx context.Context, rl *ResponseLanguageConfig, text string) (string, error) {
+	if rl.Detect != nil {
+		return rl.Detect(ctx, text)
+	}
+	type detection struct {
+		Language string `json:"language" description:"BCP-47 code of the language of the text, such as en or fr."`
+	}
+	model := rl.DetectionModel
+	if model == "" {
+		model = defaultLanguageDetectionModel
+	}
+	prompt := fmt.Sprintf("What language is the following text written in?\n\n<text>\n%s\n</text>", text)
+	d, _, err := GenerateContentAs[detection](ctx, &m, model, Text(prompt), &GenerateContentConfig{Temperature: Ptr[float32](0)})
+	if err != nil {
+		return "", fmt.Errorf("detecting the response language: %w", err)
+	}
+	return d.Language, nil
+}
+
+// checkResponseLanguage checks the language of resp and retries the request
+// once if it's wrong. generate makes the request; it's called with the
+// contents to send.
+func (m Models) checkResponseLanguage(ctx context.Context, contents []*Content, rl *ResponseLanguageConfig, resp *GenerateContentResponse, generate func([]*Content) (*GenerateContentResponse, error)) (*GenerateContentResponse, error) {
+	for attempt := 0; ; attempt++ {
+		text := resp.Text()
+		if strings.TrimSpace(text) == "" {
+			return resp, nil
+		}
+		got, err := m.detectLanguage(ctx, rl, text)
+		if err != nil {
+			return resp, err
+		}
+		if sameLanguage(got, rl.Language) {
+			return resp, nil
+		}
+		if attempt > 0 || rl.DisableRetry {
+			return resp, &ResponseLanguageError{Want: rl.Language, Got: got}
+		}
+		var retryContents []*Content
+		retryContents = append(retryContents, contents...)
+		if c := firstCandidate(resp); c != nil && c.Content != nil {
+			retryContents = append(retryContents, c.Content)
+		}
+		retryContents = append(retryContents, &Content{Role: RoleUser, Parts: []*Part{{Text: fmt.Sprintf("Your answer isn't in the language with BCP-47 code %q. Answer again, in that language only.", rl.Language)}}})
+		resp, err = generate(retryContents)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
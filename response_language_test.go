@@ -0,0 +1,187 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// languageServer answers the requests to gemini-2.5-flash with answers, in
+// order, and the ones to gemini-2.5-flash-lite with a language detection of
+// the text to detect, taken as its language code. It records the requests to
+// gemini-2.5-flash.
+func languageServer(t *testing.T, answers ...string) (*httptest.Server, *[]map[string]any) {
+	t.Helper()
+	var requests []map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		var text string
+		if strings.Contains(r.URL.Path, "gemini-2.5-flash-lite") {
+			prompt := body["contents"].([]any)[0].(map[string]any)["parts"].([]any)[0].(map[string]any)["text"].(string)
+			_, rest, _ := strings.Cut(prompt, "<text>\n")
+			lang, _, _ := strings.Cut(rest, ":")
+			text = fmt.Sprintf(`{"language": %q}`, lang)
+		} else {
+			requests = append(requests, body)
+			text = answers[0]
+			answers = answers[1:]
+		}
+		fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": %q}]}, "finishReason": "STOP"}]}`, text)
+	}))
+	return ts, &requests
+}
+
+func TestResponseLanguage(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name         string
+		answers      []string
+		disableRetry bool
+		wantText     string
+		wantRequests int
+		wantErr      bool
+	}{
+		{
+			name:         "RightLanguage",
+			answers:      []string{"fr-CA: Bonjour"},
+			wantText:     "fr-CA: Bonjour",
+			wantRequests: 1,
+		},
+		{
+			name:         "Retried",
+			answers:      []string{"en: Hello", "fr: Bonjour"},
+			wantText:     "fr: Bonjour",
+			wantRequests: 2,
+		},
+		{
+			name:         "StillWrong",
+			answers:      []string{"en: Hello", "de: Hallo"},
+			wantText:     "de: Hallo",
+			wantRequests: 2,
+			wantErr:      true,
+		},
+		{
+			name:         "RetryDisabled",
+			answers:      []string{"en: Hello"},
+			disableRetry: true,
+			wantText:     "en: Hello",
+			wantRequests: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, requests := languageServer(t, tt.answers...)
+			defer ts.Close()
+			m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+
+			config := &GenerateContentConfig{
+				SystemInstruction: &Content{Parts: []*Part{{Text: "Be brief."}}},
+			}
+			options := &GenerateContentOptions{
+				ResponseLanguage: &ResponseLanguageConfig{Language: "fr", DisableRetry: tt.disableRetry},
+			}
+			resp, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("Say hello"), config, options)
+			var langErr *ResponseLanguageError
+			if tt.wantErr != errors.As(err, &langErr) {
+				t.Fatalf("GenerateContentWithOptions() error = %v, want a ResponseLanguageError: %v", err, tt.wantErr)
+			}
+			if err != nil && !tt.wantErr {
+				t.Fatal(err)
+			}
+			if got := resp.Text(); got != tt.wantText {
+				t.Errorf("Text() = %q, want %q", got, tt.wantText)
+			}
+			if len(*requests) != tt.wantRequests {
+				t.Fatalf("got %d requests, want %d", len(*requests), tt.wantRequests)
+			}
+			parts := (*requests)[0]["systemInstruction"].(map[string]any)["parts"].([]any)
+			if len(parts) != 2 || !strings.Contains(parts[1].(map[string]any)["text"].(string), `"fr"`) {
+				t.Errorf("system instruction parts = %v, want the instruction and the language", parts)
+			}
+			if len(config.SystemInstruction.Parts) != 1 {
+				t.Errorf("config system instruction was modified: %v", config.SystemInstruction.Parts)
+			}
+			if tt.wantRequests == 2 {
+				if got := len((*requests)[1]["contents"].([]any)); got != 3 {
+					t.Errorf("retry has %d contents, want 3", got)
+				}
+			}
+		})
+	}
+}
+
+func TestResponseLanguageDetect(t *testing.T) {
+	ts, _ := languageServer(t, "Hola")
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+
+	var detected []string
+	options := &GenerateContentOptions{ResponseLanguage: &ResponseLanguageConfig{
+		Language: "es",
+		Detect: func(ctx context.Context, text string) (string, error) {
+			detected = append(detected, text)
+			return "es-ES", nil
+		},
+	}}
+	resp, err := m.GenerateContentWithOptions(context.Background(), "gemini-2.5-flash", Text("Say hello"), nil, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Text() != "Hola" || len(detected) != 1 || detected[0] != "Hola" {
+		t.Errorf("got %q after detecting %q, want %q after detecting it once", resp.Text(), detected, "Hola")
+	}
+}
+
+func TestResponseLanguageStream(t *testing.T) {
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		fmt.Fprint(w, "data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"Hello\"}]}}]}\n\n")
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+
+	config := &GenerateContentConfig{}
+	options := &GenerateContentOptions{ResponseLanguage: &ResponseLanguageConfig{Language: "fr"}}
+	for resp, err := range m.GenerateContentStreamWithOptions(context.Background(), "gemini-2.5-flash", Text("Say hello"), config, options) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Streamed responses aren't checked.
+		if got := resp.Text(); got != "Hello" {
+			t.Errorf("Text() = %q, want %q", got, "Hello")
+		}
+	}
+	instruction, _ := body["systemInstruction"].(map[string]any)
+	if instruction == nil || !strings.Contains(fmt.Sprint(instruction["parts"]), `"fr"`) {
+		t.Errorf("systemInstruction = %v, want the instruction to answer in fr", instruction)
+	}
+	if config.SystemInstruction != nil {
+		t.Errorf("config system instruction was modified: %v", config.SystemInstruction)
+	}
+}
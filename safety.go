@@ -0,0 +1,234 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// presetSafetyCategories are the harm categories set by the safety settings
+// presets.
+var presetSafetyCategories = []HarmCategory{
+	HarmCategoryHarassment,
+	HarmCategoryHateSpeech,
+	HarmCategorySexuallyExplicit,
+	HarmCategoryDangerousContent,
+}
+
+func safetySettingsPreset(threshold HarmBlockThreshold) []*SafetySetting {
+	settings := make([]*SafetySetting, len(presetSafetyCategories))
+	for i, c := range presetSafetyCategories {
+		settings[i] = &SafetySetting{Category: c, Threshold: threshold}
+	}
+	return settings
+}
+
+// SafetySettingsBlockNone returns safety settings that block no content of the
+// harassment, hate speech, sexually explicit and dangerous content categories.
+// Content is still rated, and some content is always blocked.
+func SafetySettingsBlockNone() []*SafetySetting {
+	return safetySettingsPreset(HarmBlockThresholdBlockNone)
+}
+
+// SafetySettingsBlockFew returns safety settings that only block content with a
+// high probability of harm in the harassment, hate speech, sexually explicit
+// and dangerous content categories.
+func SafetySettingsBlockFew() []*SafetySetting {
+	return safetySettingsPreset(HarmBlockThresholdBlockOnlyHigh)
+}
+
+// SafetySettingsBlockMost returns safety settings that block content with a
+// low probability of harm or above in the harassment, hate speech, sexually
+// explicit and dangerous content categories.
+func SafetySettingsBlockMost() []*SafetySetting {
+	return safetySettingsPreset(HarmBlockThresholdBlockLowAndAbove)
+}
+
+// safetyFinishReasons are the finish reasons of responses blocked for safety.
+var safetyFinishReasons = map[FinishReason]bool{
+	FinishReasonSafety:                 true,
+	FinishReasonBlocklist:              true,
+	FinishReasonProhibitedContent:      true,
+	FinishReasonSPII:                   true,
+	FinishReasonImageSafety:            true,
+	FinishReasonImageProhibitedContent: true,
+}
+
+// SafetyBlockedError is returned when a prompt or a response was blocked for
+// safety, see [GenerateContentOptions.SafetyBlockedErrors].
+type SafetyBlockedError struct {
+	// BlockReason is the reason why the prompt was blocked. It's empty if the
+	// response was blocked.
+	BlockReason BlockedReason
+	// FinishReason is the reason why the response was blocked. It's empty if
+	// the prompt was blocked.
+	FinishReason FinishReason
+	// Message explains the block, if the API returned an explanation.
+	Message string
+	// Ratings are the safety ratings of the blocked prompt or response.
+	Ratings []*SafetyRating
+	// Response is the response that reported the block.
+	Response *GenerateContentResponse
+}
+
+// PromptBlocked reports whether the prompt was blocked, rather than the
+// response.
+func (e *SafetyBlockedError) PromptBlocked() bool {
+	return e.BlockReason != ""
+}
+
+// Unwrap returns a [*PromptBlockedError] if the prompt was blocked, so that
+// prompt blocks can be handled the same way whichever error is enabled.
+func (e *SafetyBlockedError) Unwrap() error {
+	if !e.PromptBlocked() {
+		return nil
+	}
+	return &PromptBlockedError{BlockReason: e.BlockReason, Message: e.Message, SafetyRatings: e.Ratings, Response: e.Response}
+}
+
+// BlockedRatings returns the ratings of the categories that caused the block.
+// If no rating is marked as blocked, it returns the ratings whose probability
+// is medium or high.
+func (e *SafetyBlockedError) BlockedRatings() []*SafetyRating {
+	var blocked, likely []*SafetyRating
+	for _, r := range e.Ratings {
+		if r == nil {
+			continue
+		}
+		if r.Blocked {
+			blocked = append(blocked, r)
+		}
+		if r.Probability == HarmProbabilityMedium || r.Probability == HarmProbabilityHigh {
+			likely = append(likely, r)
+		}
+	}
+	if len(blocked) > 0 {
+		return blocked
+	}
+	return likely
+}
+
+func (e *SafetyBlockedError) Error() string {
+	var b strings.Builder
+	if e.PromptBlocked() {
+		fmt.Fprintf(&b, "the prompt was blocked: %s", e.BlockReason)
+	} else {
+		fmt.Fprintf(&b, "the response was blocked: %s", e.FinishReason)
+	}
+	if ratings := e.BlockedRatings(); len(ratings) > 0 {
+		categories := make([]string, len(ratings))
+		for i, r := range ratings {
+			categories[i] = fmt.Sprintf("%s=%s", r.Category, r.Probability)
+		}
+		fmt.Fprintf(&b, " (%s)", strings.Join(categories, ", "))
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	return b.String()
+}
+
+// SafetyError returns a [*SafetyBlockedError] if the prompt was blocked or if
+// the first candidate was stopped for safety, and nil otherwise.
+func (r *GenerateContentResponse) SafetyError() error {
+	if r == nil {
+		return nil
+	}
+	if f := r.PromptFeedback; f != nil && f.BlockReason != "" {
+		return &SafetyBlockedError{
+			BlockReason: f.BlockReason,
+			Message:     f.BlockReasonMessage,
+			Ratings:     f.SafetyRatings,
+			Response:    r,
+		}
+	}
+	if c := firstCandidate(r); c != nil && safetyFinishReasons[c.FinishReason] {
+		return &SafetyBlockedError{
+			FinishReason: c.FinishReason,
+			Message:      c.FinishMessage,
+			Ratings:      c.SafetyRatings,
+			Response:     r,
+		}
+	}
+	return nil
+}
+
+// PromptBlockedError is returned when the prompt was blocked, see
+// [GenerateContentOptions.PromptBlockedErrors].
+type PromptBlockedError struct {
+	// BlockReason is the reason why the prompt was blocked.
+	BlockReason BlockedReason
+	// Message explains the block, if the API returned an explanation.
+	Message string
+	// SafetyRatings are the safety ratings of the prompt.
+	SafetyRatings []*SafetyRating
+	// Response is the response that reported the block.
+	Response *GenerateContentResponse
+}
+
+func (e *PromptBlockedError) Error() string {
+	msg := fmt.Sprintf("the prompt was blocked: %s", e.BlockReason)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// PromptError returns a [*PromptBlockedError] if the prompt was blocked, and
+// nil otherwise.
+func (r *GenerateContentResponse) PromptError() error {
+	if r == nil || r.PromptFeedback == nil || r.PromptFeedback.BlockReason == "" {
+		return nil
+	}
+	f := r.PromptFeedback
+	return &PromptBlockedError{BlockReason: f.BlockReason, Message: f.BlockReasonMessage, SafetyRatings: f.SafetyRatings, Response: r}
+}
+
+// blockError returns the error of a blocked generation enabled by o, if any.
+func (o *GenerateContentOptions) blockError(resp *GenerateContentResponse) error {
+	if o == nil {
+		return nil
+	}
+	if o.SafetyBlockedErrors {
+		if err := resp.SafetyError(); err != nil {
+			return err
+		}
+	}
+	if o.PromptBlockedErrors {
+		return resp.PromptError()
+	}
+	return nil
+}
+
+// stopOnBlock returns an iterator over the chunks of stream that yields the
+// first chunk for which blockError returns an error along with the error and
+// then stops.
+func stopOnBlock(stream iter.Seq2[*GenerateContentResponse, error], blockError func(*GenerateContentResponse) error) iter.Seq2[*GenerateContentResponse, error] {
+	return func(yield func(*GenerateContentResponse, error) bool) {
+		for chunk, err := range stream {
+			if err == nil {
+				if err := blockError(chunk); err != nil {
+					yield(chunk, err)
+					return
+				}
+			}
+			if !yield(chunk, err) {
+				return
+			}
+		}
+	}
+}
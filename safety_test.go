@@ -0,0 +1,165 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSafetySettingsPresets(t *testing.T) {
+	for _, tt := range []struct {
+		settings []*SafetySetting
+		want     HarmBlockThreshold
+	}{
+		{SafetySettingsBlockNone(), HarmBlockThresholdBlockNone},
+		{SafetySettingsBlockFew(), HarmBlockThresholdBlockOnlyHigh},
+		{SafetySettingsBlockMost(), HarmBlockThresholdBlockLowAndAbove},
+	} {
+		if len(tt.settings) != 4 {
+			t.Errorf("got %d settings, want 4", len(tt.settings))
+		}
+		for _, s := range tt.settings {
+			if s.Threshold != tt.want {
+				t.Errorf("%s threshold = %s, want %s", s.Category, s.Threshold, tt.want)
+			}
+		}
+	}
+	// Presets are fresh copies that can be modified.
+	SafetySettingsBlockFew()[0].Threshold = HarmBlockThresholdOff
+	if got := SafetySettingsBlockFew()[0].Threshold; got != HarmBlockThresholdBlockOnlyHigh {
+		t.Errorf("preset was modified: got threshold %s", got)
+	}
+}
+
+func TestSafetyBlockedError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prompt := `{"promptFeedback": {"blockReason": "SAFETY", "safetyRatings": [{"category": "HARM_CATEGORY_HARASSMENT", "probability": "NEGLIGIBLE"}, {"category": "HARM_CATEGORY_DANGEROUS_CONTENT", "probability": "HIGH", "blocked": true}]}}`
+		response := `{"candidates": [{"content": {"role": "model", "parts": [{"text": "Sure"}]}, "finishReason": "SAFETY", "safetyRatings": [{"category": "HARM_CATEGORY_HATE_SPEECH", "probability": "MEDIUM"}]}]}`
+		body := prompt
+		if strings.Contains(r.URL.Path, "response") {
+			body = response
+		}
+		if strings.HasSuffix(r.URL.Path, "streamGenerateContent") {
+			fmt.Fprintf(w, "data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"Hi\"}]}}]}\n\ndata: %s\n\n", body)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+	ctx := context.Background()
+
+	resp, err := m.GenerateContent(ctx, "prompt", Text("hi"), nil)
+	if err != nil {
+		t.Fatalf("GenerateContent() without SafetyBlockedErrors failed: %v", err)
+	}
+	if resp.SafetyError() == nil {
+		t.Error("SafetyError() = nil, want an error")
+	}
+
+	options := &GenerateContentOptions{SafetyBlockedErrors: true}
+	resp, err = m.GenerateContentWithOptions(ctx, "prompt", Text("hi"), nil, options)
+	var blocked *SafetyBlockedError
+	if !errors.As(err, &blocked) {
+		t.Fatalf("GenerateContentWithOptions() error = %v, want a *SafetyBlockedError", err)
+	}
+	if resp == nil || blocked.Response != resp {
+		t.Error("the blocked response wasn't returned")
+	}
+	if !blocked.PromptBlocked() || blocked.BlockReason != BlockedReasonSafety {
+		t.Errorf("got block reason %q, want SAFETY", blocked.BlockReason)
+	}
+	if want := "the prompt was blocked: SAFETY (HARM_CATEGORY_DANGEROUS_CONTENT=HIGH)"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	_, err = m.GenerateContentWithOptions(ctx, "response", Text("hi"), nil, options)
+	if !errors.As(err, &blocked) || blocked.PromptBlocked() || blocked.FinishReason != FinishReasonSafety {
+		t.Fatalf("GenerateContentWithOptions() error = %v, want a blocked response", err)
+	}
+	if want := "the response was blocked: SAFETY (HARM_CATEGORY_HATE_SPEECH=MEDIUM)"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	var texts []string
+	var streamErr error
+	for chunk, err := range m.GenerateContentStreamWithOptions(ctx, "response", Text("hi"), nil, options) {
+		if err != nil {
+			streamErr = err
+			break
+		}
+		texts = append(texts, chunk.Text())
+	}
+	if !errors.As(streamErr, &blocked) || blocked.FinishReason != FinishReasonSafety {
+		t.Errorf("GenerateContentStreamWithOptions() error = %v, want a blocked response", streamErr)
+	}
+	if len(texts) != 1 || texts[0] != "Hi" {
+		t.Errorf("got chunks %q before the block, want [Hi]", texts)
+	}
+}
+
+func TestPromptBlockedError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "allowed") {
+			fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "ok"}]}, "finishReason": "STOP"}]}`)
+			return
+		}
+		body := `{"promptFeedback": {"blockReason": "MODEL_ARMOR", "blockReasonMessage": "blocked by the template", "safetyRatings": [{"category": "HARM_CATEGORY_HARASSMENT", "probability": "LOW"}]}}`
+		if strings.HasSuffix(r.URL.Path, "streamGenerateContent") {
+			fmt.Fprintf(w, "data: %s\n\n", body)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+	ctx := context.Background()
+	options := &GenerateContentOptions{PromptBlockedErrors: true}
+
+	if _, err := m.GenerateContentWithOptions(ctx, "allowed", Text("hi"), nil, options); err != nil {
+		t.Fatalf("GenerateContentWithOptions() failed: %v", err)
+	}
+
+	resp, err := m.GenerateContentWithOptions(ctx, "blocked", Text("hi"), nil, options)
+	var blocked *PromptBlockedError
+	if !errors.As(err, &blocked) {
+		t.Fatalf("GenerateContentWithOptions() error = %v, want a *PromptBlockedError", err)
+	}
+	if blocked.BlockReason != BlockedReasonModelArmor || len(blocked.SafetyRatings) != 1 || blocked.Response != resp {
+		t.Errorf("got %+v, want the block reason, ratings and response", blocked)
+	}
+	if want := "the prompt was blocked: MODEL_ARMOR: blocked by the template"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	for _, err := range m.GenerateContentStreamWithOptions(ctx, "blocked", Text("hi"), nil, options) {
+		if !errors.As(err, &blocked) {
+			t.Errorf("GenerateContentStreamWithOptions() error = %v, want a *PromptBlockedError", err)
+		}
+		break
+	}
+
+	// A safety error of a blocked prompt is also a prompt blocked error.
+	_, err = m.GenerateContentWithOptions(ctx, "blocked", Text("hi"), nil, &GenerateContentOptions{SafetyBlockedErrors: true})
+	if !errors.As(err, &blocked) || blocked.BlockReason != BlockedReasonModelArmor {
+		t.Errorf("GenerateContentWithOptions() error = %v, want a *PromptBlockedError", err)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// SchemaFor returns a Schema describing the JSON encoding of T, for use as
+// [GenerateContentConfig.ResponseSchema] or [FunctionDeclaration.Parameters].
+//
+// Struct fields follow the field names and options of encoding/json. Pointer
+// fields and fields tagged omitempty or omitzero are optional, all other
+// fields are required. Properties are ordered like the struct fields, and
+// embedded structs are flattened. The fields of embedded struct pointers are
+// optional. The following struct tags are supported as
+// well:
+//
+//   - description: the description of the field.
+//   - enum: a comma separated list of allowed values. Only string fields may
+//     have an enum.
+//
+// For example:
+//
+//	type Recipe struct {
+//		Name       string   `json:"name" description:"The name of the recipe."`
+//		Difficulty string   `json:"difficulty" enum:"easy,medium,hard"`
+//		Steps      []string `json:"steps"`
+//		Rating     *float64 `json:"rating"`
+//	}
+//	schema, err := genai.SchemaFor[Recipe]()
+//
+// time.Time is a string in date-time format and []byte a base64 encoded
+// string. Maps must have string keys. Channels, functions, complex numbers and
+// recursive types can't be represented and return an error.
+func SchemaFor[T any]() (*Schema, error) {
+	return schemaForType(reflect.TypeFor[T]())
+}
+
+// schemaForType converts a Go type into a Schema. See [SchemaFor].
+func schemaForType(t reflect.Type) (*Schema, error) {
+	return schemaForTypeVisiting(t, map[reflect.Type]bool{})
+}
+
+func schemaForTypeVisiting(t reflect.Type, visiting map[reflect.Type]bool) (*Schema, error) {
+	if t == timeType {
+		return &Schema{Type: TypeString, Format: "date-time"}, nil
+	}
+	if t == rawMessageType {
+		return &Schema{}, nil
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		s, err := schemaForTypeVisiting(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		s.Nullable = Ptr(true)
+		return s, nil
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: TypeInteger, Format: "int32"}, nil
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: TypeInteger, Format: "int64"}, nil
+	case reflect.Float32:
+		return &Schema{Type: TypeNumber, Format: "float"}, nil
+	case reflect.Float64:
+		return &Schema{Type: TypeNumber, Format: "double"}, nil
+	case reflect.String:
+		return &Schema{Type: TypeString}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes []byte as a base64 string, but byte
+			// arrays as arrays of numbers.
+			return &Schema{Type: TypeString, Format: "byte"}, nil
+		}
+		items, err := schemaForTypeVisiting(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		s := &Schema{Type: TypeArray, Items: items}
+		if t.Kind() == reflect.Array {
+			s.MinItems = Ptr(int64(t.Len()))
+			s.MaxItems = Ptr(int64(t.Len()))
+		}
+		return s, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s: only string keys can be represented in a schema", t.Key())
+		}
+		// Schema can't describe the values of free-form objects.
+		return &Schema{Type: TypeObject}, nil
+	case reflect.Struct:
+		return structSchema(t, visiting)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool) (*Schema, error) {
+	fields, err := structFields(t, 0, true, visiting)
+	if err != nil {
+		return nil, err
+	}
+	byName := map[string][]int{}
+	for i, f := range fields {
+		byName[f.name] = append(byName[f.name], i)
+	}
+	s := &Schema{Type: TypeObject, Properties: map[string]*Schema{}}
+	for i, f := range fields {
+		if dominant, ok := dominantField(fields, byName[f.name]); !ok || dominant != i {
+			continue
+		}
+		s.Properties[f.name] = f.schema
+		s.PropertyOrdering = append(s.PropertyOrdering, f.name)
+		if f.required {
+			s.Required = append(s.Required, f.name)
+		}
+	}
+	return s, nil
+}
+
+// schemaField is a property of the schema of a struct, from one of its fields
+// or from a field of one of its embedded structs.
+type schemaField struct {
+	name   string
+	schema *Schema
+	// required is whether the field is always encoded: it isn't omitempty,
+	// and neither it nor the embedded structs it's in are pointers.
+	required bool
+	// depth is the number of embedded structs the field is in.
+	depth int
+	// tagged is whether the name comes from the json tag.
+	tagged bool
+}
+
+// structFields returns the fields of the struct type t that encoding/json
+// encodes, including those of its embedded structs, in order. Fields with the
+// same name are all returned, see dominantField.
+func structFields(t reflect.Type, depth int, required bool, visiting map[reflect.Type]bool) ([]schemaField, error) {
+	if visiting[t] {
+		return nil, fmt.Errorf("recursive type %s can't be represented in a schema", t)
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	var fields []schemaField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				// The fields of a nil embedded pointer are omitted.
+				embedded, err := structFields(ft, depth+1, required && field.Type.Kind() != reflect.Pointer, visiting)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, embedded...)
+				continue
+			}
+		}
+		tagged := name != ""
+		if !tagged {
+			name = field.Name
+		}
+		fs, err := schemaForTypeVisiting(field.Type, visiting)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if description, ok := field.Tag.Lookup("description"); ok {
+			fs.Description = description
+		}
+		if enum, ok := field.Tag.Lookup("enum"); ok {
+			if fs.Type != TypeString {
+				return nil, fmt.Errorf("field %s: enum is only supported on string fields, got %s", field.Name, field.Type)
+			}
+			fs.Format = "enum"
+			fs.Enum = strings.Split(enum, ",")
+		}
+		fields = append(fields, schemaField{
+			name:     name,
+			schema:   fs,
+			required: required && !omitEmpty && field.Type.Kind() != reflect.Pointer,
+			depth:    depth,
+			tagged:   tagged,
+		})
+	}
+	return fields, nil
+}
+
+// dominantField returns which of the fields at indexes, which have the same
+// name, encoding/json encodes: the least nested one, or else the only tagged
+// one among the least nested. It returns false if none dominates, in which
+// case encoding/json encodes none of them.
+func dominantField(fields []schemaField, indexes []int) (int, bool) {
+	minDepth := fields[indexes[0]].depth
+	for _, i := range indexes {
+		minDepth = min(minDepth, fields[i].depth)
+	}
+	dominant, count, tagged := -1, 0, 0
+	for _, i := range indexes {
+		if fields[i].depth != minDepth {
+			continue
+		}
+		count++
+		if fields[i].tagged {
+			tagged++
+			dominant = i
+		} else if tagged == 0 {
+			dominant = i
+		}
+	}
+	switch {
+	case count == 1:
+		return dominant, true
+	case tagged == 1:
+		return dominant, true
+	}
+	return 0, false
+}
+
+// jsonFieldName returns the name given to a struct field by its json tag, if
+// any, whether it is tagged omitempty, and whether encoding/json skips it.
+func jsonFieldName(field reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	if field.Anonymous {
+		t := field.Type
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		// Like encoding/json, keep embedded structs of unexported types, since
+		// they may have exported fields.
+		if !field.IsExported() && t.Kind() != reflect.Struct {
+			return "", false, true
+		}
+	} else if !field.IsExported() {
+		return "", false, true
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" || opt == "omitzero" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testBase struct {
+	ID string `json:"id"`
+}
+
+type testRecipe struct {
+	testBase
+	Name        string            `json:"name"`
+	Servings    int               `json:"servings,omitempty"`
+	Ingredients []string          `json:"ingredients"`
+	Rating      *float64          `json:"rating"`
+	Created     time.Time         `json:"created"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Internal    string            `json:"-"`
+	unexported  string
+}
+
+type testNode struct {
+	Children []testNode `json:"children"`
+}
+
+func TestSchemaForType(t *testing.T) {
+	got, err := schemaForType(reflect.TypeFor[testRecipe]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"id":          {Type: TypeString},
+			"name":        {Type: TypeString},
+			"servings":    {Type: TypeInteger, Format: "int32"},
+			"ingredients": {Type: TypeArray, Items: &Schema{Type: TypeString}},
+			"rating":      {Type: TypeNumber, Format: "double", Nullable: Ptr(true)},
+			"created":     {Type: TypeString, Format: "date-time"},
+			"labels":      {Type: TypeObject},
+		},
+		PropertyOrdering: []string{"id", "name", "servings", "ingredients", "rating", "created", "labels"},
+		Required:         []string{"id", "name", "ingredients", "created"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("schemaForType() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type testAudit struct {
+	Author string `json:"author"`
+}
+
+type testLabel string
+
+type testEmbedding struct {
+	*testAudit
+	testLabel
+	Title string `json:"title"`
+}
+
+func TestSchemaForTypeEmbedded(t *testing.T) {
+	got, err := schemaForType(reflect.TypeFor[testEmbedding]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"author": {Type: TypeString},
+			"title":  {Type: TypeString},
+		},
+		PropertyOrdering: []string{"author", "title"},
+		Required:         []string{"title"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("schemaForType() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+type testNamed struct {
+	Name  string
+	ID    string
+	Label string `json:"Kind"`
+}
+
+type testOther struct {
+	ID   string
+	Kind string
+}
+
+type testShadowing struct {
+	testNamed
+	testOther
+	Name string `description:"Outer name."`
+}
+
+func TestSchemaForTypeShadowing(t *testing.T) {
+	got, err := schemaForType(reflect.TypeFor[testShadowing]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The outer Name shadows the embedded one, the tagged Kind wins over the
+	// untagged one, and the embedded IDs, at the same depth, cancel out.
+	want := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"Kind": {Type: TypeString},
+			"Name": {Type: TypeString, Description: "Outer name."},
+		},
+		PropertyOrdering: []string{"Kind", "Name"},
+		Required:         []string{"Kind", "Name"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("schemaForType() mismatch (-want +got):\n%s", diff)
+	}
+
+	data, err := json.Marshal(testShadowing{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var encoded map[string]any
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want.PropertyOrdering, slices.Sorted(maps.Keys(encoded))); diff != "" {
+		t.Errorf("properties differ from the fields encoded by encoding/json (-schema +json):\n%s", diff)
+	}
+}
+
+func TestSchemaForTypeBytes(t *testing.T) {
+	for _, tc := range []struct {
+		typ  reflect.Type
+		want *Schema
+	}{
+		{reflect.TypeFor[[]byte](), &Schema{Type: TypeString, Format: "byte"}},
+		{reflect.TypeFor[[4]byte](), &Schema{
+			Type:     TypeArray,
+			Items:    &Schema{Type: TypeInteger, Format: "int32"},
+			MinItems: Ptr(int64(4)),
+			MaxItems: Ptr(int64(4)),
+		}},
+	} {
+		got, err := schemaForType(tc.typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("schemaForType(%s) mismatch (-want +got):\n%s", tc.typ, diff)
+		}
+	}
+}
+
+func TestSchemaForTypeErrors(t *testing.T) {
+	for _, typ := range []reflect.Type{
+		reflect.TypeFor[testNode](),
+		reflect.TypeFor[map[int]string](),
+		reflect.TypeFor[chan int](),
+	} {
+		if _, err := schemaForType(typ); err == nil {
+			t.Errorf("schemaForType(%s) got no error, want error", typ)
+		}
+	}
+}
+
+func TestSchemaForTags(t *testing.T) {
+	type task struct {
+		Title    string  `json:"title" description:"Short title."`
+		Priority *string `json:"priority" enum:"low,high"`
+	}
+	got, err := SchemaFor[task]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"title":    {Type: TypeString, Description: "Short title."},
+			"priority": {Type: TypeString, Format: "enum", Enum: []string{"low", "high"}, Nullable: Ptr(true)},
+		},
+		PropertyOrdering: []string{"title", "priority"},
+		Required:         []string{"title"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SchemaFor() mismatch (-want +got):\n%s", diff)
+	}
+
+	type badEnum struct {
+		Count int `json:"count" enum:"1,2"`
+	}
+	if _, err := SchemaFor[badEnum](); err == nil {
+		t.Errorf("SchemaFor() with enum on int field got no error, want error")
+	}
+}
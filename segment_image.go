@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// Decode decodes the mask, a grayscale PNG in which the pixels of the
+// segmented entity are white.
+func (m *GeneratedImageMask) Decode() (image.Image, error) {
+	if m.Mask == nil || len(m.Mask.ImageBytes) == 0 {
+		if m.Mask != nil && m.Mask.GCSURI != "" {
+			return nil, fmt.Errorf("Decode: the mask is stored at %s, download it first", m.Mask.GCSURI)
+		}
+		return nil, fmt.Errorf("Decode: the mask has no image bytes")
+	}
+	img, err := png.Decode(bytes.NewReader(m.Mask.ImageBytes))
+	if err != nil {
+		return nil, fmt.Errorf("Decode: %w", err)
+	}
+	return img, nil
+}
+
+// Alpha returns the mask as an alpha mask in which the pixels of the segmented
+// entity are opaque. Use it with [draw.DrawMask] to cut the entity out of the
+// source image or to edit it.
+func (m *GeneratedImageMask) Alpha() (*image.Alpha, error) {
+	img, err := m.Decode()
+	if err != nil {
+		return nil, err
+	}
+	bounds := img.Bounds()
+	alpha := image.NewAlpha(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			gray := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+			alpha.SetAlpha(x, y, color.Alpha{A: gray.Y})
+		}
+	}
+	return alpha, nil
+}
+
+// Coverage returns the fraction of the pixels of the mask that belong to the
+// segmented entity, that is whose intensity is at least half of the maximum.
+func (m *GeneratedImageMask) Coverage() (float64, error) {
+	alpha, err := m.Alpha()
+	if err != nil {
+		return 0, err
+	}
+	bounds := alpha.Bounds()
+	if bounds.Empty() {
+		return 0, nil
+	}
+	covered := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if alpha.AlphaAt(x, y).A >= 0x80 {
+				covered++
+			}
+		}
+	}
+	return float64(covered) / float64(bounds.Dx()*bounds.Dy()), nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// maskPNG returns a 4x2 grayscale PNG whose left half is white.
+func maskPNG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, 4, 2))
+	for y := range 2 {
+		for x := range 2 {
+			img.SetGray(x, y, color.Gray{Y: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestModelsSegmentImage(t *testing.T) {
+	mask := maskPNG(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/v1beta1/projects/my-project/locations/us-central1/publishers/google/models/image-segmentation-001:predict"; r.URL.Path != want {
+			t.Errorf("path = %s, want %s", r.URL.Path, want)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		want := map[string]any{
+			"instances":  []any{map[string]any{"prompt": "the cat", "image": map[string]any{"gcsUri": "gs://bucket/cat.png"}}},
+			"parameters": map[string]any{"mode": "PROMPT", "maxPredictions": 1.0},
+		}
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Errorf("body mismatch (-want +got):\n%s", diff)
+		}
+		fmt.Fprintf(w, `{"predictions": [{"bytesBase64Encoded": %q, "mimeType": "image/png", "labels": [{"label": "cat", "score": "0.9"}]}]}`, base64.StdEncoding.EncodeToString(mask))
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+
+	source := &SegmentImageSource{Prompt: "the cat", Image: &Image{GCSURI: "gs://bucket/cat.png"}}
+	resp, err := m.SegmentImage(context.Background(), "image-segmentation-001", source, &SegmentImageConfig{Mode: SegmentModePrompt, MaxPredictions: Ptr[int32](1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GeneratedMasks) != 1 {
+		t.Fatalf("got %d masks, want 1", len(resp.GeneratedMasks))
+	}
+	got := resp.GeneratedMasks[0]
+	if diff := cmp.Diff([]*EntityLabel{{Label: "cat", Score: 0.9}}, got.Labels); diff != "" {
+		t.Errorf("labels mismatch (-want +got):\n%s", diff)
+	}
+
+	alpha, err := got.Alpha()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := alpha.AlphaAt(0, 0).A; a != 0xff {
+		t.Errorf("alpha at (0, 0) = %d, want 255", a)
+	}
+	if a := alpha.AlphaAt(3, 1).A; a != 0 {
+		t.Errorf("alpha at (3, 1) = %d, want 0", a)
+	}
+	coverage, err := got.Coverage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if coverage != 0.5 {
+		t.Errorf("Coverage() = %v, want 0.5", coverage)
+	}
+}
+
+func TestGeneratedImageMaskDecodeErrors(t *testing.T) {
+	for _, mask := range []*GeneratedImageMask{
+		{},
+		{Mask: &Image{GCSURI: "gs://bucket/mask.png"}},
+		{Mask: &Image{ImageBytes: []byte("not a png")}},
+	} {
+		if _, err := mask.Decode(); err == nil {
+			t.Errorf("Decode() of %+v succeeded, want error", mask.Mask)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"runtime"
+	"sync"
+	"weak"
+)
+
+// sideTable holds values of the SDK attached to values of the generated types,
+// which only have the fields of the API. A value is attached to a pointer, not
+// to the value it points to, so copies don't have it. It's removed once the
+// pointer is garbage collected, so it must not refer back to it.
+//
+// A sideTable is safe for concurrent use.
+type sideTable[K, V any] struct {
+	m sync.Map // weak.Pointer[K] -> V
+}
+
+// get returns the value attached to k, or the zero value if there is none.
+func (t *sideTable[K, V]) get(k *K) V {
+	var v V
+	if k == nil {
+		return v
+	}
+	if e, ok := t.m.Load(weak.Make(k)); ok {
+		v = e.(V)
+	}
+	return v
+}
+
+// set attaches v to k, replacing the value attached to it, if any.
+func (t *sideTable[K, V]) set(k *K, v V) {
+	if k == nil {
+		return
+	}
+	wp := weak.Make(k)
+	if _, loaded := t.m.Swap(wp, v); !loaded {
+		runtime.AddCleanup(k, func(wp weak.Pointer[K]) { t.m.Delete(wp) }, wp)
+	}
+}
+
+// delete removes the value attached to k, if any.
+func (t *sideTable[K, V]) delete(k *K) {
+	if k != nil {
+		t.m.Delete(weak.Make(k))
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const defaultSignedURLExpiry = 15 * time.Minute
+
+// URLSigner returns a URL granting read access to an object of a Cloud
+// Storage bucket until expires. Wrap the storage client or signer of your
+// choice, for example with the cloud.google.com/go/storage package:
+//
+//	signer := func(ctx context.Context, bucket, object string, expires time.Time) (string, error) {
+//		return storageClient.Bucket(bucket).SignedURL(object, &storage.SignedURLOptions{
+//			Method:  http.MethodGet,
+//			Expires: expires,
+//		})
+//	}
+type URLSigner func(ctx context.Context, bucket, object string, expires time.Time) (string, error)
+
+// SignGCSURI returns a signed URL for the object at uri, such as
+// gs://bucket/path/to/video.mp4, valid for expiry. An expiry of zero defaults
+// to 15 minutes.
+func SignGCSURI(ctx context.Context, uri string, signer URLSigner, expiry time.Duration) (string, error) {
+	if expiry < 0 {
+		return "", fmt.Errorf("SignGCSURI: expiry must be positive, got %v", expiry)
+	}
+	if expiry == 0 {
+		expiry = defaultSignedURLExpiry
+	}
+	bucket, object, err := parseGCSURI(uri)
+	if err != nil {
+		return "", fmt.Errorf("SignGCSURI: %w", err)
+	}
+	url, err := signer(ctx, bucket, object, time.Now().Add(expiry))
+	if err != nil {
+		return "", fmt.Errorf("SignGCSURI: signing %s: %w", uri, err)
+	}
+	return url, nil
+}
+
+// SignedVideoURLs returns signed URLs, valid for expiry, for the videos
+// generated by a done operation whose output was written to Cloud Storage
+// with [GenerateVideosConfig.OutputGCSURI]. The URLs are in the order of the
+// generated videos.
+func SignedVideoURLs(ctx context.Context, operation *GenerateVideosOperation, signer URLSigner, expiry time.Duration) ([]string, error) {
+	if operation == nil || !operation.Done {
+		return nil, fmt.Errorf("SignedVideoURLs: the operation isn't done")
+	}
+	if operation.Response == nil {
+		return nil, fmt.Errorf("SignedVideoURLs: the operation has no response")
+	}
+	urls := make([]string, len(operation.Response.GeneratedVideos))
+	for i, v := range operation.Response.GeneratedVideos {
+		if v == nil || v.Video == nil || !strings.HasPrefix(v.Video.URI, "gs://") {
+			return nil, fmt.Errorf("SignedVideoURLs: video %d isn't stored in Cloud Storage", i)
+		}
+		url, err := SignGCSURI(ctx, v.Video.URI, signer, expiry)
+		if err != nil {
+			return nil, err
+		}
+		urls[i] = url
+	}
+	return urls, nil
+}
+
+// SignedImageURLs returns signed URLs, valid for expiry, for images generated
+// to Cloud Storage, such as the GeneratedImages of a [GenerateImagesResponse]
+// when [GenerateImagesConfig.OutputGCSURI] is set. The URLs are in the order
+// of the images.
+func SignedImageURLs(ctx context.Context, images []*GeneratedImage, signer URLSigner, expiry time.Duration) ([]string, error) {
+	urls := make([]string, len(images))
+	for i, image := range images {
+		if image == nil || image.Image == nil || image.Image.GCSURI == "" {
+			return nil, fmt.Errorf("SignedImageURLs: image %d isn't stored in Cloud Storage", i)
+		}
+		url, err := SignGCSURI(ctx, image.Image.GCSURI, signer, expiry)
+		if err != nil {
+			return nil, err
+		}
+		urls[i] = url
+	}
+	return urls, nil
+}
+
+// parseGCSURI splits a gs://bucket/object URI.
+func parseGCSURI(uri string) (bucket, object string, err error) {
+	rest, ok := strings.CutPrefix(uri, "gs://")
+	if !ok {
+		return "", "", fmt.Errorf("%q isn't a Cloud Storage URI", uri)
+	}
+	bucket, object, _ = strings.Cut(rest, "/")
+	if bucket == "" || object == "" {
+		return "", "", fmt.Errorf("%q doesn't name a Cloud Storage object", uri)
+	}
+	return bucket, object, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSignedURLs(t *testing.T) {
+	ctx := context.Background()
+	var gotExpires time.Time
+	signer := func(ctx context.Context, bucket, object string, expires time.Time) (string, error) {
+		gotExpires = expires
+		return "https://storage.example.com/" + bucket + "/" + object + "?signed", nil
+	}
+
+	t.Run("Videos", func(t *testing.T) {
+		op := &GenerateVideosOperation{Done: true, Response: &GenerateVideosResponse{GeneratedVideos: []*GeneratedVideo{
+			{Video: &Video{URI: "gs://bucket/out/1.mp4"}},
+			{Video: &Video{URI: "gs://bucket/out/2.mp4"}},
+		}}}
+		start := time.Now()
+		got, err := SignedVideoURLs(ctx, op, signer, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"https://storage.example.com/bucket/out/1.mp4?signed", "https://storage.example.com/bucket/out/2.mp4?signed"}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("SignedVideoURLs() mismatch (-want +got):\n%s", diff)
+		}
+		if gotExpires.Before(start.Add(time.Hour)) || gotExpires.After(time.Now().Add(time.Hour)) {
+			t.Errorf("expires = %v, want an hour from now", gotExpires)
+		}
+	})
+
+	t.Run("Images", func(t *testing.T) {
+		images := []*GeneratedImage{{Image: &Image{GCSURI: "gs://bucket/image.png"}}}
+		got, err := SignedImageURLs(ctx, images, signer, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"https://storage.example.com/bucket/image.png?signed"}, got); diff != "" {
+			t.Errorf("SignedImageURLs() mismatch (-want +got):\n%s", diff)
+		}
+		if d := time.Until(gotExpires); d <= 0 || d > defaultSignedURLExpiry {
+			t.Errorf("expires in %v, want the default expiry", d)
+		}
+	})
+
+	errorTests := []struct {
+		name string
+		sign func() error
+	}{
+		{"NotDone", func() error {
+			_, err := SignedVideoURLs(ctx, &GenerateVideosOperation{}, signer, 0)
+			return err
+		}},
+		{"InlineVideo", func() error {
+			op := &GenerateVideosOperation{Done: true, Response: &GenerateVideosResponse{GeneratedVideos: []*GeneratedVideo{{Video: &Video{VideoBytes: []byte("video")}}}}}
+			_, err := SignedVideoURLs(ctx, op, signer, 0)
+			return err
+		}},
+		{"InlineImage", func() error {
+			_, err := SignedImageURLs(ctx, []*GeneratedImage{{Image: &Image{ImageBytes: []byte("image")}}}, signer, 0)
+			return err
+		}},
+		{"BucketOnly", func() error {
+			_, err := SignGCSURI(ctx, "gs://bucket/", signer, 0)
+			return err
+		}},
+		{"NegativeExpiry", func() error {
+			_, err := SignGCSURI(ctx, "gs://bucket/object", signer, -time.Second)
+			return err
+		}},
+		{"SignerError", func() error {
+			_, err := SignGCSURI(ctx, "gs://bucket/object", func(context.Context, string, string, time.Time) (string, error) {
+				return "", errors.New("no signing key")
+			}, 0)
+			return err
+		}},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.sign(); err == nil {
+				t.Errorf("signing succeeded, want error")
+			}
+		})
+	}
+}
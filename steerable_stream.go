@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var experimentalWarningSteerableStream sync.Once
+
+// SteerableStream is a streamed generation that can be interrupted and
+// steered with a new user message, see [Models.GenerateContentSteerable].
+type SteerableStream struct {
+	ctx    context.Context
+	models Models
+	model  string
+	config *GenerateContentConfig
+
+	mu       sync.Mutex
+	history  []*Content
+	steering []string
+	cancel   context.CancelFunc
+	started  bool
+	done     bool
+	steers   int
+}
+
+// GenerateContentSteerable starts a streamed generation that can be steered
+// while it's generated: [SteerableStream.Steer] stops the current generation
+// and starts a new one whose contents are the original contents, followed by
+// the partial output of the interrupted generation and by the steering
+// message, such as "Be more concise.", as a user turn. The chunks of all the
+// generations are yielded by [SteerableStream.Chunks] in order.
+//
+//	s := client.Models.GenerateContentSteerable(ctx, model, contents, config)
+//	for chunk, err := range s.Chunks() {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Print(chunk.Text())
+//		if tooVerbose(chunk) {
+//			s.Steer("Be more concise.")
+//		}
+//	}
+//
+// This API is experimental and may change in future versions.
+func (m Models) GenerateContentSteerable(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig) *SteerableStream {
+	experimentalWarningSteerableStream.Do(func() {
+		log.Println("GenerateContentSteerable is experimental, and may change in future versions.")
+	})
+	return &SteerableStream{
+		ctx:     ctx,
+		models:  m,
+		model:   model,
+		config:  config,
+		history: slices.Clone(contents),
+	}
+}
+
+// Steer stops the current generation and starts a new one steered by message.
+// It can be called from the loop over [SteerableStream.Chunks] or from
+// another goroutine. Messages sent before the new generation starts are
+// joined. It returns false if the generation is already over.
+func (s *SteerableStream) Steer(message string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done {
+		return false
+	}
+	s.steering = append(s.steering, message)
+	if s.cancel != nil {
+		s.cancel()
+	}
+	return true
+}
+
+// Steers returns the number of times the generation was steered.
+func (s *SteerableStream) Steers() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.steers
+}
+
+// History returns the contents of the conversation so far: the original
+// contents, followed by the partial output and the steering message of each
+// interrupted generation, and by the final output once the generation is
+// over.
+func (s *SteerableStream) History() []*Content {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.history)
+}
+
+// Chunks returns an iterator over the chunks of the generations. It can only
+// be ranged over once.
+func (s *SteerableStream) Chunks() iter.Seq2[*GenerateContentResponse, error] {
+	return func(yield func(*GenerateContentResponse, error) bool) {
+		s.mu.Lock()
+		started := s.started
+		s.started = true
+		s.mu.Unlock()
+		if started {
+			yield(nil, errors.New("SteerableStream: Chunks can only be ranged over once"))
+			return
+		}
+		defer s.finish()
+		for {
+			ctx, cancel := context.WithCancel(s.ctx)
+			s.mu.Lock()
+			s.cancel = cancel
+			contents := slices.Clone(s.history)
+			s.mu.Unlock()
+
+			var acc StreamAccumulator
+			message, steered := "", false
+			for chunk, err := range s.models.GenerateContentStreamWithOptions(ctx, s.model, contents, s.config, nil) {
+				if message, steered = s.takeSteering(); steered {
+					break
+				}
+				if err != nil {
+					cancel()
+					yield(nil, err)
+					return
+				}
+				acc.Add(chunk)
+				if !yield(chunk, nil) {
+					cancel()
+					return
+				}
+			}
+			cancel()
+			if !steered {
+				message, steered = s.takeSteering()
+			}
+
+			s.mu.Lock()
+			if partial := outputContent(acc.Response()); partial != nil {
+				s.history = append(s.history, partial)
+			}
+			if steered {
+				s.history = append(s.history, NewContentFromText(message, RoleUser))
+				s.steers++
+			}
+			s.mu.Unlock()
+			if !steered {
+				return
+			}
+		}
+	}
+}
+
+// takeSteering returns the pending steering messages, joined, if any.
+func (s *SteerableStream) takeSteering() (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.steering) == 0 {
+		return "", false
+	}
+	message := strings.Join(s.steering, "\n")
+	s.steering = nil
+	return message, true
+}
+
+func (s *SteerableStream) finish() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done = true
+	s.cancel = nil
+}
+
+// outputContent returns the content of the first candidate of resp without
+// its thoughts, or nil if it has no other parts.
+func outputContent(resp *GenerateContentResponse) *Content {
+	c := firstCandidate(resp)
+	if c == nil || c.Content == nil {
+		return nil
+	}
+	var parts []*Part
+	for _, p := range c.Content.Parts {
+		if p != nil && !p.Thought {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	return &Content{Role: RoleModel, Parts: parts}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSteerableStream(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Contents []*Content `json:"contents"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+			return
+		}
+		if len(body.Contents) == 1 {
+			// The first generation streams until it's interrupted.
+			fmt.Fprint(w, "data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"Once upon a time\"}]}}]}\n\n")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		last := body.Contents[len(body.Contents)-1]
+		fmt.Fprintf(w, "data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"Short: %d turns, %s\"}]}, \"finishReason\": \"STOP\"}]}\n\n", len(body.Contents), last.Parts[0].Text)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}}
+
+	for _, fromGoroutine := range []bool{false, true} {
+		t.Run(fmt.Sprintf("fromGoroutine=%v", fromGoroutine), func(t *testing.T) {
+			s := m.GenerateContentSteerable(context.Background(), "gemini-2.5-flash", Text("Tell a story"), nil)
+			var texts []string
+			for chunk, err := range s.Chunks() {
+				if err != nil {
+					t.Fatal(err)
+				}
+				texts = append(texts, chunk.Text())
+				if len(texts) == 1 {
+					if fromGoroutine {
+						go s.Steer("Be brief.")
+					} else if !s.Steer("Be brief.") {
+						t.Error("Steer() = false during the generation")
+					}
+				}
+			}
+			if diff := cmp.Diff([]string{"Once upon a time", "Short: 3 turns, Be brief."}, texts); diff != "" {
+				t.Errorf("chunks mismatch (-want +got):\n%s", diff)
+			}
+			if got := s.Steers(); got != 1 {
+				t.Errorf("Steers() = %d, want 1", got)
+			}
+			want := []*Content{
+				{Role: RoleUser, Parts: []*Part{{Text: "Tell a story"}}},
+				{Role: RoleModel, Parts: []*Part{{Text: "Once upon a time"}}},
+				{Role: RoleUser, Parts: []*Part{{Text: "Be brief."}}},
+				{Role: RoleModel, Parts: []*Part{{Text: "Short: 3 turns, Be brief."}}},
+			}
+			if diff := cmp.Diff(want, s.History()); diff != "" {
+				t.Errorf("History() mismatch (-want +got):\n%s", diff)
+			}
+			if s.Steer("too late") {
+				t.Error("Steer() = true after the generation ended")
+			}
+			for _, err := range s.Chunks() {
+				if err == nil {
+					t.Error("ranging over Chunks() twice didn't fail")
+				}
+			}
+		})
+	}
+}
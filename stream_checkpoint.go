@@ -0,0 +1,98 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"fmt"
+	"iter"
+)
+
+// StreamCheckpoint is the progress of a stream passed to the callback of
+// [CheckpointStream].
+type StreamCheckpoint struct {
+	// Chunks is the number of chunks received so far.
+	Chunks int
+	// Text is the text generated so far, as returned by
+	// [GenerateContentResponse.Text] for Response.
+	Text string
+	// UsageMetadata is the latest token usage reported by the model, or nil if
+	// none was reported yet.
+	UsageMetadata *GenerateContentResponseUsageMetadata
+	// Response holds the chunks received so far merged with a
+	// [StreamAccumulator]. It's updated as the stream goes on, so it must not
+	// be kept past the callback.
+	Response *GenerateContentResponse
+	// Done reports whether the stream ended, successfully or not.
+	Done bool
+}
+
+// CheckpointStream returns an iterator over the chunks of stream that calls
+// checkpoint with the progress of the stream every n chunks, and once more
+// when the stream ends, unless the caller stopped iterating. It lets callers persist the progress of long
+// generations, for example to show the partial answer again after a crash.
+// If checkpoint returns an error, the stream is stopped and the error is
+// yielded.
+//
+//	stream := client.Models.GenerateContentStream(ctx, model, contents, config)
+//	for chunk, err := range genai.CheckpointStream(stream, 20, func(cp *genai.StreamCheckpoint) error {
+//		return store.Save(requestID, cp.Text)
+//	}) {
+//		...
+//	}
+func CheckpointStream(stream iter.Seq2[*GenerateContentResponse, error], n int, checkpoint func(*StreamCheckpoint) error) iter.Seq2[*GenerateContentResponse, error] {
+	return func(yield func(*GenerateContentResponse, error) bool) {
+		if n <= 0 {
+			yield(nil, fmt.Errorf("CheckpointStream: n must be positive, got %d", n))
+			return
+		}
+		var acc StreamAccumulator
+		chunks := 0
+		save := func(done bool) error {
+			cp := &StreamCheckpoint{Chunks: chunks, Response: acc.Response(), Done: done}
+			if r := acc.Response(); r != nil {
+				cp.Text = r.Text()
+				cp.UsageMetadata = r.UsageMetadata
+			}
+			if err := checkpoint(cp); err != nil {
+				return fmt.Errorf("checkpoint: %w", err)
+			}
+			return nil
+		}
+		for chunk, err := range stream {
+			if err != nil {
+				if cpErr := save(true); cpErr != nil {
+					yield(nil, cpErr)
+					return
+				}
+				yield(nil, err)
+				return
+			}
+			chunks++
+			acc.Add(chunk)
+			if chunks%n == 0 {
+				if err := save(false); err != nil {
+					yield(nil, err)
+					return
+				}
+			}
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+		if err := save(true); err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCheckpointStream(t *testing.T) {
+	chunk := func(text string, tokens int32) *GenerateContentResponse {
+		return &GenerateContentResponse{
+			Candidates:    []*Candidate{{Content: &Content{Role: RoleModel, Parts: []*Part{{Text: text}}}}},
+			UsageMetadata: &GenerateContentResponseUsageMetadata{CandidatesTokenCount: tokens},
+		}
+	}
+	chunks := []*GenerateContentResponse{chunk("a", 1), chunk("b", 2), chunk("c", 3), chunk("d", 4), chunk("e", 5)}
+
+	t.Run("EveryN", func(t *testing.T) {
+		var got []string
+		var text string
+		for c, err := range CheckpointStream(streamOf(chunks, nil), 2, func(cp *StreamCheckpoint) error {
+			got = append(got, fmt.Sprintf("%d:%s:%d:%v", cp.Chunks, cp.Text, cp.UsageMetadata.CandidatesTokenCount, cp.Done))
+			return nil
+		}) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			text += c.Text()
+		}
+		if want := "[2:ab:2:false 4:abcd:4:false 5:abcde:5:true]"; fmt.Sprint(got) != want {
+			t.Errorf("checkpoints = %v, want %s", got, want)
+		}
+		if text != "abcde" {
+			t.Errorf("streamed text = %q, want abcde", text)
+		}
+	})
+
+	t.Run("StreamError", func(t *testing.T) {
+		streamErr := errors.New("connection reset")
+		var got []string
+		var gotErr error
+		for _, err := range CheckpointStream(streamOf(chunks[:3], streamErr), 2, func(cp *StreamCheckpoint) error {
+			got = append(got, fmt.Sprintf("%d:%s:%v", cp.Chunks, cp.Text, cp.Done))
+			return nil
+		}) {
+			gotErr = err
+		}
+		if want := "[2:ab:false 3:abc:true]"; fmt.Sprint(got) != want {
+			t.Errorf("checkpoints = %v, want %s", got, want)
+		}
+		if gotErr != streamErr {
+			t.Errorf("error = %v, want the stream error", gotErr)
+		}
+	})
+
+	t.Run("CheckpointError", func(t *testing.T) {
+		saveErr := errors.New("disk full")
+		n := 0
+		var gotErr error
+		for c, err := range CheckpointStream(streamOf(chunks, nil), 2, func(*StreamCheckpoint) error { return saveErr }) {
+			if c != nil {
+				n++
+			}
+			gotErr = err
+		}
+		if n != 1 || !errors.Is(gotErr, saveErr) {
+			t.Errorf("got %d chunks and error %v, want 1 chunk and the checkpoint error", n, gotErr)
+		}
+	})
+
+	t.Run("InvalidN", func(t *testing.T) {
+		for _, err := range CheckpointStream(streamOf(chunks, nil), 0, func(*StreamCheckpoint) error { return nil }) {
+			if err == nil {
+				t.Errorf("CheckpointStream() with n = 0 yielded no error")
+			}
+		}
+	})
+}
@@ -0,0 +1,344 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"encoding/json"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// StreamAccumulator merges the chunks of a [Models.GenerateContentStream]
+// response into a single [GenerateContentResponse]. The zero value is ready to
+// use.
+//
+// Candidates are matched by their Index. Consecutive text parts of a candidate
+// are joined into one part, keeping thoughts apart from the answer, and other
+// parts such as function calls are kept as they are. Citations and logprobs
+// are appended.
+//
+// Safety ratings are merged by category, keeping the most severe rating seen
+// for each: a category is blocked if any chunk blocked it, and its
+// probability, severity and scores are the highest reported. Grounding
+// metadata is merged too: grounding chunks are deduplicated and the chunk
+// indices of grounding supports are remapped to the merged list, queries are
+// deduplicated, and for the other grounding fields the last value reported
+// wins. Segments of grounding supports are kept as reported. The URLs
+// retrieved by the URL context tool are merged, keeping the latest status of
+// each.
+//
+// For the other fields, including the usage metadata, which covers the whole
+// response, the last value reported wins.
+//
+//	var acc genai.StreamAccumulator
+//	for chunk, err := range client.Models.GenerateContentStream(ctx, model, contents, config) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Print(chunk.Text())
+//		acc.Add(chunk)
+//	}
+//	resp := acc.Response()
+type StreamAccumulator struct {
+	response   *GenerateContentResponse
+	candidates map[int32]*Candidate
+}
+
+// Add merges chunk into the accumulated response. chunk isn't modified.
+func (a *StreamAccumulator) Add(chunk *GenerateContentResponse) {
+	if chunk == nil {
+		return
+	}
+	if a.response == nil {
+		a.response = &GenerateContentResponse{}
+		a.candidates = map[int32]*Candidate{}
+	}
+	r := a.response
+	if chunk.SDKHTTPResponse != nil {
+		r.SDKHTTPResponse = chunk.SDKHTTPResponse
+	}
+	if !chunk.CreateTime.IsZero() {
+		r.CreateTime = chunk.CreateTime
+	}
+	if chunk.ModelVersion != "" {
+		r.ModelVersion = chunk.ModelVersion
+	}
+	if chunk.PromptFeedback != nil {
+		r.PromptFeedback = chunk.PromptFeedback
+	}
+	if chunk.ResponseID != "" {
+		r.ResponseID = chunk.ResponseID
+	}
+	if chunk.UsageMetadata != nil {
+		r.UsageMetadata = chunk.UsageMetadata
+	}
+	if chunk.ModelStatus != nil {
+		r.ModelStatus = chunk.ModelStatus
+	}
+	for _, c := range chunk.Candidates {
+		if c == nil {
+			continue
+		}
+		candidate, ok := a.candidates[c.Index]
+		if !ok {
+			candidate = &Candidate{Index: c.Index}
+			a.candidates[c.Index] = candidate
+			r.Candidates = append(r.Candidates, candidate)
+		}
+		mergeCandidate(candidate, c)
+	}
+}
+
+// Response returns the accumulated response, or nil if no chunk was added.
+// The returned response shares the accumulator's state, so call it once all
+// chunks have been added.
+func (a *StreamAccumulator) Response() *GenerateContentResponse {
+	return a.response
+}
+
+// CollectStream reads stream to the end and returns its chunks merged into a
+// single response, as described in [StreamAccumulator].
+//
+//	resp, err := genai.CollectStream(client.Models.GenerateContentStream(ctx, model, contents, config))
+func CollectStream(stream iter.Seq2[*GenerateContentResponse, error]) (*GenerateContentResponse, error) {
+	var acc StreamAccumulator
+	for chunk, err := range stream {
+		if err != nil {
+			return nil, err
+		}
+		acc.Add(chunk)
+	}
+	return acc.Response(), nil
+}
+
+func mergeCandidate(dst, src *Candidate) {
+	if src.Content != nil {
+		if dst.Content == nil {
+			dst.Content = &Content{Role: src.Content.Role}
+		}
+		if dst.Content.Role == "" {
+			dst.Content.Role = src.Content.Role
+		}
+		for _, p := range src.Content.Parts {
+			if p != nil {
+				dst.Content.Parts = appendPart(dst.Content.Parts, p)
+			}
+		}
+	}
+	if src.CitationMetadata != nil {
+		if dst.CitationMetadata == nil {
+			dst.CitationMetadata = &CitationMetadata{}
+		}
+		dst.CitationMetadata.Citations = append(dst.CitationMetadata.Citations, src.CitationMetadata.Citations...)
+	}
+	if src.FinishMessage != "" {
+		dst.FinishMessage = src.FinishMessage
+	}
+	if src.TokenCount != 0 {
+		dst.TokenCount = src.TokenCount
+	}
+	if src.FinishReason != "" {
+		dst.FinishReason = src.FinishReason
+	}
+	if src.GroundingMetadata != nil {
+		dst.GroundingMetadata = mergeGroundingMetadata(dst.GroundingMetadata, src.GroundingMetadata)
+	}
+	if src.AvgLogprobs != 0 {
+		dst.AvgLogprobs = src.AvgLogprobs
+	}
+	if src.LogprobsResult != nil {
+		if dst.LogprobsResult == nil {
+			dst.LogprobsResult = &LogprobsResult{}
+		}
+		dst.LogprobsResult.ChosenCandidates = append(dst.LogprobsResult.ChosenCandidates, src.LogprobsResult.ChosenCandidates...)
+		dst.LogprobsResult.TopCandidates = append(dst.LogprobsResult.TopCandidates, src.LogprobsResult.TopCandidates...)
+		if src.LogprobsResult.LogProbabilitySum != nil {
+			dst.LogprobsResult.LogProbabilitySum = src.LogprobsResult.LogProbabilitySum
+		}
+	}
+	if len(src.SafetyRatings) > 0 {
+		dst.SafetyRatings = mergeSafetyRatings(dst.SafetyRatings, src.SafetyRatings)
+	}
+	if src.URLContextMetadata != nil {
+		dst.URLContextMetadata = mergeURLContextMetadata(dst.URLContextMetadata, src.URLContextMetadata)
+	}
+}
+
+// appendPart appends a copy of p to parts, joining it with the last part if
+// both are text parts of the same kind.
+func appendPart(parts []*Part, p *Part) []*Part {
+	if n := len(parts); n > 0 {
+		last := parts[n-1]
+		if isTextPart(last) && isTextPart(p) && last.Thought == p.Thought && last.ThoughtSignature == nil {
+			last.Text += p.Text
+			last.ThoughtSignature = p.ThoughtSignature
+			return parts
+		}
+	}
+	cp := *p
+	return append(parts, &cp)
+}
+
+// isTextPart reports whether p only holds text, possibly a thought.
+func isTextPart(p *Part) bool {
+	if p.Text == "" {
+		return false
+	}
+	q := *p
+	q.Text, q.Thought, q.ThoughtSignature = "", false, nil
+	return reflect.ValueOf(q).IsZero()
+}
+
+var (
+	harmProbabilityRank = map[HarmProbability]int{
+		HarmProbabilityNegligible: 1,
+		HarmProbabilityLow:        2,
+		HarmProbabilityMedium:     3,
+		HarmProbabilityHigh:       4,
+	}
+	harmSeverityRank = map[HarmSeverity]int{
+		HarmSeverityNegligible: 1,
+		HarmSeverityLow:        2,
+		HarmSeverityMedium:     3,
+		HarmSeverityHigh:       4,
+	}
+)
+
+// mergeSafetyRatings returns the ratings of dst updated with src, keeping the
+// most severe rating of each category. The ratings of dst are replaced rather
+// than modified, since they may belong to a chunk.
+func mergeSafetyRatings(dst, src []*SafetyRating) []*SafetyRating {
+	merged := append([]*SafetyRating{}, dst...)
+	for _, r := range src {
+		if r == nil {
+			continue
+		}
+		i := slices.IndexFunc(merged, func(m *SafetyRating) bool { return m.Category == r.Category })
+		if i < 0 {
+			merged = append(merged, r)
+			continue
+		}
+		m := *merged[i]
+		m.Blocked = m.Blocked || r.Blocked
+		if harmProbabilityRank[r.Probability] > harmProbabilityRank[m.Probability] {
+			m.Probability = r.Probability
+		}
+		if harmSeverityRank[r.Severity] > harmSeverityRank[m.Severity] {
+			m.Severity = r.Severity
+		}
+		m.ProbabilityScore = max(m.ProbabilityScore, r.ProbabilityScore)
+		m.SeverityScore = max(m.SeverityScore, r.SeverityScore)
+		if r.OverwrittenThreshold != "" {
+			m.OverwrittenThreshold = r.OverwrittenThreshold
+		}
+		merged[i] = &m
+	}
+	return merged
+}
+
+// mergeGroundingMetadata returns dst, which may be nil, merged with src.
+func mergeGroundingMetadata(dst, src *GroundingMetadata) *GroundingMetadata {
+	merged := &GroundingMetadata{}
+	if dst != nil {
+		*merged = *dst
+	}
+	// Grounding chunks are identified by their JSON encoding.
+	keys := map[string]int{}
+	for i, c := range merged.GroundingChunks {
+		if b, err := json.Marshal(c); err == nil {
+			keys[string(b)] = i
+		}
+	}
+	merged.GroundingChunks = slices.Clone(merged.GroundingChunks)
+	indexes := make([]int32, len(src.GroundingChunks))
+	for i, c := range src.GroundingChunks {
+		b, err := json.Marshal(c)
+		if j, ok := keys[string(b)]; ok && err == nil {
+			indexes[i] = int32(j)
+			continue
+		}
+		indexes[i] = int32(len(merged.GroundingChunks))
+		keys[string(b)] = len(merged.GroundingChunks)
+		merged.GroundingChunks = append(merged.GroundingChunks, c)
+	}
+
+	merged.GroundingSupports = slices.Clone(merged.GroundingSupports)
+	for _, support := range src.GroundingSupports {
+		if support == nil {
+			continue
+		}
+		s := *support
+		s.GroundingChunkIndices = make([]int32, len(support.GroundingChunkIndices))
+		for i, index := range support.GroundingChunkIndices {
+			if index >= 0 && int(index) < len(indexes) {
+				index = indexes[index]
+			}
+			s.GroundingChunkIndices[i] = index
+		}
+		if !slices.ContainsFunc(merged.GroundingSupports, func(m *GroundingSupport) bool { return reflect.DeepEqual(m, &s) }) {
+			merged.GroundingSupports = append(merged.GroundingSupports, &s)
+		}
+	}
+
+	merged.WebSearchQueries = appendUnique(merged.WebSearchQueries, src.WebSearchQueries)
+	merged.ImageSearchQueries = appendUnique(merged.ImageSearchQueries, src.ImageSearchQueries)
+	merged.RetrievalQueries = appendUnique(merged.RetrievalQueries, src.RetrievalQueries)
+	if src.RetrievalMetadata != nil {
+		merged.RetrievalMetadata = src.RetrievalMetadata
+	}
+	if src.SearchEntryPoint != nil {
+		merged.SearchEntryPoint = src.SearchEntryPoint
+	}
+	if src.GoogleMapsWidgetContextToken != "" {
+		merged.GoogleMapsWidgetContextToken = src.GoogleMapsWidgetContextToken
+	}
+	if len(src.SourceFlaggingUris) > 0 {
+		merged.SourceFlaggingUris = src.SourceFlaggingUris
+	}
+	return merged
+}
+
+// mergeURLContextMetadata returns dst, which may be nil, merged with src. A
+// URL reported again keeps its position and takes its latest status.
+func mergeURLContextMetadata(dst, src *URLContextMetadata) *URLContextMetadata {
+	merged := &URLContextMetadata{}
+	if dst != nil {
+		merged.URLMetadata = slices.Clone(dst.URLMetadata)
+	}
+	for _, m := range src.URLMetadata {
+		if m == nil {
+			continue
+		}
+		i := slices.IndexFunc(merged.URLMetadata, func(u *URLMetadata) bool { return u.RetrievedURL == m.RetrievedURL })
+		if i < 0 {
+			merged.URLMetadata = append(merged.URLMetadata, m)
+		} else {
+			merged.URLMetadata[i] = m
+		}
+	}
+	return merged
+}
+
+// appendUnique returns dst with the strings of src it doesn't contain yet.
+func appendUnique(dst, src []string) []string {
+	dst = slices.Clone(dst)
+	for _, s := range src {
+		if !slices.Contains(dst, s) {
+			dst = append(dst, s)
+		}
+	}
+	return dst
+}
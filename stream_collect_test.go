@@ -0,0 +1,206 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func streamOf(chunks []*GenerateContentResponse, err error) iter.Seq2[*GenerateContentResponse, error] {
+	return func(yield func(*GenerateContentResponse, error) bool) {
+		for _, chunk := range chunks {
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func TestCollectStream(t *testing.T) {
+	chunks := []*GenerateContentResponse{
+		{
+			ResponseID: "r1",
+			Candidates: []*Candidate{{Content: &Content{Role: RoleModel, Parts: []*Part{
+				{Text: "Let me ", Thought: true},
+				{Text: "think.", Thought: true, ThoughtSignature: []byte("sig")},
+			}}}},
+		},
+		{
+			Candidates: []*Candidate{{
+				Content:          &Content{Role: RoleModel, Parts: []*Part{{Text: "The weather "}}},
+				CitationMetadata: &CitationMetadata{Citations: []*Citation{{URI: "a"}}},
+			}},
+		},
+		{
+			Candidates: []*Candidate{{Content: &Content{Role: RoleModel, Parts: []*Part{
+				{Text: "is:"},
+				{FunctionCall: &FunctionCall{Name: "get_weather"}},
+				{FunctionCall: &FunctionCall{Name: "get_time"}},
+			}}}},
+		},
+		{
+			Candidates: []*Candidate{{
+				Content:          &Content{Role: RoleModel, Parts: []*Part{{Text: "Done."}}},
+				CitationMetadata: &CitationMetadata{Citations: []*Citation{{URI: "b"}}},
+				FinishReason:     FinishReasonStop,
+			}},
+			UsageMetadata: &GenerateContentResponseUsageMetadata{TotalTokenCount: 42},
+		},
+	}
+	got, err := CollectStream(streamOf(chunks, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &GenerateContentResponse{
+		ResponseID: "r1",
+		Candidates: []*Candidate{{
+			Content: &Content{Role: RoleModel, Parts: []*Part{
+				{Text: "Let me think.", Thought: true, ThoughtSignature: []byte("sig")},
+				{Text: "The weather is:"},
+				{FunctionCall: &FunctionCall{Name: "get_weather"}},
+				{FunctionCall: &FunctionCall{Name: "get_time"}},
+				{Text: "Done."},
+			}},
+			CitationMetadata: &CitationMetadata{Citations: []*Citation{{URI: "a"}, {URI: "b"}}},
+			FinishReason:     FinishReasonStop,
+		}},
+		UsageMetadata: &GenerateContentResponseUsageMetadata{TotalTokenCount: 42},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CollectStream() mismatch (-want +got):\n%s", diff)
+	}
+	if text := chunks[1].Candidates[0].Content.Parts[0].Text; text != "The weather " {
+		t.Errorf("CollectStream() modified a chunk: got text %q", text)
+	}
+
+	wantErr := errors.New("stream failed")
+	if _, err := CollectStream(streamOf(chunks, wantErr)); !errors.Is(err, wantErr) {
+		t.Errorf("CollectStream() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestStreamAccumulatorCandidates(t *testing.T) {
+	var acc StreamAccumulator
+	if acc.Response() != nil {
+		t.Errorf("Response() = %v, want nil before any chunk", acc.Response())
+	}
+	acc.Add(&GenerateContentResponse{Candidates: []*Candidate{
+		{Index: 0, Content: &Content{Parts: []*Part{{Text: "a"}}}},
+		{Index: 1, Content: &Content{Parts: []*Part{{Text: "x"}}}},
+	}})
+	acc.Add(&GenerateContentResponse{Candidates: []*Candidate{
+		{Index: 1, Content: &Content{Parts: []*Part{{Text: "y"}}}},
+		{Index: 0, Content: &Content{Parts: []*Part{{Text: "b"}}}},
+	}})
+	got := acc.Response()
+	if len(got.Candidates) != 2 || got.Candidates[0].Content.Parts[0].Text != "ab" || got.Candidates[1].Content.Parts[0].Text != "xy" {
+		t.Errorf("Response() = %+v, want candidates ab and xy", got.Candidates)
+	}
+}
+
+func TestStreamAccumulatorSafetyAndGrounding(t *testing.T) {
+	web := func(uri string) *GroundingChunk { return &GroundingChunk{Web: &GroundingChunkWeb{URI: uri}} }
+	chunks := []*GenerateContentResponse{
+		{Candidates: []*Candidate{{
+			SafetyRatings: []*SafetyRating{
+				{Category: HarmCategoryHarassment, Probability: HarmProbabilityMedium, ProbabilityScore: 0.5},
+				{Category: HarmCategoryHateSpeech, Probability: HarmProbabilityNegligible},
+			},
+			GroundingMetadata: &GroundingMetadata{
+				WebSearchQueries:  []string{"weather paris"},
+				GroundingChunks:   []*GroundingChunk{web("a"), web("b")},
+				GroundingSupports: []*GroundingSupport{{GroundingChunkIndices: []int32{1}, Segment: &Segment{Text: "Sunny"}}},
+			},
+		}}},
+		{Candidates: []*Candidate{{
+			SafetyRatings: []*SafetyRating{
+				{Category: HarmCategoryHarassment, Probability: HarmProbabilityLow, ProbabilityScore: 0.2, Blocked: true},
+				{Category: HarmCategoryDangerousContent, Probability: HarmProbabilityLow},
+			},
+			GroundingMetadata: &GroundingMetadata{
+				WebSearchQueries:  []string{"weather paris", "paris forecast"},
+				GroundingChunks:   []*GroundingChunk{web("c"), web("b")},
+				GroundingSupports: []*GroundingSupport{{GroundingChunkIndices: []int32{0, 1}, Segment: &Segment{Text: "Warm"}}},
+				SearchEntryPoint:  &SearchEntryPoint{RenderedContent: "<div/>"},
+			},
+		}}},
+	}
+	got, err := CollectStream(streamOf(chunks, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	candidate := got.Candidates[0]
+	wantRatings := []*SafetyRating{
+		{Category: HarmCategoryHarassment, Probability: HarmProbabilityMedium, ProbabilityScore: 0.5, Blocked: true},
+		{Category: HarmCategoryHateSpeech, Probability: HarmProbabilityNegligible},
+		{Category: HarmCategoryDangerousContent, Probability: HarmProbabilityLow},
+	}
+	if diff := cmp.Diff(wantRatings, candidate.SafetyRatings); diff != "" {
+		t.Errorf("SafetyRatings mismatch (-want +got):\n%s", diff)
+	}
+	wantGrounding := &GroundingMetadata{
+		WebSearchQueries: []string{"weather paris", "paris forecast"},
+		GroundingChunks:  []*GroundingChunk{web("a"), web("b"), web("c")},
+		GroundingSupports: []*GroundingSupport{
+			{GroundingChunkIndices: []int32{1}, Segment: &Segment{Text: "Sunny"}},
+			{GroundingChunkIndices: []int32{2, 1}, Segment: &Segment{Text: "Warm"}},
+		},
+		SearchEntryPoint: &SearchEntryPoint{RenderedContent: "<div/>"},
+	}
+	if diff := cmp.Diff(wantGrounding, candidate.GroundingMetadata); diff != "" {
+		t.Errorf("GroundingMetadata mismatch (-want +got):\n%s", diff)
+	}
+	if chunks[0].Candidates[0].SafetyRatings[0].Blocked {
+		t.Errorf("CollectStream() modified a chunk")
+	}
+
+	// A chunk repeating the grounding metadata of the response adds nothing.
+	var acc StreamAccumulator
+	acc.Add(chunks[1])
+	acc.Add(chunks[1])
+	if diff := cmp.Diff(chunks[1].Candidates[0].GroundingMetadata, acc.Response().Candidates[0].GroundingMetadata); diff != "" {
+		t.Errorf("repeated GroundingMetadata mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStreamAccumulatorURLContextMetadata(t *testing.T) {
+	url := func(u string, status URLRetrievalStatus) *URLMetadata {
+		return &URLMetadata{RetrievedURL: u, URLRetrievalStatus: status}
+	}
+	chunks := []*GenerateContentResponse{
+		{Candidates: []*Candidate{{URLContextMetadata: &URLContextMetadata{URLMetadata: []*URLMetadata{
+			url("https://a.example", URLRetrievalStatusUnspecified),
+		}}}}},
+		{Candidates: []*Candidate{{URLContextMetadata: &URLContextMetadata{URLMetadata: []*URLMetadata{
+			url("https://b.example", URLRetrievalStatusPaywall),
+			url("https://a.example", URLRetrievalStatusSuccess),
+		}}}}},
+	}
+	got, err := CollectStream(streamOf(chunks, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*URLMetadata{url("https://a.example", URLRetrievalStatusSuccess), url("https://b.example", URLRetrievalStatusPaywall)}
+	if diff := cmp.Diff(want, got.URLMetadata()); diff != "" {
+		t.Errorf("URLMetadata() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import "iter"
+
+// StreamEventType is the type of a [StreamEvent].
+type StreamEventType string
+
+const (
+	// Content generated by the model, such as text, thoughts or images, in
+	// StreamEvent.Part.
+	StreamEventTypeContentDelta StreamEventType = "CONTENT_DELTA"
+	// A call of a tool or its result, in StreamEvent.Part: code generated by
+	// the code execution tool and the result of running it, function calls and
+	// responses, and calls and responses of server-side tools.
+	StreamEventTypeToolEvent StreamEventType = "TOOL_EVENT"
+	// Grounding metadata of a candidate, in StreamEvent.GroundingMetadata,
+	// such as the sources found by Google Search, or metadata of the URLs
+	// retrieved by the URL context tool, in StreamEvent.URLContextMetadata.
+	StreamEventTypeGroundingUpdate StreamEventType = "GROUNDING_UPDATE"
+	// Token usage of the response so far, in StreamEvent.UsageMetadata.
+	StreamEventTypeUsageUpdate StreamEventType = "USAGE_UPDATE"
+)
+
+// StreamEvent is an item of a chunk of a streamed response, classified by
+// type. Only the fields of its type are set.
+type StreamEvent struct {
+	// Type is the type of the event.
+	Type StreamEventType
+	// CandidateIndex is the index of the candidate the event belongs to. It's
+	// 0 for usage updates.
+	CandidateIndex int32
+	// Part is the part of a content delta or a tool event.
+	Part *Part
+	// GroundingMetadata is set for grounding updates of grounded candidates.
+	GroundingMetadata *GroundingMetadata
+	// URLContextMetadata is set for grounding updates of candidates that used
+	// the URL context tool.
+	URLContextMetadata *URLContextMetadata
+	// UsageMetadata is set for usage updates. Usage metadata covers the whole
+	// response, so the last update holds the totals.
+	UsageMetadata *GenerateContentResponseUsageMetadata
+}
+
+// Events returns the events carried by the chunk of a streamed response: the
+// parts of each candidate in order, classified as content deltas or tool
+// events, then the grounding update of the candidate, if any, and the usage
+// update of the chunk last. It also works on a response that isn't streamed.
+func (r *GenerateContentResponse) Events() []*StreamEvent {
+	if r == nil {
+		return nil
+	}
+	var events []*StreamEvent
+	for _, c := range r.Candidates {
+		if c == nil {
+			continue
+		}
+		if c.Content != nil {
+			for _, p := range c.Content.Parts {
+				if p == nil {
+					continue
+				}
+				t := StreamEventTypeContentDelta
+				if isToolPart(p) {
+					t = StreamEventTypeToolEvent
+				}
+				events = append(events, &StreamEvent{Type: t, CandidateIndex: c.Index, Part: p})
+			}
+		}
+		if c.GroundingMetadata != nil || c.URLContextMetadata != nil {
+			events = append(events, &StreamEvent{
+				Type:               StreamEventTypeGroundingUpdate,
+				CandidateIndex:     c.Index,
+				GroundingMetadata:  c.GroundingMetadata,
+				URLContextMetadata: c.URLContextMetadata,
+			})
+		}
+	}
+	if r.UsageMetadata != nil {
+		events = append(events, &StreamEvent{Type: StreamEventTypeUsageUpdate, UsageMetadata: r.UsageMetadata})
+	}
+	return events
+}
+
+// isToolPart reports whether p is a call of a tool or its result.
+func isToolPart(p *Part) bool {
+	return p.ExecutableCode != nil || p.CodeExecutionResult != nil ||
+		p.FunctionCall != nil || p.FunctionResponse != nil ||
+		p.ToolCall != nil || p.ToolResponse != nil
+}
+
+// StreamEvents returns an iterator over the events of the chunks of stream,
+// see [GenerateContentResponse.Events]. Errors of stream are yielded as they
+// are.
+//
+//	for event, err := range genai.StreamEvents(client.Models.GenerateContentStream(ctx, model, contents, config)) {
+//		if err != nil {
+//			return err
+//		}
+//		switch event.Type {
+//		case genai.StreamEventTypeContentDelta:
+//			fmt.Print(event.Part.Text)
+//		case genai.StreamEventTypeToolEvent:
+//			if event.Part.ExecutableCode != nil {
+//				fmt.Printf("\nRunning:\n%s\n", event.Part.ExecutableCode.Code)
+//			}
+//		case genai.StreamEventTypeGroundingUpdate:
+//			sources = event.GroundingMetadata.GroundingChunks
+//		}
+//	}
+func StreamEvents(stream iter.Seq2[*GenerateContentResponse, error]) iter.Seq2[*StreamEvent, error] {
+	return func(yield func(*StreamEvent, error) bool) {
+		for chunk, err := range stream {
+			if err != nil {
+				if !yield(nil, err) {
+					return
+				}
+				continue
+			}
+			for _, e := range chunk.Events() {
+				if !yield(e, nil) {
+					return
+				}
+			}
+		}
+	}
+}
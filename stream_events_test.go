@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStreamEvents(t *testing.T) {
+	text := &Part{Text: "Let me compute it."}
+	code := &Part{ExecutableCode: &ExecutableCode{Code: "print(2+2)", Language: LanguagePython}}
+	result := &Part{CodeExecutionResult: &CodeExecutionResult{Outcome: OutcomeOK, Output: "4"}}
+	answer := &Part{Text: "It's 4."}
+	grounding := &GroundingMetadata{WebSearchQueries: []string{"2+2"}}
+	usage := &GenerateContentResponseUsageMetadata{TotalTokenCount: 42}
+	errStream := errors.New("stream failed")
+	var stream iter.Seq2[*GenerateContentResponse, error] = func(yield func(*GenerateContentResponse, error) bool) {
+		chunks := []*GenerateContentResponse{
+			{Candidates: []*Candidate{{Content: &Content{Parts: []*Part{text, code}}}}},
+			{Candidates: []*Candidate{{Content: &Content{Parts: []*Part{result, nil}}}}},
+			{
+				Candidates:    []*Candidate{{Content: &Content{Parts: []*Part{answer}}, GroundingMetadata: grounding}, nil},
+				UsageMetadata: usage,
+			},
+		}
+		for _, c := range chunks {
+			if !yield(c, nil) {
+				return
+			}
+		}
+		yield(nil, errStream)
+	}
+
+	var got []*StreamEvent
+	var gotErr error
+	for e, err := range StreamEvents(stream) {
+		if err != nil {
+			gotErr = err
+			continue
+		}
+		got = append(got, e)
+	}
+	want := []*StreamEvent{
+		{Type: StreamEventTypeContentDelta, Part: text},
+		{Type: StreamEventTypeToolEvent, Part: code},
+		{Type: StreamEventTypeToolEvent, Part: result},
+		{Type: StreamEventTypeContentDelta, Part: answer},
+		{Type: StreamEventTypeGroundingUpdate, GroundingMetadata: grounding},
+		{Type: StreamEventTypeUsageUpdate, UsageMetadata: usage},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("StreamEvents() mismatch (-want +got):\n%s", diff)
+	}
+	if gotErr != errStream {
+		t.Errorf("StreamEvents() error = %v, want %v", gotErr, errStream)
+	}
+
+	var n int
+	for range StreamEvents(stream) {
+		n++
+		break
+	}
+	if n != 1 {
+		t.Errorf("StreamEvents() yielded %d events after break, want 1", n)
+	}
+	if events := (*GenerateContentResponse)(nil).Events(); events != nil {
+		t.Errorf("Events() of a nil response = %v, want nil", events)
+	}
+}
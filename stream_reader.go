@@ -0,0 +1,77 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"io"
+	"iter"
+)
+
+// streamTextReader reads the text of a GenerateContentStream response.
+type streamTextReader struct {
+	next func() (*GenerateContentResponse, error, bool)
+	stop func()
+	buf  []byte
+	err  error
+}
+
+// StreamTextReader returns a reader of the text of stream, as returned by
+// [GenerateContentResponse.Text] for each chunk, so that the response can be
+// passed to io.Copy or any API that takes an io.Reader. Thoughts and non-text
+// parts are skipped. Chunks are read from stream as the reader is read, and an
+// error of stream is returned by Read once the text before it was read.
+//
+// Close the reader to stop the stream early.
+//
+//	r := genai.StreamTextReader(client.Models.GenerateContentStream(ctx, model, contents, config))
+//	defer r.Close()
+//	if _, err := io.Copy(os.Stdout, r); err != nil {
+//		return err
+//	}
+func StreamTextReader(stream iter.Seq2[*GenerateContentResponse, error]) io.ReadCloser {
+	next, stop := iter.Pull2(stream)
+	return &streamTextReader{next: next, stop: stop}
+}
+
+func (r *streamTextReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		chunk, err, ok := r.next()
+		switch {
+		case !ok:
+			r.err = io.EOF
+		case err != nil:
+			r.err = err
+			r.stop()
+		case chunk != nil:
+			r.buf = []byte(chunk.Text())
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Close stops the stream. Read returns io.EOF after Close.
+func (r *streamTextReader) Close() error {
+	r.stop()
+	r.buf = nil
+	if r.err == nil {
+		r.err = io.EOF
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestStreamTextReader(t *testing.T) {
+	chunks := []*GenerateContentResponse{
+		{Candidates: []*Candidate{{Content: &Content{Parts: []*Part{{Text: "Thinking.", Thought: true}, {Text: "Hello, "}}}}}},
+		{Candidates: []*Candidate{{Content: &Content{Parts: []*Part{{FunctionCall: &FunctionCall{Name: "f"}}}}}}},
+		{Candidates: []*Candidate{{Content: &Content{Parts: []*Part{{Text: "world!"}}}}}},
+	}
+	got, err := io.ReadAll(StreamTextReader(streamOf(chunks, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Hello, world!"; string(got) != want {
+		t.Errorf("ReadAll() = %q, want %q", got, want)
+	}
+
+	wantErr := errors.New("stream failed")
+	r := StreamTextReader(streamOf(chunks[:1], wantErr))
+	got, err = io.ReadAll(r)
+	if !errors.Is(err, wantErr) || string(got) != "Hello, " {
+		t.Errorf("ReadAll() = %q, %v, want %q, %v", got, err, "Hello, ", wantErr)
+	}
+
+	r = StreamTextReader(streamOf(chunks, nil))
+	buf := make([]byte, 3)
+	if n, err := r.Read(buf); err != nil || string(buf[:n]) != "Hel" {
+		t.Errorf("Read() = %q, %v, want %q, nil", buf[:n], err, "Hel")
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Read(buf); err != io.EOF {
+		t.Errorf("Read() after Close() error = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// StreamRetryConfig configures the retries of
+// [Models.GenerateContentStreamWithOptions] after transient errors, such as a dropped connection.
+//
+// When the stream fails before any chunk was yielded, the request is simply
+// sent again. When it fails part-way through, the request is sent again and
+// the text that was already yielded is skipped, so that the caller receives
+// each piece of text once. This only works if the model generates the same
+// text again, for example with a Seed and a zero Temperature, and if the
+// chunks yielded so far only hold text. Otherwise, the stream ends with a
+// [*ResumableStreamError].
+type StreamRetryConfig struct {
+	// Optional. Maximum number of retries. Defaults to 3.
+	MaxRetries int32
+	// Optional. Delay before the first retry, doubled for each following retry.
+	// Defaults to 1 second.
+	InitialDelay time.Duration
+}
+
+// ResumableStreamError is returned by
+// [Models.GenerateContentStreamWithOptions] with a StreamRetry option when the stream fails with a transient error after some
+// chunks were yielded. Partial
+// holds the chunks yielded so far, merged as by [StreamAccumulator], so that
+// the caller can keep them or continue the generation from them.
+type ResumableStreamError struct {
+	// Err is the error that ended the stream.
+	Err error
+	// Partial is the merged response of the chunks yielded before the error.
+	Partial *GenerateContentResponse
+}
+
+func (e *ResumableStreamError) Error() string {
+	return fmt.Sprintf("stream interrupted after a partial response: %v", e.Err)
+}
+
+func (e *ResumableStreamError) Unwrap() error {
+	return e.Err
+}
+
+// Text returns the text yielded before the error.
+func (e *ResumableStreamError) Text() string {
+	if e.Partial == nil {
+		return ""
+	}
+	return e.Partial.Text()
+}
+
+// isTransientStreamError reports whether a streaming request that failed with
+// err may succeed when sent again.
+func isTransientStreamError(err error) bool {
+	var apiErr APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case 408, 429, 500, 502, 503, 504:
+			return true
+		}
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// generateContentStreamWithRetry is generateContentStreamWithBudget with the
+// retries of retry. Each attempt is charged to the budgets. Transient errors
+// after the first chunk are returned as a *ResumableStreamError. Without retry,
+// the stream of generateContentStreamWithBudget is returned unchanged.
+func (m Models) generateContentStreamWithRetry(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, retry *StreamRetryConfig) iter.Seq2[*GenerateContentResponse, error] {
+	if retry == nil {
+		return m.generateContentStreamWithBudget(ctx, model, contents, config)
+	}
+	rc := *retry
+	maxRetries := rc.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = 3
+	}
+	if rc.InitialDelay == 0 {
+		rc.InitialDelay = time.Second
+	}
+	return func(yield func(*GenerateContentResponse, error) bool) {
+		var acc StreamAccumulator
+		// transcript is the text yielded so far, and resumable reports whether
+		// it's all that was yielded.
+		var transcript strings.Builder
+		resumable := true
+		delay := rc.InitialDelay
+		for attempt := int32(0); ; attempt++ {
+			skip := transcript.String()
+			replayed := 0
+			diverged := false
+			var streamErr error
+			for chunk, err := range m.generateContentStreamWithBudget(ctx, model, contents, config) {
+				if err != nil {
+					streamErr = err
+					break
+				}
+				if replayed < len(skip) {
+					var ok bool
+					if chunk, ok = skipReplayedText(chunk, skip, &replayed); !ok {
+						diverged = true
+						break
+					}
+					if chunk == nil {
+						continue
+					}
+				}
+				acc.Add(chunk)
+				resumable = resumable && appendTranscript(&transcript, chunk)
+				if !yield(chunk, nil) {
+					return
+				}
+			}
+			if diverged || (streamErr == nil && replayed < len(skip)) {
+				// The retry can't continue where the previous attempt stopped.
+				yield(nil, &ResumableStreamError{Err: errors.New("the retried stream differs from the partial response"), Partial: acc.Response()})
+				return
+			}
+			if streamErr == nil {
+				return
+			}
+			partial := acc.Response()
+			transient := isTransientStreamError(streamErr)
+			if !transient || attempt >= maxRetries || (partial != nil && !resumable) {
+				if transient && partial != nil {
+					streamErr = &ResumableStreamError{Err: streamErr, Partial: partial}
+				}
+				yield(nil, streamErr)
+				return
+			}
+			select {
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+	}
+}
+
+// appendTranscript appends the text of chunk to b. It returns false if chunk
+// holds anything a retry couldn't skip, such as a function call.
+func appendTranscript(b *strings.Builder, chunk *GenerateContentResponse) bool {
+	if len(chunk.Candidates) > 1 {
+		return false
+	}
+	if len(chunk.Candidates) == 0 || chunk.Candidates[0].Content == nil {
+		return true
+	}
+	for _, p := range chunk.Candidates[0].Content.Parts {
+		if !isTextPart(p) {
+			return false
+		}
+		b.WriteString(p.Text)
+	}
+	return true
+}
+
+// skipReplayedText removes the text of chunk that was already yielded, given
+// that skip is the text yielded and *replayed the length of it that the retry
+// has replayed so far. It returns nil if nothing is left to yield, and false
+// if the chunk doesn't match the text yielded.
+func skipReplayedText(chunk *GenerateContentResponse, skip string, replayed *int) (*GenerateContentResponse, bool) {
+	if len(chunk.Candidates) == 0 {
+		return nil, true
+	}
+	if len(chunk.Candidates) > 1 {
+		return nil, false
+	}
+	candidate := chunk.Candidates[0]
+	var parts []*Part
+	if candidate.Content != nil {
+		parts = candidate.Content.Parts
+	}
+	for len(parts) > 0 && *replayed < len(skip) {
+		p := parts[0]
+		if !isTextPart(p) {
+			return nil, false
+		}
+		remaining := skip[*replayed:]
+		if len(p.Text) <= len(remaining) {
+			if !strings.HasPrefix(remaining, p.Text) {
+				return nil, false
+			}
+			*replayed += len(p.Text)
+			parts = parts[1:]
+			continue
+		}
+		if !strings.HasPrefix(p.Text, remaining) {
+			return nil, false
+		}
+		*replayed = len(skip)
+		tail := *p
+		tail.Text = p.Text[len(remaining):]
+		parts = append([]*Part{&tail}, parts[1:]...)
+	}
+	if *replayed < len(skip) {
+		if candidate.FinishReason != "" {
+			// The retry ended before replaying the text yielded.
+			return nil, false
+		}
+		return nil, true
+	}
+	if len(parts) == 0 && candidate.FinishReason == "" && chunk.UsageMetadata == nil {
+		return nil, true
+	}
+	c := *chunk
+	cc := *candidate
+	if candidate.Content != nil {
+		content := *candidate.Content
+		content.Parts = parts
+		cc.Content = &content
+	}
+	c.Candidates = []*Candidate{&cc}
+	return &c, true
+}
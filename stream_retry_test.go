@@ -0,0 +1,164 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// streamAttempt is the response of the test server to one streaming request.
+type streamAttempt struct {
+	parts []string
+	// drop closes the connection after the parts instead of finishing the
+	// response.
+	drop bool
+}
+
+func newStreamRetryTestModels(t *testing.T, attempts []streamAttempt) (Models, *atomic.Int32) {
+	t.Helper()
+	calls := new(atomic.Int32)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		attempt := attempts[min(n-1, len(attempts)-1)]
+		w.Header().Set("Content-Type", "text/event-stream")
+		for i, text := range attempt.parts {
+			finishReason := ""
+			if i == len(attempt.parts)-1 && !attempt.drop {
+				finishReason = `, "finishReason": "STOP"`
+			}
+			fmt.Fprintf(w, "data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": %q}]}%s}]}\n\n", text, finishReason)
+			w.(http.Flusher).Flush()
+		}
+		if attempt.drop {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("Hijack() failed: %v", err)
+				return
+			}
+			conn.Close()
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}, calls
+}
+
+func collectStreamText(m Models, options *GenerateContentOptions) (string, error) {
+	var b strings.Builder
+	for chunk, err := range m.GenerateContentStreamWithOptions(context.Background(), "test-model", Text("hi"), nil, options) {
+		if err != nil {
+			return b.String(), err
+		}
+		b.WriteString(chunk.Text())
+	}
+	return b.String(), nil
+}
+
+func TestGenerateContentStreamRetry(t *testing.T) {
+	retry := &StreamRetryConfig{InitialDelay: time.Millisecond}
+
+	t.Run("ResumesAfterYieldedText", func(t *testing.T) {
+		m, calls := newStreamRetryTestModels(t, []streamAttempt{
+			{parts: []string{"The quick ", "brown"}, drop: true},
+			{parts: []string{"The quick br", "own fox."}},
+		})
+		got, err := collectStreamText(m, &GenerateContentOptions{StreamRetry: retry})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "The quick brown fox."; got != want {
+			t.Errorf("streamed text = %q, want %q", got, want)
+		}
+		if got := calls.Load(); got != 2 {
+			t.Errorf("server got %d requests, want 2", got)
+		}
+	})
+
+	t.Run("ErrorWithoutRetry", func(t *testing.T) {
+		m, calls := newStreamRetryTestModels(t, []streamAttempt{
+			{parts: []string{"The quick ", "brown"}, drop: true},
+		})
+		got, err := collectStreamText(m, nil)
+		var resumable *ResumableStreamError
+		if errors.As(err, &resumable) {
+			t.Errorf("error = %v, want the error of the stream unchanged", err)
+		}
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("error = %v, want it to wrap io.ErrUnexpectedEOF", err)
+		}
+		if got != "The quick brown" {
+			t.Errorf("streamed text = %q, want %q", got, "The quick brown")
+		}
+		if got := calls.Load(); got != 1 {
+			t.Errorf("server got %d requests, want 1", got)
+		}
+	})
+
+	t.Run("PartialAfterRetries", func(t *testing.T) {
+		m, _ := newStreamRetryTestModels(t, []streamAttempt{
+			{parts: []string{"The quick ", "brown"}, drop: true},
+		})
+		got, err := collectStreamText(m, &GenerateContentOptions{StreamRetry: &StreamRetryConfig{MaxRetries: 1, InitialDelay: time.Millisecond}})
+		var resumable *ResumableStreamError
+		if !errors.As(err, &resumable) {
+			t.Fatalf("error = %v, want a *ResumableStreamError", err)
+		}
+		if resumable.Text() != got || got != "The quick brown" {
+			t.Errorf("partial text = %q, streamed text = %q, want %q", resumable.Text(), got, "The quick brown")
+		}
+	})
+
+	t.Run("Diverged", func(t *testing.T) {
+		m, _ := newStreamRetryTestModels(t, []streamAttempt{
+			{parts: []string{"The quick "}, drop: true},
+			{parts: []string{"A slow turtle."}},
+		})
+		got, err := collectStreamText(m, &GenerateContentOptions{StreamRetry: retry})
+		var resumable *ResumableStreamError
+		if !errors.As(err, &resumable) || resumable.Text() != "The quick " {
+			t.Errorf("error = %v, want a *ResumableStreamError with the partial text", err)
+		}
+		if got != "The quick " {
+			t.Errorf("streamed text = %q, want %q", got, "The quick ")
+		}
+	})
+
+	t.Run("GivesUp", func(t *testing.T) {
+		m, calls := newStreamRetryTestModels(t, []streamAttempt{{drop: true}})
+		_, err := collectStreamText(m, &GenerateContentOptions{StreamRetry: &StreamRetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond}})
+		if err == nil {
+			t.Fatal("got no error, want one")
+		}
+		var resumable *ResumableStreamError
+		if errors.As(err, &resumable) {
+			t.Errorf("error = %v, want a plain error when nothing was yielded", err)
+		}
+		if got := calls.Load(); got != 3 {
+			t.Errorf("server got %d requests, want 3", got)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"iter"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// StopCondition decides when to stop a streamed generation on the client. It's
+// called with all the text generated so far and returns whether to stop and,
+// if so, the length of the text to keep.
+type StopCondition func(text string) (keep int, stop bool)
+
+// StopOnRegexp returns a [StopCondition] that stops when re matches the text.
+// The text is kept up to the end of the match.
+func StopOnRegexp(re *regexp.Regexp) StopCondition {
+	return func(text string) (int, bool) {
+		loc := re.FindStringIndex(text)
+		if loc == nil {
+			return 0, false
+		}
+		return loc[1], true
+	}
+}
+
+// StopAfterSentences returns a [StopCondition] that stops once n sentences
+// were generated. A sentence ends with '.', '!' or '?' followed by a space, or
+// with their CJK full-width forms.
+func StopAfterSentences(n int) StopCondition {
+	return func(text string) (int, bool) {
+		if n <= 0 {
+			return 0, false
+		}
+		pos := 0
+		for range n {
+			end := sentenceEnd(text[pos:])
+			if end < 0 {
+				return 0, false
+			}
+			pos += end
+		}
+		return pos, true
+	}
+}
+
+// sentenceEnd returns the index right after the end of the first sentence of
+// text, or -1 if text doesn't contain a complete sentence.
+func sentenceEnd(text string) int {
+	for i, r := range text {
+		end := i + utf8.RuneLen(r)
+		switch r {
+		case '.', '!', '?':
+			next, _ := utf8.DecodeRuneInString(text[end:])
+			if end == len(text) || !unicode.IsSpace(next) {
+				continue
+			}
+		case '。', '！', '？':
+		default:
+			continue
+		}
+		return end
+	}
+	return -1
+}
+
+// StopWhenJSONClosed returns a [StopCondition] that stops when the first JSON
+// object or array of the text is closed, ignoring any text before it, such as
+// a code fence. The text is kept up to the closing bracket.
+func StopWhenJSONClosed() StopCondition {
+	return func(text string) (int, bool) {
+		depth := 0
+		inString, escaped := false, false
+		for i := 0; i < len(text); i++ {
+			c := text[i]
+			switch {
+			case inString:
+				switch {
+				case escaped:
+					escaped = false
+				case c == '\\':
+					escaped = true
+				case c == '"':
+					inString = false
+				}
+			case c == '"' && depth > 0:
+				inString = true
+			case c == '{' || c == '[':
+				depth++
+			case (c == '}' || c == ']') && depth > 0:
+				depth--
+				if depth == 0 {
+					return i + 1, true
+				}
+			}
+		}
+		return 0, false
+	}
+}
+
+// StopStream returns an iterator over the chunks of stream that stops as soon
+// as cond is met by the text generated so far, as returned by
+// [GenerateContentResponse.Text]. The text of the last chunk is truncated to
+// the length kept by cond, and stream is stopped, which closes the connection
+// so that the model stops generating. This saves the tokens that would be
+// generated until a stop sequence or the end of the response.
+//
+//	stream := client.Models.GenerateContentStream(ctx, model, contents, config)
+//	for chunk, err := range genai.StopStream(stream, genai.StopAfterSentences(3)) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Print(chunk.Text())
+//	}
+func StopStream(stream iter.Seq2[*GenerateContentResponse, error], cond StopCondition) iter.Seq2[*GenerateContentResponse, error] {
+	return func(yield func(*GenerateContentResponse, error) bool) {
+		var text strings.Builder
+		for chunk, err := range stream {
+			if err != nil || chunk == nil {
+				if !yield(chunk, err) {
+					return
+				}
+				continue
+			}
+			yielded := text.Len()
+			text.WriteString(chunk.Text())
+			keep, stop := cond(text.String())
+			if !stop {
+				if !yield(chunk, nil) {
+					return
+				}
+				continue
+			}
+			yield(truncateChunkText(chunk, max(keep-yielded, 0)), nil)
+			return
+		}
+	}
+}
+
+// truncateChunkText returns a copy of chunk whose first candidate only keeps
+// the first n bytes of its text. Thoughts are kept, and other parts are kept
+// if they come before the end of the kept text.
+func truncateChunkText(chunk *GenerateContentResponse, n int) *GenerateContentResponse {
+	if len(chunk.Candidates) == 0 || chunk.Candidates[0].Content == nil {
+		return chunk
+	}
+	var parts []*Part
+	for _, p := range chunk.Candidates[0].Content.Parts {
+		if p == nil || p.Text == "" || p.Thought {
+			if n > 0 {
+				parts = append(parts, p)
+			}
+			continue
+		}
+		if n == 0 {
+			break
+		}
+		if len(p.Text) > n {
+			cp := *p
+			cp.Text = p.Text[:n]
+			p = &cp
+		}
+		n -= len(p.Text)
+		parts = append(parts, p)
+	}
+	c := *chunk
+	candidate := *chunk.Candidates[0]
+	content := *candidate.Content
+	content.Parts = parts
+	candidate.Content = &content
+	c.Candidates = append([]*Candidate{&candidate}, chunk.Candidates[1:]...)
+	return &c
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestStopConditions(t *testing.T) {
+	tests := []struct {
+		name     string
+		cond     StopCondition
+		text     string
+		wantKeep int
+		wantStop bool
+	}{
+		{"RegexpMatch", StopOnRegexp(regexp.MustCompile(`END`)), "abc END def", 7, true},
+		{"RegexpNoMatch", StopOnRegexp(regexp.MustCompile(`END`)), "abc EN", 0, false},
+		{"Sentences", StopAfterSentences(2), "Pi is 3.14. It is irrational! More.", 29, true},
+		{"SentencesPending", StopAfterSentences(2), "One. Two.", 0, false},
+		{"SentencesCJK", StopAfterSentences(1), "你好。世界", 9, true},
+		{"JSONObject", StopWhenJSONClosed(), "```json\n{\"a\": \"}\\\"\", \"b\": [1, {}]}\n```", 34, true},
+		{"JSONOpen", StopWhenJSONClosed(), `{"a": [1, 2`, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keep, stop := tt.cond(tt.text)
+			if keep != tt.wantKeep || stop != tt.wantStop {
+				t.Errorf("cond(%q) = %d, %v, want %d, %v", tt.text, keep, stop, tt.wantKeep, tt.wantStop)
+			}
+		})
+	}
+}
+
+func TestStopStream(t *testing.T) {
+	var chunks []*GenerateContentResponse
+	for _, text := range []string{"First. Sec", "ond. Third.", " Fourth."} {
+		chunks = append(chunks, &GenerateContentResponse{Candidates: []*Candidate{{Content: &Content{Parts: []*Part{{Text: text}}}}}})
+	}
+	read := 0
+	source := func(yield func(*GenerateContentResponse, error) bool) {
+		for _, chunk := range chunks {
+			read++
+			if !yield(chunk, nil) {
+				return
+			}
+		}
+	}
+	var got string
+	for chunk, err := range StopStream(source, StopAfterSentences(2)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got += chunk.Text()
+	}
+	if want := "First. Second."; got != want {
+		t.Errorf("streamed text = %q, want %q", got, want)
+	}
+	if read != 2 {
+		t.Errorf("read %d chunks of the source, want 2", read)
+	}
+	if chunks[1].Text() != "ond. Third." {
+		t.Errorf("StopStream() modified a chunk")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"iter"
+	"sync"
+)
+
+// teeBufferSize is the number of items a consumer of TeeStream can fall behind
+// the fastest one.
+const teeBufferSize = 16
+
+type teeItem[T any] struct {
+	v   T
+	err error
+}
+
+type teeConsumer[T any] struct {
+	items    chan teeItem[T]
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func (c *teeConsumer[T]) stop() {
+	c.doneOnce.Do(func() { close(c.done) })
+}
+
+// TeeStream returns n iterators that each yield all items of stream, so that
+// one stream, such as the result of [Models.GenerateContentStream], can feed
+// several consumers, for example a UI, a logger and a safety check, without
+// generating the response again.
+//
+// stream is read once, when the first iterator is ranged over. Each iterator
+// must be ranged over once, in its own goroutine: items are only buffered
+// until all consumers received them, so the slowest consumer sets the pace.
+// An iterator that stops early is detached, and stream is stopped once all of
+// them stopped. Items are shared by all consumers and must not be modified.
+//
+//	streams := genai.TeeStream(client.Models.GenerateContentStream(ctx, model, contents, config), 2)
+//	go func() {
+//		for chunk, err := range streams[1] {
+//			logChunk(chunk, err)
+//		}
+//	}()
+//	for chunk, err := range streams[0] {
+//		// Render chunk.
+//	}
+func TeeStream[T any](stream iter.Seq2[T, error], n int) []iter.Seq2[T, error] {
+	consumers := make([]*teeConsumer[T], n)
+	for i := range consumers {
+		consumers[i] = &teeConsumer[T]{items: make(chan teeItem[T], teeBufferSize), done: make(chan struct{})}
+	}
+	var start sync.Once
+	broadcast := func() {
+		defer func() {
+			for _, c := range consumers {
+				close(c.items)
+			}
+		}()
+		for v, err := range stream {
+			active := false
+			for _, c := range consumers {
+				select {
+				case <-c.done:
+					continue
+				default:
+				}
+				select {
+				case c.items <- teeItem[T]{v, err}:
+					active = true
+				case <-c.done:
+				}
+			}
+			if !active {
+				return
+			}
+		}
+	}
+
+	streams := make([]iter.Seq2[T, error], n)
+	for i, c := range consumers {
+		streams[i] = func(yield func(T, error) bool) {
+			start.Do(func() { go broadcast() })
+			defer c.stop()
+			for item := range c.items {
+				if !yield(item.v, item.err) {
+					return
+				}
+			}
+		}
+	}
+	return streams
+}
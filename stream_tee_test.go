@@ -0,0 +1,97 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"iter"
+	"sync"
+	"testing"
+)
+
+func TestTeeStream(t *testing.T) {
+	var chunks []*GenerateContentResponse
+	for _, text := range []string{"a", "b", "c"} {
+		chunks = append(chunks, &GenerateContentResponse{Candidates: []*Candidate{{Content: &Content{Parts: []*Part{{Text: text}}}}}})
+	}
+	wantErr := errors.New("stream failed")
+	reads := 0
+	source := func(yield func(*GenerateContentResponse, error) bool) {
+		reads++
+		streamOf(chunks, wantErr)(yield)
+	}
+
+	streams := TeeStream(iter.Seq2[*GenerateContentResponse, error](source), 3)
+	texts := make([]string, len(streams))
+	errs := make([]error, len(streams))
+	var wg sync.WaitGroup
+	for i, stream := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk, err := range stream {
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				texts[i] += chunk.Text()
+				// The third consumer stops early.
+				if i == 2 {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reads != 1 {
+		t.Errorf("source stream read %d times, want 1", reads)
+	}
+	for i := range 2 {
+		if texts[i] != "abc" || !errors.Is(errs[i], wantErr) {
+			t.Errorf("consumer %d got %q, %v, want %q, %v", i, texts[i], errs[i], "abc", wantErr)
+		}
+	}
+	if texts[2] != "a" {
+		t.Errorf("consumer 2 got %q, want %q", texts[2], "a")
+	}
+}
+
+func TestTeeStreamAllStop(t *testing.T) {
+	stopped := make(chan struct{})
+	source := func(yield func(int, error) bool) {
+		defer close(stopped)
+		for i := 0; ; i++ {
+			if !yield(i, nil) {
+				return
+			}
+		}
+	}
+	streams := TeeStream(iter.Seq2[int, error](source), 2)
+	var wg sync.WaitGroup
+	for _, stream := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range stream {
+				if v == 5 {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	<-stopped
+}
@@ -0,0 +1,336 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const defaultDecodeMaxRetries = 1
+
+// TextFormat describes a textual response format that the model is asked to
+// produce and that is decoded into a Go value.
+type TextFormat struct {
+	// Name is the human readable name of the format, used in instructions and
+	// errors, for example "YAML".
+	Name string
+	// MIMEType is the response MIME type requested from the model. Formats that
+	// the API doesn't support natively use "text/plain" and rely on an
+	// instruction instead.
+	MIMEType string
+	// Instruction is appended to the system instruction to ask the model for
+	// the format. It may be empty if MIMEType is enough.
+	Instruction string
+	// Unmarshal decodes the model output into v.
+	Unmarshal func(data []byte, v any) error
+}
+
+// JSONFormat requests JSON output and decodes it with [json.Unmarshal].
+var JSONFormat = TextFormat{
+	Name:      "JSON",
+	MIMEType:  "application/json",
+	Unmarshal: json.Unmarshal,
+}
+
+// XMLFormat requests an XML document and decodes it with [xml.Unmarshal].
+var XMLFormat = TextFormat{
+	Name:        "XML",
+	MIMEType:    "text/plain",
+	Instruction: "Respond only with a single well-formed XML document. Do not add any explanation or Markdown code fences.",
+	Unmarshal:   xml.Unmarshal,
+}
+
+// YAMLFormat requests a YAML document and decodes it with the given unmarshal
+// function, typically yaml.Unmarshal from a YAML package of your choice. The
+// SDK doesn't depend on a YAML implementation itself.
+func YAMLFormat(unmarshal func(data []byte, v any) error) TextFormat {
+	return TextFormat{
+		Name:        "YAML",
+		MIMEType:    "text/plain",
+		Instruction: "Respond only with a single valid YAML document. Do not add any explanation or Markdown code fences.",
+		Unmarshal:   unmarshal,
+	}
+}
+
+// DecodeConfig configures how model output is decoded and repaired.
+type DecodeConfig struct {
+	// Optional. Maximum number of times the model is asked again, with the
+	// decoding error as feedback, after its output failed to decode. Defaults
+	// to 1. Set to 0 to disable retries.
+	MaxRetries *int32
+}
+
+// DecodeError is returned when the model output can't be decoded into the
+// requested Go value, even after retries.
+type DecodeError struct {
+	// Format is the name of the requested format.
+	Format string
+	// Text is the model output of the last attempt.
+	Text string
+	// FinishReason is the finish reason of the last attempt. FinishReasonMaxTokens
+	// indicates the output was truncated.
+	FinishReason FinishReason
+	// Attempts is the number of GenerateContent calls made.
+	Attempts int
+	// Err is the decoding error of the last attempt.
+	Err error
+}
+
+// Error returns a string representation of the DecodeError.
+func (e *DecodeError) Error() string {
+	if e.FinishReason == FinishReasonMaxTokens {
+		return fmt.Sprintf("failed to decode %s response after %d attempt(s): the response was truncated because it reached MaxOutputTokens: %v", e.Format, e.Attempts, e.Err)
+	}
+	return fmt.Sprintf("failed to decode %s response after %d attempt(s): %v", e.Format, e.Attempts, e.Err)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// GenerateContentDecoded generates content in the given textual format and
+// decodes the first candidate into out, which must be a pointer.
+//
+// Markdown code fences around the output are removed before decoding. If
+// decoding fails, the model is asked again with the error as feedback, up to
+// [DecodeConfig.MaxRetries] times. If all attempts fail, a [*DecodeError] is
+// returned together with the last response.
+//
+//	var order Order
+//	_, err := client.Models.GenerateContentDecoded(ctx, model, contents, nil, genai.XMLFormat, &order, nil)
+func (m Models) GenerateContentDecoded(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, format TextFormat, out any, decodeConfig *DecodeConfig) (*GenerateContentResponse, error) {
+	if format.Unmarshal == nil {
+		return nil, fmt.Errorf("GenerateContentDecoded: format %q has no Unmarshal function", format.Name)
+	}
+	c := GenerateContentConfig{}
+	if config != nil {
+		c = *config
+	}
+	if format.MIMEType != "" {
+		c.ResponseMIMEType = format.MIMEType
+	}
+	if format.Instruction != "" {
+		c.SystemInstruction = appendInstruction(c.SystemInstruction, format.Instruction)
+	}
+	return m.generateAndDecode(ctx, model, contents, &c, format, out, decodeConfig)
+}
+
+// generateAndDecode calls GenerateContent and decodes the first candidate
+// with format.Unmarshal, asking the model to repair its output on failure.
+// Each attempt is decoded into a new value, which is only stored in out, a
+// pointer, once it's decoded successfully.
+func (m Models) generateAndDecode(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, format TextFormat, out any, decodeConfig *DecodeConfig) (*GenerateContentResponse, error) {
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return nil, fmt.Errorf("can't decode the response into %T, want a non-nil pointer", out)
+	}
+	maxRetries := int32(defaultDecodeMaxRetries)
+	if decodeConfig != nil && decodeConfig.MaxRetries != nil {
+		maxRetries = *decodeConfig.MaxRetries
+	}
+
+	history := append([]*Content{}, contents...)
+	var lastErr *DecodeError
+	for attempt := 1; attempt <= int(maxRetries)+1; attempt++ {
+		resp, err := m.GenerateContentWithOptions(ctx, model, history, config, nil)
+		if err != nil {
+			return nil, err
+		}
+		candidate := firstCandidate(resp)
+		text := candidateText(candidate)
+		lastErr = &DecodeError{Format: format.Name, Text: text, Attempts: attempt}
+		if candidate != nil {
+			lastErr.FinishReason = candidate.FinishReason
+		}
+		if strings.TrimSpace(text) == "" {
+			lastErr.Err = fmt.Errorf("the model returned no text")
+			if err := resp.PromptError(); err != nil {
+				lastErr.Err = err
+				return resp, lastErr
+			}
+		} else {
+			value := reflect.New(target.Type().Elem())
+			if err := format.Unmarshal([]byte(stripCodeFence(text)), value.Interface()); err != nil {
+				lastErr.Err = err
+			} else {
+				target.Elem().Set(value.Elem())
+				return resp, nil
+			}
+		}
+		// Retrying a truncated answer with the same limits doesn't help.
+		if attempt == int(maxRetries)+1 || lastErr.FinishReason == FinishReasonMaxTokens {
+			return resp, lastErr
+		}
+		history = append(history,
+			NewContentFromText(text, RoleModel),
+			NewContentFromText(fmt.Sprintf("Your previous response could not be parsed as %s: %v. Respond again with only the corrected %s.", format.Name, lastErr.Err, format.Name), RoleUser),
+		)
+	}
+	return nil, lastErr
+}
+
+// appendInstruction returns a copy of systemInstruction with an additional
+// text part.
+func appendInstruction(systemInstruction *Content, instruction string) *Content {
+	if systemInstruction == nil {
+		return &Content{Role: RoleUser, Parts: []*Part{{Text: instruction}}}
+	}
+	c := *systemInstruction
+	c.Parts = append(append([]*Part{}, systemInstruction.Parts...), &Part{Text: instruction})
+	return &c
+}
+
+// stripCodeFence removes a surrounding Markdown code fence, such as
+// "```yaml\n...\n```", from text.
+func stripCodeFence(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "```") {
+		return trimmed
+	}
+	body := strings.TrimPrefix(trimmed, "```")
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		return trimmed
+	}
+	body = strings.TrimSpace(body)
+	body = strings.TrimSuffix(body, "```")
+	return strings.TrimSpace(body)
+}
+
+// GenerateContentAs generates JSON content and decodes the first candidate
+// into a value of type T.
+//
+// Unless config already sets ResponseSchema or ResponseJsonSchema, the
+// response schema is derived from T with [SchemaFor]. The output is validated
+// against the schema before it is decoded. If the output is truncated or
+// doesn't match the schema, a [*DecodeError] is returned together with the
+// response. Invalid output that wasn't truncated is retried once with the
+// validation error as feedback.
+//
+//	type Recipe struct {
+//		Name        string   `json:"name"`
+//		Ingredients []string `json:"ingredients"`
+//	}
+//	recipe, _, err := genai.GenerateContentAs[Recipe](ctx, client.Models, model, genai.Text("A cookie recipe"), nil)
+func GenerateContentAs[T any](ctx context.Context, m *Models, model string, contents []*Content, config *GenerateContentConfig) (T, *GenerateContentResponse, error) {
+	var out T
+	c := GenerateContentConfig{}
+	if config != nil {
+		c = *config
+	}
+	c.ResponseMIMEType = JSONFormat.MIMEType
+	if c.ResponseSchema == nil && c.ResponseJsonSchema == nil {
+		schema, err := SchemaFor[T]()
+		if err != nil {
+			return out, nil, fmt.Errorf("GenerateContentAs: can't derive a response schema from %s: %w", reflect.TypeFor[T](), err)
+		}
+		c.ResponseSchema = schema
+	}
+	format := JSONFormat
+	if c.ResponseSchema != nil {
+		schema := c.ResponseSchema
+		format.Unmarshal = func(data []byte, v any) error {
+			var raw any
+			if err := json.Unmarshal(data, &raw); err != nil {
+				return err
+			}
+			if err := validateAgainstSchema(raw, schema, "$"); err != nil {
+				return err
+			}
+			return json.Unmarshal(data, v)
+		}
+	}
+	resp, err := m.generateAndDecode(ctx, model, contents, &c, format, &out, nil)
+	return out, resp, err
+}
+
+// validateAgainstSchema checks a decoded JSON value against the type, enum and
+// required constraints of schema. path is the JSON path of v used in errors.
+func validateAgainstSchema(v any, schema *Schema, path string) error {
+	if schema == nil {
+		return nil
+	}
+	if v == nil {
+		if schema.Nullable != nil && *schema.Nullable || schema.Type == "" || schema.Type == TypeNULL {
+			return nil
+		}
+		return fmt.Errorf("schema violation at %s: got null, want %s", path, schema.Type)
+	}
+	if len(schema.AnyOf) > 0 {
+		for _, s := range schema.AnyOf {
+			if validateAgainstSchema(v, s, path) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("schema violation at %s: value matches none of the anyOf schemas", path)
+	}
+	switch schema.Type {
+	case TypeString:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("schema violation at %s: got %T, want string", path, v)
+		}
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, s) {
+			return fmt.Errorf("schema violation at %s: %q is not one of %q", path, s, schema.Enum)
+		}
+	case TypeNumber:
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("schema violation at %s: got %T, want number", path, v)
+		}
+	case TypeInteger:
+		f, ok := v.(float64)
+		if !ok || f != float64(int64(f)) {
+			return fmt.Errorf("schema violation at %s: got %v, want integer", path, v)
+		}
+	case TypeBoolean:
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("schema violation at %s: got %T, want boolean", path, v)
+		}
+	case TypeArray:
+		items, ok := v.([]any)
+		if !ok {
+			return fmt.Errorf("schema violation at %s: got %T, want array", path, v)
+		}
+		for i, item := range items {
+			if err := validateAgainstSchema(item, schema.Items, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case TypeObject:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return fmt.Errorf("schema violation at %s: got %T, want object", path, v)
+		}
+		for _, name := range schema.Required {
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("schema violation at %s: missing required property %q", path, name)
+			}
+		}
+		for name, value := range obj {
+			if err := validateAgainstSchema(value, schema.Properties[name], path+"."+name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
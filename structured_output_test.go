@@ -0,0 +1,247 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// newTestModels returns a Models backed by a test server that replies to
+// each request with the next text answer in texts.
+func newTestModels(t *testing.T, texts []string, finishReason string, requests *[]map[string]any) Models {
+	t.Helper()
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if requests != nil {
+			*requests = append(*requests, body)
+		}
+		text := texts[min(calls, len(texts)-1)]
+		calls++
+		fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": %q}]}, "finishReason": %q}]}`, text, finishReason)
+	}))
+	t.Cleanup(ts.Close)
+	return Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+}
+
+type testOrder struct {
+	ID   string `xml:"id"`
+	Qty  int    `xml:"qty"`
+	Note string `xml:"note"`
+}
+
+type testList struct {
+	Items []string `xml:"item"`
+}
+
+func TestStripCodeFence(t *testing.T) {
+	tests := []struct {
+		input, want string
+	}{
+		{"<a/>", "<a/>"},
+		{"```xml\n<a/>\n```", "<a/>"},
+		{"  ```\nkey: v\n```  ", "key: v"},
+		{"```inline```", "```inline```"},
+	}
+	for _, tt := range tests {
+		if got := stripCodeFence(tt.input); got != tt.want {
+			t.Errorf("stripCodeFence(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateContentDecoded(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("XML", func(t *testing.T) {
+		var requests []map[string]any
+		m := newTestModels(t, []string{"```xml\n<order><id>a1</id><qty>2</qty></order>\n```"}, "STOP", &requests)
+		var got testOrder
+		if _, err := m.GenerateContentDecoded(ctx, "gemini-2.5-flash", Text("order"), nil, XMLFormat, &got, nil); err != nil {
+			t.Fatal(err)
+		}
+		if got.ID != "a1" || got.Qty != 2 {
+			t.Errorf("got %+v, want ID a1 and Qty 2", got)
+		}
+		genConfig := requests[0]["generationConfig"].(map[string]any)
+		if genConfig["responseMimeType"] != "text/plain" {
+			t.Errorf("responseMimeType = %v, want text/plain", genConfig["responseMimeType"])
+		}
+		if requests[0]["systemInstruction"] == nil {
+			t.Errorf("want format instruction in systemInstruction")
+		}
+	})
+
+	t.Run("Repair", func(t *testing.T) {
+		var requests []map[string]any
+		m := newTestModels(t, []string{"<order><id>a1", "<order><id>a1</id></order>"}, "STOP", &requests)
+		var got testOrder
+		if _, err := m.GenerateContentDecoded(ctx, "gemini-2.5-flash", Text("order"), nil, XMLFormat, &got, nil); err != nil {
+			t.Fatal(err)
+		}
+		if len(requests) != 2 {
+			t.Fatalf("got %d requests, want 2", len(requests))
+		}
+		if n := len(requests[1]["contents"].([]any)); n != 3 {
+			t.Errorf("repair request has %d contents, want 3", n)
+		}
+	})
+
+	t.Run("RepairDiscardsPartialDecode", func(t *testing.T) {
+		m := newTestModels(t, []string{"<list><item>a</item><item>b</item><oops", "<list><item>c</item></list>"}, "STOP", nil)
+		var got testList
+		if _, err := m.GenerateContentDecoded(ctx, "gemini-2.5-flash", Text("list"), nil, XMLFormat, &got, nil); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"c"}; !slices.Equal(got.Items, want) {
+			t.Errorf("Items = %q, want %q", got.Items, want)
+		}
+	})
+
+	t.Run("RetriesExhausted", func(t *testing.T) {
+		m := newTestModels(t, []string{"not xml <"}, "STOP", nil)
+		var got testOrder
+		_, err := m.GenerateContentDecoded(ctx, "gemini-2.5-flash", Text("order"), nil, XMLFormat, &got, &DecodeConfig{MaxRetries: Ptr[int32](2)})
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) {
+			t.Fatalf("got error %v, want *DecodeError", err)
+		}
+		if decodeErr.Attempts != 3 {
+			t.Errorf("Attempts = %d, want 3", decodeErr.Attempts)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		var requests []map[string]any
+		m := newTestModels(t, []string{"<order><id>a1"}, "MAX_TOKENS", &requests)
+		var got testOrder
+		_, err := m.GenerateContentDecoded(ctx, "gemini-2.5-flash", Text("order"), nil, XMLFormat, &got, nil)
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) || decodeErr.FinishReason != FinishReasonMaxTokens {
+			t.Fatalf("got error %v, want truncation *DecodeError", err)
+		}
+		if len(requests) != 1 {
+			t.Errorf("got %d requests, want no retry for truncated output", len(requests))
+		}
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		m := newTestModels(t, []string{"name: x"}, "STOP", nil)
+		unmarshal := func(data []byte, v any) error {
+			*(v.(*string)) = string(data)
+			return nil
+		}
+		var got string
+		if _, err := m.GenerateContentDecoded(ctx, "gemini-2.5-flash", Text("q"), nil, YAMLFormat(unmarshal), &got, nil); err != nil {
+			t.Fatal(err)
+		}
+		if got != "name: x" {
+			t.Errorf("got %q, want %q", got, "name: x")
+		}
+	})
+}
+
+func TestGenerateContentAs(t *testing.T) {
+	ctx := context.Background()
+	type item struct {
+		Name string `json:"name"`
+		Qty  int    `json:"qty"`
+	}
+
+	t.Run("Decode", func(t *testing.T) {
+		var requests []map[string]any
+		m := newTestModels(t, []string{`{"name": "apple", "qty": 3}`}, "STOP", &requests)
+		got, resp, err := GenerateContentAs[item](ctx, &m, "gemini-2.5-flash", Text("q"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp == nil || got.Name != "apple" || got.Qty != 3 {
+			t.Errorf("got %+v, want apple x3", got)
+		}
+		genConfig := requests[0]["generationConfig"].(map[string]any)
+		if genConfig["responseMimeType"] != "application/json" {
+			t.Errorf("responseMimeType = %v, want application/json", genConfig["responseMimeType"])
+		}
+		if genConfig["responseSchema"] == nil {
+			t.Errorf("want responseSchema derived from the type")
+		}
+	})
+
+	t.Run("SchemaViolation", func(t *testing.T) {
+		m := newTestModels(t, []string{`{"name": "apple"}`}, "STOP", nil)
+		_, _, err := GenerateContentAs[item](ctx, &m, "gemini-2.5-flash", Text("q"), nil)
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) || !strings.Contains(err.Error(), `missing required property "qty"`) {
+			t.Fatalf("got error %v, want schema violation *DecodeError", err)
+		}
+		if decodeErr.Attempts != 2 {
+			t.Errorf("Attempts = %d, want 2", decodeErr.Attempts)
+		}
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		m := newTestModels(t, []string{`{"name": "app`}, "MAX_TOKENS", nil)
+		_, _, err := GenerateContentAs[item](ctx, &m, "gemini-2.5-flash", Text("q"), nil)
+		if err == nil || !strings.Contains(err.Error(), "truncated") {
+			t.Errorf("got error %v, want truncation error", err)
+		}
+	})
+}
+
+func TestValidateAgainstSchema(t *testing.T) {
+	schema := &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"color": {Type: TypeString, Enum: []string{"red", "blue"}},
+			"sizes": {Type: TypeArray, Items: &Schema{Type: TypeInteger}},
+			"note":  {Type: TypeString, Nullable: Ptr(true)},
+		},
+		Required: []string{"color"},
+	}
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{`{"color": "red", "sizes": [1, 2], "note": null}`, false},
+		{`{"color": "green"}`, true},
+		{`{"color": "red", "sizes": [1.5]}`, true},
+		{`{"sizes": []}`, true},
+		{`[]`, true},
+	}
+	for _, tt := range tests {
+		var v any
+		if err := json.Unmarshal([]byte(tt.input), &v); err != nil {
+			t.Fatal(err)
+		}
+		if err := validateAgainstSchema(v, schema, "$"); (err != nil) != tt.wantErr {
+			t.Errorf("validateAgainstSchema(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const defaultSummarizationModel = "gemini-2.5-flash-lite"
+
+// summaryPrefix starts the text of the contents returned by [SummarizeHistory].
+const summaryPrefix = "Summary of the conversation so far:\n\n"
+
+// SummarizeHistory compresses a conversation history into a single user
+// content that summarizes it in about budget tokens, to be sent instead of the
+// history. The summary is written by the low-cost model set as
+// [ClientConfig.SummarizationModel].
+//
+// Thoughts are left out of the summary, and function calls and responses and
+// media are described rather than included.
+func SummarizeHistory(ctx context.Context, client *Client, history []*Content, budget int32) (*Content, error) {
+	return summarizeHistory(ctx, *client.Models, history, budget)
+}
+
+func summarizeHistory(ctx context.Context, m Models, history []*Content, budget int32) (*Content, error) {
+	if budget <= 0 {
+		return nil, fmt.Errorf("SummarizeHistory: budget must be positive, got %d", budget)
+	}
+	model := m.apiClient.clientConfig.SummarizationModel
+	if model == "" {
+		model = defaultSummarizationModel
+	}
+	transcript := historyTranscript(history)
+	if transcript == "" {
+		return nil, fmt.Errorf("SummarizeHistory: the history has no content to summarize")
+	}
+	// A token is about three quarters of a word of English.
+	prompt := fmt.Sprintf("Summarize the following conversation between a user and an AI model in at most %d words, "+
+		"so that the model can carry on the conversation from the summary alone. Keep the facts, decisions, "+
+		"open questions and the results of function calls that later turns may rely on. Write only the summary.\n\n"+
+		"<conversation>\n%s</conversation>", budget*3/4, transcript)
+	resp, err := m.GenerateContentWithOptions(ctx, model, Text(prompt), &GenerateContentConfig{
+		MaxOutputTokens: budget,
+		Temperature:     Ptr[float32](0),
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("SummarizeHistory: %w", err)
+	}
+	summary := strings.TrimSpace(resp.Text())
+	if summary == "" {
+		return nil, fmt.Errorf("SummarizeHistory: %s returned an empty summary", model)
+	}
+	return NewContentFromText(summaryPrefix+summary, RoleUser), nil
+}
+
+// historyTranscript renders history as text, one line per turn prefixed by its
+// role.
+func historyTranscript(history []*Content) string {
+	var b strings.Builder
+	for _, c := range history {
+		if c == nil {
+			continue
+		}
+		var parts []string
+		for _, p := range c.Parts {
+			if s := partTranscript(p); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		role := c.Role
+		if role == "" {
+			role = RoleUser
+		}
+		fmt.Fprintf(&b, "%s: %s\n", role, strings.Join(parts, " "))
+	}
+	return b.String()
+}
+
+func partTranscript(p *Part) string {
+	switch {
+	case p == nil || p.Thought:
+		return ""
+	case p.Text != "":
+		return p.Text
+	case p.FunctionCall != nil:
+		args, _ := json.Marshal(p.FunctionCall.Args)
+		return fmt.Sprintf("[called function %s with %s]", p.FunctionCall.Name, args)
+	case p.FunctionResponse != nil:
+		response, _ := json.Marshal(p.FunctionResponse.Response)
+		return fmt.Sprintf("[function %s returned %s]", p.FunctionResponse.Name, response)
+	case p.ExecutableCode != nil:
+		return fmt.Sprintf("[ran code:\n%s]", p.ExecutableCode.Code)
+	case p.CodeExecutionResult != nil:
+		return fmt.Sprintf("[code output: %s]", p.CodeExecutionResult.Output)
+	case p.InlineData != nil:
+		return fmt.Sprintf("[%s attachment]", p.InlineData.MIMEType)
+	case p.FileData != nil:
+		return fmt.Sprintf("[%s file %s]", p.FileData.MIMEType, p.FileData.FileURI)
+	}
+	return ""
+}
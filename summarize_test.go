@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummarizeHistory(t *testing.T) {
+	history := []*Content{
+		NewContentFromText("What's the weather in Paris?", RoleUser),
+		{Role: RoleModel, Parts: []*Part{
+			{Text: "Let me check.", Thought: true},
+			NewPartFromFunctionCall("getWeather", map[string]any{"city": "Paris"}),
+		}},
+		{Role: RoleUser, Parts: []*Part{NewPartFromFunctionResponse("getWeather", map[string]any{"sky": "sunny"})}},
+		NewContentFromText("It's sunny in Paris.", RoleModel),
+	}
+	wantTranscript := `user: What's the weather in Paris?
+model: [called function getWeather with {"city":"Paris"}]
+user: [function getWeather returned {"sky":"sunny"}]
+model: It's sunny in Paris.
+`
+
+	tests := []struct {
+		name               string
+		summarizationModel string
+		wantPath           string
+	}{
+		{name: "DefaultModel", wantPath: "/v1beta/models/gemini-2.5-flash-lite:generateContent"},
+		{name: "ConfiguredModel", summarizationModel: "gemini-2.0-flash-lite", wantPath: "/v1beta/models/gemini-2.0-flash-lite:generateContent"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %s, want %s", r.URL.Path, tt.wantPath)
+				}
+				var req struct {
+					Contents         []*Content        `json:"contents"`
+					GenerationConfig *GenerationConfig `json:"generationConfig"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Error(err)
+				}
+				if got := req.GenerationConfig.MaxOutputTokens; got != 200 {
+					t.Errorf("MaxOutputTokens = %d, want 200", got)
+				}
+				prompt := req.Contents[0].Parts[0].Text
+				if !strings.Contains(prompt, "at most 150 words") {
+					t.Errorf("prompt doesn't ask for 150 words:\n%s", prompt)
+				}
+				if !strings.Contains(prompt, "<conversation>\n"+wantTranscript+"</conversation>") {
+					t.Errorf("prompt doesn't contain the transcript:\n%s", prompt)
+				}
+				fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": " The user asked about Paris, where it's sunny. "}]}}]}`)
+			}))
+			defer ts.Close()
+			client := &Client{Models: &Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+				Backend:            BackendGeminiAPI,
+				HTTPOptions:        HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta"},
+				HTTPClient:         ts.Client(),
+				SummarizationModel: tt.summarizationModel,
+			}}}}
+
+			got, err := SummarizeHistory(context.Background(), client, history, 200)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := NewContentFromText("Summary of the conversation so far:\n\nThe user asked about Paris, where it's sunny.", RoleUser)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("SummarizeHistory() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		client := &Client{Models: &Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI}}}}
+		if _, err := SummarizeHistory(context.Background(), client, history, 0); err == nil {
+			t.Error("SummarizeHistory() with a zero budget succeeded, want error")
+		}
+		thoughts := []*Content{{Role: RoleModel, Parts: []*Part{{Text: "Hmm.", Thought: true}}}}
+		if _, err := SummarizeHistory(context.Background(), client, thoughts, 100); err == nil {
+			t.Error("SummarizeHistory() with nothing to summarize succeeded, want error")
+		}
+	})
+}
+
+func TestChatSummarizeHistory(t *testing.T) {
+	var lastContents []*Content
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Contents []*Content `json:"contents"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Error(err)
+		}
+		lastContents = req.Contents
+		text := "Fine."
+		if strings.Contains(r.URL.Path, "flash-lite") {
+			text = "The user greeted the model."
+		}
+		fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": %q}]}, "finishReason": "STOP"}]}`, text)
+	}))
+	defer ts.Close()
+	ac := &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client()}}
+	chats := &Chats{apiClient: ac}
+	ctx := context.Background()
+
+	chat, err := chats.Create(ctx, "gemini-2.5-flash", nil, []*Content{
+		NewContentFromText("Hello!", RoleUser),
+		NewContentFromText("Hi! How can I help?", RoleModel),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := chat.SummarizeHistory(ctx, 100); err != nil {
+		t.Fatal(err)
+	}
+	summary := NewContentFromText("Summary of the conversation so far:\n\nThe user greeted the model.", RoleUser)
+	if diff := cmp.Diff([]*Content{summary}, chat.History(true)); diff != "" {
+		t.Errorf("History(true) mismatch (-want +got):\n%s", diff)
+	}
+	if got := len(chat.History(false)); got != 2 {
+		t.Errorf("len(History(false)) = %d, want 2", got)
+	}
+
+	if _, err := chat.SendMessage(ctx, Part{Text: "How are you?"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []*Content{summary, NewContentFromText("How are you?", RoleUser)}
+	if diff := cmp.Diff(want, lastContents); diff != "" {
+		t.Errorf("sent contents mismatch (-want +got):\n%s", diff)
+	}
+}
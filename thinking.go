@@ -0,0 +1,166 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import "strings"
+
+// ThinkingOff returns a [ThinkingConfig] that disables thinking. Some models
+// can't turn thinking off and reject it.
+func ThinkingOff() *ThinkingConfig {
+	return &ThinkingConfig{ThinkingBudget: Ptr[int32](0)}
+}
+
+// ThinkingDynamic returns a [ThinkingConfig] that lets the model decide how
+// much to think based on the complexity of the request. If includeThoughts is
+// true, summaries of the thoughts are returned as parts with Thought set.
+func ThinkingDynamic(includeThoughts bool) *ThinkingConfig {
+	return &ThinkingConfig{ThinkingBudget: Ptr[int32](-1), IncludeThoughts: includeThoughts}
+}
+
+// ThinkingBudgeted returns a [ThinkingConfig] that caps thinking to about
+// budget tokens. As for [ThinkingConfig.ThinkingBudget], a budget of 0 is the
+// same as [ThinkingOff] and -1 is the same as [ThinkingDynamic].
+func ThinkingBudgeted(budget int32, includeThoughts bool) *ThinkingConfig {
+	return &ThinkingConfig{ThinkingBudget: Ptr(budget), IncludeThoughts: includeThoughts}
+}
+
+// Thoughts concatenates the text of the thought parts of the first candidate,
+// which are only returned when [ThinkingConfig.IncludeThoughts] is set.
+func (r *GenerateContentResponse) Thoughts() string {
+	var b strings.Builder
+	for _, p := range r.firstCandidateParts("thoughts") {
+		if p != nil && p.Thought {
+			b.WriteString(p.Text)
+		}
+	}
+	return b.String()
+}
+
+// TextWithThoughts concatenates the text parts of the first candidate,
+// including thought parts, in the order the model returned them. Use
+// [GenerateContentResponse.Text] for the text meant for the user.
+func (r *GenerateContentResponse) TextWithThoughts() string {
+	var b strings.Builder
+	for _, p := range r.firstCandidateParts("text") {
+		if p != nil {
+			b.WriteString(p.Text)
+		}
+	}
+	return b.String()
+}
+
+// withoutThoughts returns contents without their thought parts. Thought parts
+// carrying a thought signature are kept since the model needs the signature
+// back, and contents only made of thought parts are dropped.
+func withoutThoughts(contents []*Content) []*Content {
+	var out []*Content
+	for _, c := range contents {
+		if c == nil {
+			continue
+		}
+		var parts []*Part
+		for _, p := range c.Parts {
+			if p != nil && p.Thought && p.ThoughtSignature == nil {
+				continue
+			}
+			parts = append(parts, p)
+		}
+		if len(parts) == 0 && len(c.Parts) > 0 {
+			continue
+		}
+		if len(parts) < len(c.Parts) {
+			cp := *c
+			cp.Parts = parts
+			c = &cp
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// ThoughtParts returns the thought parts of the first candidate, which are
+// only returned when [ThinkingConfig.IncludeThoughts] is set.
+func (r *GenerateContentResponse) ThoughtParts() []*Part {
+	var parts []*Part
+	for _, p := range r.firstCandidateParts("thought parts") {
+		if p != nil && p.Thought {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+// ThoughtSummary separates the thought summaries of a response from its
+// answer, so that they can be shown apart, for example in a collapsible
+// "reasoning" section. Use [GenerateContentResponse.ThoughtSummary] for a
+// response, or add the chunks of a streamed response to the zero value:
+//
+//	var summary genai.ThoughtSummary
+//	for chunk, err := range client.Models.GenerateContentStream(ctx, model, contents, config) {
+//		if err != nil {
+//			return err
+//		}
+//		thoughts, answer := summary.Add(chunk)
+//		ui.AppendReasoning(thoughts)
+//		ui.AppendAnswer(answer)
+//	}
+//	log.Printf("%d tokens spent thinking", summary.ThoughtsTokenCount)
+type ThoughtSummary struct {
+	// Thoughts is the text of the thought parts of the first candidate.
+	Thoughts string
+	// Answer is the text of the other parts of the first candidate.
+	Answer string
+	// ThoughtsTokenCount is the number of tokens spent thinking, as reported in
+	// the usage metadata. Thoughts are billed even when their summaries aren't
+	// returned.
+	ThoughtsTokenCount int32
+	// AnswerTokenCount is the number of tokens of the candidates, as reported
+	// in the usage metadata.
+	AnswerTokenCount int32
+}
+
+// ThoughtSummary returns the thought summaries of the response apart from its
+// answer, along with their token usage.
+func (r *GenerateContentResponse) ThoughtSummary() *ThoughtSummary {
+	s := &ThoughtSummary{}
+	s.Add(r)
+	return s
+}
+
+// Add adds the text of chunk to the summary and returns the text it added to
+// Thoughts and to Answer. The token counts are updated to the usage metadata
+// of chunk, if any, since it covers the whole response.
+func (s *ThoughtSummary) Add(chunk *GenerateContentResponse) (thoughts, answer string) {
+	if chunk == nil {
+		return "", ""
+	}
+	var t, a strings.Builder
+	for _, p := range chunk.firstCandidateParts("text") {
+		switch {
+		case p == nil:
+		case p.Thought:
+			t.WriteString(p.Text)
+		default:
+			a.WriteString(p.Text)
+		}
+	}
+	s.Thoughts += t.String()
+	s.Answer += a.String()
+	if u := chunk.UsageMetadata; u != nil {
+		s.ThoughtsTokenCount = u.ThoughtsTokenCount
+		s.AnswerTokenCount = u.CandidatesTokenCount
+	}
+	return t.String(), a.String()
+}
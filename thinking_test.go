@@ -0,0 +1,117 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestThinkingPresets(t *testing.T) {
+	tests := []struct {
+		name string
+		got  *ThinkingConfig
+		want *ThinkingConfig
+	}{
+		{"Off", ThinkingOff(), &ThinkingConfig{ThinkingBudget: Ptr[int32](0)}},
+		{"Dynamic", ThinkingDynamic(true), &ThinkingConfig{ThinkingBudget: Ptr[int32](-1), IncludeThoughts: true}},
+		{"Budgeted", ThinkingBudgeted(1024, false), &ThinkingConfig{ThinkingBudget: Ptr[int32](1024)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.got); diff != "" {
+				t.Errorf("ThinkingConfig mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResponseThoughts(t *testing.T) {
+	resp := &GenerateContentResponse{Candidates: []*Candidate{{Content: &Content{Parts: []*Part{
+		{Text: "Let me think. ", Thought: true},
+		{Text: "The answer is 4."},
+		{Text: " Double-checked.", Thought: true},
+	}}}}}
+	if got, want := resp.Text(), "The answer is 4."; got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+	if got, want := resp.Thoughts(), "Let me think.  Double-checked."; got != want {
+		t.Errorf("Thoughts() = %q, want %q", got, want)
+	}
+	if got, want := resp.TextWithThoughts(), "Let me think. The answer is 4. Double-checked."; got != want {
+		t.Errorf("TextWithThoughts() = %q, want %q", got, want)
+	}
+	if got := (&GenerateContentResponse{}).Thoughts(); got != "" {
+		t.Errorf("Thoughts() of an empty response = %q, want empty", got)
+	}
+}
+
+func TestWithoutThoughts(t *testing.T) {
+	signed := &Part{Thought: true, ThoughtSignature: []byte("sig")}
+	answer := &Content{Role: RoleModel, Parts: []*Part{{Text: "4"}}}
+	empty := &Content{Role: RoleModel, Parts: []*Part{}}
+	contents := []*Content{
+		empty,
+		{Role: RoleModel, Parts: []*Part{{Text: "thinking", Thought: true}}},
+		{Role: RoleModel, Parts: []*Part{{Text: "still thinking", Thought: true}, signed, {Text: "2+2"}}},
+		answer,
+	}
+	want := []*Content{
+		empty,
+		{Role: RoleModel, Parts: []*Part{signed, {Text: "2+2"}}},
+		answer,
+	}
+	if diff := cmp.Diff(want, withoutThoughts(contents)); diff != "" {
+		t.Errorf("withoutThoughts() mismatch (-want +got):\n%s", diff)
+	}
+	if len(contents[2].Parts) != 3 {
+		t.Error("withoutThoughts() modified its input")
+	}
+}
+
+func TestThoughtSummary(t *testing.T) {
+	chunks := []*GenerateContentResponse{
+		{Candidates: []*Candidate{{Content: &Content{Parts: []*Part{{Text: "Adding ", Thought: true}}}}}},
+		{Candidates: []*Candidate{{Content: &Content{Parts: []*Part{{Text: "numbers.", Thought: true}, {Text: "It's "}}}}}},
+		{
+			Candidates:    []*Candidate{{Content: &Content{Parts: []*Part{{Text: "4."}, {FunctionCall: &FunctionCall{Name: "f"}}}}}},
+			UsageMetadata: &GenerateContentResponseUsageMetadata{ThoughtsTokenCount: 12, CandidatesTokenCount: 3},
+		},
+	}
+	var s ThoughtSummary
+	var deltas [][2]string
+	for _, c := range chunks {
+		thoughts, answer := s.Add(c)
+		deltas = append(deltas, [2]string{thoughts, answer})
+	}
+	s.Add(nil)
+	wantDeltas := [][2]string{{"Adding ", ""}, {"numbers.", "It's "}, {"", "4."}}
+	if diff := cmp.Diff(wantDeltas, deltas); diff != "" {
+		t.Errorf("Add() mismatch (-want +got):\n%s", diff)
+	}
+	want := ThoughtSummary{Thoughts: "Adding numbers.", Answer: "It's 4.", ThoughtsTokenCount: 12, AnswerTokenCount: 3}
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Errorf("ThoughtSummary mismatch (-want +got):\n%s", diff)
+	}
+
+	resp := chunks[1]
+	if diff := cmp.Diff(&ThoughtSummary{Thoughts: "numbers.", Answer: "It's "}, resp.ThoughtSummary()); diff != "" {
+		t.Errorf("GenerateContentResponse.ThoughtSummary() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]*Part{{Text: "numbers.", Thought: true}}, resp.ThoughtParts()); diff != "" {
+		t.Errorf("ThoughtParts() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	sentencepiece "github.com/eliben/go-sentencepiece"
@@ -84,6 +85,8 @@ var tokenizers = map[string]tokenizerConfig{
 
 // getLocalTokenizerName returns the tokenizer name for the given model name
 func getLocalTokenizerName(modelName string) (string, error) {
+	modelName = strings.TrimPrefix(modelName, "publishers/google/")
+	modelName = strings.TrimPrefix(modelName, "models/")
 	if tokenizerName, ok := geminiModelsToLocalTokenizerNames[modelName]; ok {
 		return tokenizerName, nil
 	}
@@ -140,6 +143,37 @@ func NewLocalTokenizer(modelName string) (*LocalTokenizer, error) {
 	return &LocalTokenizer{processor: processor}, nil
 }
 
+// NewLocalTokenizerFromReader creates a new [LocalTokenizer] for the given
+// model name from a SentencePiece model read from r, without any network
+// access. Use it in environments without internet access by shipping the
+// model file alongside the binary. The model data must match the model that
+// [NewLocalTokenizer] would download for modelName.
+func NewLocalTokenizerFromReader(modelName string, r io.Reader) (*LocalTokenizer, error) {
+	tokenizerName, err := getLocalTokenizerName(modelName)
+	if err != nil {
+		return nil, fmt.Errorf("model %s is not supported", modelName)
+	}
+	config, ok := tokenizers[tokenizerName]
+	if !ok {
+		return nil, fmt.Errorf("model %s is not supported", modelName)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading model: %w", err)
+	}
+	if hashString(data) != config.modelHash {
+		return nil, fmt.Errorf("model data hash mismatch: the data is not the %s tokenizer model", tokenizerName)
+	}
+
+	processor, err := sentencepiece.NewProcessor(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("creating processor: %w", err)
+	}
+
+	return &LocalTokenizer{processor: processor}, nil
+}
+
 // CountTokens counts tokens in the given contents with optional configuration,
 // similar to the Python LocalLocalTokenizer.count_tokens method.
 func (tok *LocalTokenizer) CountTokens(contents []*genai.Content, config *genai.CountTokensConfig) (*genai.CountTokensResult, error) {
@@ -216,6 +250,16 @@ func (tok *LocalTokenizer) ComputeTokens(contents []*genai.Content) (*genai.Comp
 	return &genai.ComputeTokensResult{TokensInfo: tokensInfo}, nil
 }
 
+// Decode converts token IDs back to text. It is the inverse of the
+// tokenization performed by [LocalTokenizer.ComputeTokens].
+func (tok *LocalTokenizer) Decode(ids []int64) string {
+	intIDs := make([]int, len(ids))
+	for i, id := range ids {
+		intIDs[i] = int(id)
+	}
+	return tok.processor.Decode(intIDs)
+}
+
 // textsAccumulator accumulates text from Content objects for tokenization.
 type textsAccumulator struct {
 	texts []string
@@ -417,3 +461,14 @@ func loadModelData(url string, wantHash string) ([]byte, error) {
 
 	return cacheData, nil
 }
+
+// NewTokenComputer returns the local tokenizer of a model as a
+// [genai.TokenComputer]. Set [genai.ClientConfig.LocalTokenizer] to it to
+// support [genai.Models.ComputeTokensWithLocalTokenizer] on the Gemini API.
+func NewTokenComputer(modelName string) (genai.TokenComputer, error) {
+	tok, err := NewLocalTokenizer(modelName)
+	if err != nil {
+		return nil, err
+	}
+	return tok, nil
+}
@@ -14,6 +14,7 @@
 package tokenizer
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,6 +23,10 @@ import (
 	"google.golang.org/genai"
 )
 
+// LocalTokenizer can count tokens for genai.TruncationConfig.
+var _ genai.TokenCounter = (*LocalTokenizer)(nil)
+var _ genai.TokenComputer = (*LocalTokenizer)(nil)
+
 func TestDownload(t *testing.T) {
 	config := tokenizers["gemma2"]
 	b, err := downloadModelFile(config.modelURL)
@@ -431,3 +436,57 @@ func TestComputeTokensEmptyContent(t *testing.T) {
 		t.Errorf("expected empty TokensInfo for nil content, got %v entries", len(got.TokensInfo))
 	}
 }
+
+func TestDecode(t *testing.T) {
+	tok, err := NewLocalTokenizer("gemini-2.5-flash")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := "hello world, tokenizers are fun"
+	got, err := tok.ComputeTokens([]*genai.Content{genai.NewContentFromText(text, "user")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded := tok.Decode(got.TokensInfo[0].TokenIDs); decoded != text {
+		t.Errorf("Decode() = %q, want %q", decoded, text)
+	}
+	if decoded := got.TokensInfo[0].Text(); decoded != text {
+		t.Errorf("TokensInfo.Text() = %q, want %q", decoded, text)
+	}
+}
+
+func TestGetLocalTokenizerName(t *testing.T) {
+	tests := []struct {
+		modelName string
+		want      string
+		wantErr   bool
+	}{
+		{"gemini-1.5-flash", "gemma2", false},
+		{"models/gemini-2.5-flash", "gemma3", false},
+		{"publishers/google/models/gemini-2.0-flash-001", "gemma3", false},
+		{"models/gemini-0.92", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.modelName, func(t *testing.T) {
+			got, err := getLocalTokenizerName(tt.modelName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getLocalTokenizerName(%q) error = %v, wantErr %v", tt.modelName, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getLocalTokenizerName(%q) = %q, want %q", tt.modelName, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewLocalTokenizerFromReaderHashMismatch(t *testing.T) {
+	_, err := NewLocalTokenizerFromReader("gemini-2.5-flash", bytes.NewReader([]byte{0, 1, 2, 3}))
+	if err == nil {
+		t.Errorf("got no error, want hash mismatch error")
+	}
+	_, err = NewLocalTokenizerFromReader("gemini-0.92", bytes.NewReader(nil))
+	if err == nil {
+		t.Errorf("got no error, want unsupported model error")
+	}
+}
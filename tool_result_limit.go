@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Estimated number of bytes of a token of JSON text.
+const toolResultBytesPerToken = 4
+
+// ToolResultTruncation is how [ToolResultLimitConfig] shortens a function
+// result that exceeds its limit.
+type ToolResultTruncation string
+
+const (
+	// ToolResultKeepHead keeps the beginning of the result. It's the default.
+	ToolResultKeepHead ToolResultTruncation = "KEEP_HEAD"
+	// ToolResultKeepTail keeps the end of the result, such as the last lines
+	// of a log.
+	ToolResultKeepTail ToolResultTruncation = "KEEP_TAIL"
+	// ToolResultSummarize replaces the result by a summary written by a model.
+	// If the summary fails, the beginning of the result is kept.
+	ToolResultSummarize ToolResultTruncation = "SUMMARIZE"
+)
+
+// ToolResultLimitConfig limits the size of the function results sent back to
+// the model by automatic function calling, so that a huge tool output doesn't
+// fill the context window in the middle of the loop. Set it as
+// [AutomaticFunctionCallingConfig.ResultLimit].
+//
+// The size of a result is the size of its JSON encoding. A result that
+// exceeds the limit is replaced by {"output": <shortened JSON>, "truncated":
+// true, "originalBytes": <size>}, or by {"summary": <summary>, "truncated":
+// true, "originalBytes": <size>} when summarized.
+type ToolResultLimitConfig struct {
+	// Optional. Maximum size of a result, in bytes.
+	MaxBytes int
+	// Optional. Maximum size of a result, in tokens, estimated at 4 bytes per
+	// token. If both MaxBytes and MaxTokens are set, the lowest limit applies.
+	MaxTokens int32
+	// Optional. How results exceeding the limit are shortened. Defaults to
+	// [ToolResultKeepHead].
+	Truncation ToolResultTruncation
+	// Optional. Model that writes the summaries of [ToolResultSummarize].
+	// Defaults to [ClientConfig.SummarizationModel], or gemini-2.5-flash-lite
+	// if that isn't set either.
+	SummarizationModel string
+}
+
+// maxBytes returns the limit in bytes, or 0 if there is none.
+func (c *ToolResultLimitConfig) maxBytes() int {
+	limit := c.MaxBytes
+	if c.MaxTokens > 0 && (limit == 0 || int(c.MaxTokens)*toolResultBytesPerToken < limit) {
+		limit = int(c.MaxTokens) * toolResultBytesPerToken
+	}
+	return limit
+}
+
+// limitFunctionResponses returns responses with the results exceeding the
+// limit of config shortened. responses[i] answers calls[i].
+func (m Models) limitFunctionResponses(ctx context.Context, config *ToolResultLimitConfig, calls []*FunctionCall, responses []*FunctionResponse) []*FunctionResponse {
+	if config == nil {
+		return responses
+	}
+	limit := config.maxBytes()
+	if limit <= 0 {
+		return responses
+	}
+	limited := make([]*FunctionResponse, len(responses))
+	for i, response := range responses {
+		limited[i] = response
+		if response == nil {
+			continue
+		}
+		b, err := json.Marshal(response.Response)
+		if err != nil || len(b) <= limit {
+			continue
+		}
+		var result map[string]any
+		if config.Truncation == ToolResultSummarize {
+			summary, err := m.summarizeToolResult(ctx, config, calls[i], b, limit)
+			if err == nil {
+				result = map[string]any{"summary": summary, "truncated": true, "originalBytes": len(b)}
+			}
+		}
+		if result == nil {
+			result = map[string]any{"output": truncateToolResult(string(b), limit, config.Truncation), "truncated": true, "originalBytes": len(b)}
+		}
+		r := *response
+		r.Response = result
+		limited[i] = &r
+	}
+	return limited
+}
+
+// truncateToolResult returns the first or the last limit bytes of s, without
+// splitting a UTF-8 character.
+func truncateToolResult(s string, limit int, truncation ToolResultTruncation) string {
+	if len(s) <= limit {
+		return s
+	}
+	if truncation == ToolResultKeepTail {
+		start := len(s) - limit
+		for start < len(s) && !utf8.RuneStart(s[start]) {
+			start++
+		}
+		return s[start:]
+	}
+	end := limit
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
+
+// summarizeToolResult asks a model to summarize the result of call in about
+// limit bytes.
+func (m Models) summarizeToolResult(ctx context.Context, config *ToolResultLimitConfig, call *FunctionCall, result []byte, limit int) (string, error) {
+	model := config.SummarizationModel
+	if model == "" {
+		model = m.apiClient.clientConfig.SummarizationModel
+	}
+	if model == "" {
+		model = defaultSummarizationModel
+	}
+	args, _ := json.Marshal(call.Args)
+	prompt := fmt.Sprintf("The function %s was called with the arguments %s and returned the following result. "+
+		"Summarize it in at most %d words, keeping the facts, figures and identifiers most likely to matter to the caller.\n\n<result>\n%s\n</result>",
+		call.Name, args, limit/toolResultBytesPerToken*3/4, result)
+	resp, err := m.GenerateContentWithOptions(ctx, model, Text(prompt), &GenerateContentConfig{MaxOutputTokens: int32(limit / toolResultBytesPerToken)}, nil)
+	if err != nil {
+		return "", err
+	}
+	summary := resp.Text()
+	if summary == "" {
+		return "", fmt.Errorf("the model returned no summary")
+	}
+	return truncateToolResult(summary, limit, ToolResultKeepHead), nil
+}
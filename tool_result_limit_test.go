@@ -0,0 +1,153 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTruncateToolResult(t *testing.T) {
+	tests := []struct {
+		s          string
+		limit      int
+		truncation ToolResultTruncation
+		want       string
+	}{
+		{"abcdef", 10, ToolResultKeepHead, "abcdef"},
+		{"abcdef", 3, ToolResultKeepHead, "abc"},
+		{"abcdef", 3, ToolResultKeepTail, "def"},
+		{"aéb", 2, ToolResultKeepHead, "a"},
+		{"aéb", 2, ToolResultKeepTail, "b"},
+	}
+	for _, tt := range tests {
+		if got := truncateToolResult(tt.s, tt.limit, tt.truncation); got != tt.want {
+			t.Errorf("truncateToolResult(%q, %d, %s) = %q, want %q", tt.s, tt.limit, tt.truncation, got, tt.want)
+		}
+	}
+}
+
+func TestToolResultLimit(t *testing.T) {
+	ctx := context.Background()
+	bigResult := func(ctx context.Context, args map[string]any) (map[string]any, error) {
+		return map[string]any{"log": strings.Repeat("x", 1000) + "END"}, nil
+	}
+	tests := []struct {
+		name  string
+		limit *ToolResultLimitConfig
+		// clientSummarizationModel is the SummarizationModel of the client.
+		clientSummarizationModel string
+		want                     map[string]any
+		// wantSummarizationModel is the model asked for the summary, if any.
+		wantSummarizationModel string
+	}{
+		{
+			name:  "Unlimited",
+			limit: &ToolResultLimitConfig{},
+			want:  map[string]any{"log": strings.Repeat("x", 1000) + "END"},
+		},
+		{
+			name:  "KeepHead",
+			limit: &ToolResultLimitConfig{MaxBytes: 20},
+			want:  map[string]any{"output": `{"log":"xxxxxxxxxxxx`, "truncated": true, "originalBytes": 1013.0},
+		},
+		{
+			name:  "KeepTail",
+			limit: &ToolResultLimitConfig{MaxBytes: 100, MaxTokens: 2, Truncation: ToolResultKeepTail},
+			want:  map[string]any{"output": `xxxEND"}`, "truncated": true, "originalBytes": 1013.0},
+		},
+		{
+			name:                   "Summarize",
+			limit:                  &ToolResultLimitConfig{MaxTokens: 50, Truncation: ToolResultSummarize},
+			want:                   map[string]any{"summary": "A long run of x.", "truncated": true, "originalBytes": 1013.0},
+			wantSummarizationModel: "gemini-2.5-flash-lite",
+		},
+		{
+			name:                     "SummarizeWithClientModel",
+			limit:                    &ToolResultLimitConfig{MaxTokens: 50, Truncation: ToolResultSummarize},
+			clientSummarizationModel: "gemini-2.0-flash-lite",
+			want:                     map[string]any{"summary": "A long run of x.", "truncated": true, "originalBytes": 1013.0},
+			wantSummarizationModel:   "gemini-2.0-flash-lite",
+		},
+		{
+			name:                     "SummarizeWithModel",
+			limit:                    &ToolResultLimitConfig{MaxTokens: 50, Truncation: ToolResultSummarize, SummarizationModel: "gemini-2.5-flash"},
+			clientSummarizationModel: "gemini-2.0-flash-lite",
+			want:                     map[string]any{"summary": "A long run of x.", "truncated": true, "originalBytes": 1013.0},
+			wantSummarizationModel:   "gemini-2.5-flash",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var functionResponse map[string]any
+			var summarizationModel string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				contents := body["contents"].([]any)
+				switch {
+				case strings.Contains(fmt.Sprint(contents), "<result>"):
+					summarizationModel = strings.TrimSuffix(r.URL.Path[strings.LastIndexByte(r.URL.Path, '/')+1:], ":generateContent")
+					fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "A long run of x."}]}, "finishReason": "STOP"}]}`)
+				case len(contents) == 1:
+					fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"functionCall": {"name": "read_log", "args": {}}}]}, "finishReason": "STOP"}]}`)
+				default:
+					part := contents[2].(map[string]any)["parts"].([]any)[0].(map[string]any)
+					functionResponse = part["functionResponse"].(map[string]any)["response"].(map[string]any)
+					fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "Done."}]}, "finishReason": "STOP"}]}`)
+				}
+			}))
+			defer ts.Close()
+			m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+				Backend:            BackendGeminiAPI,
+				HTTPOptions:        HTTPOptions{BaseURL: ts.URL},
+				HTTPClient:         ts.Client(),
+				SummarizationModel: tt.clientSummarizationModel,
+			}}}
+
+			config := &GenerateContentConfig{
+				Tools: []*Tool{{FunctionDeclarations: []*FunctionDeclaration{{Name: "read_log"}}}},
+			}
+			options := &GenerateContentOptions{
+				AutomaticFunctionCalling: &AutomaticFunctionCallingConfig{
+					Functions:   map[string]FunctionHandler{"read_log": bigResult},
+					ResultLimit: tt.limit,
+				},
+			}
+			resp, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("What's in the log?"), config, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Text() != "Done." {
+				t.Errorf("Text() = %q, want %q", resp.Text(), "Done.")
+			}
+			if diff := cmp.Diff(tt.want, functionResponse); diff != "" {
+				t.Errorf("function response mismatch (-want +got):\n%s", diff)
+			}
+			if summarizationModel != tt.wantSummarizationModel {
+				t.Errorf("summarization model = %q, want %q", summarizationModel, tt.wantSummarizationModel)
+			}
+		})
+	}
+}
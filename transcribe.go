@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TranscribeConfig configures [Models.Transcribe].
+type TranscribeConfig struct {
+	// Optional. Language of the audio, as a BCP-47 code such as "fr-FR". If
+	// empty, the model detects it.
+	Language string
+	// Optional. If true, segments are labeled with the speaker.
+	IdentifySpeakers bool
+	// Optional. Additional instructions, such as the spelling of names or
+	// domain-specific vocabulary.
+	Instructions string
+	// Optional. Configuration used for the GenerateContent call. Its response
+	// schema is set by Transcribe.
+	GenerateContentConfig *GenerateContentConfig
+}
+
+// TranscriptSegment is a segment of a [Transcript].
+type TranscriptSegment struct {
+	// Start is the offset of the start of the segment in the audio.
+	Start time.Duration
+	// End is the offset of the end of the segment in the audio.
+	End time.Duration
+	// Speaker labels the speaker of the segment. It's only set if
+	// [TranscribeConfig.IdentifySpeakers] is true.
+	Speaker string
+	// Text is the transcribed speech.
+	Text string
+}
+
+// Transcript is the outcome of [Models.Transcribe].
+type Transcript struct {
+	// Segments are the segments of the audio, in order.
+	Segments []*TranscriptSegment
+	// Response is the full model response.
+	Response *GenerateContentResponse
+}
+
+// Text returns the text of the segments, one per line.
+func (t *Transcript) Text() string {
+	lines := make([]string, len(t.Segments))
+	for i, s := range t.Segments {
+		lines[i] = s.Text
+	}
+	return strings.Join(lines, "\n")
+}
+
+// transcriptOutput is the response schema of Transcribe.
+type transcriptOutput struct {
+	Segments []struct {
+		Start   string `json:"start" description:"Start of the segment, as MM:SS."`
+		End     string `json:"end" description:"End of the segment, as MM:SS."`
+		Speaker string `json:"speaker,omitempty" description:"Label of the speaker, such as Speaker 1."`
+		Text    string `json:"text" description:"Verbatim transcription of the segment."`
+	} `json:"segments"`
+}
+
+// Transcribe transcribes the speech of audio into timestamped segments.
+// audio is typically built with [NewPartFromPath], [NewPartFromBytes] or,
+// for uploaded files, [NewPartFromURI].
+//
+//	audio, err := genai.NewPartFromPath("interview.mp3", nil)
+//	if err != nil {
+//		return err
+//	}
+//	transcript, err := client.Models.Transcribe(ctx, "gemini-2.5-flash", audio, &genai.TranscribeConfig{IdentifySpeakers: true})
+func (m Models) Transcribe(ctx context.Context, model string, audio *Part, config *TranscribeConfig) (*Transcript, error) {
+	if audio == nil {
+		return nil, fmt.Errorf("Transcribe: audio is required")
+	}
+	cfg := TranscribeConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	prompt := []string{"Transcribe the speech of this audio verbatim. Split the transcription into segments at sentence boundaries or pauses, with the start and end of each segment as MM:SS."}
+	if cfg.Language != "" {
+		prompt = append(prompt, fmt.Sprintf("The audio is in %s.", cfg.Language))
+	}
+	if cfg.IdentifySpeakers {
+		prompt = append(prompt, "Label each segment with its speaker, using the same label for the same speaker throughout.")
+	}
+	if cfg.Instructions != "" {
+		prompt = append(prompt, cfg.Instructions)
+	}
+	contents := []*Content{NewContentFromParts([]*Part{audio, NewPartFromText(strings.Join(prompt, " "))}, RoleUser)}
+
+	out, resp, err := GenerateContentAs[transcriptOutput](ctx, &m, model, contents, cfg.GenerateContentConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Transcribe: %w", err)
+	}
+	transcript := &Transcript{Response: resp}
+	for i, s := range out.Segments {
+		start, err := parseTimestamp(s.Start)
+		if err != nil {
+			return nil, fmt.Errorf("Transcribe: segment %d: %w", i, err)
+		}
+		end, err := parseTimestamp(s.End)
+		if err != nil {
+			return nil, fmt.Errorf("Transcribe: segment %d: %w", i, err)
+		}
+		transcript.Segments = append(transcript.Segments, &TranscriptSegment{Start: start, End: end, Speaker: s.Speaker, Text: s.Text})
+	}
+	return transcript, nil
+}
+
+// parseTimestamp parses timestamps such as 1:05, 01:02:05 or 1:05.250.
+func parseTimestamp(s string) (time.Duration, error) {
+	fields := strings.Split(strings.TrimSpace(s), ":")
+	if len(fields) < 2 || len(fields) > 3 {
+		return 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+	var d time.Duration
+	for i, f := range fields {
+		unit := time.Minute
+		if i == len(fields)-1 {
+			seconds, err := strconv.ParseFloat(f, 64)
+			if err != nil || seconds < 0 {
+				return 0, fmt.Errorf("invalid timestamp %q", s)
+			}
+			d += time.Duration(seconds * float64(time.Second))
+			continue
+		}
+		if i == 0 && len(fields) == 3 {
+			unit = time.Hour
+		}
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid timestamp %q", s)
+		}
+		d += time.Duration(n) * unit
+	}
+	return d, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTranscribe(t *testing.T) {
+	ctx := context.Background()
+	var gotParts []*Part
+	var gotSchema bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		var contents []*Content
+		data, _ := json.Marshal(body["contents"])
+		json.Unmarshal(data, &contents)
+		gotParts = contents[0].Parts
+		_, gotSchema = body["generationConfig"].(map[string]any)["responseSchema"]
+		transcript := `{"segments": [
+			{"start": "00:00", "end": "00:04", "speaker": "Speaker 1", "text": "Welcome to the show."},
+			{"start": "00:04", "end": "1:02:05.5", "speaker": "Speaker 2", "text": "Thanks for having me."}
+		]}`
+		answer, _ := json.Marshal(transcript)
+		fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": %s}]}}]}`, answer)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+
+	audio := NewPartFromBytes([]byte("RIFF"), "audio/wav")
+	got, err := m.Transcribe(ctx, "gemini-2.5-flash", audio, &TranscribeConfig{Language: "en-US", IdentifySpeakers: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*TranscriptSegment{
+		{Start: 0, End: 4 * time.Second, Speaker: "Speaker 1", Text: "Welcome to the show."},
+		{Start: 4 * time.Second, End: time.Hour + 2*time.Minute + 5500*time.Millisecond, Speaker: "Speaker 2", Text: "Thanks for having me."},
+	}
+	if diff := cmp.Diff(want, got.Segments); diff != "" {
+		t.Errorf("Segments mismatch (-want +got):\n%s", diff)
+	}
+	if got.Text() != "Welcome to the show.\nThanks for having me." {
+		t.Errorf("Text() = %q", got.Text())
+	}
+	if len(gotParts) != 2 || gotParts[0].InlineData == nil || !gotSchema {
+		t.Fatalf("request parts = %+v, schema = %v, want the audio and the prompt with a response schema", gotParts, gotSchema)
+	}
+	for _, want := range []string{"en-US", "speaker"} {
+		if !strings.Contains(gotParts[1].Text, want) {
+			t.Errorf("prompt %q doesn't mention %q", gotParts[1].Text, want)
+		}
+	}
+
+	if _, err := m.Transcribe(ctx, "gemini-2.5-flash", nil, nil); err == nil {
+		t.Errorf("Transcribe() without audio succeeded, want error")
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	for s, want := range map[string]time.Duration{
+		"0:05":      5 * time.Second,
+		"12:30":     12*time.Minute + 30*time.Second,
+		"01:00:01":  time.Hour + time.Second,
+		"00:01.250": 1250 * time.Millisecond,
+		" 02:00 ":   2 * time.Minute,
+	} {
+		got, err := parseTimestamp(s)
+		if err != nil || got != want {
+			t.Errorf("parseTimestamp(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+	for _, s := range []string{"", "5", "a:05", "1:2:3:4", "-1:00"} {
+		if _, err := parseTimestamp(s); err == nil {
+			t.Errorf("parseTimestamp(%q) succeeded, want error", s)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// TruncationStrategy is the order in which parts are dropped from contents
+// that exceed the input token limit of a model.
+type TruncationStrategy string
+
+const (
+	// TruncateHead drops the first parts, such as the oldest turns of a
+	// conversation. It's the default.
+	TruncateHead TruncationStrategy = "HEAD"
+	// TruncateTail drops the last parts.
+	TruncateTail TruncationStrategy = "TAIL"
+	// TruncateMiddle drops the parts in the middle first, keeping the
+	// beginning and the end.
+	TruncateMiddle TruncationStrategy = "MIDDLE"
+	// TruncateLargest drops the parts with the most tokens first, such as
+	// large documents or tool results, and the first ones among parts of the
+	// same size.
+	TruncateLargest TruncationStrategy = "LARGEST"
+)
+
+// TokenCounter counts the tokens of contents without calling the API. A
+// *tokenizer.LocalTokenizer of google.golang.org/genai/tokenizer implements
+// it.
+type TokenCounter interface {
+	CountTokens(contents []*Content, config *CountTokensConfig) (*CountTokensResult, error)
+}
+
+// TruncationConfig configures the truncation of contents that exceed the input
+// token limit of a model, by [Models.TruncateContents] or, set as
+// [GenerateContentOptions.Truncation], by [Models.GenerateContentWithOptions]
+// and [Models.GenerateContentStreamWithOptions].
+//
+// Whole parts are dropped, the ones with the lowest priority first and, for
+// parts of the same priority, in the order of the strategy. Contents left
+// without parts are removed.
+type TruncationConfig struct {
+	// Optional. Order in which parts of the same priority are dropped. Defaults
+	// to [TruncateHead].
+	Strategy TruncationStrategy
+	// Optional. Maximum number of input tokens. Defaults to the input token
+	// limit of the model, which is looked up with [Models.Get] every time, so
+	// set it to save that request.
+	MaxInputTokens int32
+	// Optional. Returns the priority of a part. Parts with a higher priority
+	// are dropped last, and parts with a negative priority are never dropped.
+	// By default, the parts of the last content and of the last user content,
+	// usually the current question, are never dropped and the others have
+	// priority 0.
+	Priority func(contentIndex, partIndex int, part *Part) int
+	// Optional. System instruction sent along with the contents. It's never
+	// dropped, but its tokens count towards MaxInputTokens. When truncating
+	// for [Models.GenerateContentWithOptions], it defaults to
+	// [GenerateContentConfig.SystemInstruction].
+	SystemInstruction *Content
+	// Optional. Counts tokens locally, for example with a
+	// *tokenizer.LocalTokenizer, instead of with [Models.CountTokens], which
+	// saves the requests but may be less accurate for non-text parts.
+	TokenCounter TokenCounter
+	// Optional. Called with the report of the truncation, if parts were
+	// dropped.
+	OnTruncate func(*TruncationReport)
+}
+
+// TruncatedPart is a part dropped by a truncation.
+type TruncatedPart struct {
+	// ContentIndex is the index of the content of the part.
+	ContentIndex int
+	// PartIndex is the index of the part in its content.
+	PartIndex int
+	// Part is the dropped part.
+	Part *Part
+	// Tokens is the token count of the part.
+	Tokens int32
+}
+
+// TruncationReport describes a truncation.
+type TruncationReport struct {
+	// MaxInputTokens is the token limit the contents were truncated to.
+	MaxInputTokens int32
+	// TotalTokens is the token count of the contents before truncation.
+	TotalTokens int32
+	// Dropped are the dropped parts, in the order they were dropped.
+	Dropped []*TruncatedPart
+}
+
+// DroppedTokens returns the sum of the token counts of the dropped parts.
+func (r *TruncationReport) DroppedTokens() int32 {
+	var n int32
+	for _, d := range r.Dropped {
+		n += d.Tokens
+	}
+	return n
+}
+
+// TruncateContents returns contents truncated to fit the input token limit of
+// model as configured by config, along with a report of what was dropped. The
+// report is nil if contents already fit. contents isn't modified. An error is
+// returned if contents can't be truncated enough.
+//
+// The token counts come from [Models.CountTokens], or from
+// [TruncationConfig.TokenCounter] if set: the contents are counted once, and
+// if they exceed the limit, each part is counted to decide which to drop.
+func (m Models) TruncateContents(ctx context.Context, model string, contents []*Content, config *TruncationConfig) ([]*Content, *TruncationReport, error) {
+	cfg := TruncationConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.Strategy == "" {
+		cfg.Strategy = TruncateHead
+	}
+	if cfg.Priority == nil {
+		last, lastUser := len(contents)-1, -1
+		for i, c := range contents {
+			if c != nil && c.Role == RoleUser {
+				lastUser = i
+			}
+		}
+		cfg.Priority = func(contentIndex, partIndex int, part *Part) int {
+			if contentIndex == last || contentIndex == lastUser {
+				return -1
+			}
+			return 0
+		}
+	}
+	if cfg.MaxInputTokens == 0 {
+		info, err := m.Get(ctx, model, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("TruncateContents: getting the input token limit: %w", err)
+		}
+		if info.InputTokenLimit == 0 {
+			return nil, nil, fmt.Errorf("TruncateContents: the input token limit of %s is unknown, please set TruncationConfig.MaxInputTokens", model)
+		}
+		cfg.MaxInputTokens = info.InputTokenLimit
+	}
+
+	countTokens := func(contents []*Content) (int32, error) {
+		if cfg.TokenCounter != nil {
+			count, err := cfg.TokenCounter.CountTokens(contents, nil)
+			if err != nil {
+				return 0, err
+			}
+			return count.TotalTokens, nil
+		}
+		count, err := m.CountTokens(ctx, model, contents, nil)
+		if err != nil {
+			return 0, err
+		}
+		return count.TotalTokens, nil
+	}
+	total, err := countTokens(contents)
+	if err != nil {
+		return nil, nil, fmt.Errorf("TruncateContents: %w", err)
+	}
+	if cfg.SystemInstruction != nil {
+		n, err := countTokens([]*Content{cfg.SystemInstruction})
+		if err != nil {
+			return nil, nil, fmt.Errorf("TruncateContents: counting the system instruction: %w", err)
+		}
+		total += n
+	}
+	if total <= cfg.MaxInputTokens {
+		return contents, nil, nil
+	}
+
+	var parts []*TruncatedPart
+	var sets [][]*Content
+	for i, c := range contents {
+		if c == nil {
+			continue
+		}
+		for j, p := range c.Parts {
+			if p != nil && cfg.Priority(i, j, p) >= 0 {
+				parts = append(parts, &TruncatedPart{ContentIndex: i, PartIndex: j, Part: p})
+				sets = append(sets, []*Content{{Role: c.Role, Parts: []*Part{p}}})
+			}
+		}
+	}
+	if cfg.TokenCounter != nil {
+		for i, p := range parts {
+			if p.Tokens, err = countTokens(sets[i]); err != nil {
+				return nil, nil, fmt.Errorf("TruncateContents: %w", err)
+			}
+		}
+	} else {
+		counts, err := m.CountTokensBatch(ctx, model, sets, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("TruncateContents: %w", err)
+		}
+		for i, p := range parts {
+			p.Tokens = counts.Items[i].TotalTokens
+		}
+	}
+	parts = truncationOrder(parts, cfg.Strategy)
+	sort.SliceStable(parts, func(i, j int) bool {
+		pi, pj := parts[i], parts[j]
+		return cfg.Priority(pi.ContentIndex, pi.PartIndex, pi.Part) < cfg.Priority(pj.ContentIndex, pj.PartIndex, pj.Part)
+	})
+
+	report := &TruncationReport{MaxInputTokens: cfg.MaxInputTokens, TotalTokens: total}
+	excess := total - cfg.MaxInputTokens
+	dropped := map[[2]int]bool{}
+	for _, p := range parts {
+		if report.DroppedTokens() >= excess {
+			break
+		}
+		report.Dropped = append(report.Dropped, p)
+		dropped[[2]int{p.ContentIndex, p.PartIndex}] = true
+	}
+	if report.DroppedTokens() < excess {
+		return nil, report, fmt.Errorf("TruncateContents: the contents have %d tokens and can't be truncated to %d", total, cfg.MaxInputTokens)
+	}
+
+	var truncated []*Content
+	for i, c := range contents {
+		if c == nil {
+			continue
+		}
+		var kept []*Part
+		for j, p := range c.Parts {
+			if !dropped[[2]int{i, j}] {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) == len(c.Parts) {
+			truncated = append(truncated, c)
+		} else if len(kept) > 0 {
+			truncated = append(truncated, &Content{Role: c.Role, Parts: kept})
+		}
+	}
+	return truncated, report, nil
+}
+
+// truncationOrder returns parts in the order strategy drops them.
+func truncationOrder(parts []*TruncatedPart, strategy TruncationStrategy) []*TruncatedPart {
+	ordered := make([]*TruncatedPart, 0, len(parts))
+	switch strategy {
+	case TruncateTail:
+		for i := len(parts) - 1; i >= 0; i-- {
+			ordered = append(ordered, parts[i])
+		}
+	case TruncateMiddle:
+		// Alternate outwards from the middle.
+		mid := len(parts) / 2
+		for d := 0; len(ordered) < len(parts); d++ {
+			if i := mid - d; i >= 0 && d > 0 {
+				ordered = append(ordered, parts[i])
+			}
+			if i := mid + d; i < len(parts) {
+				ordered = append(ordered, parts[i])
+			}
+		}
+	case TruncateLargest:
+		ordered = append(ordered, parts...)
+		sort.SliceStable(ordered, func(i, j int) bool {
+			return ordered[i].Tokens > ordered[j].Tokens
+		})
+	default:
+		ordered = append(ordered, parts...)
+	}
+	return ordered
+}
+
+// truncateForRequest truncates the contents of a request with config as
+// configured by truncation, which may be nil.
+func (m Models) truncateForRequest(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, truncation *TruncationConfig) ([]*Content, error) {
+	if truncation == nil {
+		return contents, nil
+	}
+	onTruncate := truncation.OnTruncate
+	if truncation.SystemInstruction == nil && config != nil && config.SystemInstruction != nil {
+		tc := *truncation
+		tc.SystemInstruction = config.SystemInstruction
+		truncation = &tc
+	}
+	truncated, report, err := m.TruncateContents(ctx, model, contents, truncation)
+	if err != nil {
+		return nil, err
+	}
+	if report != nil && onTruncate != nil {
+		onTruncate(report)
+	}
+	return truncated, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTruncateContents(t *testing.T) {
+	ctx := context.Background()
+	var gets int
+	var generated []*Content
+	// Each character of text is a token.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets++
+			fmt.Fprint(w, `{"name": "models/gemini-2.5-flash", "inputTokenLimit": 10}`)
+			return
+		}
+		var body struct {
+			Contents []*Content `json:"contents"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, ":countTokens"):
+			n := 0
+			for _, c := range body.Contents {
+				for _, p := range c.Parts {
+					n += len(p.Text)
+				}
+			}
+			fmt.Fprintf(w, `{"totalTokens": %d}`, n)
+		case strings.HasSuffix(r.URL.Path, ":generateContent"):
+			generated = body.Contents
+			fmt.Fprint(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "ok"}]}}]}`)
+		}
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+	contents := []*Content{
+		NewContentFromText("aaaa", RoleUser),
+		NewContentFromText("bbbb", RoleModel),
+		NewContentFromParts([]*Part{NewPartFromText("cccc"), NewPartFromText("dd")}, RoleUser),
+		NewContentFromText("question", RoleUser),
+	}
+	texts := func(contents []*Content) string {
+		var s []string
+		for _, c := range contents {
+			for _, p := range c.Parts {
+				s = append(s, p.Text)
+			}
+		}
+		return strings.Join(s, " ")
+	}
+
+	tests := []struct {
+		name        string
+		config      *TruncationConfig
+		wantTexts   string
+		wantDropped int32
+	}{
+		{name: "Head", config: &TruncationConfig{MaxInputTokens: 16}, wantTexts: "cccc dd question", wantDropped: 8},
+		{name: "Tail", config: &TruncationConfig{MaxInputTokens: 16, Strategy: TruncateTail}, wantTexts: "aaaa bbbb question", wantDropped: 6},
+		{name: "Middle", config: &TruncationConfig{MaxInputTokens: 16, Strategy: TruncateMiddle}, wantTexts: "aaaa dd question", wantDropped: 8},
+		{
+			name: "Priority",
+			config: &TruncationConfig{MaxInputTokens: 16, Priority: func(i, j int, p *Part) int {
+				if p.Text == "aaaa" {
+					return -1
+				}
+				return len(p.Text)
+			}},
+			wantTexts:   "aaaa cccc question",
+			wantDropped: 6,
+		},
+		{name: "ModelLimit", config: &TruncationConfig{}, wantTexts: "dd question", wantDropped: 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, report, err := m.TruncateContents(ctx, "gemini-2.5-flash", contents, tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if texts(got) != tt.wantTexts {
+				t.Errorf("TruncateContents() = %q, want %q", texts(got), tt.wantTexts)
+			}
+			if report.TotalTokens != 22 || report.DroppedTokens() != tt.wantDropped {
+				t.Errorf("report = %d tokens with %d dropped, want 22 with %d dropped", report.TotalTokens, report.DroppedTokens(), tt.wantDropped)
+			}
+		})
+	}
+	if gets != 1 {
+		t.Errorf("the model was looked up %d times, want once", gets)
+	}
+	if texts(contents) != "aaaa bbbb cccc dd question" {
+		t.Errorf("the caller's contents were modified: %q", texts(contents))
+	}
+
+	t.Run("Fits", func(t *testing.T) {
+		got, report, err := m.TruncateContents(ctx, "gemini-2.5-flash", contents, &TruncationConfig{MaxInputTokens: 100})
+		if err != nil || report != nil || len(got) != len(contents) {
+			t.Errorf("TruncateContents() = %d contents, %v, %v, want the contents unchanged", len(got), report, err)
+		}
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		if _, _, err := m.TruncateContents(ctx, "gemini-2.5-flash", contents, &TruncationConfig{MaxInputTokens: 5}); err == nil {
+			t.Errorf("TruncateContents() succeeded, want error")
+		}
+	})
+
+	t.Run("GenerateContent", func(t *testing.T) {
+		var report *TruncationReport
+		options := &GenerateContentOptions{Truncation: &TruncationConfig{MaxInputTokens: 16, OnTruncate: func(r *TruncationReport) { report = r }}}
+		if _, err := m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", contents, nil, options); err != nil {
+			t.Fatal(err)
+		}
+		if texts(generated) != "cccc dd question" || report == nil || len(report.Dropped) != 2 {
+			t.Errorf("generated from %q with report %+v, want the truncated contents", texts(generated), report)
+		}
+	})
+
+	t.Run("SystemInstruction", func(t *testing.T) {
+		config := &TruncationConfig{MaxInputTokens: 16, SystemInstruction: NewContentFromText("sys", RoleUser)}
+		got, report, err := m.TruncateContents(ctx, "gemini-2.5-flash", contents, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if texts(got) != "dd question" || report.TotalTokens != 25 {
+			t.Errorf("TruncateContents() = %q with %d tokens, want %q with 25 tokens", texts(got), report.TotalTokens, "dd question")
+		}
+	})
+}
+
+// charCounter counts a token per character of text.
+type charCounter struct{}
+
+func (charCounter) CountTokens(contents []*Content, config *CountTokensConfig) (*CountTokensResult, error) {
+	var n int32
+	for _, c := range contents {
+		for _, p := range c.Parts {
+			n += int32(len(p.Text))
+		}
+	}
+	return &CountTokensResult{TotalTokens: n}, nil
+}
+
+func TestTruncateContentsLocal(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+	contents := []*Content{
+		NewContentFromText("aa", RoleUser),
+		NewContentFromText("bbbbbb", RoleModel),
+		NewContentFromText("c", RoleUser),
+		NewContentFromText("qq", RoleUser),
+		NewContentFromText("pre", RoleModel),
+	}
+	config := &TruncationConfig{
+		MaxInputTokens:    10,
+		Strategy:          TruncateLargest,
+		SystemInstruction: NewContentFromText("sys", RoleUser),
+		TokenCounter:      charCounter{},
+	}
+	got, report, err := m.TruncateContents(context.Background(), "gemini-2.5-flash", contents, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var texts []string
+	for _, c := range got {
+		texts = append(texts, c.Parts[0].Text)
+	}
+	if got, want := strings.Join(texts, " "), "c qq pre"; got != want {
+		t.Errorf("TruncateContents() = %q, want %q", got, want)
+	}
+	if report.TotalTokens != 17 || report.DroppedTokens() != 8 {
+		t.Errorf("report = %d tokens with %d dropped, want 17 with 8 dropped", report.TotalTokens, report.DroppedTokens())
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// DeployedModel is a model deployed to a Vertex AI endpoint.
+type DeployedModel struct {
+	// ID is the ID of the deployment in the endpoint.
+	ID string `json:"id,omitempty"`
+	// Model is the resource name of the deployed model.
+	Model string `json:"model,omitempty"`
+	// ModelVersionID is the version of the deployed model.
+	ModelVersionID string `json:"modelVersionId,omitempty"`
+	// DisplayName is the display name of the deployment.
+	DisplayName string `json:"displayName,omitempty"`
+	// TrafficPercent is the percentage of the traffic of the endpoint served by
+	// the deployment.
+	TrafficPercent int32 `json:"-"`
+}
+
+// TunedModelDeployment is a Vertex AI endpoint that a tuned model is deployed
+// to, with the traffic split of the endpoint.
+type TunedModelDeployment struct {
+	// Endpoint is the resource name of the endpoint. Generate content with it
+	// as the model to call the models deployed to it.
+	Endpoint string
+	// DeployedModelID is the ID of the deployment of the tuned model in the
+	// endpoint.
+	DeployedModelID string
+	// DeployedModels are all the models deployed to the endpoint, possibly
+	// other versions of the tuned model, with their share of the traffic.
+	DeployedModels []*DeployedModel
+}
+
+// TrafficPercent returns the percentage of the traffic of the endpoint served
+// by the tuned model. A deployment with no traffic doesn't serve any request
+// sent to the endpoint.
+func (d *TunedModelDeployment) TrafficPercent() int32 {
+	for _, m := range d.DeployedModels {
+		if m.ID == d.DeployedModelID {
+			return m.TrafficPercent
+		}
+	}
+	return 0
+}
+
+// TunedModelNotServedError is returned by [Models.TunedModelDeployments] when
+// a tuned model has no active deployment, and by
+// [Models.GenerateContentWithOptions] in
+// place of the 404 error returned when a tuned model is called directly
+// rather than through the endpoint it's deployed to.
+type TunedModelNotServedError struct {
+	// Model is the resource name of the tuned model.
+	Model string
+	// Deployments are the endpoints the model is deployed to. If none serves
+	// traffic, the model has no active deployment.
+	Deployments []*TunedModelDeployment
+	// Err is the error of the request that failed, if any.
+	Err error
+}
+
+func (e *TunedModelNotServedError) Error() string {
+	for _, d := range e.Deployments {
+		if d.TrafficPercent() > 0 {
+			return fmt.Sprintf("tuned model %s must be called through the endpoint it's deployed to, %s", e.Model, d.Endpoint)
+		}
+	}
+	if len(e.Deployments) > 0 {
+		return fmt.Sprintf("tuned model %s has no active deployment: it serves no traffic of endpoint %s", e.Model, e.Deployments[0].Endpoint)
+	}
+	return fmt.Sprintf("tuned model %s has no active deployment: it isn't deployed to any endpoint", e.Model)
+}
+
+func (e *TunedModelNotServedError) Unwrap() error {
+	return e.Err
+}
+
+// TunedModelDeployments returns the endpoints that the tuned model is deployed
+// to, with their traffic split and the versions deployed to them. model is the
+// resource name of a model registered in Vertex AI, such as
+// "projects/p/locations/us-central1/models/123". If the model isn't deployed
+// to any endpoint, a [*TunedModelNotServedError] is returned. Only supported
+// by Vertex AI.
+func (m Models) TunedModelDeployments(ctx context.Context, model string) ([]*TunedModelDeployment, error) {
+	if m.apiClient.clientConfig.Backend != BackendVertexAI {
+		return nil, fmt.Errorf("TunedModelDeployments: only supported by Vertex AI")
+	}
+	info, err := m.Get(ctx, model, nil)
+	if err != nil {
+		return nil, fmt.Errorf("TunedModelDeployments: %w", err)
+	}
+	var deployments []*TunedModelDeployment
+	for _, e := range info.Endpoints {
+		if e == nil || e.Name == "" {
+			continue
+		}
+		resp, err := sendRequest(ctx, m.apiClient, e.Name, http.MethodGet, nil, &HTTPOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("TunedModelDeployments: getting endpoint %s: %w", e.Name, err)
+		}
+		var endpoint struct {
+			DeployedModels []*DeployedModel `json:"deployedModels"`
+			TrafficSplit   map[string]int32 `json:"trafficSplit"`
+		}
+		if err := mapToStruct(resp, &endpoint); err != nil {
+			return nil, fmt.Errorf("TunedModelDeployments: endpoint %s: %w", e.Name, err)
+		}
+		for _, d := range endpoint.DeployedModels {
+			d.TrafficPercent = endpoint.TrafficSplit[d.ID]
+		}
+		deployments = append(deployments, &TunedModelDeployment{Endpoint: e.Name, DeployedModelID: e.DeployedModelID, DeployedModels: endpoint.DeployedModels})
+	}
+	if len(deployments) == 0 {
+		return nil, &TunedModelNotServedError{Model: info.Name}
+	}
+	return deployments, nil
+}
+
+// explainTunedModelNotFound returns a [*TunedModelNotServedError] in place of
+// err if err is the 404 error returned when model, a tuned model, is called
+// directly. Otherwise err is returned as it is.
+func (m Models) explainTunedModelNotFound(ctx context.Context, model string, err error) error {
+	var apiErr APIError
+	if m.apiClient.clientConfig.Backend != BackendVertexAI || !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+		return err
+	}
+	name, perr := ParseResourceName(model)
+	if perr != nil || name.Collection != ResourceCollectionModels || name.Project == "" || name.Publisher != "" {
+		return err
+	}
+	deployments, derr := m.TunedModelDeployments(ctx, model)
+	var notServed *TunedModelNotServedError
+	switch {
+	case errors.As(derr, &notServed):
+		notServed.Err = err
+		return notServed
+	case derr != nil:
+		return err
+	}
+	return &TunedModelNotServedError{Model: model, Deployments: deployments, Err: err}
+}
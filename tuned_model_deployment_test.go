@@ -0,0 +1,104 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTunedModelDeployments(t *testing.T) {
+	ctx := context.Background()
+	const prefix = "/v1beta1/projects/my-project/locations/us-central1/"
+	traffic := `{"111": 0, "222": 100}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		switch {
+		case strings.HasSuffix(path, ":generateContent"):
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}}`)
+		case path == "models/123":
+			fmt.Fprintf(w, `{"name": "%smodels/123", "deployedModels": [{"endpoint": "projects/my-project/locations/us-central1/endpoints/456", "deployedModelId": "111"}]}`, prefix[len("/v1beta1/"):])
+		case path == "models/789":
+			fmt.Fprintf(w, `{"name": "%smodels/789"}`, prefix[len("/v1beta1/"):])
+		case path == "endpoints/456":
+			fmt.Fprintf(w, `{"deployedModels": [{"id": "111", "model": "m123", "modelVersionId": "2"}, {"id": "222", "model": "m123", "modelVersionId": "1"}], "trafficSplit": %s}`, traffic)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": {"code": 404, "message": "not found", "status": "NOT_FOUND"}}`)
+		}
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendVertexAI,
+		Project:     "my-project",
+		Location:    "us-central1",
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"},
+		HTTPClient:  ts.Client(),
+	}}}
+	tuned := "projects/my-project/locations/us-central1/models/123"
+
+	deployments, err := m.TunedModelDeployments(ctx, tuned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*TunedModelDeployment{{
+		Endpoint:        "projects/my-project/locations/us-central1/endpoints/456",
+		DeployedModelID: "111",
+		DeployedModels: []*DeployedModel{
+			{ID: "111", Model: "m123", ModelVersionID: "2", TrafficPercent: 0},
+			{ID: "222", Model: "m123", ModelVersionID: "1", TrafficPercent: 100},
+		},
+	}}
+	if diff := cmp.Diff(want, deployments); diff != "" {
+		t.Errorf("TunedModelDeployments() mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = m.GenerateContentWithOptions(ctx, tuned, Text("hi"), nil, nil)
+	var notServed *TunedModelNotServedError
+	if !errors.As(err, &notServed) || !strings.Contains(err.Error(), "no active deployment") {
+		t.Fatalf("GenerateContentWithOptions() error = %v, want a TunedModelNotServedError about no active deployment", err)
+	}
+	var apiErr APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+		t.Errorf("GenerateContentWithOptions() error = %v, want it to wrap the 404 error", err)
+	}
+
+	traffic = `{"111": 100}`
+	if _, err := m.GenerateContentWithOptions(ctx, tuned, Text("hi"), nil, nil); err == nil || !strings.Contains(err.Error(), "endpoints/456") {
+		t.Errorf("GenerateContentWithOptions() error = %v, want error naming the endpoint", err)
+	}
+
+	_, err = m.TunedModelDeployments(ctx, "projects/my-project/locations/us-central1/models/789")
+	if !errors.As(err, &notServed) || len(notServed.Deployments) != 0 {
+		t.Errorf("TunedModelDeployments() of an undeployed model error = %v, want a TunedModelNotServedError", err)
+	}
+
+	_, err = m.GenerateContentWithOptions(ctx, "projects/my-project/locations/us-central1/models/000", Text("hi"), nil, nil)
+	if errors.As(err, &notServed) {
+		t.Errorf("GenerateContentWithOptions() of a missing model error = %v, want the 404 error", err)
+	}
+	_, err = m.GenerateContentWithOptions(ctx, "gemini-2.5-flash", Text("hi"), nil, nil)
+	if errors.As(err, &notServed) {
+		t.Errorf("GenerateContentWithOptions() of a base model error = %v, want the 404 error", err)
+	}
+}
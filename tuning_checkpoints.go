@@ -0,0 +1,167 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Metric names of a [TuningEvaluationScore].
+const (
+	TuningMetricBleu                = "bleu"
+	TuningMetricRouge               = "rouge"
+	TuningMetricExactMatch          = "exactMatch"
+	TuningMetricPointwise           = "pointwise"
+	TuningMetricCustomCodeExecution = "customCodeExecution"
+)
+
+// TuningEvaluationScore is an aggregated score of an evaluation run of a
+// tuning job.
+type TuningEvaluationScore struct {
+	// Metric is the name of the metric, such as [TuningMetricRouge].
+	Metric string
+	// Aggregation is how the scores of the examples were aggregated.
+	Aggregation AggregationMetric
+	// Value is the score.
+	Value float64
+}
+
+// TuningCheckpointEvaluation is the evaluation of a checkpoint of a tuning job
+// on the validation dataset.
+type TuningCheckpointEvaluation struct {
+	// Checkpoint is the evaluated checkpoint. It's nil for evaluations of the
+	// final tuned model.
+	Checkpoint *TunedModelCheckpoint
+	// Run is the evaluation run, with its error if it failed.
+	Run *EvaluateDatasetRun
+	// Scores are the aggregated scores of the run, in the order returned by
+	// the API. Pairwise metrics have no score and aren't included.
+	Scores []*TuningEvaluationScore
+}
+
+// Score returns the value of the score of metric with the given aggregation,
+// and whether there is one.
+func (e *TuningCheckpointEvaluation) Score(metric string, aggregation AggregationMetric) (float64, bool) {
+	for _, s := range e.Scores {
+		if s.Metric == metric && s.Aggregation == aggregation {
+			return s.Value, true
+		}
+	}
+	return 0, false
+}
+
+// CheckpointEvaluations returns the evaluations of the intermediate
+// checkpoints of the job, ordered by step, followed by the evaluations of the
+// final tuned model. Evaluations are run while the job trains, so calling it
+// on the job returned by [Tunings.Get] shows how training progresses. Loss
+// curves aren't part of the job; they're logged to its [TuningJob.Experiment].
+func (j *TuningJob) CheckpointEvaluations() []*TuningCheckpointEvaluation {
+	checkpoints := map[string]*TunedModelCheckpoint{}
+	if j.TunedModel != nil {
+		for _, c := range j.TunedModel.Checkpoints {
+			if c != nil {
+				checkpoints[c.CheckpointID] = c
+			}
+		}
+	}
+	var evals []*TuningCheckpointEvaluation
+	for _, run := range j.EvaluateDatasetRuns {
+		if run == nil {
+			continue
+		}
+		e := &TuningCheckpointEvaluation{Run: run, Checkpoint: checkpoints[run.CheckpointID]}
+		if r := run.EvaluateDatasetResponse; r != nil && r.AggregationOutput != nil {
+			for _, a := range r.AggregationOutput.AggregationResults {
+				if s := evaluationScore(a); s != nil {
+					e.Scores = append(e.Scores, s)
+				}
+			}
+		}
+		evals = append(evals, e)
+	}
+	sort.SliceStable(evals, func(i, k int) bool {
+		a, b := evals[i].Checkpoint, evals[k].Checkpoint
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.Step < b.Step
+	})
+	return evals
+}
+
+// evaluationScore returns the score of an aggregation result, or nil for
+// results without a score.
+func evaluationScore(a *AggregationResult) *TuningEvaluationScore {
+	if a == nil {
+		return nil
+	}
+	s := &TuningEvaluationScore{Aggregation: a.AggregationMetric}
+	switch {
+	case a.BleuMetricValue != nil:
+		s.Metric, s.Value = TuningMetricBleu, float64(a.BleuMetricValue.Score)
+	case a.RougeMetricValue != nil:
+		s.Metric, s.Value = TuningMetricRouge, float64(a.RougeMetricValue.Score)
+	case a.ExactMatchMetricValue != nil:
+		s.Metric, s.Value = TuningMetricExactMatch, float64(a.ExactMatchMetricValue.Score)
+	case a.PointwiseMetricResult != nil:
+		s.Metric, s.Value = TuningMetricPointwise, float64(a.PointwiseMetricResult.Score)
+	case a.CustomCodeExecutionResult != nil:
+		s.Metric, s.Value = TuningMetricCustomCodeExecution, float64(a.CustomCodeExecutionResult.Score)
+	default:
+		return nil
+	}
+	return s
+}
+
+// BestCheckpoint returns the intermediate checkpoint of the job with the
+// highest score of metric with the given aggregation. Ties are broken in favor
+// of the earliest checkpoint. It returns an error if no checkpoint has such a
+// score.
+func (j *TuningJob) BestCheckpoint(metric string, aggregation AggregationMetric) (*TunedModelCheckpoint, error) {
+	var best *TunedModelCheckpoint
+	bestScore := 0.0
+	for _, e := range j.CheckpointEvaluations() {
+		if e.Checkpoint == nil {
+			continue
+		}
+		if score, ok := e.Score(metric, aggregation); ok && (best == nil || score > bestScore) {
+			best, bestScore = e.Checkpoint, score
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("BestCheckpoint: no checkpoint of tuning job %q has a %s %s score", j.Name, aggregation, metric)
+	}
+	return best, nil
+}
+
+// SelectCheckpoint makes the checkpoint with the given ID the default
+// checkpoint of the model tuned by job, which is the one used when the model
+// or its endpoint is called. Each checkpoint is also deployed to its own
+// [TunedModelCheckpoint.Endpoint], which can be called directly.
+func (t Tunings) SelectCheckpoint(ctx context.Context, job *TuningJob, checkpointID string) (*Model, error) {
+	if job == nil || job.TunedModel == nil || job.TunedModel.Model == "" {
+		return nil, fmt.Errorf("SelectCheckpoint: the tuning job has no tuned model")
+	}
+	found := false
+	for _, c := range job.TunedModel.Checkpoints {
+		found = found || (c != nil && c.CheckpointID == checkpointID)
+	}
+	if !found {
+		return nil, fmt.Errorf("SelectCheckpoint: tuning job %q has no checkpoint %q", job.Name, checkpointID)
+	}
+	return Models{apiClient: t.apiClient}.UpdateWithDefaults(ctx, job.TunedModel.Model, nil, &UpdateModelConfig{DefaultCheckpointID: checkpointID})
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func evaluationRun(checkpointID string, results ...*AggregationResult) *EvaluateDatasetRun {
+	return &EvaluateDatasetRun{
+		CheckpointID:            checkpointID,
+		EvaluateDatasetResponse: &EvaluateDatasetResponse{AggregationOutput: &AggregationOutput{AggregationResults: results}},
+	}
+}
+
+func rougeAverage(score float32) *AggregationResult {
+	return &AggregationResult{AggregationMetric: AggregationMetricAverage, RougeMetricValue: &RougeMetricValue{Score: score}}
+}
+
+func TestTuningJobCheckpointEvaluations(t *testing.T) {
+	first := &TunedModelCheckpoint{CheckpointID: "1", Epoch: 1, Step: 10, Endpoint: "projects/p/locations/l/endpoints/1"}
+	second := &TunedModelCheckpoint{CheckpointID: "2", Epoch: 2, Step: 20, Endpoint: "projects/p/locations/l/endpoints/2"}
+	job := &TuningJob{
+		Name:       "projects/p/locations/l/tuningJobs/123",
+		TunedModel: &TunedModel{Model: "projects/p/locations/l/models/456@1", Checkpoints: []*TunedModelCheckpoint{first, second}},
+		EvaluateDatasetRuns: []*EvaluateDatasetRun{
+			evaluationRun("", rougeAverage(0.6)),
+			evaluationRun("2", rougeAverage(0.8), &AggregationResult{AggregationMetric: AggregationMetricMode, PairwiseMetricResult: &PairwiseMetricResult{}}),
+			evaluationRun("1",
+				rougeAverage(0.7),
+				&AggregationResult{AggregationMetric: AggregationMetricMaximum, BleuMetricValue: &BleuMetricValue{Score: 0.5}},
+			),
+		},
+	}
+
+	got := job.CheckpointEvaluations()
+	want := []*TuningCheckpointEvaluation{
+		{Checkpoint: first, Run: job.EvaluateDatasetRuns[2], Scores: []*TuningEvaluationScore{
+			{Metric: TuningMetricRouge, Aggregation: AggregationMetricAverage, Value: float64(float32(0.7))},
+			{Metric: TuningMetricBleu, Aggregation: AggregationMetricMaximum, Value: 0.5},
+		}},
+		{Checkpoint: second, Run: job.EvaluateDatasetRuns[1], Scores: []*TuningEvaluationScore{
+			{Metric: TuningMetricRouge, Aggregation: AggregationMetricAverage, Value: float64(float32(0.8))},
+		}},
+		{Run: job.EvaluateDatasetRuns[0], Scores: []*TuningEvaluationScore{
+			{Metric: TuningMetricRouge, Aggregation: AggregationMetricAverage, Value: float64(float32(0.6))},
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CheckpointEvaluations() mismatch (-want +got):\n%s", diff)
+	}
+
+	best, err := job.BestCheckpoint(TuningMetricRouge, AggregationMetricAverage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best != second {
+		t.Errorf("BestCheckpoint(rouge) = %+v, want %+v", best, second)
+	}
+	best, err = job.BestCheckpoint(TuningMetricBleu, AggregationMetricMaximum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best != first {
+		t.Errorf("BestCheckpoint(bleu) = %+v, want %+v", best, first)
+	}
+	if _, err := job.BestCheckpoint(TuningMetricExactMatch, AggregationMetricAverage); err == nil {
+		t.Error("BestCheckpoint() of a metric without scores succeeded, want error")
+	}
+}
+
+func TestTuningsSelectCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/v1beta1/projects/my-project/locations/us-central1/models/456@1"; r.URL.Path != want {
+			t.Errorf("path = %s, want %s", r.URL.Path, want)
+		}
+		if got, want := r.URL.Query().Get("updateMask"), "defaultCheckpointId"; got != want {
+			t.Errorf("updateMask = %q, want %q", got, want)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		if diff := cmp.Diff(map[string]any{"defaultCheckpointId": "2"}, body); diff != "" {
+			t.Errorf("body mismatch (-want +got):\n%s", diff)
+		}
+		fmt.Fprint(w, `{"name": "projects/my-project/locations/us-central1/models/456@1", "defaultCheckpointId": "2"}`)
+	}))
+	defer ts.Close()
+	tunings := Tunings{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+	job := &TuningJob{TunedModel: &TunedModel{
+		Model:       "projects/my-project/locations/us-central1/models/456@1",
+		Checkpoints: []*TunedModelCheckpoint{{CheckpointID: "1"}, {CheckpointID: "2"}},
+	}}
+
+	model, err := tunings.SelectCheckpoint(ctx, job, "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if model.DefaultCheckpointID != "2" {
+		t.Errorf("DefaultCheckpointID = %q, want %q", model.DefaultCheckpointID, "2")
+	}
+	if _, err := tunings.SelectCheckpoint(ctx, job, "3"); err == nil {
+		t.Error("SelectCheckpoint() of an unknown checkpoint succeeded, want error")
+	}
+	if _, err := tunings.SelectCheckpoint(ctx, &TuningJob{}, "1"); err == nil {
+		t.Error("SelectCheckpoint() of a job without tuned model succeeded, want error")
+	}
+}
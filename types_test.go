@@ -258,6 +258,46 @@ func TestCodeExecutionResult(t *testing.T) {
 	}
 }
 
+func TestResponsePartAccessors(t *testing.T) {
+	response := createGenerateContentResponse([]*Candidate{
+		{Content: &Content{Parts: []*Part{
+			{Text: "Here is the plot."},
+			{ExecutableCode: &ExecutableCode{Code: "print(1)", Language: LanguagePython}},
+			{CodeExecutionResult: &CodeExecutionResult{Outcome: OutcomeOK, Output: "1"}},
+			{ExecutableCode: &ExecutableCode{Code: "plot()", Language: LanguagePython}},
+			{CodeExecutionResult: &CodeExecutionResult{Outcome: OutcomeOK}},
+			{InlineData: &Blob{Data: []byte("png"), MIMEType: "image/png"}},
+			{InlineData: &Blob{Data: []byte("pcm"), MIMEType: "audio/pcm"}},
+		}}},
+	})
+
+	if got := response.ExecutableCodes(); len(got) != 2 || got[0].Code != "print(1)" || got[1].Code != "plot()" {
+		t.Errorf("ExecutableCodes() = %v, want print(1) and plot()", got)
+	}
+	if got := response.CodeExecutionResults(); len(got) != 2 || got[0].Output != "1" || got[1].Output != "" {
+		t.Errorf("CodeExecutionResults() = %v, want two results", got)
+	}
+	if got := response.InlineImages(); len(got) != 1 || got[0].MIMEType != "image/png" {
+		t.Errorf("InlineImages() = %v, want the PNG image", got)
+	}
+
+	response.Candidates[0].URLContextMetadata = &URLContextMetadata{URLMetadata: []*URLMetadata{
+		{RetrievedURL: "https://example.com", URLRetrievalStatus: URLRetrievalStatusSuccess},
+	}}
+	if got := response.URLMetadata(); len(got) != 1 || got[0].URLRetrievalStatus != URLRetrievalStatusSuccess {
+		t.Errorf("URLMetadata() = %v, want the retrieved URL", got)
+	}
+
+	empty := createGenerateContentResponse([]*Candidate{})
+	if empty.ExecutableCodes() != nil || empty.CodeExecutionResults() != nil || empty.InlineImages() != nil || empty.URLMetadata() != nil {
+		t.Errorf("accessors of an empty response returned values")
+	}
+	var nilResponse *GenerateContentResponse
+	if nilResponse.InlineImages() != nil {
+		t.Errorf("InlineImages() of a nil response returned values")
+	}
+}
+
 func TestNewPartFromURI(t *testing.T) {
 	fileURI := "http://example.com/video.mp4"
 	mimeType := "video/mp4"
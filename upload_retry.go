@@ -0,0 +1,198 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UploadStats are statistics of a resumable upload.
+type UploadStats struct {
+	// Chunks is the number of chunks uploaded.
+	Chunks int
+	// Retries is the number of times a chunk was sent again after a
+	// transient error.
+	Retries int
+	// OffsetQueries is the number of times the server was asked how much of
+	// the file it received, to resume after an error.
+	OffsetQueries int
+}
+
+// uploadStats are the statistics of the uploads of the files returned by
+// Files.Upload.
+var uploadStats sideTable[File, *UploadStats]
+
+// UploadStats returns the statistics of the upload of f, if it was returned
+// by [Files.Upload], or nil.
+func (f *File) UploadStats() *UploadStats {
+	return uploadStats.get(f)
+}
+
+// isRetryableUploadStatus reports whether a chunk upload that failed with the
+// HTTP status code may succeed when sent again.
+func isRetryableUploadStatus(code int) bool {
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500
+}
+
+// uploadChunk uploads chunk, which starts at offset in the file, with the
+// upload command. Network errors and 408, 429 and 5xx responses are retried:
+// the server is asked how much of the file it received, and the rest of the
+// chunk is sent again. Other error responses fail the upload. A successful
+// response without an upload status is sent again as it is.
+func (ac *apiClient) uploadChunk(ctx context.Context, uploadURL string, httpOptions *HTTPOptions, command string, offset int64, chunk []byte, stats *UploadStats) (*http.Response, error) {
+	finalize := strings.Contains(command, "finalize")
+	// sent is the length of the chunk the server received.
+	var sent int64
+	for attempt := 0; ; attempt++ {
+		req, err := ac.newUploadRequest(ctx, uploadURL, httpOptions, command, bytes.NewReader(chunk[sent:]))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to create upload request for chunk at offset %d: %w", offset, err)
+		}
+		req.Header.Set("X-Goog-Upload-Offset", strconv.FormatInt(offset+sent, 10))
+		req.Header.Set("Content-Length", strconv.FormatInt(int64(len(chunk))-sent, 10))
+
+		var lastErr error
+		query := true
+		resp, err := doRequest(ac, req)
+		ac.clientConfig.UsageReport.recordResponse(uploadUsagePath, resp, err)
+		switch {
+		case err != nil:
+			if !isTransientStreamError(err) {
+				return nil, fmt.Errorf("upload request failed for chunk at offset %d: %w", offset, err)
+			}
+			lastErr = err
+		case isRetryableUploadStatus(resp.StatusCode):
+			lastErr = newAPIError(resp)
+			resp.Body.Close()
+		case !httpStatusOk(resp):
+			defer resp.Body.Close()
+			return nil, fmt.Errorf("upload request failed for chunk at offset %d: %w", offset, newAPIError(resp))
+		case resp.Header.Get("X-Goog-Upload-Status") == "":
+			resp.Body.Close()
+			lastErr = fmt.Errorf("the response has no upload status")
+			query = false
+		default:
+			stats.Chunks++
+			return resp, nil
+		}
+		if attempt+1 >= maxRetryCount {
+			return nil, fmt.Errorf("upload request failed for chunk at offset %d after %d attempts: %w", offset, maxRetryCount, lastErr)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("upload aborted while waiting to retry (attempt %d, offset %d): %w", attempt+1, offset, ctx.Err())
+		case <-time.After(initialRetryDelay * time.Duration(1<<attempt)):
+			// Sleep completed, continue to the next attempt.
+		}
+		stats.Retries++
+		if !query {
+			continue
+		}
+
+		resp, received, err := ac.queryUploadOffset(ctx, uploadURL, httpOptions)
+		stats.OffsetQueries++
+		if err != nil {
+			// The whole chunk is sent again.
+			sent = 0
+			continue
+		}
+		if received < offset || received > offset+int64(len(chunk)) {
+			resp.Body.Close()
+			return nil, fmt.Errorf("upload request failed for chunk at offset %d: the server received %d bytes of the file", offset, received)
+		}
+		sent = received - offset
+		if sent == int64(len(chunk)) && !finalize {
+			// The server received the chunk before the error.
+			stats.Chunks++
+			return resp, nil
+		}
+		resp.Body.Close()
+	}
+}
+
+// queryUploadOffset asks the server how many bytes of the file it received.
+func (ac *apiClient) queryUploadOffset(ctx context.Context, uploadURL string, httpOptions *HTTPOptions) (*http.Response, int64, error) {
+	req, err := ac.newUploadRequest(ctx, uploadURL, httpOptions, "query", nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := doRequest(ac, req)
+	ac.clientConfig.UsageReport.recordResponse(uploadUsagePath, resp, err)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !httpStatusOk(resp) {
+		defer resp.Body.Close()
+		return nil, 0, newAPIError(resp)
+	}
+	received, err := strconv.ParseInt(resp.Header.Get("X-Goog-Upload-Size-Received"), 10, 64)
+	if err != nil {
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("invalid X-Goog-Upload-Size-Received header: %w", err)
+	}
+	return resp, received, nil
+}
+
+// newUploadRequest returns a request of the resumable upload protocol with the
+// upload command.
+func (ac *apiClient) newUploadRequest(ctx context.Context, uploadURL string, httpOptions *HTTPOptions, command string, body *bytes.Reader) (*http.Request, error) {
+	patchedHTTPOptions, err := patchHTTPOptions(ac.clientConfig.HTTPOptions, *httpOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	finalUploadURL := uploadURL
+	if patchedHTTPOptions.BaseURL != "" {
+		parsedBase, errBase := url.Parse(patchedHTTPOptions.BaseURL)
+		parsedUpload, errUpload := url.Parse(uploadURL)
+		if errBase == nil && errUpload == nil {
+			parsedUpload.Scheme = parsedBase.Scheme
+			parsedUpload.Host = parsedBase.Host
+			finalUploadURL = parsedUpload.String()
+		}
+	}
+
+	// TODO(b/427540996): Support timeout.
+	var req *http.Request
+	if body != nil {
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, finalUploadURL, body)
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, finalUploadURL, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = patchedHTTPOptions.Headers.Clone()
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if ac.clientConfig.APIKey != "" {
+		req.Header.Set("x-goog-api-key", ac.clientConfig.APIKey)
+	}
+	// TODO(b/427540996): Add timeout logging.
+
+	req.Header.Set("X-Goog-Upload-Command", command)
+	return req, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUploadChunkRetries(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 100)
+
+	tests := []struct {
+		name string
+		// fail returns the status of the nth chunk request, and how much of
+		// its body the server keeps.
+		fail         func(n int, body []byte) (int, int)
+		wantErr      bool
+		wantStats    *UploadStats
+		wantRequests []string
+	}{
+		{
+			name: "ResumesAfterServerError",
+			fail: func(n int, body []byte) (int, int) {
+				if n == 0 {
+					return http.StatusServiceUnavailable, len(body) / 2
+				}
+				return http.StatusOK, len(body)
+			},
+			wantStats:    &UploadStats{Chunks: 1, Retries: 1, OffsetQueries: 1},
+			wantRequests: []string{"upload, finalize@0", "query", "upload, finalize@500"},
+		},
+		{
+			name: "FailsOnClientError",
+			fail: func(n int, body []byte) (int, int) {
+				return http.StatusForbidden, 0
+			},
+			wantErr:      true,
+			wantRequests: []string{"upload, finalize@0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var received []byte
+			var requests []string
+			chunkRequests := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				command := r.Header.Get("X-Goog-Upload-Command")
+				if command == "query" {
+					requests = append(requests, command)
+					w.Header().Set("X-Goog-Upload-Status", "active")
+					w.Header().Set("X-Goog-Upload-Size-Received", strconv.Itoa(len(received)))
+					return
+				}
+				requests = append(requests, command+"@"+r.Header.Get("X-Goog-Upload-Offset"))
+				if offset, _ := strconv.Atoi(r.Header.Get("X-Goog-Upload-Offset")); offset != len(received) {
+					t.Errorf("offset = %d, want %d", offset, len(received))
+				}
+				body, _ := io.ReadAll(r.Body)
+				status, keep := tt.fail(chunkRequests, body)
+				chunkRequests++
+				received = append(received, body[:keep]...)
+				if status != http.StatusOK {
+					w.WriteHeader(status)
+					fmt.Fprintf(w, `{"error": {"code": %d, "message": "failed", "status": "FAILED"}}`, status)
+					return
+				}
+				w.Header().Set("X-Goog-Upload-Status", "final")
+				fmt.Fprintf(w, `{"file": {"name": "files/abc", "sizeBytes": "%d"}}`, len(received))
+			}))
+			defer ts.Close()
+			ac := &apiClient{clientConfig: &ClientConfig{HTTPClient: ts.Client(), APIKey: "test-key"}}
+
+			file, err := ac.uploadFile(context.Background(), bytes.NewReader(data), ts.URL+"/upload", &HTTPOptions{})
+			if diff := cmp.Diff(tt.wantRequests, requests); diff != "" {
+				t.Errorf("requests mismatch (-want +got):\n%s", diff)
+			}
+			if tt.wantErr {
+				var apiErr APIError
+				if !errors.As(err, &apiErr) || apiErr.Code != http.StatusForbidden {
+					t.Errorf("uploadFile() error = %v, want a 403 APIError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(received, data) {
+				t.Errorf("the server received %d bytes, want the %d bytes of the file", len(received), len(data))
+			}
+			if diff := cmp.Diff(tt.wantStats, file.UploadStats()); diff != "" {
+				t.Errorf("UploadStats mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
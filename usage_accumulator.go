@@ -0,0 +1,220 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// UsageTotals are token counts totaled over several responses.
+type UsageTotals struct {
+	// Responses is the number of responses whose usage was added.
+	Responses int64
+	// PromptTokens is the number of prompt tokens, including the cached ones.
+	PromptTokens int64
+	// CachedTokens is the number of prompt tokens served by cached content.
+	CachedTokens int64
+	// ResponseTokens is the number of generated tokens, excluding thoughts.
+	ResponseTokens int64
+	// ThoughtsTokens is the number of thought tokens.
+	ThoughtsTokens int64
+	// ToolUsePromptTokens is the number of tokens of tool results.
+	ToolUsePromptTokens int64
+	// TotalTokens is the total number of tokens, as reported by the API.
+	TotalTokens int64
+	// PromptTokensByModality breaks PromptTokens down by modality.
+	PromptTokensByModality map[MediaModality]int64
+	// CachedTokensByModality breaks CachedTokens down by modality.
+	CachedTokensByModality map[MediaModality]int64
+	// ResponseTokensByModality breaks ResponseTokens down by modality.
+	ResponseTokensByModality map[MediaModality]int64
+}
+
+// UncachedPromptTokens returns the number of prompt tokens that weren't served
+// by cached content, which are usually billed at the full rate.
+func (t *UsageTotals) UncachedPromptTokens() int64 {
+	return t.PromptTokens - t.CachedTokens
+}
+
+func (t *UsageTotals) add(u usageCounts) {
+	t.Responses++
+	t.PromptTokens += int64(u.prompt)
+	t.CachedTokens += int64(u.cached)
+	t.ResponseTokens += int64(u.response)
+	t.ThoughtsTokens += int64(u.thoughts)
+	t.ToolUsePromptTokens += int64(u.toolUsePrompt)
+	t.TotalTokens += int64(u.total)
+	t.PromptTokensByModality = addModalityCounts(t.PromptTokensByModality, u.promptDetails)
+	t.CachedTokensByModality = addModalityCounts(t.CachedTokensByModality, u.cachedDetails)
+	t.ResponseTokensByModality = addModalityCounts(t.ResponseTokensByModality, u.responseDetails)
+}
+
+func (t *UsageTotals) clone() *UsageTotals {
+	c := *t
+	c.PromptTokensByModality = maps.Clone(t.PromptTokensByModality)
+	c.CachedTokensByModality = maps.Clone(t.CachedTokensByModality)
+	c.ResponseTokensByModality = maps.Clone(t.ResponseTokensByModality)
+	return &c
+}
+
+func addModalityCounts(m map[MediaModality]int64, counts []*ModalityTokenCount) map[MediaModality]int64 {
+	for _, c := range counts {
+		if c == nil {
+			continue
+		}
+		if m == nil {
+			m = make(map[MediaModality]int64)
+		}
+		m[c.Modality] += int64(c.TokenCount)
+	}
+	return m
+}
+
+// usageCounts are the counts common to the usage metadata of content
+// generation and of the Live API.
+type usageCounts struct {
+	prompt, cached, response, thoughts, toolUsePrompt, total int32
+	promptDetails, cachedDetails, responseDetails            []*ModalityTokenCount
+}
+
+// UsageAccumulator totals the usage metadata of responses, overall and by an
+// attribution key, such as a request, tenant or feature ID, for cost
+// attribution. The zero value is ready to use, and it's safe for concurrent
+// use.
+type UsageAccumulator struct {
+	mu    sync.Mutex
+	total UsageTotals
+	byKey map[string]*UsageTotals
+}
+
+// Add adds the usage metadata of a response attributed to key. An empty key
+// only counts in the overall totals.
+func (a *UsageAccumulator) Add(key string, usage *GenerateContentResponseUsageMetadata) {
+	if usage == nil {
+		return
+	}
+	a.add(key, usageCounts{
+		prompt:          usage.PromptTokenCount,
+		cached:          usage.CachedContentTokenCount,
+		response:        usage.CandidatesTokenCount,
+		thoughts:        usage.ThoughtsTokenCount,
+		toolUsePrompt:   usage.ToolUsePromptTokenCount,
+		total:           usage.TotalTokenCount,
+		promptDetails:   usage.PromptTokensDetails,
+		cachedDetails:   usage.CacheTokensDetails,
+		responseDetails: usage.CandidatesTokensDetails,
+	})
+}
+
+// AddResponse adds the usage metadata of resp, such as a response of
+// [Models.GenerateContent] or [Chat.SendMessage], attributed to key. Don't
+// add every chunk of a stream, since the last chunk reports the usage of the
+// whole response; use [UsageAccumulator.Stream] instead.
+func (a *UsageAccumulator) AddResponse(key string, resp *GenerateContentResponse) {
+	if resp != nil {
+		a.Add(key, resp.UsageMetadata)
+	}
+}
+
+// AddLive adds the usage metadata of a message of a Live API session,
+// attributed to key.
+func (a *UsageAccumulator) AddLive(key string, usage *UsageMetadata) {
+	if usage == nil {
+		return
+	}
+	a.add(key, usageCounts{
+		prompt:          usage.PromptTokenCount,
+		cached:          usage.CachedContentTokenCount,
+		response:        usage.ResponseTokenCount,
+		thoughts:        usage.ThoughtsTokenCount,
+		toolUsePrompt:   usage.ToolUsePromptTokenCount,
+		total:           usage.TotalTokenCount,
+		promptDetails:   usage.PromptTokensDetails,
+		cachedDetails:   usage.CacheTokensDetails,
+		responseDetails: usage.ResponseTokensDetails,
+	})
+}
+
+// Stream returns an iterator that yields the chunks of stream and adds the
+// usage of the last chunk reporting usage metadata, attributed to key, once
+// the stream ends.
+func (a *UsageAccumulator) Stream(key string, stream iter.Seq2[*GenerateContentResponse, error]) iter.Seq2[*GenerateContentResponse, error] {
+	return func(yield func(*GenerateContentResponse, error) bool) {
+		var usage *GenerateContentResponseUsageMetadata
+		defer func() { a.Add(key, usage) }()
+		for chunk, err := range stream {
+			if chunk != nil && chunk.UsageMetadata != nil {
+				usage = chunk.UsageMetadata
+			}
+			if !yield(chunk, err) {
+				return
+			}
+		}
+	}
+}
+
+func (a *UsageAccumulator) add(key string, u usageCounts) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.total.add(u)
+	if key == "" {
+		return
+	}
+	if a.byKey == nil {
+		a.byKey = make(map[string]*UsageTotals)
+	}
+	t := a.byKey[key]
+	if t == nil {
+		t = &UsageTotals{}
+		a.byKey[key] = t
+	}
+	t.add(u)
+}
+
+// Totals returns the overall totals.
+func (a *UsageAccumulator) Totals() *UsageTotals {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.total.clone()
+}
+
+// TotalsFor returns the totals attributed to key. They're zero if no usage
+// was attributed to key.
+func (a *UsageAccumulator) TotalsFor(key string) *UsageTotals {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if t := a.byKey[key]; t != nil {
+		return t.clone()
+	}
+	return &UsageTotals{}
+}
+
+// Keys returns the attribution keys, in lexical order.
+func (a *UsageAccumulator) Keys() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Sorted(maps.Keys(a.byKey))
+}
+
+// Reset clears the totals.
+func (a *UsageAccumulator) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.total = UsageTotals{}
+	a.byKey = nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUsageAccumulator(t *testing.T) {
+	var a UsageAccumulator
+	a.AddResponse("tenant-a", &GenerateContentResponse{UsageMetadata: &GenerateContentResponseUsageMetadata{
+		PromptTokenCount:        100,
+		CachedContentTokenCount: 60,
+		CandidatesTokenCount:    20,
+		ThoughtsTokenCount:      5,
+		TotalTokenCount:         125,
+		PromptTokensDetails:     []*ModalityTokenCount{{Modality: MediaModalityText, TokenCount: 40}, {Modality: MediaModalityImage, TokenCount: 60}},
+		CacheTokensDetails:      []*ModalityTokenCount{{Modality: MediaModalityImage, TokenCount: 60}},
+		CandidatesTokensDetails: []*ModalityTokenCount{{Modality: MediaModalityText, TokenCount: 20}},
+	}})
+	a.AddLive("tenant-b", &UsageMetadata{
+		PromptTokenCount:      10,
+		ResponseTokenCount:    30,
+		TotalTokenCount:       40,
+		PromptTokensDetails:   []*ModalityTokenCount{{Modality: MediaModalityAudio, TokenCount: 10}},
+		ResponseTokensDetails: []*ModalityTokenCount{{Modality: MediaModalityAudio, TokenCount: 30}},
+	})
+	a.AddResponse("", &GenerateContentResponse{UsageMetadata: &GenerateContentResponseUsageMetadata{PromptTokenCount: 1, TotalTokenCount: 1}})
+	a.AddResponse("tenant-a", &GenerateContentResponse{})
+
+	want := &UsageTotals{
+		Responses:                3,
+		PromptTokens:             111,
+		CachedTokens:             60,
+		ResponseTokens:           50,
+		ThoughtsTokens:           5,
+		TotalTokens:              166,
+		PromptTokensByModality:   map[MediaModality]int64{MediaModalityText: 40, MediaModalityImage: 60, MediaModalityAudio: 10},
+		CachedTokensByModality:   map[MediaModality]int64{MediaModalityImage: 60},
+		ResponseTokensByModality: map[MediaModality]int64{MediaModalityText: 20, MediaModalityAudio: 30},
+	}
+	totals := a.Totals()
+	if diff := cmp.Diff(want, totals); diff != "" {
+		t.Errorf("Totals() mismatch (-want +got):\n%s", diff)
+	}
+	if got := totals.UncachedPromptTokens(); got != 51 {
+		t.Errorf("UncachedPromptTokens() = %d, want 51", got)
+	}
+	if diff := cmp.Diff([]string{"tenant-a", "tenant-b"}, a.Keys()); diff != "" {
+		t.Errorf("Keys() mismatch (-want +got):\n%s", diff)
+	}
+	if got := a.TotalsFor("tenant-a"); got.Responses != 1 || got.CachedTokens != 60 || got.TotalTokens != 125 {
+		t.Errorf("TotalsFor(tenant-a) = %+v, want 1 response with 60 cached tokens", got)
+	}
+	if got := a.TotalsFor("unknown"); got.Responses != 0 {
+		t.Errorf("TotalsFor(unknown) = %+v, want zero", got)
+	}
+
+	// The returned totals are copies.
+	totals.PromptTokensByModality[MediaModalityText] = 0
+	if got := a.Totals().PromptTokensByModality[MediaModalityText]; got != 40 {
+		t.Errorf("Totals() was modified through a copy: got %d", got)
+	}
+
+	a.Reset()
+	if got := a.Totals(); got.Responses != 0 || len(a.Keys()) != 0 {
+		t.Errorf("after Reset(), Totals() = %+v", got)
+	}
+}
+
+func TestUsageAccumulatorStream(t *testing.T) {
+	var a UsageAccumulator
+	chunks := []*GenerateContentResponse{
+		{UsageMetadata: &GenerateContentResponseUsageMetadata{PromptTokenCount: 10, TotalTokenCount: 12}},
+		{UsageMetadata: &GenerateContentResponseUsageMetadata{PromptTokenCount: 10, CandidatesTokenCount: 7, TotalTokenCount: 17}},
+	}
+	for range a.Stream("req-1", streamOf(chunks, errors.New("interrupted"))) {
+	}
+	want := &UsageTotals{Responses: 1, PromptTokens: 10, ResponseTokens: 7, TotalTokens: 17}
+	if diff := cmp.Diff(want, a.TotalsFor("req-1")); diff != "" {
+		t.Errorf("TotalsFor() mismatch (-want +got):\n%s", diff)
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.Add("concurrent", &GenerateContentResponseUsageMetadata{TotalTokenCount: 1})
+		}()
+	}
+	wg.Wait()
+	if got := a.TotalsFor("concurrent").TotalTokens; got != 10 {
+		t.Errorf("got %d concurrent tokens, want 10", got)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UsageReport counts the requests, errors and tokens of the API calls of a
+// client by model and module, so that small deployments get usage reporting
+// without a metrics stack. Attach it to a client with
+// [ClientConfig.UsageReport]. The zero value is ready to use, and it's safe for
+// concurrent use.
+//
+// Every request sent to the API is counted, including the retries, the calls
+// made on behalf of chats and batches, and the requests of file uploads, under
+// the "upload" module, and downloads. Token counts are taken from the
+// usage metadata of content generation responses; for streams, from the last
+// chunk that reports them.
+type UsageReport struct {
+	mu      sync.Mutex
+	start   time.Time
+	entries map[usageReportKey]*UsageReportEntry
+}
+
+type usageReportKey struct {
+	module, model string
+}
+
+// UsageReportEntry are the counters of a model and module.
+type UsageReportEntry struct {
+	// Module is the API method called, such as "generateContent",
+	// "streamGenerateContent", "embedContent" or "download", the collection of
+	// the resource, such as "files" or "cachedContents", for other calls, or
+	// "upload" for the requests that upload the content of files.
+	Module string `json:"module"`
+	// Model is the model the calls were made with, if any, such as
+	// "gemini-2.5-flash".
+	Model string `json:"model,omitempty"`
+	// Requests is the number of requests sent.
+	Requests int64 `json:"requests"`
+	// Errors is the number of requests that failed, either with an API error
+	// or before a response was received.
+	Errors int64 `json:"errors"`
+	// PromptTokens is the number of prompt tokens, including the cached ones.
+	PromptTokens int64 `json:"promptTokens"`
+	// CachedTokens is the number of prompt tokens served by cached content.
+	CachedTokens int64 `json:"cachedTokens"`
+	// ResponseTokens is the number of generated tokens, excluding thoughts.
+	ResponseTokens int64 `json:"responseTokens"`
+	// ThoughtsTokens is the number of thought tokens.
+	ThoughtsTokens int64 `json:"thoughtsTokens"`
+	// TotalTokens is the total number of tokens, as reported by the API.
+	TotalTokens int64 `json:"totalTokens"`
+}
+
+// UsageSnapshot is the state of a [UsageReport] at a point in time.
+type UsageSnapshot struct {
+	// Start is the time the first call was counted since the report was
+	// created or reset. It's zero if no call was counted.
+	Start time.Time `json:"start"`
+	// End is the time the snapshot was taken.
+	End time.Time `json:"end"`
+	// Entries are the counters by model and module, ordered by module and then
+	// by model.
+	Entries []*UsageReportEntry `json:"entries"`
+}
+
+// Snapshot returns the current counters.
+func (r *UsageReport) Snapshot() *UsageSnapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.snapshot()
+}
+
+// Reset clears the counters.
+func (r *UsageReport) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reset()
+}
+
+// SnapshotAndReset returns the current counters and clears them atomically,
+// so that no call is missed or counted twice when exporting periodically.
+func (r *UsageReport) SnapshotAndReset() *UsageSnapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.snapshot()
+	r.reset()
+	return s
+}
+
+// WriteJSON writes a snapshot of the counters to w as JSON.
+func (r *UsageReport) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r.Snapshot())
+}
+
+func (r *UsageReport) snapshot() *UsageSnapshot {
+	s := &UsageSnapshot{Start: r.start, End: time.Now(), Entries: []*UsageReportEntry{}}
+	for _, e := range r.entries {
+		c := *e
+		s.Entries = append(s.Entries, &c)
+	}
+	slices.SortFunc(s.Entries, func(a, b *UsageReportEntry) int {
+		return cmp.Or(strings.Compare(a.Module, b.Module), strings.Compare(a.Model, b.Model))
+	})
+	return s
+}
+
+func (r *UsageReport) reset() {
+	r.start = time.Time{}
+	r.entries = nil
+}
+
+// record counts a request to path. usage is the usage metadata of the
+// response, if any.
+func (r *UsageReport) record(path string, usage map[string]any, err error) {
+	if r == nil {
+		return
+	}
+	module, model := usageReportKeyOf(path)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.start.IsZero() {
+		r.start = time.Now()
+	}
+	key := usageReportKey{module: module, model: model}
+	e := r.entries[key]
+	if e == nil {
+		if r.entries == nil {
+			r.entries = make(map[usageReportKey]*UsageReportEntry)
+		}
+		e = &UsageReportEntry{Module: module, Model: model}
+		r.entries[key] = e
+	}
+	e.Requests++
+	if err != nil {
+		e.Errors++
+	}
+	e.PromptTokens += usageCount(usage, "promptTokenCount")
+	e.CachedTokens += usageCount(usage, "cachedContentTokenCount")
+	e.ResponseTokens += usageCount(usage, "candidatesTokenCount")
+	e.ThoughtsTokens += usageCount(usage, "thoughtsTokenCount")
+	e.TotalTokens += usageCount(usage, "totalTokenCount")
+}
+
+// uploadUsagePath is the path under which the requests of the resumable
+// upload protocol are counted, as their URL isn't an API path.
+const uploadUsagePath = "upload"
+
+// recordResponse counts a request to path that wasn't sent by sendRequest or
+// sendStreamRequest, which either failed with err or got resp.
+func (r *UsageReport) recordResponse(path string, resp *http.Response, err error) {
+	if err == nil && !httpStatusOk(resp) {
+		err = fmt.Errorf("HTTP status %s", resp.Status)
+	}
+	r.record(path, nil, err)
+}
+
+// usageReportKeyOf returns the module and model of a request path, such as
+// models/gemini-2.5-flash:generateContent or
+// projects/p/locations/l/publishers/google/models/gemini-2.5-flash:generateContent.
+func usageReportKeyOf(path string) (module, model string) {
+	path, _, _ = strings.Cut(path, "?")
+	resource, method, hasMethod := strings.Cut(path, ":")
+	segments := strings.Split(strings.Trim(resource, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == "models" || segments[i] == "tunedModels" {
+			model = segments[i+1]
+			break
+		}
+	}
+	if hasMethod {
+		return method, model
+	}
+	// Skip the project and location of Vertex AI resources.
+	for len(segments) >= 2 && (segments[0] == "projects" || segments[0] == "locations") {
+		segments = segments[2:]
+	}
+	if len(segments) > 0 {
+		module = segments[0]
+	}
+	return module, model
+}
+
+func usageCount(usage map[string]any, key string) int64 {
+	n, _ := usage[key].(float64)
+	return int64(n)
+}
@@ -0,0 +1,152 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestUsageReportKeyOf(t *testing.T) {
+	tests := []struct {
+		path       string
+		wantModule string
+		wantModel  string
+	}{
+		{"models/gemini-2.5-flash:generateContent", "generateContent", "gemini-2.5-flash"},
+		{"projects/p/locations/us-central1/publishers/google/models/gemini-2.5-flash:streamGenerateContent?alt=sse", "streamGenerateContent", "gemini-2.5-flash"},
+		{"tunedModels/my-model:generateContent", "generateContent", "my-model"},
+		{"files?pageSize=10", "files", ""},
+		{"projects/p/locations/us-central1/cachedContents/123", "cachedContents", ""},
+		{"models/gemini-2.5-flash", "models", "gemini-2.5-flash"},
+	}
+	for _, tt := range tests {
+		module, model := usageReportKeyOf(tt.path)
+		if module != tt.wantModule || model != tt.wantModel {
+			t.Errorf("usageReportKeyOf(%q) = (%q, %q), want (%q, %q)", tt.path, module, model, tt.wantModule, tt.wantModel)
+		}
+	}
+}
+
+func TestUsageReport(t *testing.T) {
+	ctx := context.Background()
+	const usage = `"usageMetadata": {"promptTokenCount": 10, "cachedContentTokenCount": 4, "candidatesTokenCount": 5, "thoughtsTokenCount": 2, "totalTokenCount": 17}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "gemini-bad"):
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": {"code": 400, "message": "bad model", "status": "INVALID_ARGUMENT"}}`)
+		case strings.HasSuffix(r.URL.Path, ":streamGenerateContent"):
+			fmt.Fprint(w, "data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"a\"}]}}]}\n\n")
+			fmt.Fprintf(w, "data: {\"candidates\": [{\"content\": {\"role\": \"model\", \"parts\": [{\"text\": \"b\"}]}, \"finishReason\": \"STOP\"}], %s}\n\n", usage)
+		default:
+			fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": "hi"}]}, "finishReason": "STOP"}], %s}`, usage)
+		}
+	}))
+	defer ts.Close()
+	report := &UsageReport{}
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL}, HTTPClient: ts.Client(), UsageReport: report}}}
+
+	for range 2 {
+		if _, err := m.GenerateContent(ctx, "gemini-2.5-flash", Text("hello"), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := m.GenerateContent(ctx, "gemini-bad", Text("hello"), nil); err == nil {
+		t.Fatal("GenerateContent() with a bad model succeeded, want error")
+	}
+	for _, err := range m.GenerateContentStream(ctx, "gemini-2.5-flash", Text("hello"), nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []*UsageReportEntry{
+		{Module: "generateContent", Model: "gemini-2.5-flash", Requests: 2, PromptTokens: 20, CachedTokens: 8, ResponseTokens: 10, ThoughtsTokens: 4, TotalTokens: 34},
+		{Module: "generateContent", Model: "gemini-bad", Requests: 1, Errors: 1},
+		{Module: "streamGenerateContent", Model: "gemini-2.5-flash", Requests: 1, PromptTokens: 10, CachedTokens: 4, ResponseTokens: 5, ThoughtsTokens: 2, TotalTokens: 17},
+	}
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var exported UsageSnapshot
+	if err := json.Unmarshal(buf.Bytes(), &exported); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, exported.Entries); diff != "" {
+		t.Errorf("exported entries mismatch (-want +got):\n%s", diff)
+	}
+	if exported.Start.IsZero() || exported.End.Before(exported.Start) {
+		t.Errorf("exported period is [%v, %v], want a non-empty period", exported.Start, exported.End)
+	}
+
+	snapshot := report.SnapshotAndReset()
+	if diff := cmp.Diff(want, snapshot.Entries); diff != "" {
+		t.Errorf("SnapshotAndReset() entries mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(&UsageSnapshot{Entries: []*UsageReportEntry{}}, report.Snapshot(), cmpopts.IgnoreFields(UsageSnapshot{}, "End")); diff != "" {
+		t.Errorf("Snapshot() after reset mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestUsageReportFiles(t *testing.T) {
+	ctx := context.Background()
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/upload/v1beta/files":
+			w.Header().Set("X-Goog-Upload-URL", ts.URL+"/upload-session")
+		case r.URL.Path == "/upload-session":
+			w.Header().Set("X-Goog-Upload-Status", "final")
+			fmt.Fprint(w, `{"file": {"name": "files/abc", "downloadUri": "https://example.com/files/abc:download", "state": "ACTIVE"}}`)
+		case r.URL.Path == "/v1beta/files/abc:download":
+			fmt.Fprint(w, "data")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	report := &UsageReport{}
+	files := Files{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta"}, HTTPClient: ts.Client(), UsageReport: report}}}
+
+	file, err := files.Upload(ctx, strings.NewReader("data"), &UploadFileConfig{MIMEType: "text/plain"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := files.Download(ctx, NewDownloadURIFromFile(file), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := files.Download(ctx, NewDownloadURIFromFile(&File{Name: "files/missing", DownloadURI: "https://example.com/files/missing:download"}), nil); err == nil {
+		t.Fatal("Download() of a missing file succeeded, want error")
+	}
+
+	want := []*UsageReportEntry{
+		{Module: "download", Requests: 2, Errors: 1},
+		{Module: "upload", Requests: 2},
+	}
+	if diff := cmp.Diff(want, report.Snapshot().Entries); diff != "" {
+		t.Errorf("entries mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const defaultValidateMaxRetries = 2
+
+// ResponseValidator checks a response of the model. A non-nil error rejects
+// the response, and its message is given to the model as feedback when it's
+// asked again.
+type ResponseValidator func(ctx context.Context, resp *GenerateContentResponse) error
+
+// ValidateText returns a [ResponseValidator] that checks the text of the
+// response with check.
+func ValidateText(check func(text string) error) ResponseValidator {
+	return func(ctx context.Context, resp *GenerateContentResponse) error {
+		return check(resp.Text())
+	}
+}
+
+// ValidateSchema returns a [ResponseValidator] that checks that the text of
+// the response is JSON matching the type, enum and required constraints of
+// schema. Markdown code fences around the JSON are ignored.
+func ValidateSchema(schema *Schema) ResponseValidator {
+	return ValidateText(func(text string) error {
+		var v any
+		if err := json.Unmarshal([]byte(stripCodeFence(text)), &v); err != nil {
+			return fmt.Errorf("the response isn't valid JSON: %w", err)
+		}
+		return validateAgainstSchema(v, schema, "$")
+	})
+}
+
+// ValidateConfig configures [Models.GenerateContentValidated].
+type ValidateConfig struct {
+	// Required. Validators the response must pass, checked in order.
+	Validators []ResponseValidator
+	// Optional. Maximum number of times the model is asked again after its
+	// response was rejected. Defaults to 2. Set to 0 to disable retries.
+	MaxRetries *int32
+	// Optional. Returns the message asking the model to fix its response
+	// rejected with err. Defaults to a message quoting err.
+	Feedback func(err error) string
+}
+
+// ValidationAttempt is a response checked by [Models.GenerateContentValidated].
+type ValidationAttempt struct {
+	// Response is the response of the model.
+	Response *GenerateContentResponse
+	// Err is the error of the validator that rejected the response, or nil if
+	// it passed.
+	Err error
+}
+
+// ValidatedResponse is the outcome of [Models.GenerateContentValidated].
+type ValidatedResponse struct {
+	// Response is the first response that passed the validators, or the last
+	// response if none did.
+	Response *GenerateContentResponse
+	// Attempts are all responses, in order.
+	Attempts []*ValidationAttempt
+}
+
+// ValidationError is returned when no response passed the validators.
+type ValidationError struct {
+	// Attempts is the number of GenerateContent calls made.
+	Attempts int
+	// Err is the validation error of the last attempt.
+	Err error
+}
+
+// Error returns a string representation of the ValidationError.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("response rejected after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+// Unwrap returns the validation error of the last attempt.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// GenerateContentValidated generates content and checks the response with
+// the validators of validateConfig. If a validator rejects the response, the
+// model is asked again with the validation error as feedback, up to
+// [ValidateConfig.MaxRetries] times. The first response that passes is
+// returned along with the trace of all attempts. If none passes, a
+// [*ValidationError] is returned together with the trace.
+//
+//	result, err := client.Models.GenerateContentValidated(ctx, model, contents, nil, &genai.ValidateConfig{
+//		Validators: []genai.ResponseValidator{
+//			genai.ValidateText(func(text string) error {
+//				if len(text) > 280 {
+//					return fmt.Errorf("the answer is %d characters long, the limit is 280", len(text))
+//				}
+//				return nil
+//			}),
+//		},
+//	})
+func (m Models) GenerateContentValidated(ctx context.Context, model string, contents []*Content, config *GenerateContentConfig, validateConfig *ValidateConfig) (*ValidatedResponse, error) {
+	if validateConfig == nil || len(validateConfig.Validators) == 0 {
+		return nil, errors.New("GenerateContentValidated: ValidateConfig.Validators is required")
+	}
+	maxRetries := int32(defaultValidateMaxRetries)
+	if validateConfig.MaxRetries != nil {
+		maxRetries = *validateConfig.MaxRetries
+	}
+	feedback := validateConfig.Feedback
+	if feedback == nil {
+		feedback = func(err error) string {
+			return fmt.Sprintf("Your previous response was rejected: %v. Respond again, fixing the problem.", err)
+		}
+	}
+
+	history := append([]*Content{}, contents...)
+	result := &ValidatedResponse{}
+	for attempt := 1; ; attempt++ {
+		resp, err := m.GenerateContentWithOptions(ctx, model, history, config, nil)
+		if err != nil {
+			return result, err
+		}
+		result.Response = resp
+		var validationErr error
+		for _, validate := range validateConfig.Validators {
+			if validationErr = validate(ctx, resp); validationErr != nil {
+				break
+			}
+		}
+		result.Attempts = append(result.Attempts, &ValidationAttempt{Response: resp, Err: validationErr})
+		if validationErr == nil {
+			return result, nil
+		}
+		if attempt > int(maxRetries) {
+			return result, &ValidationError{Attempts: attempt, Err: validationErr}
+		}
+		if c := firstCandidate(resp); c != nil && c.Content != nil {
+			history = append(history, &Content{Role: RoleModel, Parts: c.Content.Parts})
+		}
+		history = append(history, NewContentFromText(feedback(validationErr), RoleUser))
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGenerateContentValidated(t *testing.T) {
+	ctx := context.Background()
+	var answers []string
+	var lastContents []*Content
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Contents []*Content `json:"contents"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		lastContents = body.Contents
+		answer, _ := json.Marshal(answers[(len(body.Contents)-1)/2])
+		fmt.Fprintf(w, `{"candidates": [{"content": {"role": "model", "parts": [{"text": %s}]}}]}`, answer)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{
+		Backend:     BackendGeminiAPI,
+		HTTPOptions: HTTPOptions{BaseURL: ts.URL},
+		HTTPClient:  ts.Client(),
+	}}}
+	schema := &Schema{Type: TypeObject, Properties: map[string]*Schema{"name": {Type: TypeString}}, Required: []string{"name"}}
+
+	t.Run("PassesAfterReask", func(t *testing.T) {
+		answers = []string{`{"title": "x"}`, "```json\n{\"name\": \"Ada\"}\n```"}
+		got, err := m.GenerateContentValidated(ctx, "gemini-2.5-flash", Text("Who?"), nil, &ValidateConfig{
+			Validators: []ResponseValidator{ValidateSchema(schema)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got.Attempts) != 2 || got.Attempts[0].Err == nil || got.Attempts[1].Err != nil {
+			t.Fatalf("attempts = %+v, want a rejected then a passing attempt", got.Attempts)
+		}
+		if got.Response != got.Attempts[1].Response {
+			t.Errorf("Response isn't the passing response")
+		}
+		if len(lastContents) != 3 || lastContents[1].Role != RoleModel || !strings.Contains(lastContents[2].Parts[0].Text, `"name"`) {
+			t.Errorf("re-ask contents = %+v, want the rejected answer and feedback naming the missing property", lastContents)
+		}
+	})
+
+	t.Run("ValidatorsInOrder", func(t *testing.T) {
+		answers = []string{"too long answer", "short"}
+		var calls []string
+		short := ValidateText(func(text string) error {
+			calls = append(calls, "short")
+			if len(text) > 5 {
+				return errors.New("too long")
+			}
+			return nil
+		})
+		custom := func(ctx context.Context, resp *GenerateContentResponse) error {
+			calls = append(calls, "custom")
+			return nil
+		}
+		got, err := m.GenerateContentValidated(ctx, "gemini-2.5-flash", Text("Hi"), nil, &ValidateConfig{
+			Validators: []ResponseValidator{short, custom},
+			Feedback:   func(err error) string { return "Fix: " + err.Error() },
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Response.Text() != "short" || fmt.Sprint(calls) != "[short short custom]" {
+			t.Errorf("got %q with validator calls %v", got.Response.Text(), calls)
+		}
+		if lastContents[2].Parts[0].Text != "Fix: too long" {
+			t.Errorf("feedback = %q, want %q", lastContents[2].Parts[0].Text, "Fix: too long")
+		}
+	})
+
+	t.Run("Exhausted", func(t *testing.T) {
+		answers = []string{"no", "still no"}
+		got, err := m.GenerateContentValidated(ctx, "gemini-2.5-flash", Text("Who?"), nil, &ValidateConfig{
+			Validators: []ResponseValidator{ValidateSchema(schema)},
+			MaxRetries: Ptr[int32](1),
+		})
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Attempts != 2 {
+			t.Fatalf("GenerateContentValidated() error = %v, want a ValidationError after 2 attempts", err)
+		}
+		if len(got.Attempts) != 2 || got.Response.Text() != "still no" {
+			t.Errorf("trace = %+v, want both attempts ending with the last response", got.Attempts)
+		}
+	})
+
+	t.Run("NoValidators", func(t *testing.T) {
+		if _, err := m.GenerateContentValidated(ctx, "gemini-2.5-flash", Text("Who?"), nil, nil); err == nil {
+			t.Errorf("GenerateContentValidated() succeeded without validators, want error")
+		}
+	})
+}
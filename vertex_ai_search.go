@@ -0,0 +1,133 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+const (
+	// defaultVertexAISearchCollection is the collection data stores and
+	// engines are created in by default.
+	defaultVertexAISearchCollection = "default_collection"
+	maxVertexAISearchResults        = 10
+)
+
+var (
+	vertexAISearchDataStoreRe = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/(collections/[^/]+/)?dataStores/[^/]+$`)
+	vertexAISearchEngineRe    = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/collections/[^/]+/engines/[^/]+$`)
+)
+
+// VertexAISearchDataStoreName returns the resource name of a Vertex AI Search
+// data store of the default collection, as expected by
+// [VertexAISearch.Datastore]. location is the location of the data store,
+// such as "global", not the location of the model.
+func VertexAISearchDataStoreName(project, location, dataStore string) string {
+	return fmt.Sprintf("projects/%s/locations/%s/collections/%s/dataStores/%s", project, location, defaultVertexAISearchCollection, dataStore)
+}
+
+// VertexAISearchEngineName returns the resource name of a Vertex AI Search
+// engine, also called app, of the default collection, as expected by
+// [VertexAISearch.Engine].
+func VertexAISearchEngineName(project, location, engine string) string {
+	return fmt.Sprintf("projects/%s/locations/%s/collections/%s/engines/%s", project, location, defaultVertexAISearchCollection, engine)
+}
+
+// VertexAISearchOptions are the options of the tools returned by
+// [NewVertexAISearchDataStoreTool] and [NewVertexAISearchEngineTool].
+type VertexAISearchOptions struct {
+	// Optional. Filter of the documents searched, see
+	// https://cloud.google.com/generative-ai-app-builder/docs/filter-search-metadata.
+	Filter string
+	// Optional. Number of search results per query, up to 10. Defaults to 10.
+	MaxResults int32
+	// Optional. Data stores searched by an engine with several data stores,
+	// with their filters. Only valid for engines.
+	DataStoreSpecs []*VertexAISearchDataStoreSpec
+}
+
+// NewVertexAISearchDataStoreTool returns a tool grounding generation on the
+// Vertex AI Search data store named dataStore, which can be built with
+// [VertexAISearchDataStoreName]. The configuration is checked with
+// [VertexAISearch.Validate].
+func NewVertexAISearchDataStoreTool(dataStore string, options *VertexAISearchOptions) (*Tool, error) {
+	return newVertexAISearchTool(&VertexAISearch{Datastore: dataStore}, options)
+}
+
+// NewVertexAISearchEngineTool returns a tool grounding generation on the
+// Vertex AI Search engine named engine, which can be built with
+// [VertexAISearchEngineName]. The configuration is checked with
+// [VertexAISearch.Validate].
+func NewVertexAISearchEngineTool(engine string, options *VertexAISearchOptions) (*Tool, error) {
+	return newVertexAISearchTool(&VertexAISearch{Engine: engine}, options)
+}
+
+func newVertexAISearchTool(search *VertexAISearch, options *VertexAISearchOptions) (*Tool, error) {
+	if options != nil {
+		search.Filter = options.Filter
+		if options.MaxResults != 0 {
+			search.MaxResults = Ptr(options.MaxResults)
+		}
+		search.DataStoreSpecs = options.DataStoreSpecs
+	}
+	if err := search.Validate(); err != nil {
+		return nil, err
+	}
+	return &Tool{Retrieval: &Retrieval{VertexAISearch: search}}, nil
+}
+
+// Validate checks that s names either a data store or an engine with valid
+// resource names, that data store specs are only set for engines, and that
+// MaxResults is between 1 and 10.
+func (s *VertexAISearch) Validate() error {
+	switch {
+	case s.Datastore == "" && s.Engine == "":
+		return errors.New("VertexAISearch: one of Datastore and Engine is required")
+	case s.Datastore != "" && s.Engine != "":
+		return errors.New("VertexAISearch: Datastore and Engine are mutually exclusive")
+	case s.Datastore != "":
+		if err := validateVertexAISearchDataStore(s.Datastore); err != nil {
+			return fmt.Errorf("VertexAISearch: %w", err)
+		}
+		if len(s.DataStoreSpecs) > 0 {
+			return errors.New("VertexAISearch: DataStoreSpecs can only be set with Engine")
+		}
+	default:
+		if !vertexAISearchEngineRe.MatchString(s.Engine) {
+			return fmt.Errorf("VertexAISearch: invalid engine %q, want projects/{project}/locations/{location}/collections/{collection}/engines/{engine}, see VertexAISearchEngineName", s.Engine)
+		}
+		for i, spec := range s.DataStoreSpecs {
+			if spec == nil {
+				return fmt.Errorf("VertexAISearch: DataStoreSpecs[%d] is nil", i)
+			}
+			if err := validateVertexAISearchDataStore(spec.DataStore); err != nil {
+				return fmt.Errorf("VertexAISearch: DataStoreSpecs[%d]: %w", i, err)
+			}
+		}
+	}
+	if s.MaxResults != nil && (*s.MaxResults < 1 || *s.MaxResults > maxVertexAISearchResults) {
+		return fmt.Errorf("VertexAISearch: MaxResults must be between 1 and %d, got %d", maxVertexAISearchResults, *s.MaxResults)
+	}
+	return nil
+}
+
+func validateVertexAISearchDataStore(name string) error {
+	if !vertexAISearchDataStoreRe.MatchString(name) {
+		return fmt.Errorf("invalid data store %q, want projects/{project}/locations/{location}/collections/{collection}/dataStores/{dataStore}, see VertexAISearchDataStoreName", name)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVertexAISearchTools(t *testing.T) {
+	dataStore := VertexAISearchDataStoreName("my-project", "global", "docs")
+	if want := "projects/my-project/locations/global/collections/default_collection/dataStores/docs"; dataStore != want {
+		t.Errorf("VertexAISearchDataStoreName() = %q, want %q", dataStore, want)
+	}
+	engine := VertexAISearchEngineName("my-project", "global", "support-app")
+	if want := "projects/my-project/locations/global/collections/default_collection/engines/support-app"; engine != want {
+		t.Errorf("VertexAISearchEngineName() = %q, want %q", engine, want)
+	}
+
+	got, err := NewVertexAISearchDataStoreTool(dataStore, &VertexAISearchOptions{Filter: `lang: ANY("en")`, MaxResults: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Tool{Retrieval: &Retrieval{VertexAISearch: &VertexAISearch{Datastore: dataStore, Filter: `lang: ANY("en")`, MaxResults: Ptr[int32](5)}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewVertexAISearchDataStoreTool() mismatch (-want +got):\n%s", diff)
+	}
+
+	specs := []*VertexAISearchDataStoreSpec{{DataStore: dataStore, Filter: "year > 2020"}}
+	got, err = NewVertexAISearchEngineTool(engine, &VertexAISearchOptions{DataStoreSpecs: specs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = &Tool{Retrieval: &Retrieval{VertexAISearch: &VertexAISearch{Engine: engine, DataStoreSpecs: specs}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewVertexAISearchEngineTool() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestVertexAISearchValidate(t *testing.T) {
+	dataStore := VertexAISearchDataStoreName("p", "global", "d")
+	engine := VertexAISearchEngineName("p", "global", "e")
+	tests := []struct {
+		name    string
+		search  *VertexAISearch
+		wantErr bool
+	}{
+		{name: "DataStore", search: &VertexAISearch{Datastore: dataStore}},
+		{name: "DataStoreWithoutCollection", search: &VertexAISearch{Datastore: "projects/p/locations/global/dataStores/d"}},
+		{name: "Engine", search: &VertexAISearch{Engine: engine, MaxResults: Ptr[int32](10)}},
+		{name: "Neither", search: &VertexAISearch{}, wantErr: true},
+		{name: "Both", search: &VertexAISearch{Datastore: dataStore, Engine: engine}, wantErr: true},
+		{name: "DataStoreID", search: &VertexAISearch{Datastore: "d"}, wantErr: true},
+		{name: "LowercaseDatastores", search: &VertexAISearch{Datastore: "projects/p/locations/global/collections/c/datastores/d"}, wantErr: true},
+		{name: "EngineWithoutCollection", search: &VertexAISearch{Engine: "projects/p/locations/global/engines/e"}, wantErr: true},
+		{name: "SpecsWithDataStore", search: &VertexAISearch{Datastore: dataStore, DataStoreSpecs: []*VertexAISearchDataStoreSpec{{DataStore: dataStore}}}, wantErr: true},
+		{name: "InvalidSpec", search: &VertexAISearch{Engine: engine, DataStoreSpecs: []*VertexAISearchDataStoreSpec{{DataStore: "d"}}}, wantErr: true},
+		{name: "TooManyResults", search: &VertexAISearch{Datastore: dataStore, MaxResults: Ptr[int32](11)}, wantErr: true},
+		{name: "ZeroResults", search: &VertexAISearch{Datastore: dataStore, MaxResults: Ptr[int32](0)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.search.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var ragCorpusRe = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/ragCorpora/[^/]+$`)
+
+// RAGCorpusName returns the resource name of a Vertex AI RAG Engine corpus, as
+// expected by [VertexRAGStoreRAGResource.RAGCorpus].
+func RAGCorpusName(project, location, corpus string) string {
+	return fmt.Sprintf("projects/%s/locations/%s/ragCorpora/%s", project, location, corpus)
+}
+
+// VertexRAGStoreOptions are the options of the tool returned by
+// [NewVertexRAGStoreTool].
+type VertexRAGStoreOptions struct {
+	// Optional. IDs of the files of the corpus to retrieve from. Defaults to
+	// all the files of the corpus.
+	FileIDs []string
+	// Optional. Number of contexts to retrieve.
+	TopK int32
+	// Optional. Only returns contexts with a vector distance smaller than the
+	// threshold. Mutually exclusive with VectorSimilarityThreshold.
+	VectorDistanceThreshold *float64
+	// Optional. Only returns contexts with a vector similarity larger than the
+	// threshold. Mutually exclusive with VectorDistanceThreshold.
+	VectorSimilarityThreshold *float64
+	// Optional. Metadata filter of the contexts.
+	MetadataFilter string
+	// Optional. Reranking of the retrieved contexts, with either an LLM or the
+	// rank service.
+	Ranking *RAGRetrievalConfigRanking
+}
+
+// NewVertexRAGStoreTool returns a tool grounding generation on the Vertex AI
+// RAG Engine corpus named corpus, which can be built with [RAGCorpusName]. The
+// configuration is checked with [VertexRAGStore.Validate]. RAG Engine is only
+// supported by Vertex AI.
+func NewVertexRAGStoreTool(corpus string, options *VertexRAGStoreOptions) (*Tool, error) {
+	opts := VertexRAGStoreOptions{}
+	if options != nil {
+		opts = *options
+	}
+	store := &VertexRAGStore{
+		RAGResources: []*VertexRAGStoreRAGResource{{RAGCorpus: corpus, RAGFileIDs: opts.FileIDs}},
+	}
+	rc := &RAGRetrievalConfig{Ranking: opts.Ranking}
+	if opts.TopK != 0 {
+		rc.TopK = Ptr(opts.TopK)
+	}
+	if opts.VectorDistanceThreshold != nil || opts.VectorSimilarityThreshold != nil || opts.MetadataFilter != "" {
+		rc.Filter = &RAGRetrievalConfigFilter{
+			MetadataFilter:            opts.MetadataFilter,
+			VectorDistanceThreshold:   opts.VectorDistanceThreshold,
+			VectorSimilarityThreshold: opts.VectorSimilarityThreshold,
+		}
+	}
+	if *rc != (RAGRetrievalConfig{}) {
+		store.RAGRetrievalConfig = rc
+	}
+	if err := store.Validate(); err != nil {
+		return nil, err
+	}
+	return &Tool{Retrieval: &Retrieval{VertexRAGStore: store}}, nil
+}
+
+// Validate checks that s retrieves from a single corpus with a valid resource
+// name and that its retrieval options are consistent: a positive top-k, at
+// most one of the distance and similarity thresholds, at most one ranker, and
+// a hybrid search alpha between 0 and 1.
+func (s *VertexRAGStore) Validate() error {
+	var corpora []string
+	for i, r := range s.RAGResources {
+		if r == nil || r.RAGCorpus == "" {
+			return fmt.Errorf("VertexRAGStore: RAGResources[%d] doesn't name a corpus", i)
+		}
+		corpora = append(corpora, r.RAGCorpus)
+	}
+	corpora = append(corpora, s.RAGCorpora...)
+	switch len(corpora) {
+	case 0:
+		return errors.New("VertexRAGStore: a corpus is required")
+	case 1:
+	default:
+		return fmt.Errorf("VertexRAGStore: only one corpus is supported, got %d", len(corpora))
+	}
+	if !ragCorpusRe.MatchString(corpora[0]) {
+		return fmt.Errorf("VertexRAGStore: invalid corpus %q, want projects/{project}/locations/{location}/ragCorpora/{corpus}, see RAGCorpusName", corpora[0])
+	}
+	if s.SimilarityTopK != nil && *s.SimilarityTopK < 1 {
+		return fmt.Errorf("VertexRAGStore: SimilarityTopK must be positive, got %d", *s.SimilarityTopK)
+	}
+	rc := s.RAGRetrievalConfig
+	if rc == nil {
+		return nil
+	}
+	if rc.TopK != nil && *rc.TopK < 1 {
+		return fmt.Errorf("VertexRAGStore: TopK must be positive, got %d", *rc.TopK)
+	}
+	if f := rc.Filter; f != nil && f.VectorDistanceThreshold != nil && f.VectorSimilarityThreshold != nil {
+		return errors.New("VertexRAGStore: VectorDistanceThreshold and VectorSimilarityThreshold are mutually exclusive")
+	}
+	if r := rc.Ranking; r != nil && r.LlmRanker != nil && r.RankService != nil {
+		return errors.New("VertexRAGStore: LlmRanker and RankService are mutually exclusive")
+	}
+	if h := rc.HybridSearch; h != nil && h.Alpha != nil && (*h.Alpha < 0 || *h.Alpha > 1) {
+		return fmt.Errorf("VertexRAGStore: hybrid search alpha must be between 0 and 1, got %v", *h.Alpha)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewVertexRAGStoreTool(t *testing.T) {
+	corpus := RAGCorpusName("my-project", "us-central1", "123")
+	if want := "projects/my-project/locations/us-central1/ragCorpora/123"; corpus != want {
+		t.Errorf("RAGCorpusName() = %q, want %q", corpus, want)
+	}
+
+	got, err := NewVertexRAGStoreTool(corpus, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Tool{Retrieval: &Retrieval{VertexRAGStore: &VertexRAGStore{
+		RAGResources: []*VertexRAGStoreRAGResource{{RAGCorpus: corpus}},
+	}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewVertexRAGStoreTool() mismatch (-want +got):\n%s", diff)
+	}
+
+	ranking := &RAGRetrievalConfigRanking{RankService: &RAGRetrievalConfigRankingRankService{ModelName: "semantic-ranker-512@latest"}}
+	got, err = NewVertexRAGStoreTool(corpus, &VertexRAGStoreOptions{
+		FileIDs:                 []string{"f1"},
+		TopK:                    5,
+		VectorDistanceThreshold: Ptr(0.3),
+		Ranking:                 ranking,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = &Tool{Retrieval: &Retrieval{VertexRAGStore: &VertexRAGStore{
+		RAGResources: []*VertexRAGStoreRAGResource{{RAGCorpus: corpus, RAGFileIDs: []string{"f1"}}},
+		RAGRetrievalConfig: &RAGRetrievalConfig{
+			Filter:  &RAGRetrievalConfigFilter{VectorDistanceThreshold: Ptr(0.3)},
+			Ranking: ranking,
+			TopK:    Ptr[int32](5),
+		},
+	}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewVertexRAGStoreTool() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestVertexRAGStoreValidate(t *testing.T) {
+	corpus := RAGCorpusName("p", "us-central1", "c")
+	resources := []*VertexRAGStoreRAGResource{{RAGCorpus: corpus}}
+	tests := []struct {
+		name    string
+		store   *VertexRAGStore
+		wantErr bool
+	}{
+		{name: "Resource", store: &VertexRAGStore{RAGResources: resources}},
+		{name: "DeprecatedCorpora", store: &VertexRAGStore{RAGCorpora: []string{corpus}, SimilarityTopK: Ptr[int32](3)}},
+		{name: "NoCorpus", store: &VertexRAGStore{}, wantErr: true},
+		{name: "EmptyResource", store: &VertexRAGStore{RAGResources: []*VertexRAGStoreRAGResource{{}}}, wantErr: true},
+		{name: "TwoCorpora", store: &VertexRAGStore{RAGResources: resources, RAGCorpora: []string{corpus}}, wantErr: true},
+		{name: "CorpusID", store: &VertexRAGStore{RAGCorpora: []string{"c"}}, wantErr: true},
+		{name: "ZeroSimilarityTopK", store: &VertexRAGStore{RAGResources: resources, SimilarityTopK: Ptr[int32](0)}, wantErr: true},
+		{name: "ZeroTopK", store: &VertexRAGStore{RAGResources: resources, RAGRetrievalConfig: &RAGRetrievalConfig{TopK: Ptr[int32](0)}}, wantErr: true},
+		{
+			name: "BothThresholds",
+			store: &VertexRAGStore{RAGResources: resources, RAGRetrievalConfig: &RAGRetrievalConfig{
+				Filter: &RAGRetrievalConfigFilter{VectorDistanceThreshold: Ptr(0.5), VectorSimilarityThreshold: Ptr(0.5)},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "BothRankers",
+			store: &VertexRAGStore{RAGResources: resources, RAGRetrievalConfig: &RAGRetrievalConfig{
+				Ranking: &RAGRetrievalConfigRanking{LlmRanker: &RAGRetrievalConfigRankingLlmRanker{}, RankService: &RAGRetrievalConfigRankingRankService{}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "InvalidAlpha",
+			store: &VertexRAGStore{RAGResources: resources, RAGRetrievalConfig: &RAGRetrievalConfig{
+				HybridSearch: &RAGRetrievalConfigHybridSearch{Alpha: Ptr[float32](1.5)},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.store.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+)
+
+// EditVideo starts editing video within the region given by mask, guided by
+// prompt, and returns the operation like [Models.GenerateVideosFromSource].
+// The mask mode tells how the region is used: an object described by prompt
+// can be inserted into it, objects can be removed from it, or the video can be
+// placed into it and the remaining area generated, which is outpainting. The
+// prompt is required to insert an object. Other options, such as the aspect
+// ratio of an outpainted video, are taken from config.
+//
+// Video editing is only supported in Vertex AI.
+func (m Models) EditVideo(ctx context.Context, model string, video *Video, prompt string, mask *VideoGenerationMask, config *GenerateVideosConfig) (*GenerateVideosOperation, error) {
+	if video == nil || (video.URI == "" && len(video.VideoBytes) == 0) {
+		return nil, fmt.Errorf("EditVideo: video is required")
+	}
+	if mask == nil || mask.Image == nil {
+		return nil, fmt.Errorf("EditVideo: mask image is required")
+	}
+	if mask.MaskMode == "" {
+		return nil, fmt.Errorf("EditVideo: mask mode is required")
+	}
+	if mask.MaskMode == VideoGenerationMaskModeInsert && prompt == "" {
+		return nil, fmt.Errorf("EditVideo: prompt is required to insert an object")
+	}
+	cfg := GenerateVideosConfig{}
+	if config != nil {
+		cfg = *config
+	}
+	cfg.Mask = mask
+	return m.GenerateVideosFromSource(ctx, model, &GenerateVideosSource{Prompt: prompt, Video: video}, &cfg)
+}
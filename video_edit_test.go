@@ -0,0 +1,88 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModelsEditVideo(t *testing.T) {
+	ctx := context.Background()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/v1beta1/projects/my-project/locations/us-central1/publishers/google/models/veo-2.0-generate-exp:predictLongRunning"; r.URL.Path != want {
+			t.Errorf("path = %s, want %s", r.URL.Path, want)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		want := map[string]any{
+			"instances": []any{map[string]any{
+				"video": map[string]any{"gcsUri": "gs://bucket/clip.mp4", "mimeType": "video/mp4"},
+				"mask":  map[string]any{"gcsUri": "gs://bucket/mask.png", "mimeType": "image/png", "maskMode": "OUTPAINT"},
+			}},
+			"parameters": map[string]any{"aspectRatio": "9:16"},
+		}
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Errorf("request body mismatch (-want +got):\n%s", diff)
+		}
+		fmt.Fprint(w, `{"name": "operations/123"}`)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+	video := &Video{URI: "gs://bucket/clip.mp4", MIMEType: "video/mp4"}
+	mask := &VideoGenerationMask{Image: &Image{GCSURI: "gs://bucket/mask.png", MIMEType: "image/png"}, MaskMode: VideoGenerationMaskModeOutpaint}
+	config := &GenerateVideosConfig{AspectRatio: "9:16"}
+
+	op, err := m.EditVideo(ctx, "veo-2.0-generate-exp", video, "", mask, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op.Name != "operations/123" {
+		t.Errorf("operation name = %q, want %q", op.Name, "operations/123")
+	}
+	if config.Mask != nil {
+		t.Error("EditVideo() modified the config")
+	}
+
+	tests := []struct {
+		name    string
+		video   *Video
+		prompt  string
+		mask    *VideoGenerationMask
+		wantErr string
+	}{
+		{"NoVideo", nil, "", mask, "video is required"},
+		{"NoMask", video, "", nil, "mask image is required"},
+		{"NoMaskMode", video, "", &VideoGenerationMask{Image: mask.Image}, "mask mode is required"},
+		{"InsertWithoutPrompt", video, "", &VideoGenerationMask{Image: mask.Image, MaskMode: VideoGenerationMaskModeInsert}, "prompt is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.EditVideo(ctx, "veo-2.0-generate-exp", tt.video, tt.prompt, tt.mask, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("EditVideo() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
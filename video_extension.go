@@ -0,0 +1,36 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExtendVideo starts extending video, such as a video generated by a previous
+// operation, guided by prompt. The extension is DurationSeconds long; set it
+// in config to the length supported by the model. It's a shortcut for
+// [Models.GenerateVideosFromSource] with a source made of the prompt and the
+// video. Call it again with the generated video to produce longer clips
+// iteratively.
+//
+// On the Gemini API, video must be a video generated by Veo, referred to by
+// its URI. On Vertex AI, it can also be a Cloud Storage URI or video bytes.
+func (m Models) ExtendVideo(ctx context.Context, model string, video *Video, prompt string, config *GenerateVideosConfig) (*GenerateVideosOperation, error) {
+	if video == nil || (video.URI == "" && len(video.VideoBytes) == 0) {
+		return nil, fmt.Errorf("ExtendVideo: video is required")
+	}
+	return m.GenerateVideosFromSource(ctx, model, &GenerateVideosSource{Prompt: prompt, Video: video}, config)
+}
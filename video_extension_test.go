@@ -0,0 +1,100 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModelsExtendVideo(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		backend        Backend
+		apiVersion     string
+		video          *Video
+		wantPath       string
+		wantInstance   map[string]any
+		wantParameters map[string]any
+	}{
+		{
+			name:           "GeminiAPI",
+			backend:        BackendGeminiAPI,
+			apiVersion:     "v1beta",
+			video:          &Video{URI: "https://generativelanguage.googleapis.com/v1beta/files/abc:download", MIMEType: "video/mp4", VideoBytes: []byte("ignored")},
+			wantPath:       "/v1beta/models/veo-3.1-generate-preview:predictLongRunning",
+			wantInstance:   map[string]any{"prompt": "The camera pans to the sea.", "video": map[string]any{"uri": "https://generativelanguage.googleapis.com/v1beta/files/abc:download", "encoding": "video/mp4"}},
+			wantParameters: map[string]any{"durationSeconds": 7.0},
+		},
+		{
+			name:           "VertexAI",
+			backend:        BackendVertexAI,
+			apiVersion:     "v1beta1",
+			video:          &Video{URI: "gs://bucket/clip.mp4", MIMEType: "video/mp4"},
+			wantPath:       "/v1beta1/projects/my-project/locations/us-central1/publishers/google/models/veo-3.1-generate-preview:predictLongRunning",
+			wantInstance:   map[string]any{"prompt": "The camera pans to the sea.", "video": map[string]any{"gcsUri": "gs://bucket/clip.mp4", "mimeType": "video/mp4"}},
+			wantParameters: map[string]any{"durationSeconds": 7.0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %s, want %s", r.URL.Path, tt.wantPath)
+				}
+				var body struct {
+					Instances  []map[string]any `json:"instances"`
+					Parameters map[string]any   `json:"parameters"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Error(err)
+				}
+				if diff := cmp.Diff([]map[string]any{tt.wantInstance}, body.Instances); diff != "" {
+					t.Errorf("instances mismatch (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tt.wantParameters, body.Parameters); diff != "" {
+					t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+				}
+				fmt.Fprint(w, `{"name": "operations/123"}`)
+			}))
+			defer ts.Close()
+			cc := &ClientConfig{Backend: tt.backend, HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: tt.apiVersion}, HTTPClient: ts.Client()}
+			if tt.backend == BackendVertexAI {
+				cc.Project, cc.Location = "my-project", "us-central1"
+			}
+			m := Models{apiClient: &apiClient{clientConfig: cc}}
+
+			op, err := m.ExtendVideo(ctx, "veo-3.1-generate-preview", tt.video, "The camera pans to the sea.", &GenerateVideosConfig{DurationSeconds: Ptr[int32](7)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if op.Name != "operations/123" {
+				t.Errorf("operation name = %q, want %q", op.Name, "operations/123")
+			}
+		})
+	}
+
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI}}}
+	if _, err := m.ExtendVideo(ctx, "veo-3.1-generate-preview", &Video{}, "More.", nil); err == nil {
+		t.Error("ExtendVideo() without a video succeeded, want error")
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// VideoCameraControl is a camera movement preset for video generation, see
+// [Models.GenerateVideosWithCameraControl].
+type VideoCameraControl string
+
+const (
+	// The camera doesn't move.
+	VideoCameraControlFixed VideoCameraControl = "FIXED"
+	// The camera rotates to the left.
+	VideoCameraControlPanLeft VideoCameraControl = "PAN_LEFT"
+	// The camera rotates to the right.
+	VideoCameraControlPanRight VideoCameraControl = "PAN_RIGHT"
+	// The camera tilts up.
+	VideoCameraControlTiltUp VideoCameraControl = "TILT_UP"
+	// The camera tilts down.
+	VideoCameraControlTiltDown VideoCameraControl = "TILT_DOWN"
+	// The camera moves to the left.
+	VideoCameraControlTruckLeft VideoCameraControl = "TRUCK_LEFT"
+	// The camera moves to the right.
+	VideoCameraControlTruckRight VideoCameraControl = "TRUCK_RIGHT"
+	// The camera moves up.
+	VideoCameraControlPedestalUp VideoCameraControl = "PEDESTAL_UP"
+	// The camera moves down.
+	VideoCameraControlPedestalDown VideoCameraControl = "PEDESTAL_DOWN"
+	// The camera moves toward the subject.
+	VideoCameraControlPushIn VideoCameraControl = "PUSH_IN"
+	// The camera moves away from the subject.
+	VideoCameraControlPullOut VideoCameraControl = "PULL_OUT"
+)
+
+// videoModelFeatures holds the advanced features supported by the Veo models
+// whose ID starts with prefix.
+type videoModelFeatures struct {
+	prefix string
+	// assetImages and styleImages are the maximum numbers of reference images
+	// of each type.
+	assetImages   int
+	styleImages   int
+	cameraControl bool
+}
+
+// knownVideoModels are the Veo models that support reference images or camera
+// controls. Other Veo models support neither. More specific prefixes come
+// first.
+var knownVideoModels = []videoModelFeatures{
+	{prefix: "veo-2.0-generate-exp", assetImages: 3, styleImages: 1, cameraControl: true},
+	{prefix: "veo-3.1", assetImages: 3},
+}
+
+// GenerateVideosWithCameraControl generates videos like
+// [Models.GenerateVideosFromSource], moving the camera as cameraControl says.
+// Camera controls are only supported in Vertex AI by Veo 2 experimental
+// models. Before the request is sent, cameraControl and the reference images
+// of config are checked against the features of the known Veo models.
+func (m Models) GenerateVideosWithCameraControl(ctx context.Context, model string, source *GenerateVideosSource, cameraControl VideoCameraControl, config *GenerateVideosConfig) (*GenerateVideosOperation, error) {
+	if err := checkVideoModelFeatures(model, config, cameraControl); err != nil {
+		return nil, err
+	}
+	if cameraControl == "" {
+		return m.GenerateVideosFromSource(ctx, model, source, config)
+	}
+	if m.apiClient.clientConfig.Backend != BackendVertexAI {
+		return nil, fmt.Errorf("cameraControl parameter is only supported in Gemini Enterprise Agent Platform mode, not in Gemini Developer API mode.")
+	}
+	// The generated request converters don't know about camera controls, so
+	// they're sent through a copy of HTTPOptions.ExtraBody.
+	controlled := GenerateVideosConfig{}
+	if config != nil {
+		controlled = *config
+	}
+	controlled.HTTPOptions = withExtraBody(controlled.HTTPOptions, map[string]any{
+		"parameters": map[string]any{"cameraControl": cameraControl},
+	})
+	return m.GenerateVideosFromSource(ctx, model, source, &controlled)
+}
+
+// checkVideoModelFeatures returns an error if the reference images of c or
+// cameraControl aren't supported by model. Models that aren't Veo models, such
+// as tuned models served by an endpoint, aren't checked.
+func checkVideoModelFeatures(model string, c *GenerateVideosConfig, cameraControl VideoCameraControl) error {
+	var referenceImages []*VideoGenerationReferenceImage
+	if c != nil {
+		referenceImages = c.ReferenceImages
+	}
+	if len(referenceImages) == 0 && cameraControl == "" {
+		return nil
+	}
+	id := model[strings.LastIndexByte(model, '/')+1:]
+	if !strings.HasPrefix(id, "veo-") {
+		return nil
+	}
+	var features videoModelFeatures
+	for _, f := range knownVideoModels {
+		if strings.HasPrefix(id, f.prefix) {
+			features = f
+			break
+		}
+	}
+	if cameraControl != "" && !features.cameraControl {
+		return fmt.Errorf("GenerateVideosWithCameraControl: %s doesn't support camera controls", id)
+	}
+	assets, styles := 0, 0
+	for _, ref := range referenceImages {
+		switch {
+		case ref == nil:
+			return fmt.Errorf("GenerateVideosConfig: ReferenceImages can't contain nil")
+		case ref.ReferenceType == VideoGenerationReferenceTypeAsset:
+			assets++
+		case ref.ReferenceType == VideoGenerationReferenceTypeStyle:
+			styles++
+		default:
+			return fmt.Errorf("GenerateVideosConfig: reference image has unknown type %q", ref.ReferenceType)
+		}
+	}
+	switch {
+	case assets > 0 && features.assetImages == 0:
+		return fmt.Errorf("GenerateVideosConfig: %s doesn't support asset reference images", id)
+	case styles > 0 && features.styleImages == 0:
+		return fmt.Errorf("GenerateVideosConfig: %s doesn't support style reference images", id)
+	case assets > 0 && styles > 0:
+		return fmt.Errorf("GenerateVideosConfig: asset and style reference images can't be combined")
+	case assets > features.assetImages:
+		return fmt.Errorf("GenerateVideosConfig: %s supports up to %d asset reference images, got %d", id, features.assetImages, assets)
+	case styles > features.styleImages:
+		return fmt.Errorf("GenerateVideosConfig: %s supports up to %d style reference images, got %d", id, features.styleImages, styles)
+	}
+	return nil
+}
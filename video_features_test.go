@@ -0,0 +1,115 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckVideoModelFeatures(t *testing.T) {
+	asset := &VideoGenerationReferenceImage{Image: &Image{GCSURI: "gs://bucket/asset.png"}, ReferenceType: VideoGenerationReferenceTypeAsset}
+	style := &VideoGenerationReferenceImage{Image: &Image{GCSURI: "gs://bucket/style.png"}, ReferenceType: VideoGenerationReferenceTypeStyle}
+	tests := []struct {
+		name          string
+		model         string
+		config        *GenerateVideosConfig
+		cameraControl VideoCameraControl
+		wantErr       string
+	}{
+		{"NilConfig", "veo-3.0-generate-001", nil, "", ""},
+		{"NoAdvancedFeatures", "veo-3.0-generate-001", &GenerateVideosConfig{AspectRatio: "16:9"}, "", ""},
+		{"AssetImages", "veo-3.1-generate-preview", &GenerateVideosConfig{ReferenceImages: []*VideoGenerationReferenceImage{asset, asset, asset}}, "", ""},
+		{"StyleImage", "publishers/google/models/veo-2.0-generate-exp", &GenerateVideosConfig{ReferenceImages: []*VideoGenerationReferenceImage{style}}, "", ""},
+		{"CameraControl", "veo-2.0-generate-exp", nil, VideoCameraControlPanLeft, ""},
+		{"UnknownModel", "projects/p/locations/l/endpoints/123", nil, VideoCameraControlPanLeft, ""},
+		{"CameraControlUnsupported", "veo-3.1-generate-preview", nil, VideoCameraControlPushIn, "doesn't support camera controls"},
+		{"AssetImagesUnsupported", "models/veo-3.0-generate-001", &GenerateVideosConfig{ReferenceImages: []*VideoGenerationReferenceImage{asset}}, "", "veo-3.0-generate-001 doesn't support asset reference images"},
+		{"StyleImageUnsupported", "veo-3.1-generate-preview", &GenerateVideosConfig{ReferenceImages: []*VideoGenerationReferenceImage{style}}, "", "doesn't support style reference images"},
+		{"TooManyAssetImages", "veo-3.1-generate-preview", &GenerateVideosConfig{ReferenceImages: []*VideoGenerationReferenceImage{asset, asset, asset, asset}}, "", "up to 3 asset reference images, got 4"},
+		{"TooManyStyleImages", "veo-2.0-generate-exp", &GenerateVideosConfig{ReferenceImages: []*VideoGenerationReferenceImage{style, style}}, "", "up to 1 style reference images, got 2"},
+		{"AssetAndStyle", "veo-2.0-generate-exp", &GenerateVideosConfig{ReferenceImages: []*VideoGenerationReferenceImage{asset, style}}, "", "can't be combined"},
+		{"MissingType", "veo-2.0-generate-exp", &GenerateVideosConfig{ReferenceImages: []*VideoGenerationReferenceImage{{Image: asset.Image}}}, "", "unknown type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkVideoModelFeatures(tt.model, tt.config, tt.cameraControl)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("checkVideoModelFeatures() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("checkVideoModelFeatures() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModelsGenerateVideosWithCameraControl(t *testing.T) {
+	ctx := context.Background()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		want := map[string]any{
+			"instances":  []any{map[string]any{"prompt": "A lighthouse at dusk."}},
+			"parameters": map[string]any{"cameraControl": "PUSH_IN"},
+		}
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Errorf("request body mismatch (-want +got):\n%s", diff)
+		}
+		fmt.Fprint(w, `{"name": "operations/123"}`)
+	}))
+	defer ts.Close()
+	source := &GenerateVideosSource{Prompt: "A lighthouse at dusk."}
+
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+	if _, err := m.GenerateVideosWithCameraControl(ctx, "veo-2.0-generate-exp", source, VideoCameraControlPushIn, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.GenerateVideosWithCameraControl(ctx, "veo-3.0-generate-001", source, VideoCameraControlPushIn, nil); err == nil {
+		t.Error("GenerateVideosWithCameraControl() with an unsupported model succeeded, want error")
+	}
+
+	m = Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendGeminiAPI, HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta"}, HTTPClient: ts.Client()}}}
+	if _, err := m.GenerateVideosWithCameraControl(ctx, "veo-2.0-generate-exp", source, VideoCameraControlPushIn, nil); err == nil {
+		t.Error("GenerateVideosWithCameraControl() on the Gemini API succeeded, want error")
+	}
+}
+
+func TestWithExtraBody(t *testing.T) {
+	options := &HTTPOptions{APIVersion: "v1beta1", ExtraBody: map[string]any{
+		"parameters": map[string]any{"seed": 1},
+	}}
+	got := withExtraBody(options, map[string]any{"parameters": map[string]any{"cameraControl": VideoCameraControlPanLeft}})
+	want := map[string]any{"parameters": map[string]any{"seed": 1, "cameraControl": VideoCameraControlPanLeft}}
+	if diff := cmp.Diff(want, got.ExtraBody); diff != "" {
+		t.Errorf("ExtraBody mismatch (-want +got):\n%s", diff)
+	}
+	if got.APIVersion != "v1beta1" {
+		t.Errorf("APIVersion = %q, want the APIVersion of the options", got.APIVersion)
+	}
+	wantOriginal := map[string]any{"parameters": map[string]any{"seed": 1}}
+	if diff := cmp.Diff(wantOriginal, options.ExtraBody); diff != "" {
+		t.Errorf("withExtraBody() modified the ExtraBody of the options (-want +got):\n%s", diff)
+	}
+}
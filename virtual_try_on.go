@@ -0,0 +1,38 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"fmt"
+)
+
+// VirtualTryOn renders person wearing the products, such as clothes or shoes,
+// with a virtual try-on model such as virtual-try-on-preview-08-04. It's a
+// shortcut for [Models.RecontextImage] with a source made of the person image
+// and the product images, and is only supported on Vertex AI.
+func (m Models) VirtualTryOn(ctx context.Context, model string, person *Image, products []*Image, config *RecontextImageConfig) (*RecontextImageResponse, error) {
+	if person == nil {
+		return nil, fmt.Errorf("VirtualTryOn: person image is required")
+	}
+	if len(products) == 0 {
+		return nil, fmt.Errorf("VirtualTryOn: at least one product image is required")
+	}
+	source := &RecontextImageSource{PersonImage: person}
+	for _, p := range products {
+		source.ProductImages = append(source.ProductImages, &ProductImage{ProductImage: p})
+	}
+	return m.RecontextImage(ctx, model, source, config)
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModelsVirtualTryOn(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/v1beta1/projects/my-project/locations/us-central1/publishers/google/models/virtual-try-on-preview-08-04:predict"; r.URL.Path != want {
+			t.Errorf("path = %s, want %s", r.URL.Path, want)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		want := map[string]any{
+			"instances": []any{map[string]any{
+				"personImage":   map[string]any{"image": map[string]any{"gcsUri": "gs://bucket/person.png"}},
+				"productImages": []any{map[string]any{"image": map[string]any{"gcsUri": "gs://bucket/jacket.png"}}},
+			}},
+			"parameters": map[string]any{"sampleCount": 1.0, "baseSteps": 32.0},
+		}
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Errorf("body mismatch (-want +got):\n%s", diff)
+		}
+		fmt.Fprint(w, `{"predictions": [{"bytesBase64Encoded": "dHJ5LW9u", "mimeType": "image/png"}]}`)
+	}))
+	defer ts.Close()
+	m := Models{apiClient: &apiClient{clientConfig: &ClientConfig{Backend: BackendVertexAI, Project: "my-project", Location: "us-central1", HTTPOptions: HTTPOptions{BaseURL: ts.URL, APIVersion: "v1beta1"}, HTTPClient: ts.Client()}}}
+
+	person := &Image{GCSURI: "gs://bucket/person.png"}
+	products := []*Image{{GCSURI: "gs://bucket/jacket.png"}}
+	resp, err := m.VirtualTryOn(context.Background(), "virtual-try-on-preview-08-04", person, products, &RecontextImageConfig{NumberOfImages: Ptr[int32](1), BaseSteps: Ptr[int32](32)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.GeneratedImages) != 1 || string(resp.GeneratedImages[0].Image.ImageBytes) != "try-on" {
+		t.Errorf("GeneratedImages = %+v, want one try-on render", resp.GeneratedImages)
+	}
+
+	if _, err := m.VirtualTryOn(context.Background(), "virtual-try-on-preview-08-04", nil, products, nil); err == nil {
+		t.Error("VirtualTryOn() without a person image succeeded, want error")
+	}
+	if _, err := m.VirtualTryOn(context.Background(), "virtual-try-on-preview-08-04", person, nil, nil); err == nil {
+		t.Error("VirtualTryOn() without product images succeeded, want error")
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultWarmConnectionInterval = 30 * time.Second
+	warmConnectionTimeout         = 10 * time.Second
+)
+
+// WarmConnectionConfig configures [ClientConfig.WarmConnection].
+//
+// The connection is opened by a HEAD request to the host of the base URL,
+// which the API answers without doing any work, and kept open by repeating it
+// while the connection would otherwise be idle. This helps bursty workloads,
+// such as serverless functions, whose requests would otherwise often open a
+// new connection. For Vertex AI with default credentials, the first request
+// also resolves the credentials, as [Client.Prime] does.
+//
+// The connection is kept open until [Client.Close] is called or the context
+// passed to [NewClient] is done.
+type WarmConnectionConfig struct {
+	// Optional. Interval between the requests keeping the connection open.
+	// Defaults to 30 seconds. If negative, the connection is only opened when
+	// the client is created.
+	Interval time.Duration
+}
+
+// warmConnection opens a connection to the host of baseURL with client and
+// keeps it open until ctx is done. Errors are ignored since the connection is
+// opened again by the next request anyway.
+func warmConnection(ctx context.Context, client *http.Client, baseURL string, config WarmConnectionConfig) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return
+	}
+	target := (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}).String()
+	interval := config.Interval
+	if interval == 0 {
+		interval = defaultWarmConnectionInterval
+	}
+	pingHost(ctx, client, target)
+	if interval < 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pingHost(ctx, client, target)
+		}
+	}
+}
+
+func pingHost(ctx context.Context, client *http.Client, target string) {
+	ctx, cancel := context.WithTimeout(ctx, warmConnectionTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, target, nil)
+	if err != nil {
+		return
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return
+	}
+	// The body is drained so that the connection is returned to the pool.
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
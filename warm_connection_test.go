@@ -0,0 +1,137 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWarmConnection(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	conns := map[string]bool{}
+	pinged := make(chan struct{}, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		conns[r.RemoteAddr] = true
+		mu.Unlock()
+		pinged <- struct{}{}
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := NewClient(ctx, &ClientConfig{
+		APIKey:         "test-api-key",
+		HTTPClient:     ts.Client(),
+		HTTPOptions:    HTTPOptions{BaseURL: ts.URL + "/proxy/"},
+		WarmConnection: &WarmConnectionConfig{Interval: 10 * time.Millisecond},
+		envVarProvider: func() map[string]string { return nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		select {
+		case <-pinged:
+		case <-time.After(5 * time.Second):
+			t.Fatal("connection wasn't warmed")
+		}
+	}
+	cancel()
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, p := range paths {
+		if p != "HEAD /" {
+			t.Errorf("got request %q, want HEAD /", p)
+		}
+	}
+	if len(conns) != 1 {
+		t.Errorf("got %d connections, want 1", len(conns))
+	}
+}
+
+func TestWarmConnectionClose(t *testing.T) {
+	var mu sync.Mutex
+	pings := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		pings++
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(context.Background(), &ClientConfig{
+		APIKey:         "test-api-key",
+		HTTPClient:     ts.Client(),
+		HTTPOptions:    HTTPOptions{BaseURL: ts.URL},
+		WarmConnection: &WarmConnectionConfig{Interval: time.Millisecond},
+		envVarProvider: func() map[string]string { return nil },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := make(chan error)
+	go func() { closed <- client.Close() }()
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Fatalf("Close() failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close() didn't stop the connection warming")
+	}
+	mu.Lock()
+	before := pings
+	mu.Unlock()
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if pings != before {
+		t.Errorf("got %d requests after Close, want none", pings-before)
+	}
+	if err := client.Close(); err != nil {
+		t.Errorf("second Close() failed: %v", err)
+	}
+}
+
+func TestWarmConnectionOnce(t *testing.T) {
+	pinged := make(chan struct{}, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pinged <- struct{}{}
+	}))
+	defer ts.Close()
+
+	done := make(chan struct{})
+	go func() {
+		warmConnection(context.Background(), ts.Client(), ts.URL, WarmConnectionConfig{Interval: -1})
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("warmConnection didn't return")
+	}
+	if len(pinged) != 1 {
+		t.Errorf("got %d requests, want 1", len(pinged))
+	}
+}